@@ -0,0 +1,455 @@
+package raws
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// ErrCacheMiss is the error of the regions without results
+// on the cache of a CachedReader in offline mode
+var ErrCacheMiss = errors.New("the results are not cached")
+
+// CacheEntry is a result cached by a CachedReader
+type CacheEntry struct {
+	// StoredAt is when the entry has been stored
+	StoredAt time.Time `json:"stored_at"`
+
+	// Data is the JSON of the result
+	Data json.RawMessage `json:"data"`
+}
+
+// CacheStore is where a CachedReader keeps its entries, by
+// their keys, which are paths separated by slashes
+type CacheStore interface {
+	// Get returns the entry of the key, or nil if there is none
+	Get(key string) (*CacheEntry, error)
+
+	// Set stores the entry of the key
+	Set(key string, e *CacheEntry) error
+
+	// Delete removes the entry of the key, if there is one
+	Delete(key string) error
+
+	// Keys returns the keys of all the entries
+	Keys() ([]string, error)
+}
+
+// CacheOptions are the options of a CachedReader
+type CacheOptions struct {
+	// Store is where the results are kept, a NewMemoryCache if nil
+	Store CacheStore
+
+	// TTL is the time the results are used, for the methods without
+	// TTLs, and 0 means that they never expire
+	TTL time.Duration
+
+	// TTLs are the TTL of the methods by their name, like "GetVpcs",
+	// where a negative TTL means that the method is not cached
+	TTLs map[string]time.Duration
+
+	// Offline makes the CachedReader use only the results on the
+	// Store, whatever their age, so the AWSReader is never called
+	Offline bool
+}
+
+// CachedReader is an AWSReader keeping the results of the methods of
+// an AWSReader, by account, method, region and input, so the same calls
+// made again don't call AWS until they expire, and the readers of
+// different accounts can share the same CacheStore.
+// The results are only cached for the regions without errors, and
+// all the regions are called again if any of them is not cached.
+// DownloadObject is not cached, neither are the methods called with a
+// context of WithResultFunc, unless in offline mode where they return
+// the cached results.
+type CachedReader struct {
+	reader  AWSReader
+	opts    CacheOptions
+	now     func() time.Time
+	account cachedAccount
+}
+
+// cachedAccount is the entry of the account
+// ID and the regions of the reader
+type cachedAccount struct {
+	AccountID string   `json:"account_id"`
+	Regions   []string `json:"regions"`
+}
+
+// cacheAccountKey is the key of the cachedAccount entry of
+// the last reader, the results being under its account ID
+const cacheAccountKey = "account"
+
+// NewCachedReader returns a CachedReader of r with the opts.
+// On Offline mode r can be nil, in which case the account ID
+// and the regions, and so the results used, are the ones of the
+// last reader used to fill the cache, and DownloadObject fails.
+// An error is returned if they can't be read or stored.
+func NewCachedReader(r AWSReader, opts CacheOptions) (*CachedReader, error) {
+	if opts.Store == nil {
+		opts.Store = NewMemoryCache()
+	}
+
+	c := &CachedReader{reader: r, opts: opts, now: time.Now}
+
+	if r == nil {
+		if !opts.Offline {
+			return nil, errors.New("the reader is required when not offline")
+		}
+
+		e, err := opts.Store.Get(cacheAccountKey)
+		if err != nil {
+			return nil, err
+		} else if e == nil {
+			return nil, ErrCacheMiss
+		}
+
+		err = json.Unmarshal(e.Data, &c.account)
+		if err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	c.account = cachedAccount{AccountID: r.GetAccountID(), Regions: r.GetRegions()}
+
+	b, err := json.Marshal(c.account)
+	if err != nil {
+		return nil, err
+	}
+
+	err = opts.Store.Set(cacheAccountKey, &CacheEntry{StoredAt: c.now(), Data: b})
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// GetAccountID returns the account ID of the AWSReader
+func (c *CachedReader) GetAccountID() string {
+	return c.account.AccountID
+}
+
+// GetRegions returns the regions of the AWSReader
+func (c *CachedReader) GetRegions() []string {
+	return c.account.Regions
+}
+
+// DownloadObject calls the DownloadObject of the AWSReader
+func (c *CachedReader) DownloadObject(
+	ctx context.Context, w io.WriterAt, input *s3.GetObjectInput, options ...func(*s3manager.Downloader),
+) (int64, error) {
+	if c.reader == nil {
+		return 0, ErrCacheMiss
+	}
+
+	return c.reader.DownloadObject(ctx, w, input, options...)
+}
+
+// Invalidate removes the results of the methods, or of all of them
+// if none is given, so they are requested again.
+// Only the results of the account of c are removed.
+func (c *CachedReader) Invalidate(methods ...string) error {
+	keys, err := c.opts.Store.Keys()
+	if err != nil {
+		return err
+	}
+
+	prefix := c.key("")
+	for _, k := range keys {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+
+		if len(methods) > 0 && !containsString(methods, strings.SplitN(strings.TrimPrefix(k, prefix), "/", 2)[0]) {
+			continue
+		}
+
+		err = c.opts.Store.Delete(k)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// call sets on opts, a pointer to the map returned by the method, the
+// results cached of the input on each region or, if any of them is not,
+// the ones returned by fn, which are cached
+func (c *CachedReader) call(
+	ctx context.Context, method, service string, input, opts interface{}, fn func(ctx context.Context) (interface{}, error),
+) error {
+	var (
+		ttl, ok = c.opts.TTLs[method]
+		optsv   = reflect.ValueOf(opts).Elem()
+		res     = reflect.MakeMap(optsv.Type())
+	)
+	if !ok {
+		ttl = c.opts.TTL
+	}
+
+	// The results sent to a ResultFunc aren't returned to be cached
+	if (ttl < 0 || resultFunc(ctx) != nil) && !c.opts.Offline {
+		return c.callReader(ctx, optsv, fn)
+	}
+
+	key, err := cacheKey(input)
+	if err != nil {
+		return err
+	}
+
+	var errs Errors
+	for _, region := range ContextRegions(ctx, c.GetRegions()) {
+		e, err := c.opts.Store.Get(c.key(method, region, key))
+		if err != nil {
+			errs = append(errs, NewError(region, service, err))
+			continue
+		}
+
+		if e == nil || (!c.opts.Offline && ttl > 0 && c.now().Sub(e.StoredAt) >= ttl) {
+			errs = append(errs, NewError(region, service, ErrCacheMiss))
+			continue
+		}
+
+		v := reflect.New(optsv.Type().Elem())
+		err = json.Unmarshal(e.Data, v.Interface())
+		if err != nil {
+			errs = append(errs, NewError(region, service, err))
+			continue
+		}
+
+		res.SetMapIndex(reflect.ValueOf(region), v.Elem())
+	}
+
+	if errs == nil || c.opts.Offline {
+		optsv.Set(res)
+		if errs != nil {
+			return errs
+		}
+		return nil
+	}
+
+	err = c.callReader(ctx, optsv, fn)
+
+	now := c.now()
+	iter := optsv.MapRange()
+	for iter.Next() {
+		region := iter.Key().String()
+
+		b, merr := json.Marshal(iter.Value().Interface())
+		if merr == nil {
+			merr = c.opts.Store.Set(c.key(method, region, key), &CacheEntry{StoredAt: now, Data: b})
+		}
+		if merr == nil {
+			continue
+		}
+
+		rerrs, ok := err.(Errors)
+		if err != nil && !ok {
+			continue
+		}
+		err = append(rerrs, NewError(region, service, merr))
+	}
+
+	return err
+}
+
+// key returns the key of the entry of the path, like the method, the
+// region and the hash of the input, under the account ID of c, so an
+// empty path returns the prefix of all the keys of the account
+func (c *CachedReader) key(path ...string) string {
+	return strings.Join(append([]string{c.account.AccountID}, path...), "/")
+}
+
+// callReader sets on optsv the results of fn
+func (c *CachedReader) callReader(ctx context.Context, optsv reflect.Value, fn func(ctx context.Context) (interface{}, error)) error {
+	opts, err := fn(ctx)
+	optsv.Set(reflect.ValueOf(opts))
+
+	return err
+}
+
+// cacheKey returns the key of the input, which is the hash of
+// its JSON, where a nil input is the same as an empty one
+func cacheKey(input interface{}) (string, error) {
+	v := reflect.ValueOf(input)
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		input = reflect.New(v.Type().Elem()).Interface()
+	}
+
+	b, err := json.Marshal(input)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.Sum256(b)
+
+	return hex.EncodeToString(h[:]), nil
+}
+
+// memoryCache is a CacheStore keeping the entries in memory
+type memoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]*CacheEntry
+}
+
+// NewMemoryCache returns a CacheStore keeping the entries in memory
+func NewMemoryCache() CacheStore {
+	return &memoryCache{entries: make(map[string]*CacheEntry)}
+}
+
+func (m *memoryCache) Get(key string) (*CacheEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.entries[key], nil
+}
+
+func (m *memoryCache) Set(key string, e *CacheEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[key] = e
+
+	return nil
+}
+
+func (m *memoryCache) Delete(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, key)
+
+	return nil
+}
+
+func (m *memoryCache) Keys() ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := make([]string, 0, len(m.entries))
+	for k := range m.entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// diskCache is a CacheStore keeping each entry
+// on a JSON file of the dir, by its key
+type diskCache struct {
+	dir string
+}
+
+// NewDiskCache returns a CacheStore keeping each entry as a JSON
+// file on the dir, like "<account ID>/GetVpcs/eu-west-1/<hash of the input>.json",
+// so they can be used by other processes or kept to be used offline
+func NewDiskCache(dir string) CacheStore {
+	return diskCache{dir: dir}
+}
+
+func (d diskCache) path(key string) string {
+	return filepath.Join(d.dir, filepath.FromSlash(key)+".json")
+}
+
+func (d diskCache) Get(key string) (*CacheEntry, error) {
+	b, err := ioutil.ReadFile(d.path(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var e CacheEntry
+	err = json.Unmarshal(b, &e)
+	if err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+func (d diskCache) Set(key string, e *CacheEntry) error {
+	p := d.path(key)
+
+	err := os.MkdirAll(filepath.Dir(p), 0755)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	// The entry is written on a temporary file which is then
+	// renamed, so the other processes never read it partially
+	f, err := ioutil.TempFile(filepath.Dir(p), ".tmp-")
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(b)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), p)
+}
+
+func (d diskCache) Delete(key string) error {
+	err := os.Remove(d.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
+
+func (d diskCache) Keys() ([]string, error) {
+	var keys []string
+
+	err := filepath.Walk(d.dir, func(p string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) && p == d.dir {
+			return filepath.SkipDir
+		} else if err != nil {
+			return err
+		}
+
+		if info.IsDir() || filepath.Ext(p) != ".json" || strings.HasPrefix(info.Name(), ".") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(d.dir, p)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, strings.TrimSuffix(filepath.ToSlash(rel), ".json"))
+
+		return nil
+	})
+
+	return keys, err
+}
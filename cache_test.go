@@ -0,0 +1,257 @@
+package raws
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// mockCacheReader is an AWSReader of the account returning the Vpcs
+// of each region, or the error of the region if there is one
+type mockCacheReader struct {
+	AWSReader
+
+	account string
+	calls   *int
+	vpcs    map[string]ec2.DescribeVpcsOutput
+	errors  map[string]error
+}
+
+func (m mockCacheReader) GetAccountID() string { return m.account }
+
+func (m mockCacheReader) GetRegions() []string { return []string{"eu-west-1", "eu-west-3"} }
+
+func (m mockCacheReader) GetVpcs(_ context.Context, _ *ec2.DescribeVpcsInput) (map[string]ec2.DescribeVpcsOutput, error) {
+	*m.calls++
+
+	var (
+		errs Errors
+		opts = make(map[string]ec2.DescribeVpcsOutput)
+	)
+	for _, r := range m.GetRegions() {
+		if err, ok := m.errors[r]; ok {
+			errs = append(errs, NewError(r, ec2.ServiceName, err))
+			continue
+		}
+		opts[r] = m.vpcs[r]
+	}
+
+	if errs != nil {
+		return opts, errs
+	}
+
+	return opts, nil
+}
+
+func TestCachedReader(t *testing.T) {
+	var (
+		vpcs = map[string]ec2.DescribeVpcsOutput{
+			"eu-west-1": {Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-1")}}},
+			"eu-west-3": {Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-3")}}},
+		}
+		err3 = NewError("eu-west-3", ec2.ServiceName, errors.New("error"))
+		now  = time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	dir, err := ioutil.TempDir("", "raws")
+	if err != nil {
+		t.Fatalf("dir - errors: received=%+v | expected=nil", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name  string
+		store CacheStore
+	}{
+		{name: "memory", store: NewMemoryCache()},
+		{name: "disk", store: NewDiskCache(dir)},
+	}
+
+	for i, tt := range tests {
+		var (
+			calls int
+			m     = mockCacheReader{account: "123", calls: &calls, vpcs: vpcs, errors: map[string]error{"eu-west-3": errors.New("error")}}
+		)
+
+		c, err := NewCachedReader(m, CacheOptions{Store: tt.store, TTL: time.Hour, TTLs: map[string]time.Duration{"GetSubnets": -1}})
+		if err != nil {
+			t.Fatalf("%s [%d] - errors: received=%+v | expected=nil", tt.name, i, err)
+		}
+		c.now = func() time.Time { return now }
+
+		// The region with an error is not cached, so the next call is made again
+		opts, err := c.GetVpcs(context.Background(), nil)
+		checkErrors(t, tt.name, i, err, Errors{err3})
+		if !reflect.DeepEqual(opts, map[string]ec2.DescribeVpcsOutput{"eu-west-1": vpcs["eu-west-1"]}) {
+			t.Errorf("%s [%d] - first: received=%+v", tt.name, i, opts)
+		}
+
+		delete(m.errors, "eu-west-3")
+		for j := 0; j < 2; j++ {
+			opts, err = c.GetVpcs(context.Background(), &ec2.DescribeVpcsInput{})
+			checkErrors(t, tt.name, i, err, nil)
+			if !reflect.DeepEqual(opts, vpcs) {
+				t.Errorf("%s [%d] - cached: received=%+v | expected=%+v", tt.name, i, opts, vpcs)
+			}
+		}
+		if calls != 2 {
+			t.Errorf("%s [%d] - calls: received=%d | expected=2", tt.name, i, calls)
+		}
+
+		// Other inputs are cached separately
+		_, err = c.GetVpcs(context.Background(), &ec2.DescribeVpcsInput{VpcIds: []*string{aws.String("vpc-1")}})
+		checkErrors(t, tt.name, i, err, nil)
+		if calls != 3 {
+			t.Errorf("%s [%d] - input calls: received=%d | expected=3", tt.name, i, calls)
+		}
+
+		// The expired entries are requested again
+		now = now.Add(time.Hour)
+		_, err = c.GetVpcs(context.Background(), nil)
+		checkErrors(t, tt.name, i, err, nil)
+		if calls != 4 {
+			t.Errorf("%s [%d] - expired calls: received=%d | expected=4", tt.name, i, calls)
+		}
+
+		err = c.Invalidate("GetSubnets")
+		checkErrors(t, tt.name, i, err, nil)
+		_, err = c.GetVpcs(context.Background(), nil)
+		checkErrors(t, tt.name, i, err, nil)
+		if calls != 4 {
+			t.Errorf("%s [%d] - other invalidated calls: received=%d | expected=4", tt.name, i, calls)
+		}
+
+		// The offline reader uses the entries of the store whatever their age,
+		// and the account ID and regions of the reader which stored them
+		now = now.Add(24 * time.Hour)
+		o, err := NewCachedReader(nil, CacheOptions{Store: tt.store, TTL: time.Hour, Offline: true})
+		if err != nil {
+			t.Fatalf("%s [%d] - offline errors: received=%+v | expected=nil", tt.name, i, err)
+		}
+		o.now = c.now
+
+		if o.GetAccountID() != "123" || !reflect.DeepEqual(o.GetRegions(), m.GetRegions()) {
+			t.Errorf("%s [%d] - offline account: received=%s %+v", tt.name, i, o.GetAccountID(), o.GetRegions())
+		}
+
+		opts, err = o.GetVpcs(context.Background(), nil)
+		checkErrors(t, tt.name, i, err, nil)
+		if !reflect.DeepEqual(opts, vpcs) {
+			t.Errorf("%s [%d] - offline: received=%+v | expected=%+v", tt.name, i, opts, vpcs)
+		}
+
+		subnets, err := o.GetSubnets(context.Background(), nil)
+		checkErrors(t, tt.name, i, err, Errors{
+			NewError("eu-west-1", ec2.ServiceName, ErrCacheMiss),
+			NewError("eu-west-3", ec2.ServiceName, ErrCacheMiss),
+		})
+		if subnets == nil || len(subnets) != 0 {
+			t.Errorf("%s [%d] - offline miss: received=%+v", tt.name, i, subnets)
+		}
+
+		err = c.Invalidate()
+		checkErrors(t, tt.name, i, err, nil)
+		_, err = c.GetVpcs(context.Background(), nil)
+		checkErrors(t, tt.name, i, err, nil)
+		if calls != 5 {
+			t.Errorf("%s [%d] - invalidated calls: received=%d | expected=5", tt.name, i, calls)
+		}
+
+		keys, err := tt.store.Keys()
+		checkErrors(t, tt.name, i, err, nil)
+		if len(keys) != 3 {
+			t.Errorf("%s [%d] - keys: received=%+v", tt.name, i, keys)
+		}
+	}
+
+	_, err = NewCachedReader(nil, CacheOptions{Store: NewMemoryCache(), Offline: true})
+	checkErrors(t, "empty offline", -1, err, ErrCacheMiss)
+}
+
+func TestCachedReaderAccounts(t *testing.T) {
+	var (
+		calls    int
+		accounts = []string{"123", "456"}
+		vpcs     = map[string]map[string]ec2.DescribeVpcsOutput{
+			"123": {
+				"eu-west-1": {Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-123-1")}}},
+				"eu-west-3": {Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-123-3")}}},
+			},
+			"456": {
+				"eu-west-1": {Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-456-1")}}},
+				"eu-west-3": {Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-456-3")}}},
+			},
+		}
+		store   = NewMemoryCache()
+		readers = make(map[string]*CachedReader)
+	)
+
+	// The readers of both accounts share the store, so each
+	// one of them calls its AWSReader and gets its own results
+	for i, a := range accounts {
+		c, err := NewCachedReader(mockCacheReader{account: a, calls: &calls, vpcs: vpcs[a]}, CacheOptions{Store: store})
+		if err != nil {
+			t.Fatalf("%s [%d] - errors: received=%+v | expected=nil", a, i, err)
+		}
+		readers[a] = c
+	}
+
+	for j := 0; j < 2; j++ {
+		for i, a := range accounts {
+			opts, err := readers[a].GetVpcs(context.Background(), nil)
+			checkErrors(t, a, i, err, nil)
+			if !reflect.DeepEqual(opts, vpcs[a]) {
+				t.Errorf("%s [%d] - vpcs: received=%+v | expected=%+v", a, i, opts, vpcs[a])
+			}
+		}
+	}
+	if calls != 2 {
+		t.Errorf("accounts - calls: received=%d | expected=2", calls)
+	}
+
+	// The offline readers use the results of their account, which
+	// is the one of the last reader if they don't have a reader
+	tests := []struct {
+		name     string
+		reader   AWSReader
+		expected string
+	}{
+		{name: "last reader", expected: "456"},
+		{name: "reader", reader: mockCacheReader{account: "123"}, expected: "123"},
+	}
+
+	for i, tt := range tests {
+		o, err := NewCachedReader(tt.reader, CacheOptions{Store: store, Offline: true})
+		if err != nil {
+			t.Fatalf("%s [%d] - errors: received=%+v | expected=nil", tt.name, i, err)
+		}
+
+		if o.GetAccountID() != tt.expected {
+			t.Errorf("%s [%d] - account: received=%s | expected=%s", tt.name, i, o.GetAccountID(), tt.expected)
+		}
+
+		opts, err := o.GetVpcs(context.Background(), nil)
+		checkErrors(t, tt.name, i, err, nil)
+		if !reflect.DeepEqual(opts, vpcs[tt.expected]) {
+			t.Errorf("%s [%d] - vpcs: received=%+v | expected=%+v", tt.name, i, opts, vpcs[tt.expected])
+		}
+	}
+
+	// Invalidate only removes the results of the account
+	err := readers["123"].Invalidate()
+	checkErrors(t, "invalidate", -1, err, nil)
+	for i, a := range accounts {
+		_, err = readers[a].GetVpcs(context.Background(), nil)
+		checkErrors(t, a, i, err, nil)
+	}
+	if calls != 3 {
+		t.Errorf("invalidate - calls: received=%d | expected=3", calls)
+	}
+}
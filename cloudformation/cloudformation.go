@@ -0,0 +1,320 @@
+// Package cloudformation generates the CloudFormation template of the
+// resources found by raws, so the infrastructure created without
+// CloudFormation can be imported on a stack.
+//
+// Each supported resource is a resource of the template, with a logical ID
+// built from its type and name, the properties known from the resource and
+// a "Retain" DeletionPolicy, which is required to import it. The properties
+// which are other resources of the template, like the VPC of a subnet, are
+// replaced by a Ref or a Fn::GetAtt to them.
+//
+// A stack is regional, so a template only has the resources of one region,
+// and the ones of the global services, like IAM.
+//
+// The resources to import file is the JSON expected by the
+// --resources-to-import flag of "aws cloudformation create-change-set"
+// when --change-set-type is IMPORT.
+package cloudformation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cycloidio/raws"
+	"github.com/cycloidio/raws/internal/value"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Template is the CloudFormation template of a list of resources
+type Template struct {
+	// Resources are the resources of the template,
+	// in the same order than the supported resources
+	Resources []Resource
+
+	// Skipped are the resources which can't
+	// be converted to CloudFormation
+	Skipped []raws.Resource
+
+	// Excluded are the resources of other regions
+	// than the Region of the GenerateOptions
+	Excluded []raws.Resource
+}
+
+// GenerateOptions are the options of Generate
+type GenerateOptions struct {
+	// Region is the region of the stack, the resources of the other
+	// regions are excluded from the Template, except the ones of the
+	// global services, like IAM. It's required if the resources are
+	// from several regions
+	Region string
+}
+
+// Resource is a resource of the Template
+type Resource struct {
+	// LogicalID is the ID of the resource on the Template
+	LogicalID string
+
+	// Type is the CloudFormation type of the
+	// resource, like "AWS::EC2::Instance"
+	Type string
+
+	// Identifier are the properties which identify the
+	// resource to import it, like {"InstanceId": "i-1"}
+	Identifier map[string]string
+
+	// Properties are the properties of the resource, with the
+	// links to the other resources of the template resolved
+	Properties map[string]interface{}
+
+	// Source is the resource from which it has been generated
+	Source raws.Resource
+}
+
+// converter converts a resource, with its Raw as a generic value,
+// to the CloudFormation type, identifier and properties
+type converter func(r raws.Resource, raw value.Value) (typ string, identifier map[string]string, props properties)
+
+// converters are the converters of the supported resources,
+// by the Service and Type of the resources joined by a dot
+var converters = map[string]converter{
+	"ec2.instance":         instance,
+	"ec2.vpc":              vpc,
+	"ec2.subnet":           subnet,
+	"ec2.security-group":   securityGroup,
+	"ec2.volume":           volume,
+	"rds.db-instance":      dbInstance,
+	"s3.bucket":            bucket,
+	"iam.role":             role,
+	"iam.user":             user,
+	"iam.group":            group,
+	"iam.instance-profile": instanceProfile,
+}
+
+// Generate returns the CloudFormation Template of the resources, which can
+// be the ones of a raws.Snapshot, built by raws.Inventory or read with
+// raws.ReadSnapshot.
+// The resources which aren't supported are returned on Template.Skipped,
+// and the ones of other regions than opts.Region on Template.Excluded.
+// An error is returned if opts.Region is empty and the supported resources
+// are from several regions.
+func Generate(resources []raws.Resource, opts GenerateOptions) (*Template, error) {
+	var (
+		t         = &Template{}
+		props     []properties
+		supported []raws.Resource
+		regions   = make(map[string]bool)
+
+		// ids are the logical IDs of the resources
+		// by their type and identifier value
+		ids  = make(map[string]string)
+		used = make(map[string]bool)
+	)
+
+	for _, r := range resources {
+		if _, ok := converters[r.Service+"."+r.Type]; !ok {
+			t.Skipped = append(t.Skipped, r)
+			continue
+		}
+
+		if r.Region != "" && opts.Region != "" && r.Region != opts.Region {
+			t.Excluded = append(t.Excluded, r)
+			continue
+		}
+
+		if r.Region != "" {
+			regions[r.Region] = true
+		}
+		supported = append(supported, r)
+	}
+
+	if len(regions) > 1 {
+		rs := make([]string, 0, len(regions))
+		for r := range regions {
+			rs = append(rs, r)
+		}
+		sort.Strings(rs)
+
+		return nil, fmt.Errorf("the resources are from several regions, %s, and a stack only has the ones of one region", strings.Join(rs, ", "))
+	}
+
+	for _, r := range supported {
+		conv := converters[r.Service+"."+r.Type]
+
+		raw, err := value.Of(r.Raw)
+		if err != nil {
+			return nil, err
+		}
+
+		typ, identifier, p := conv(r, raw)
+
+		// The suffix can give the logical ID of another
+		// resource, like "InstanceWeb2", so it's checked too
+		base := logicalID(typ, r)
+		id := base
+		for n := 2; used[id]; n++ {
+			id = base + strconv.Itoa(n)
+		}
+		used[id] = true
+
+		for _, v := range identifier {
+			ids[typ+"|"+v] = id
+		}
+
+		t.Resources = append(t.Resources, Resource{
+			LogicalID:  id,
+			Type:       typ,
+			Identifier: identifier,
+			Source:     r,
+		})
+		props = append(props, p)
+	}
+
+	for i := range t.Resources {
+		resolved := make(map[string]interface{}, len(props[i]))
+		for k, v := range props[i] {
+			resolved[k] = resolve(v, ids, t.Resources[i].LogicalID)
+		}
+		t.Resources[i].Properties = resolved
+	}
+
+	return t, nil
+}
+
+// template is the document of the Template
+type template struct {
+	AWSTemplateFormatVersion string              `json:"AWSTemplateFormatVersion" yaml:"AWSTemplateFormatVersion"`
+	Description              string              `json:"Description" yaml:"Description"`
+	Resources                map[string]resource `json:"Resources" yaml:"Resources"`
+}
+
+type resource struct {
+	Type           string                 `json:"Type" yaml:"Type"`
+	DeletionPolicy string                 `json:"DeletionPolicy" yaml:"DeletionPolicy"`
+	Properties     map[string]interface{} `json:"Properties,omitempty" yaml:"Properties,omitempty"`
+}
+
+func (t *Template) document() template {
+	doc := template{
+		AWSTemplateFormatVersion: "2010-09-09",
+		Description:              "Resources imported by raws",
+		Resources:                make(map[string]resource, len(t.Resources)),
+	}
+
+	for _, r := range t.Resources {
+		doc.Resources[r.LogicalID] = resource{
+			Type:           r.Type,
+			DeletionPolicy: "Retain",
+			Properties:     r.Properties,
+		}
+	}
+
+	return doc
+}
+
+// WriteJSON writes on w the Template as JSON
+func (t *Template) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(t.document())
+}
+
+// WriteYAML writes on w the Template as YAML
+func (t *Template) WriteYAML(w io.Writer) error {
+	b, err := yaml.Marshal(t.document())
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	return err
+}
+
+// WriteImports writes on w the resources to import, as
+// expected by the CreateChangeSet of type IMPORT
+func (t *Template) WriteImports(w io.Writer) error {
+	type resourceToImport struct {
+		ResourceType       string            `json:"ResourceType"`
+		LogicalResourceID  string            `json:"LogicalResourceId"`
+		ResourceIdentifier map[string]string `json:"ResourceIdentifier"`
+	}
+
+	imports := make([]resourceToImport, 0, len(t.Resources))
+	for _, r := range t.Resources {
+		imports = append(imports, resourceToImport{
+			ResourceType:       r.Type,
+			LogicalResourceID:  r.LogicalID,
+			ResourceIdentifier: r.Identifier,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(imports)
+}
+
+// ref is a property which is another resource, identified by
+// its type and the value of its identifier, which is replaced
+// by a Ref to it, or a Fn::GetAtt of attr if it's set, when
+// the resource is on the template, or by the id if it's not
+type ref struct {
+	typ  string
+	id   string
+	attr string
+}
+
+func resolve(v interface{}, ids map[string]string, self string) interface{} {
+	switch vv := v.(type) {
+	case ref:
+		// A resource can't reference itself
+		id, ok := ids[vv.typ+"|"+vv.id]
+		if !ok || id == self {
+			return vv.id
+		}
+		if vv.attr == "" {
+			return map[string]interface{}{"Ref": id}
+		}
+		return map[string]interface{}{"Fn::GetAtt": []interface{}{id, vv.attr}}
+	case []interface{}:
+		l := make([]interface{}, 0, len(vv))
+		for _, e := range vv {
+			l = append(l, resolve(e, ids, self))
+		}
+		return l
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(vv))
+		for k, e := range vv {
+			m[k] = resolve(e, ids, self)
+		}
+		return m
+	default:
+		return v
+	}
+}
+
+var nonAlphanumeric = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// logicalID returns the logical ID of r, which only has alphanumeric
+// characters, as the type followed by the Name, or the ID, of r in
+// Pascal case, like "InstanceWebServer" for the "web-server" instance
+func logicalID(typ string, r raws.Resource) string {
+	name := r.Name
+	if name == "" {
+		name = r.ID
+	}
+
+	id := typ[strings.LastIndex(typ, ":")+1:]
+	for _, p := range nonAlphanumeric.Split(name, -1) {
+		if p != "" {
+			id += strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+
+	return id
+}
@@ -0,0 +1,280 @@
+package cloudformation
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/cycloidio/raws"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func newResource(t *testing.T, region string, v interface{}) raws.Resource {
+	r, err := raws.NewResource("123", region, v)
+	if err != nil {
+		t.Fatalf("resource - errors: received=%+v | expected=nil", err)
+	}
+
+	return r
+}
+
+func TestGenerate(t *testing.T) {
+	resources := []raws.Resource{
+		newResource(t, "eu-west-1", &ec2.Instance{
+			InstanceId:         aws.String("i-1"),
+			ImageId:            aws.String("ami-1"),
+			SubnetId:           aws.String("subnet-1"),
+			SecurityGroups:     []*ec2.GroupIdentifier{{GroupId: aws.String("sg-1")}, {GroupId: aws.String("sg-2")}},
+			IamInstanceProfile: &ec2.IamInstanceProfile{Arn: aws.String("arn:aws:iam::123:instance-profile/web")},
+			Tags: []*ec2.Tag{
+				{Key: aws.String("Name"), Value: aws.String("web-server")},
+				{Key: aws.String("aws:cloudformation:stack-name"), Value: aws.String("web")},
+			},
+		}),
+		newResource(t, "eu-west-1", &ec2.Subnet{SubnetId: aws.String("subnet-1"), VpcId: aws.String("vpc-1")}),
+		newResource(t, "eu-west-1", &ec2.Vpc{VpcId: aws.String("vpc-1"), CidrBlock: aws.String("10.0.0.0/16")}),
+		newResource(t, "eu-west-1", &ec2.SecurityGroup{
+			GroupId:     aws.String("sg-1"),
+			GroupName:   aws.String("web"),
+			Description: aws.String("Web"),
+			VpcId:       aws.String("vpc-1"),
+			IpPermissions: []*ec2.IpPermission{{
+				FromPort:         aws.Int64(443),
+				ToPort:           aws.Int64(443),
+				IpProtocol:       aws.String("tcp"),
+				IpRanges:         []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+				UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: aws.String("sg-1")}},
+			}},
+		}),
+		newResource(t, "", &iam.InstanceProfile{
+			InstanceProfileId:   aws.String("AIPA1"),
+			InstanceProfileName: aws.String("web"),
+			Roles:               []*iam.Role{{RoleName: aws.String("web")}},
+		}),
+		newResource(t, "", raws.RoleWithPolicies{
+			Item: &iam.Role{
+				RoleId:                   aws.String("AROA1"),
+				RoleName:                 aws.String("web"),
+				AssumeRolePolicyDocument: aws.String("%7B%22Version%22%3A%222012-10-17%22%7D"),
+			},
+			AttachedPolicies: &iam.ListAttachedRolePoliciesOutput{
+				AttachedPolicies: []*iam.AttachedPolicy{{PolicyArn: aws.String("arn:aws:iam::aws:policy/ReadOnlyAccess")}},
+			},
+		}),
+		newResource(t, "eu-west-1", &ec2.Image{ImageId: aws.String("ami-1")}),
+	}
+
+	tpl, err := Generate(resources, GenerateOptions{})
+	if err != nil {
+		t.Fatalf("generate - errors: received=%+v | expected=nil", err)
+	}
+
+	var ids []string
+	properties := make(map[string]map[string]interface{})
+	for _, r := range tpl.Resources {
+		ids = append(ids, r.LogicalID)
+		properties[r.LogicalID] = r.Properties
+	}
+
+	tests := []struct {
+		name     string
+		received interface{}
+		expected interface{}
+	}{
+		{name: "logical IDs",
+			received: ids,
+			expected: []string{"InstanceWebServer", "SubnetSubnet1", "VPCVpc1", "SecurityGroupWeb", "InstanceProfileWeb", "RoleWeb"},
+		},
+		{name: "skipped",
+			received: len(tpl.Skipped),
+			expected: 1,
+		},
+		{name: "instance",
+			received: properties["InstanceWebServer"],
+			expected: map[string]interface{}{
+				"ImageId":  "ami-1",
+				"SubnetId": map[string]interface{}{"Ref": "SubnetSubnet1"},
+				"SecurityGroupIds": []interface{}{
+					map[string]interface{}{"Fn::GetAtt": []interface{}{"SecurityGroupWeb", "GroupId"}},
+					"sg-2",
+				},
+				"IamInstanceProfile": map[string]interface{}{"Ref": "InstanceProfileWeb"},
+				"Tags":               []interface{}{map[string]interface{}{"Key": "Name", "Value": "web-server"}},
+			},
+		},
+		{name: "security group",
+			received: properties["SecurityGroupWeb"],
+			expected: map[string]interface{}{
+				"GroupName":        "web",
+				"GroupDescription": "Web",
+				"VpcId":            map[string]interface{}{"Ref": "VPCVpc1"},
+				"SecurityGroupIngress": []interface{}{
+					map[string]interface{}{"IpProtocol": "tcp", "FromPort": 443.0, "ToPort": 443.0, "CidrIp": "0.0.0.0/0"},
+					map[string]interface{}{"IpProtocol": "tcp", "FromPort": 443.0, "ToPort": 443.0, "SourceSecurityGroupId": "sg-1"},
+				},
+			},
+		},
+		{name: "role",
+			received: properties["RoleWeb"],
+			expected: map[string]interface{}{
+				"RoleName":                 "web",
+				"AssumeRolePolicyDocument": map[string]interface{}{"Version": "2012-10-17"},
+				"ManagedPolicyArns":        []interface{}{"arn:aws:iam::aws:policy/ReadOnlyAccess"},
+			},
+		}}
+
+	for i, tt := range tests {
+		if !reflect.DeepEqual(tt.received, tt.expected) {
+			t.Errorf("%s [%d] - received=%+v | expected=%+v", tt.name, i, tt.received, tt.expected)
+		}
+	}
+
+	tpl.Resources = tpl.Resources[1:3]
+
+	var buff bytes.Buffer
+	err = tpl.WriteYAML(&buff)
+	if err != nil {
+		t.Fatalf("yaml - errors: received=%+v | expected=nil", err)
+	}
+
+	expectedYAML := `AWSTemplateFormatVersion: "2010-09-09"
+Description: Resources imported by raws
+Resources:
+  SubnetSubnet1:
+    Type: AWS::EC2::Subnet
+    DeletionPolicy: Retain
+    Properties:
+      VpcId:
+        Ref: VPCVpc1
+  VPCVpc1:
+    Type: AWS::EC2::VPC
+    DeletionPolicy: Retain
+    Properties:
+      CidrBlock: 10.0.0.0/16
+`
+	if buff.String() != expectedYAML {
+		t.Errorf("yaml: received=%s | expected=%s", buff.String(), expectedYAML)
+	}
+
+	var doc interface{}
+	err = yaml.Unmarshal(buff.Bytes(), &doc)
+	if err != nil {
+		t.Fatalf("yaml - errors: received=%+v | expected=nil", err)
+	}
+
+	buff.Reset()
+	err = tpl.WriteJSON(&buff)
+	if err != nil {
+		t.Fatalf("json - errors: received=%+v | expected=nil", err)
+	}
+	err = json.Unmarshal(buff.Bytes(), &doc)
+	if err != nil {
+		t.Fatalf("json - errors: received=%+v | expected=nil", err)
+	}
+
+	buff.Reset()
+	err = tpl.WriteImports(&buff)
+	if err != nil {
+		t.Fatalf("imports - errors: received=%+v | expected=nil", err)
+	}
+
+	var imports []map[string]interface{}
+	err = json.Unmarshal(buff.Bytes(), &imports)
+	if err != nil {
+		t.Fatalf("imports - errors: received=%+v | expected=nil", err)
+	}
+
+	expectedImports := []map[string]interface{}{
+		{"ResourceType": "AWS::EC2::Subnet", "LogicalResourceId": "SubnetSubnet1", "ResourceIdentifier": map[string]interface{}{"SubnetId": "subnet-1"}},
+		{"ResourceType": "AWS::EC2::VPC", "LogicalResourceId": "VPCVpc1", "ResourceIdentifier": map[string]interface{}{"VpcId": "vpc-1"}},
+	}
+	if !reflect.DeepEqual(imports, expectedImports) {
+		t.Errorf("imports: received=%+v | expected=%+v", imports, expectedImports)
+	}
+}
+
+func TestGenerate_regions(t *testing.T) {
+	var (
+		vpc1 = newResource(t, "eu-west-1", &ec2.Vpc{VpcId: aws.String("vpc-1")})
+		vpc2 = newResource(t, "eu-west-3", &ec2.Vpc{VpcId: aws.String("vpc-2")})
+		role = newResource(t, "", &iam.Role{RoleId: aws.String("AROA1"), RoleName: aws.String("web")})
+		img  = newResource(t, "eu-west-3", &ec2.Image{ImageId: aws.String("ami-1")})
+	)
+
+	tests := []struct {
+		name             string
+		resources        []raws.Resource
+		opts             GenerateOptions
+		expectedIDs      []string
+		expectedExcluded int
+		expectedError    bool
+	}{
+		{name: "single region",
+			resources:   []raws.Resource{vpc1, role, img},
+			expectedIDs: []string{"VPCVpc1", "RoleWeb"},
+		},
+		{name: "several regions",
+			resources:     []raws.Resource{vpc1, vpc2, role},
+			expectedError: true,
+		},
+		{name: "several regions with a region",
+			resources:        []raws.Resource{vpc1, vpc2, role},
+			opts:             GenerateOptions{Region: "eu-west-3"},
+			expectedIDs:      []string{"VPCVpc2", "RoleWeb"},
+			expectedExcluded: 1,
+		}}
+
+	for i, tt := range tests {
+		tpl, err := Generate(tt.resources, tt.opts)
+		if (err != nil) != tt.expectedError {
+			t.Errorf("%s [%d] - errors: received=%+v | expected=%+v", tt.name, i, err, tt.expectedError)
+		}
+		if err != nil {
+			continue
+		}
+
+		var ids []string
+		for _, r := range tpl.Resources {
+			ids = append(ids, r.LogicalID)
+		}
+		if !reflect.DeepEqual(ids, tt.expectedIDs) {
+			t.Errorf("%s [%d] - logical IDs: received=%+v | expected=%+v", tt.name, i, ids, tt.expectedIDs)
+		}
+		if len(tpl.Excluded) != tt.expectedExcluded {
+			t.Errorf("%s [%d] - excluded: received=%d | expected=%d", tt.name, i, len(tpl.Excluded), tt.expectedExcluded)
+		}
+	}
+}
+
+func TestGenerate_logicalIDs(t *testing.T) {
+	instance := func(id, name string) raws.Resource {
+		return newResource(t, "eu-west-1", &ec2.Instance{
+			InstanceId: aws.String(id),
+			Tags:       []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String(name)}},
+		})
+	}
+
+	tpl, err := Generate([]raws.Resource{
+		instance("i-1", "web"),
+		instance("i-2", "web2"),
+		instance("i-3", "web"),
+		instance("i-4", "web"),
+	}, GenerateOptions{})
+	if err != nil {
+		t.Fatalf("generate - errors: received=%+v | expected=nil", err)
+	}
+
+	var ids []string
+	for _, r := range tpl.Resources {
+		ids = append(ids, r.LogicalID)
+	}
+
+	expected := []string{"InstanceWeb", "InstanceWeb2", "InstanceWeb3", "InstanceWeb4"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("logical IDs: received=%+v | expected=%+v", ids, expected)
+	}
+}
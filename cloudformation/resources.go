@@ -0,0 +1,297 @@
+package cloudformation
+
+import (
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cycloidio/raws"
+	"github.com/cycloidio/raws/internal/value"
+)
+
+const (
+	typeInstance        = "AWS::EC2::Instance"
+	typeVPC             = "AWS::EC2::VPC"
+	typeSubnet          = "AWS::EC2::Subnet"
+	typeSecurityGroup   = "AWS::EC2::SecurityGroup"
+	typeVolume          = "AWS::EC2::Volume"
+	typeDBInstance      = "AWS::RDS::DBInstance"
+	typeBucket          = "AWS::S3::Bucket"
+	typeRole            = "AWS::IAM::Role"
+	typeUser            = "AWS::IAM::User"
+	typeGroup           = "AWS::IAM::Group"
+	typeInstanceProfile = "AWS::IAM::InstanceProfile"
+)
+
+// properties builds the properties skipping the ones without value
+type properties map[string]interface{}
+
+func (p properties) set(name string, v interface{}) {
+	switch vv := v.(type) {
+	case nil:
+		return
+	case string:
+		if vv == "" {
+			return
+		}
+	case []interface{}:
+		if len(vv) == 0 {
+			return
+		}
+	case value.Value:
+		p.set(name, vv.V)
+		return
+	}
+
+	p[name] = v
+}
+
+// tags returns the tags of r as a CloudFormation list of tags, sorted by
+// key, without the ones starting by "aws:" which can't be set
+func tags(r raws.Resource) []interface{} {
+	keys := make([]string, 0, len(r.Tags))
+	for k := range r.Tags {
+		if !strings.HasPrefix(k, "aws:") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	l := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		l = append(l, map[string]interface{}{"Key": k, "Value": r.Tags[k]})
+	}
+
+	return l
+}
+
+// refs returns the ids as refs to resources of typ
+func refs(typ, attr string, ids []string) []interface{} {
+	l := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		l = append(l, ref{typ: typ, id: id, attr: attr})
+	}
+
+	return l
+}
+
+// refOrNil returns the ref to the resource id of typ,
+// or nil if id is empty so the property is not set
+func refOrNil(typ, attr, id string) interface{} {
+	if id == "" {
+		return nil
+	}
+
+	return ref{typ: typ, id: id, attr: attr}
+}
+
+func instance(r raws.Resource, raw value.Value) (string, map[string]string, properties) {
+	item := raw.Item()
+	p := properties{}
+	p.set("ImageId", item.Get("ImageId"))
+	p.set("InstanceType", item.Get("InstanceType"))
+	p.set("AvailabilityZone", item.Get("Placement.AvailabilityZone"))
+	p.set("SubnetId", refOrNil(typeSubnet, "", item.Str("SubnetId")))
+	p.set("SecurityGroupIds", refs(typeSecurityGroup, "GroupId", item.Strs("SecurityGroups", "GroupId")))
+	p.set("KeyName", item.Get("KeyName"))
+	if arn := item.Str("IamInstanceProfile.Arn"); arn != "" {
+		p.set("IamInstanceProfile", ref{typ: typeInstanceProfile, id: arn[strings.LastIndex(arn, "/")+1:]})
+	}
+	p.set("Tags", tags(r))
+
+	return typeInstance, map[string]string{"InstanceId": r.ID}, p
+}
+
+func vpc(r raws.Resource, raw value.Value) (string, map[string]string, properties) {
+	item := raw.Item()
+	p := properties{}
+	p.set("CidrBlock", item.Get("CidrBlock"))
+	p.set("InstanceTenancy", item.Get("InstanceTenancy"))
+	p.set("Tags", tags(r))
+
+	return typeVPC, map[string]string{"VpcId": r.ID}, p
+}
+
+func subnet(r raws.Resource, raw value.Value) (string, map[string]string, properties) {
+	item := raw.Item()
+	p := properties{}
+	p.set("VpcId", refOrNil(typeVPC, "", item.Str("VpcId")))
+	p.set("CidrBlock", item.Get("CidrBlock"))
+	p.set("AvailabilityZone", item.Get("AvailabilityZone"))
+	p.set("MapPublicIpOnLaunch", item.Get("MapPublicIpOnLaunch"))
+	p.set("Tags", tags(r))
+
+	return typeSubnet, map[string]string{"SubnetId": r.ID}, p
+}
+
+func securityGroup(r raws.Resource, raw value.Value) (string, map[string]string, properties) {
+	item := raw.Item()
+	p := properties{}
+	p.set("GroupName", item.Get("GroupName"))
+	p.set("GroupDescription", item.Get("Description"))
+	p.set("VpcId", refOrNil(typeVPC, "", item.Str("VpcId")))
+	p.set("SecurityGroupIngress", securityGroupRules(item.List("IpPermissions"), "Source"))
+	p.set("SecurityGroupEgress", securityGroupRules(item.List("IpPermissionsEgress"), "Destination"))
+	p.set("Tags", tags(r))
+
+	return typeSecurityGroup, map[string]string{"Id": r.ID}, p
+}
+
+// securityGroupRules returns the rules of the permissions, which have
+// a single source or destination each, with the prefix dir.
+// The security groups of the rules are not replaced by references
+// as the groups referencing each other are circular dependencies
+func securityGroupRules(perms []value.Value, dir string) []interface{} {
+	var rules []interface{}
+	for _, perm := range perms {
+		rule := func(target string, v interface{}) {
+			p := properties{}
+			p.set("IpProtocol", perm.Get("IpProtocol"))
+			p.set("FromPort", perm.Get("FromPort"))
+			p.set("ToPort", perm.Get("ToPort"))
+			p.set(target, v)
+			rules = append(rules, map[string]interface{}(p))
+		}
+
+		for _, c := range perm.Strs("IpRanges", "CidrIp") {
+			rule("CidrIp", c)
+		}
+		for _, c := range perm.Strs("Ipv6Ranges", "CidrIpv6") {
+			rule("CidrIpv6", c)
+		}
+		for _, id := range perm.Strs("PrefixListIds", "PrefixListId") {
+			rule(dir+"PrefixListId", id)
+		}
+		for _, id := range perm.Strs("UserIdGroupPairs", "GroupId") {
+			rule(dir+"SecurityGroupId", id)
+		}
+	}
+
+	return rules
+}
+
+func volume(r raws.Resource, raw value.Value) (string, map[string]string, properties) {
+	item := raw.Item()
+	p := properties{}
+	p.set("AvailabilityZone", item.Get("AvailabilityZone"))
+	p.set("Size", item.Get("Size"))
+	p.set("VolumeType", item.Get("VolumeType"))
+	if item.Str("VolumeType") == "io1" {
+		p.set("Iops", item.Get("Iops"))
+	}
+	p.set("Encrypted", item.Get("Encrypted"))
+	p.set("KmsKeyId", item.Get("KmsKeyId"))
+	p.set("SnapshotId", item.Get("SnapshotId"))
+	p.set("Tags", tags(r))
+
+	return typeVolume, map[string]string{"VolumeId": r.ID}, p
+}
+
+func dbInstance(r raws.Resource, raw value.Value) (string, map[string]string, properties) {
+	item := raw.Item()
+	p := properties{}
+	p.set("DBInstanceIdentifier", item.Get("DBInstanceIdentifier"))
+	p.set("Engine", item.Get("Engine"))
+	p.set("EngineVersion", item.Get("EngineVersion"))
+	p.set("DBInstanceClass", item.Get("DBInstanceClass"))
+	// The storage is a string on CloudFormation
+	if s, ok := item.Get("AllocatedStorage").V.(float64); ok {
+		p.set("AllocatedStorage", strconv.FormatFloat(s, 'f', -1, 64))
+	}
+	p.set("StorageType", item.Get("StorageType"))
+	p.set("StorageEncrypted", item.Get("StorageEncrypted"))
+	p.set("MasterUsername", item.Get("MasterUsername"))
+	p.set("DBSubnetGroupName", item.Get("DBSubnetGroup.DBSubnetGroupName"))
+	p.set("VPCSecurityGroups", refs(typeSecurityGroup, "GroupId", item.Strs("VpcSecurityGroups", "VpcSecurityGroupId")))
+	p.set("MultiAZ", item.Get("MultiAZ"))
+	p.set("PubliclyAccessible", item.Get("PubliclyAccessible"))
+	p.set("Tags", tags(r))
+
+	return typeDBInstance, map[string]string{"DBInstanceIdentifier": r.ID}, p
+}
+
+func bucket(r raws.Resource, raw value.Value) (string, map[string]string, properties) {
+	p := properties{}
+	p.set("BucketName", raw.Item().Get("Name"))
+	p.set("Tags", tags(r))
+
+	return typeBucket, map[string]string{"BucketName": r.ID}, p
+}
+
+func role(r raws.Resource, raw value.Value) (string, map[string]string, properties) {
+	item := raw.Item()
+	p := properties{}
+	p.set("RoleName", item.Get("RoleName"))
+	p.set("Path", item.Get("Path"))
+	p.set("Description", item.Get("Description"))
+	p.set("MaxSessionDuration", item.Get("MaxSessionDuration"))
+	p.set("AssumeRolePolicyDocument", policyDocument(item.Str("AssumeRolePolicyDocument")))
+	p.set("ManagedPolicyArns", managedPolicies(raw))
+	p.set("Tags", tags(r))
+
+	return typeRole, map[string]string{"RoleName": r.Name}, p
+}
+
+func user(r raws.Resource, raw value.Value) (string, map[string]string, properties) {
+	item := raw.Item()
+	p := properties{}
+	p.set("UserName", item.Get("UserName"))
+	p.set("Path", item.Get("Path"))
+	p.set("ManagedPolicyArns", managedPolicies(raw))
+	p.set("Tags", tags(r))
+
+	return typeUser, map[string]string{"UserName": r.Name}, p
+}
+
+func group(r raws.Resource, raw value.Value) (string, map[string]string, properties) {
+	item := raw.Item()
+	p := properties{}
+	p.set("GroupName", item.Get("GroupName"))
+	p.set("Path", item.Get("Path"))
+	p.set("ManagedPolicyArns", managedPolicies(raw))
+
+	return typeGroup, map[string]string{"GroupName": r.Name}, p
+}
+
+func instanceProfile(r raws.Resource, raw value.Value) (string, map[string]string, properties) {
+	item := raw.Item()
+	p := properties{}
+	p.set("InstanceProfileName", item.Get("InstanceProfileName"))
+	p.set("Path", item.Get("Path"))
+	p.set("Roles", refs(typeRole, "", item.Strs("Roles", "RoleName")))
+
+	return typeInstanceProfile, map[string]string{"InstanceProfileName": r.Name}, p
+}
+
+// managedPolicies returns the ARNs of the policies attached to the
+// roles, users and groups, which are only on the types with policies
+// like raws.RoleWithPolicies
+func managedPolicies(raw value.Value) []interface{} {
+	var arns []interface{}
+	for _, arn := range raw.Strs("AttachedPolicies.AttachedPolicies", "PolicyArn") {
+		arns = append(arns, arn)
+	}
+
+	return arns
+}
+
+// policyDocument returns the policy document, returned URL
+// encoded by IAM, as an object, or nil if it's not valid
+func policyDocument(doc string) interface{} {
+	if doc == "" {
+		return nil
+	}
+
+	if d, err := url.QueryUnescape(doc); err == nil {
+		doc = d
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(doc), &v); err != nil {
+		return nil
+	}
+
+	return v
+}
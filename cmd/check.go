@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// checkOutputs generates in memory the outputs and writes to w the
+// unified diff with the current files if they are different, and
+// also writes the functions with NoGenerateFn which do not have
+// an implementation in the package of the first output.
+// An error is returned if any of those problems is found
+func checkOutputs(w io.Writer, outputs []genOutput, fns []Function) error {
+	var problems int
+
+	for _, o := range outputs {
+		var buff bytes.Buffer
+
+		err := o.generate(&buff, fns)
+		if err != nil {
+			return err
+		}
+
+		current, err := ioutil.ReadFile(o.path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		if bytes.Equal(current, buff.Bytes()) {
+			continue
+		}
+
+		problems++
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(current)),
+			B:        difflib.SplitLines(buff.String()),
+			FromFile: o.path,
+			ToFile:   fmt.Sprintf("%s (generated)", o.path),
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, diff)
+	}
+
+	if len(outputs) > 0 {
+		var generated []string
+		for _, o := range outputs {
+			generated = append(generated, o.path)
+		}
+
+		missing, err := missingImplementations(filepath.Dir(outputs[0].path), generated, fns)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range missing {
+			problems++
+			fmt.Fprintf(w, "the function %s is not generated (NoGenerateFn) and it's not implemented\n", m)
+		}
+	}
+
+	if problems != 0 {
+		return fmt.Errorf("%d problem(s) found, the generated code is not up to date with the functions", problems)
+	}
+
+	return nil
+}
+
+// missingImplementations returns the Name of the fns with NoGenerateFn which
+// are not implemented by the connector on the package in dir, the generated
+// files and the tests are not checked
+func missingImplementations(dir string, generated []string, fns []Function) ([]string, error) {
+	var excluded = make(map[string]bool)
+	for _, g := range generated {
+		excluded[filepath.Base(g)] = filepath.Dir(g) == filepath.Clean(dir)
+	}
+
+	pkgs, err := parser.ParseDir(token.NewFileSet(), dir, func(fi os.FileInfo) bool {
+		return !excluded[fi.Name()] && !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var methods = make(map[string]bool)
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, d := range f.Decls {
+				fd, ok := d.(*ast.FuncDecl)
+				if !ok || fd.Recv == nil || len(fd.Recv.List) != 1 {
+					continue
+				}
+
+				rt := fd.Recv.List[0].Type
+				if se, ok := rt.(*ast.StarExpr); ok {
+					rt = se.X
+				}
+				if id, ok := rt.(*ast.Ident); ok && id.Name == "connector" {
+					methods[fd.Name.Name] = true
+				}
+			}
+		}
+	}
+
+	var missing []string
+	for _, fn := range fns {
+		if fn.NoGenerateFn && !methods[fn.Name()] {
+			missing = append(missing, fn.Name())
+		}
+	}
+
+	return missing, nil
+}
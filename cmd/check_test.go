@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkOutputs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "raws-check")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		gen = func(w io.Writer, fns []Function) error {
+			_, err := io.WriteString(w, "package raws\n\nfunc (c *connector) Generated() {}\n")
+			return err
+		}
+		output = genOutput{path: filepath.Join(dir, "generate.go"), generate: gen}
+		fns    = []Function{
+			Function{FnName: "ListBuckets", NoGenerateFn: true},
+			Function{
+				FnSignature:  "DownloadObject(ctx context.Context) (int64, error)",
+				NoGenerateFn: true,
+			},
+		}
+	)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "list_buckets.go"), []byte("package raws\n\nfunc (c *connector) ListBuckets() {}\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "download_test.go"), []byte("package raws\n\nfunc (c *connector) DownloadObject() {}\n"), 0644))
+
+	t.Run("Outdated", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(output.path, []byte("package raws\n"), 0644))
+
+		buff := bytes.Buffer{}
+		err := checkOutputs(&buff, []genOutput{output}, fns)
+		assert.EqualError(t, err, "2 problem(s) found, the generated code is not up to date with the functions")
+		assert.Contains(t, buff.String(), "--- "+output.path+"\n+++ "+output.path+" (generated)\n")
+		assert.Contains(t, buff.String(), "\n+func (c *connector) Generated() {}\n")
+		assert.Contains(t, buff.String(), "\nthe function DownloadObject is not generated (NoGenerateFn) and it's not implemented\n")
+	})
+
+	t.Run("UpToDate", func(t *testing.T) {
+		require.NoError(t, writeOutput(output, fns))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "s3downloader.go"), []byte("package raws\n\nfunc (c *connector) DownloadObject() {}\n"), 0644))
+
+		buff := bytes.Buffer{}
+		err := checkOutputs(&buff, []genOutput{output}, fns)
+		require.NoError(t, err)
+		assert.Empty(t, buff.String())
+	})
+}
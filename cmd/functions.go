@@ -6,27 +6,35 @@ var (
 	functions = []Function{
 		// ec2
 		Function{
-			Entity:  "Instances",
-			Prefix:  "Describe",
-			Service: "ec2",
+			Entity:      "Instances",
+			Prefix:      "Describe",
+			Service:     "ec2",
+			Flatten:     "Reservations.Instances",
+			FlattenItem: "Instance",
+			Inventory:   true,
 			Documentation: `
 			// GetInstances returns all EC2 instances based on the input given.
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "Vpcs",
-			Prefix:  "Describe",
-			Service: "ec2",
+			Entity:      "Vpcs",
+			Prefix:      "Describe",
+			Service:     "ec2",
+			Flatten:     "Vpcs",
+			FlattenItem: "Vpc",
+			Inventory:   true,
 			Documentation: `
 			// GetVpcs returns all EC2 VPCs based on the input given.
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "Images",
-			Prefix:  "Describe",
-			Service: "ec2",
+			Entity:      "Images",
+			Prefix:      "Describe",
+			Service:     "ec2",
+			Flatten:     "Images",
+			FlattenItem: "Image",
 			Documentation: `
 			// GetImages returns all EC2 AMI based on the input given.
 			// Returned values are commented in the interface doc comment block.
@@ -36,6 +44,9 @@ var (
 			Entity:        "Images",
 			Prefix:        "Describe",
 			Service:       "ec2",
+			Flatten:       "Images",
+			FlattenItem:   "Image",
+			Inventory:     true,
 			FilterByOwner: "Owners",
 			Documentation: `
 			// GetOwnImages returns all EC2 AMI belonging to the Account ID based on the input given.
@@ -43,36 +54,47 @@ var (
 			`,
 		},
 		Function{
-			Entity:  "SecurityGroups",
-			Prefix:  "Describe",
-			Service: "ec2",
+			Entity:      "SecurityGroups",
+			Prefix:      "Describe",
+			Service:     "ec2",
+			Flatten:     "SecurityGroups",
+			FlattenItem: "SecurityGroup",
+			Inventory:   true,
 			Documentation: `
 			// GetSecurityGroups returns all EC2 security groups based on the input given.
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "Subnets",
-			Prefix:  "Describe",
-			Service: "ec2",
+			Entity:      "Subnets",
+			Prefix:      "Describe",
+			Service:     "ec2",
+			Flatten:     "Subnets",
+			FlattenItem: "Subnet",
+			Inventory:   true,
 			Documentation: `
 			// GetSubnets returns all EC2 subnets based on the input given.
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "Volumes",
-			Prefix:  "Describe",
-			Service: "ec2",
+			Entity:      "Volumes",
+			Prefix:      "Describe",
+			Service:     "ec2",
+			Flatten:     "Volumes",
+			FlattenItem: "Volume",
+			Inventory:   true,
 			Documentation: `
 			// GetVolumes returns all EC2 volumes based on the input given.
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "Snapshots",
-			Prefix:  "Describe",
-			Service: "ec2",
+			Entity:      "Snapshots",
+			Prefix:      "Describe",
+			Service:     "ec2",
+			Flatten:     "Snapshots",
+			FlattenItem: "Snapshot",
 			Documentation: `
 			// GetSnapshots returns all snapshots based on the input given.
 			// Returned values are commented in the interface doc comment block.
@@ -82,6 +104,9 @@ var (
 			Entity:        "Snapshots",
 			Prefix:        "Describe",
 			Service:       "ec2",
+			Flatten:       "Snapshots",
+			FlattenItem:   "Snapshot",
+			Inventory:     true,
 			FilterByOwner: "OwnerIds",
 			Documentation: `
 			// GetOwnSnapshots returns all snapshots belonging to the Account ID based on the input given.
@@ -89,9 +114,12 @@ var (
 			`,
 		},
 		Function{
-			Entity:  "LaunchTemplates",
-			Prefix:  "Describe",
-			Service: "ec2",
+			Entity:      "LaunchTemplates",
+			Prefix:      "Describe",
+			Service:     "ec2",
+			Flatten:     "LaunchTemplates",
+			FlattenItem: "LaunchTemplate",
+			Inventory:   true,
 			Documentation: `
 			// GetLaunchTemplates returns all LaunchTemplate belonging to the Account ID based on the input given.
 			// Returned values are commented in the interface doc comment block.
@@ -100,18 +128,25 @@ var (
 
 		// autoscaling
 		Function{
-			Entity:  "AutoScalingGroups",
-			Prefix:  "Describe",
-			Service: "autoscaling",
+			Entity:        "AutoScalingGroups",
+			Prefix:        "Describe",
+			Service:       "autoscaling",
+			Flatten:       "AutoScalingGroups",
+			FlattenItem:   "Group",
+			FnFlattenType: "RegionAutoScalingGroup",
+			Inventory:     true,
 			Documentation: `
 			// GetAutoScalingGroups returns all AutoScalingGroup belonging to the Account ID based on the input given.
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "LaunchConfigurations",
-			Prefix:  "Describe",
-			Service: "autoscaling",
+			Entity:      "LaunchConfigurations",
+			Prefix:      "Describe",
+			Service:     "autoscaling",
+			Flatten:     "LaunchConfigurations",
+			FlattenItem: "LaunchConfiguration",
+			Inventory:   true,
 			Documentation: `
 			// GetLaunchConfigurations returns all LaunchConfiguration belonging to the Account ID based on the input given.
 			// Returned values are commented in the interface doc comment block.
@@ -120,10 +155,13 @@ var (
 
 		// elasticache
 		Function{
-			FnName:  "GetElastiCacheClusters",
-			Entity:  "CacheClusters",
-			Prefix:  "Describe",
-			Service: "elasticache",
+			FnName:      "GetElastiCacheClusters",
+			Entity:      "CacheClusters",
+			Prefix:      "Describe",
+			Service:     "elasticache",
+			Flatten:     "CacheClusters",
+			FlattenItem: "CacheCluster",
+			Inventory:   true,
 			Documentation: `
 			// GetElastiCacheClusters returns all Elasticache clusters based on the input given.
 			// Returned values are commented in the interface doc comment block.
@@ -143,13 +181,37 @@ var (
 
 		// elb
 		Function{
-			Entity:  "LoadBalancers",
-			Prefix:  "Describe",
-			Service: "elb",
+			Entity:      "LoadBalancers",
+			Prefix:      "Describe",
+			Service:     "elb",
+			Flatten:     "LoadBalancerDescriptions",
+			FlattenItem: "LoadBalancerDescription",
+			Inventory:   true,
 			Documentation: `
 			// GetLoadBalancers returns a list of ELB (v1) based on the input from the different regions.
 			// Returned values are commented in the interface doc comment block.
 			`,
+			Enrich: &Enrich{
+				Name: "WithTags",
+				Type: "LoadBalancerWithTags",
+				Documentation: `
+				// GetLoadBalancersWithTags returns a list of ELB (v1) based on the input from the different regions
+				// with their tags.
+				// Returned values are commented in the interface doc comment block.
+				`,
+				FollowUps: []FollowUp{
+					FollowUp{
+						Attach:     "Tags",
+						Function:   "GetLoadBalancersTags",
+						From:       "LoadBalancerName",
+						To:         "LoadBalancerNames",
+						Batch:      20,
+						Result:     "TagDescriptions",
+						ResultItem: "TagDescription",
+						ResultKey:  "LoadBalancerName",
+					},
+				},
+			},
 		},
 		Function{
 			FnName:  "GetLoadBalancersTags",
@@ -164,14 +226,38 @@ var (
 
 		// elbv2
 		Function{
-			FnName:  "GetLoadBalancersV2",
-			Entity:  "LoadBalancers",
-			Prefix:  "Describe",
-			Service: "elbv2",
+			FnName:      "GetLoadBalancersV2",
+			Entity:      "LoadBalancers",
+			Prefix:      "Describe",
+			Service:     "elbv2",
+			Flatten:     "LoadBalancers",
+			FlattenItem: "LoadBalancer",
+			Inventory:   true,
 			Documentation: `
 			// GetLoadBalancersV2 returns a list of ELB (v2) - also known as ALB - based on the input from the different regions.
 			// Returned values are commented in the interface doc comment block.
 			`,
+			Enrich: &Enrich{
+				Name: "WithTags",
+				Type: "LoadBalancerV2WithTags",
+				Documentation: `
+				// GetLoadBalancersV2WithTags returns a list of ELB (v2) - also known as ALB - based on the input
+				// from the different regions with their tags.
+				// Returned values are commented in the interface doc comment block.
+				`,
+				FollowUps: []FollowUp{
+					FollowUp{
+						Attach:     "Tags",
+						Function:   "GetLoadBalancersV2Tags",
+						From:       "LoadBalancerArn",
+						To:         "ResourceArns",
+						Batch:      20,
+						Result:     "TagDescriptions",
+						ResultItem: "TagDescription",
+						ResultKey:  "ResourceArn",
+					},
+				},
+			},
 		},
 		Function{
 			FnName:  "GetLoadBalancersV2Tags",
@@ -186,13 +272,32 @@ var (
 
 		// rds
 		Function{
-			Entity:  "DBInstances",
-			Prefix:  "Describe",
-			Service: "rds",
+			Entity:      "DBInstances",
+			Prefix:      "Describe",
+			Service:     "rds",
+			Flatten:     "DBInstances",
+			FlattenItem: "DBInstance",
+			Inventory:   true,
 			Documentation: `
 			// GetDBInstances returns all DB instances based on the input given.
 			// Returned values are commented in the interface doc comment block.
 			`,
+			Enrich: &Enrich{
+				Name: "WithTags",
+				Type: "DBInstanceWithTags",
+				Documentation: `
+				// GetDBInstancesWithTags returns all DB instances based on the input given with their tags.
+				// Returned values are commented in the interface doc comment block.
+				`,
+				FollowUps: []FollowUp{
+					FollowUp{
+						Attach:   "Tags",
+						Function: "GetDBInstancesTags",
+						From:     "DBInstanceArn",
+						To:       "ResourceName",
+					},
+				},
+			},
 		},
 		Function{
 			FnName:  "GetDBInstancesTags",
@@ -206,13 +311,45 @@ var (
 		},
 
 		// s3
+		Function{
+			Entity:  "BucketPolicyStatus",
+			Prefix:  "Get",
+			Service: "s3",
+			Documentation: `
+			// GetBucketPolicyStatus returns if the policy of a S3 bucket makes it public based on the input given.
+			// Returned values are commented in the interface doc comment block.
+			`,
+		},
+		Function{
+			Entity:  "BucketAcl",
+			Prefix:  "Get",
+			Service: "s3",
+			Documentation: `
+			// GetBucketAcl returns the access control list of a S3 bucket based on the input given.
+			// Returned values are commented in the interface doc comment block.
+			`,
+		},
+		Function{
+			Entity:       "PublicAccessBlock",
+			Prefix:       "Get",
+			Service:      "s3",
+			FnIAMActions: []string{"s3:GetBucketPublicAccessBlock"},
+			Documentation: `
+			// GetPublicAccessBlock returns the public access block configuration of a S3 bucket based on the input given.
+			// Returned values are commented in the interface doc comment block.
+			`,
+		},
 		Function{
 			// TODO: https://github.com/cycloidio/raws/issues/44
 			FnName:       "ListBuckets",
 			Entity:       "Buckets",
 			Prefix:       "List",
 			Service:      "s3",
+			Flatten:      "Buckets",
+			FlattenItem:  "Bucket",
+			Inventory:    true,
 			NoGenerateFn: true,
+			FnIAMActions: []string{"s3:ListAllMyBuckets", "s3:GetBucketLocation"},
 			Documentation: `
 			// ListBuckets returns all S3 buckets based on the input given and specifically
 			// filtering by Location as ListBuckets does not do it by itself
@@ -231,10 +368,13 @@ var (
 		},
 		Function{
 			// TODO: https://github.com/cycloidio/raws/issues/44
-			FnName:  "ListObjects",
-			Entity:  "Objects",
-			Prefix:  "List",
-			Service: "s3",
+			FnName:       "ListObjects",
+			Entity:       "Objects",
+			Prefix:       "List",
+			Service:      "s3",
+			Flatten:      "Contents",
+			FlattenItem:  "Object",
+			FnIAMActions: []string{"s3:ListBucket"},
 			Documentation: `
 			// ListObjects returns a list of all S3 objects in a bucket based on the input given.
 			// Returned values are commented in the interface doc comment block.
@@ -267,6 +407,9 @@ var (
 		// s3downloader
 		Function{
 			FnSignature:  "DownloadObject(ctx context.Context, w io.WriterAt, input *s3.GetObjectInput, options ...func(*s3manager.Downloader)) (int64, error)",
+			Entity:       "Object",
+			Prefix:       "Get",
+			Service:      "s3",
 			NoGenerateFn: true,
 			Documentation: `
 			// DownloadObject downloads an object in a bucket based on the input given
@@ -275,29 +418,38 @@ var (
 
 		// cloudfront
 		Function{
-			FnName:  "GetCloudFrontDistributions",
-			Entity:  "Distributions",
-			Prefix:  "List",
-			Service: "cloudfront",
+			FnName:      "GetCloudFrontDistributions",
+			Entity:      "Distributions",
+			Prefix:      "List",
+			Service:     "cloudfront",
+			Flatten:     "DistributionList.Items",
+			FlattenItem: "DistributionSummary",
+			Inventory:   true,
 			Documentation: `
 			// GetCloudFrontDistributions returns all the CloudFront Distributions on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			FnName:  "GetCloudFrontPublicKeys",
-			Entity:  "PublicKeys",
-			Prefix:  "List",
-			Service: "cloudfront",
+			FnName:      "GetCloudFrontPublicKeys",
+			Entity:      "PublicKeys",
+			Prefix:      "List",
+			Service:     "cloudfront",
+			Flatten:     "PublicKeyList.Items",
+			FlattenItem: "PublicKeySummary",
+			Inventory:   true,
 			Documentation: `
 			// GetCloudFrontPublicKeys returns all the CloudFront Public Keys on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "CloudFrontOriginAccessIdentities",
-			Prefix:  "List",
-			Service: "cloudfront",
+			Entity:      "CloudFrontOriginAccessIdentities",
+			Prefix:      "List",
+			Service:     "cloudfront",
+			Flatten:     "CloudFrontOriginAccessIdentityList.Items",
+			FlattenItem: "OriginAccessIdentitySummary",
+			Inventory:   true,
 			Documentation: `
 			// GetCloudFrontOriginAccessIdentities returns all the CloudFront Origin Access Identities on the given input
 			// Returned values are commented in the interface doc comment block.
@@ -306,9 +458,12 @@ var (
 
 		// iam
 		Function{
-			Entity:  "AccessKeys",
-			Prefix:  "List",
-			Service: "iam",
+			Entity:      "AccessKeys",
+			Prefix:      "List",
+			Service:     "iam",
+			Flatten:     "AccessKeyMetadata",
+			FlattenItem: "AccessKeyMetadata",
+			Inventory:   true,
 			Documentation: `
 			// GetAccessKeys returns all the IAM AccessKeys on the given input
 			// Returned values are commented in the interface doc comment block.
@@ -334,13 +489,39 @@ var (
 			`,
 		},
 		Function{
-			Entity:  "Groups",
-			Prefix:  "List",
-			Service: "iam",
+			Entity:      "Groups",
+			Prefix:      "List",
+			Service:     "iam",
+			Flatten:     "Groups",
+			FlattenItem: "Group",
+			Inventory:   true,
 			Documentation: `
 			// GetGroups returns the IAM Groups on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
+			Enrich: &Enrich{
+				Name: "WithPolicies",
+				Type: "GroupWithPolicies",
+				Documentation: `
+				// GetGroupsWithPolicies returns all the IAM Groups on the given input with the names of
+				// their inline policies and their attached policies.
+				// Returned values are commented in the interface doc comment block.
+				`,
+				FollowUps: []FollowUp{
+					FollowUp{
+						Attach:   "Policies",
+						Function: "GetGroupPolicies",
+						From:     "GroupName",
+						To:       "GroupName",
+					},
+					FollowUp{
+						Attach:   "AttachedPolicies",
+						Function: "GetAttachedGroupPolicies",
+						From:     "GroupName",
+						To:       "GroupName",
+					},
+				},
+			},
 		},
 		Function{
 			Entity:  "GroupPolicies",
@@ -352,49 +533,85 @@ var (
 			`,
 		},
 		Function{
-			Entity:  "AttachedGroupPolicies",
-			Prefix:  "List",
-			Service: "iam",
+			Entity:      "AttachedGroupPolicies",
+			Prefix:      "List",
+			Service:     "iam",
+			Flatten:     "AttachedPolicies",
+			FlattenItem: "AttachedPolicy",
 			Documentation: `
 			// GetAttachedGroupPolicies returns the IAM AttachedGroupPolicies on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "InstanceProfiles",
-			Prefix:  "List",
-			Service: "iam",
+			Entity:      "InstanceProfiles",
+			Prefix:      "List",
+			Service:     "iam",
+			Flatten:     "InstanceProfiles",
+			FlattenItem: "InstanceProfile",
+			Inventory:   true,
 			Documentation: `
 			// GetIstanceProfiles returns the IAM InstanceProfiles on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "OpenIDConnectProviders",
-			Prefix:  "List",
-			Service: "iam",
+			Entity:      "OpenIDConnectProviders",
+			Prefix:      "List",
+			Service:     "iam",
+			Flatten:     "OpenIDConnectProviderList",
+			FlattenItem: "OpenIDConnectProviderListEntry",
+			Inventory:   true,
 			Documentation: `
 			// GetOpenIDConnectProviders returns the IAM OpenIDConnectProviders on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "Policies",
-			Prefix:  "List",
-			Service: "iam",
+			Entity:      "Policies",
+			Prefix:      "List",
+			Service:     "iam",
+			Flatten:     "Policies",
+			FlattenItem: "Policy",
 			Documentation: `
 			// GetPolicies returns the IAM Policies on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "Roles",
-			Prefix:  "List",
-			Service: "iam",
+			Entity:      "Roles",
+			Prefix:      "List",
+			Service:     "iam",
+			Flatten:     "Roles",
+			FlattenItem: "Role",
+			Inventory:   true,
 			Documentation: `
 			// GetRoles returns the IAM Roles on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
+			Enrich: &Enrich{
+				Name: "WithPolicies",
+				Type: "RoleWithPolicies",
+				Documentation: `
+				// GetRolesWithPolicies returns all the IAM Roles on the given input with the names of
+				// their inline policies and their attached policies.
+				// Returned values are commented in the interface doc comment block.
+				`,
+				FollowUps: []FollowUp{
+					FollowUp{
+						Attach:   "Policies",
+						Function: "GetRolePolicies",
+						From:     "RoleName",
+						To:       "RoleName",
+					},
+					FollowUp{
+						Attach:   "AttachedPolicies",
+						Function: "GetAttachedRolePolicies",
+						From:     "RoleName",
+						To:       "RoleName",
+					},
+				},
+			},
 		},
 		Function{
 			Entity:  "RolePolicies",
@@ -406,40 +623,83 @@ var (
 			`,
 		},
 		Function{
-			Entity:  "AttachedRolePolicies",
-			Prefix:  "List",
-			Service: "iam",
+			Entity:      "AttachedRolePolicies",
+			Prefix:      "List",
+			Service:     "iam",
+			Flatten:     "AttachedPolicies",
+			FlattenItem: "AttachedPolicy",
 			Documentation: `
 			// GetAttachedRolePolicies returns the IAM AttachedRolePolicies on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "SAMLProviders",
+			Entity:  "RoleTags",
 			Prefix:  "List",
 			Service: "iam",
 			Documentation: `
+			// GetRoleTags returns the IAM RoleTags on the given input
+			// Returned values are commented in the interface doc comment block.
+			`,
+		},
+		Function{
+			Entity:      "SAMLProviders",
+			Prefix:      "List",
+			Service:     "iam",
+			Flatten:     "SAMLProviderList",
+			FlattenItem: "SAMLProviderListEntry",
+			Inventory:   true,
+			Documentation: `
 			// GetSAMLProviders returns the IAM SAMLProviders on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "ServerCertificates",
-			Prefix:  "List",
-			Service: "iam",
+			Entity:      "ServerCertificates",
+			Prefix:      "List",
+			Service:     "iam",
+			Flatten:     "ServerCertificateMetadataList",
+			FlattenItem: "ServerCertificateMetadata",
+			Inventory:   true,
 			Documentation: `
 			// GetServerCertificates returns the IAM ServerCertificates on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "Users",
-			Prefix:  "List",
-			Service: "iam",
+			Entity:      "Users",
+			Prefix:      "List",
+			Service:     "iam",
+			Flatten:     "Users",
+			FlattenItem: "User",
+			Inventory:   true,
 			Documentation: `
 			// GetUsers returns the IAM Users on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
+			Enrich: &Enrich{
+				Name: "WithPolicies",
+				Type: "UserWithPolicies",
+				Documentation: `
+				// GetUsersWithPolicies returns all the IAM Users on the given input with the names of
+				// their inline policies and their attached policies.
+				// Returned values are commented in the interface doc comment block.
+				`,
+				FollowUps: []FollowUp{
+					FollowUp{
+						Attach:   "Policies",
+						Function: "GetUserPolicies",
+						From:     "UserName",
+						To:       "UserName",
+					},
+					FollowUp{
+						Attach:   "AttachedPolicies",
+						Function: "GetAttachedUserPolicies",
+						From:     "UserName",
+						To:       "UserName",
+					},
+				},
+			},
 		},
 		Function{
 			Entity:  "UserPolicies",
@@ -451,11 +711,45 @@ var (
 			`,
 		},
 		Function{
-			Entity:  "AttachedUserPolicies",
+			Entity:      "AttachedUserPolicies",
+			Prefix:      "List",
+			Service:     "iam",
+			Flatten:     "AttachedPolicies",
+			FlattenItem: "AttachedPolicy",
+			Documentation: `
+			// GetAttachedUserPolicies returns the IAM AttachedUserPolicies on the given input
+			// Returned values are commented in the interface doc comment block.
+			`,
+		},
+		Function{
+			Entity:  "UserTags",
 			Prefix:  "List",
 			Service: "iam",
 			Documentation: `
-			// GetAttachedUserPolicies returns the IAM AttachedUserPolicies on the given input
+			// GetUserTags returns the IAM UserTags on the given input
+			// Returned values are commented in the interface doc comment block.
+			`,
+		},
+		Function{
+			Entity:      "MFADevices",
+			Prefix:      "List",
+			Service:     "iam",
+			Flatten:     "MFADevices",
+			FlattenItem: "MFADevice",
+			Documentation: `
+			// GetMFADevices returns the IAM MFADevices on the given input
+			// Returned values are commented in the interface doc comment block.
+			`,
+		},
+		Function{
+			Entity:       "AccountAuthorizationDetails",
+			Prefix:       "Get",
+			Service:      "iam",
+			NoGenerateFn: true,
+			Documentation: `
+			// GetAccountAuthorizationDetails returns the IAM users, groups, roles and policies, with
+			// their policy documents, on the given input.
+			// All the pages are requested, so the output of each region has all the details.
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
@@ -488,18 +782,24 @@ var (
 			`,
 		},
 		Function{
-			Entity:  "ReceiptFilters",
-			Prefix:  "List",
-			Service: "ses",
+			Entity:      "ReceiptFilters",
+			Prefix:      "List",
+			Service:     "ses",
+			Flatten:     "Filters",
+			FlattenItem: "ReceiptFilter",
+			Inventory:   true,
 			Documentation: `
 			// GetReceiptFilters returns the SES ReceiptFilters on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "ConfigurationSets",
-			Prefix:  "List",
-			Service: "ses",
+			Entity:      "ConfigurationSets",
+			Prefix:      "List",
+			Service:     "ses",
+			Flatten:     "ConfigurationSets",
+			FlattenItem: "ConfigurationSet",
+			Inventory:   true,
 			Documentation: `
 			// GetConfigurationSets returns the SES ConfigurationSets on the given input
 			// Returned values are commented in the interface doc comment block.
@@ -515,9 +815,12 @@ var (
 			`,
 		},
 		Function{
-			Entity:  "Templates",
-			Prefix:  "List",
-			Service: "ses",
+			Entity:      "Templates",
+			Prefix:      "List",
+			Service:     "ses",
+			Flatten:     "TemplatesMetadata",
+			FlattenItem: "TemplateMetadata",
+			Inventory:   true,
 			Documentation: `
 			// GetTemplates returns the SES Templates on the given input
 			// Returned values are commented in the interface doc comment block.
@@ -526,45 +829,61 @@ var (
 
 		// route53
 		Function{
-			Entity:  "ReusableDelegationSets",
-			Prefix:  "List",
-			Service: "route53",
+			Entity:      "ReusableDelegationSets",
+			Prefix:      "List",
+			Service:     "route53",
+			Flatten:     "DelegationSets",
+			FlattenItem: "DelegationSet",
+			Inventory:   true,
 			Documentation: `
 			// GetReusableDelegationSets returns the Route53 ReusableDelegationSets on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "HealthChecks",
-			Prefix:  "List",
-			Service: "route53",
+			Entity:      "HealthChecks",
+			Prefix:      "List",
+			Service:     "route53",
+			Flatten:     "HealthChecks",
+			FlattenItem: "HealthCheck",
+			Inventory:   true,
 			Documentation: `
 			// GetHealthChecks returns the Route53 HealthChecks on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "QueryLoggingConfigs",
-			Prefix:  "List",
-			Service: "route53",
+			Entity:      "QueryLoggingConfigs",
+			Prefix:      "List",
+			Service:     "route53",
+			Flatten:     "QueryLoggingConfigs",
+			FlattenItem: "QueryLoggingConfig",
+			Inventory:   true,
 			Documentation: `
 			// GetQueryLoggingConfigs returns the Route53 QueryLoggingConfigs on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "ResourceRecordSets",
-			Prefix:  "List",
-			Service: "route53",
+			Entity:      "ResourceRecordSets",
+			Prefix:      "List",
+			Service:     "route53",
+			Flatten:     "ResourceRecordSets",
+			FlattenItem: "ResourceRecordSet",
+			Inventory:   true,
+			InventoryFn: "ListAllHostedZoneRecordSets",
 			Documentation: `
 			// GetResourceRecordSets returns the Route53 ResourceRecordSets on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "HostedZones",
-			Prefix:  "List",
-			Service: "route53",
+			Entity:      "HostedZones",
+			Prefix:      "List",
+			Service:     "route53",
+			Flatten:     "HostedZones",
+			FlattenItem: "HostedZone",
+			Inventory:   true,
 			Documentation: `
 			// GetHostedZones returns the Route53 HostedZones on the given input
 			// Returned values are commented in the interface doc comment block.
@@ -582,31 +901,57 @@ var (
 
 		// route53resolver
 		Function{
-			Entity:  "ResolverEndpoints",
-			Prefix:  "List",
-			Service: "route53resolver",
+			Entity:      "ResolverEndpoints",
+			Prefix:      "List",
+			Service:     "route53resolver",
+			Flatten:     "ResolverEndpoints",
+			FlattenItem: "ResolverEndpoint",
+			Inventory:   true,
 			Documentation: `
 			// GetResolverEndpoints returns the Route53Resolver ResolverEndpoints on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "ResolverRules",
-			Prefix:  "List",
-			Service: "route53resolver",
+			Entity:      "ResolverRules",
+			Prefix:      "List",
+			Service:     "route53resolver",
+			Flatten:     "ResolverRules",
+			FlattenItem: "ResolverRule",
+			Inventory:   true,
 			Documentation: `
 			// GetResolverRules returns the Route53Resolver ResolverRules on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
 		Function{
-			Entity:  "ResolverRuleAssociations",
-			Prefix:  "List",
-			Service: "route53resolver",
+			Entity:      "ResolverRuleAssociations",
+			Prefix:      "List",
+			Service:     "route53resolver",
+			Flatten:     "ResolverRuleAssociations",
+			FlattenItem: "ResolverRuleAssociation",
+			Inventory:   true,
 			Documentation: `
 			// GetResolverRuleAssociations returns the Route53Resolver ResolverRuleAssociations on the given input
 			// Returned values are commented in the interface doc comment block.
 			`,
 		},
+
+		// resourcegroupstaggingapi
+		Function{
+			FnName:       "GetTaggedResources",
+			Entity:       "Resources",
+			Prefix:       "Get",
+			Service:      "resourcegroupstaggingapi",
+			Flatten:      "ResourceTagMappingList",
+			FlattenItem:  "ResourceTagMapping",
+			NoGenerateFn: true,
+			Documentation: `
+			// GetTaggedResources returns the ARN and the tags of all the tagged resources, of all
+			// the services supported by the Resource Groups Tagging API, based on the input given.
+			// All the pages are requested, so the output of each region has all the resources.
+			// Returned values are commented in the interface doc comment block.
+			`,
+		},
 	}
 )
@@ -3,17 +3,35 @@ package main
 import (
 	"bytes"
 	"flag"
+	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 )
 
 var (
-	output string
+	output       string
+	cliOutput    string
+	docOutput    string
+	policyOutput string
+	fakeOutput   string
+	check        bool
 )
 
 func init() {
 	flag.StringVar(&output, "output", "", "The output file of the generated code")
+	flag.StringVar(&cliOutput, "cli-output", "", "The output file of the generated commands of the raws CLI")
+	flag.StringVar(&docOutput, "doc-output", "", "The output file of the Markdown reference of the AWSReader")
+	flag.StringVar(&policyOutput, "policy-output", "", "The output file of the IAM policy needed by the AWSReader")
+	flag.StringVar(&fakeOutput, "fake-output", "", "The output file of the generated methods of the rawsfake.Reader")
+	flag.BoolVar(&check, "check", false, "Checks that the outputs are up to date, instead of writing them, and that the functions not generated are implemented")
+}
+
+// genOutput is a file generated from the list of functions
+type genOutput struct {
+	path     string
+	generate func(io.Writer, []Function) error
 }
 
 func main() {
@@ -23,23 +41,70 @@ func main() {
 		panic("The 'output' it's required")
 	}
 
-	f, err := os.OpenFile(output, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
-	if err != nil {
-		panic(err)
+	outputs := []genOutput{
+		genOutput{path: output, generate: generate},
+	}
+	if cliOutput != "" {
+		outputs = append(outputs, genOutput{path: cliOutput, generate: generateCLI})
+	}
+	if docOutput != "" {
+		var policy string
+		if policyOutput != "" {
+			p, err := filepath.Rel(filepath.Dir(docOutput), policyOutput)
+			if err != nil {
+				panic(err)
+			}
+			policy = filepath.ToSlash(p)
+		}
+		outputs = append(outputs, genOutput{path: docOutput, generate: generateReference(policy)})
+	}
+	if policyOutput != "" {
+		outputs = append(outputs, genOutput{path: policyOutput, generate: generatePolicy})
+	}
+
+	if fakeOutput != "" {
+		outputs = append(outputs, genOutput{path: fakeOutput, generate: generateFake})
 	}
-	defer f.Close()
 
-	err = generate(f, functions)
+	if check {
+		err := checkOutputs(os.Stdout, outputs, functions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	for _, o := range outputs {
+		err := writeOutput(o, functions)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// writeOutput generates the o file with fns
+func writeOutput(o genOutput, fns []Function) error {
+	f, err := os.OpenFile(o.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
-		panic(err)
+		return err
 	}
+	defer f.Close()
+
+	return o.generate(f, fns)
 }
 
 func generate(opt io.Writer, fns []Function) error {
 	var fnBuff = bytes.Buffer{}
 
+	// Checks the paths walked by the generated code
+	err := checkPaths(fns)
+	if err != nil {
+		return err
+	}
+
 	// Adds the package definition
-	err := pkgTmpl.Execute(&fnBuff, nil)
+	err = pkgTmpl.Execute(&fnBuff, nil)
 	if err != nil {
 		return err
 	}
@@ -56,16 +121,160 @@ func generate(opt io.Writer, fns []Function) error {
 		if err != nil {
 			return err
 		}
+
+		err = fn.ExecuteEnrich(&fnBuff, fns)
+		if err != nil {
+			return err
+		}
 	}
 
-	// Formats the output using goimports
-	cmd := exec.Command("goimports")
-	cmd.Stdin = &fnBuff
-	cmd.Stdout = opt
-	err = cmd.Run()
+	// Adds the types pairing the flattened items with their region
+	rifns, err := regionItems(fns)
+	if err != nil {
+		return err
+	}
+	for _, fn := range rifns {
+		err = fn.ExecuteRegionItem(&fnBuff)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Adds the ListAll functions
+	for _, fn := range fns {
+		err = fn.ExecuteFlatten(&fnBuff)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Adds the calls made by Inventory
+	for _, fn := range fns {
+		if fn.Inventory && fn.Flatten == "" {
+			return fmt.Errorf("the Function %s has Inventory defined without Flatten", fn.Name())
+		}
+	}
+	err = invTmpl.Execute(&fnBuff, fns)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	// Adds the paths of the items of the outputs
+	err = itemsTmpl.Execute(&fnBuff, fns)
+	if err != nil {
+		return err
+	}
+
+	// Adds the methods of the CachedReader
+	err = cacheTmpl.Execute(&fnBuff, fns)
+	if err != nil {
+		return err
+	}
+
+	return goimports(opt, &fnBuff)
+}
+
+// generateCLI writes on opt the list of commands of
+// the raws CLI, one for each of the fns that can be
+// called from it
+func generateCLI(opt io.Writer, fns []Function) error {
+	var (
+		cmdBuff = bytes.Buffer{}
+		names   = make(map[string]string)
+	)
+
+	for _, fn := range fns {
+		if fn.CLIName() == "" {
+			continue
+		}
+
+		cmds := []string{fn.CLIName()}
+		if fn.Enrich != nil {
+			cmds = append(cmds, fn.EnrichCLIName())
+		}
+
+		for _, c := range cmds {
+			name := fmt.Sprintf("%s %s", fn.Service, c)
+			if n, ok := names[name]; ok {
+				return fmt.Errorf("the command %q of %s is already used by %s", name, fn.Name(), n)
+			}
+			names[name] = fn.Name()
+		}
+	}
+
+	err := cmdsTmpl.Execute(&cmdBuff, fns)
+	if err != nil {
+		return err
+	}
+
+	return goimports(opt, &cmdBuff)
+}
+
+// generateFake writes on opt the methods of the
+// rawsfake.Reader, one for each of the fns and of
+// their Enrich functions
+func generateFake(opt io.Writer, fns []Function) error {
+	var (
+		fakeBuff = bytes.Buffer{}
+		ffns     []fakeFunction
+	)
+
+	for _, fn := range fns {
+		ffn := fakeFunction{Function: fn}
+		if fn.Enrich != nil {
+			fups, err := fn.followUps(fns)
+			if err != nil {
+				return err
+			}
+			ffn.FollowUps = fups
+		}
+		ffns = append(ffns, ffn)
+	}
+
+	err := fakeTmpl.Execute(&fakeBuff, ffns)
+	if err != nil {
+		return err
+	}
+
+	return goimports(opt, &fakeBuff)
+}
+
+// goimports formats the code on in using goimports
+// and writes the result on opt
+func goimports(opt io.Writer, in io.Reader) error {
+	cmd := exec.Command("goimports")
+	cmd.Stdin = in
+	cmd.Stdout = opt
+
+	return cmd.Run()
+}
+
+// regionItems returns the first Function of fns defining each of the
+// FlattenType, as some of them share the same one, like GetImages and
+// GetOwnImages. An error is returned if the same FlattenType is used
+// for different items
+func regionItems(fns []Function) ([]Function, error) {
+	var (
+		rifns []Function
+		items = make(map[string]string)
+	)
+
+	for _, fn := range fns {
+		if fn.Flatten == "" {
+			continue
+		}
+
+		item := fmt.Sprintf("%s.%s", fn.Service, fn.FlattenItem)
+		if it, ok := items[fn.FlattenType()]; ok {
+			if it != item {
+				return nil, fmt.Errorf("the FlattenType %q of %s is already used by %s, FnFlattenType has to be defined", fn.FlattenType(), item, it)
+			}
+			continue
+		}
+
+		items[fn.FlattenType()] = item
+		rifns = append(rifns, fn)
+	}
+
+	return rifns, nil
 }
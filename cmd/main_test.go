@@ -13,9 +13,12 @@ func Test_generate(t *testing.T) {
 	buff := bytes.Buffer{}
 	fns := []Function{
 		Function{
-			Entity:  "Instances",
-			Prefix:  "Describe",
-			Service: "ec2",
+			Entity:      "Instances",
+			Prefix:      "Describe",
+			Service:     "ec2",
+			Flatten:     "Reservations.Instances",
+			FlattenItem: "Instance",
+			Inventory:   true,
 			Documentation: `
 			// GetInstances returns all EC2 instances based on the input given.
 			// Returned values are commented in the interface doc comment block.
@@ -35,3 +38,86 @@ func Test_generate(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, exopt, buff.Bytes())
 }
+
+func Test_generateFake(t *testing.T) {
+	buff := bytes.Buffer{}
+	fns := []Function{
+		Function{
+			Entity:      "Instances",
+			Prefix:      "Describe",
+			Service:     "ec2",
+			Flatten:     "Reservations.Instances",
+			FlattenItem: "Instance",
+		},
+		Function{
+			FnSignature:  "DownloadObject(ctx context.Context, w io.WriterAt, input *s3.GetObjectInput, options ...func(*s3manager.Downloader)) (int64, error)",
+			NoGenerateFn: true,
+		},
+	}
+	exopt, err := ioutil.ReadFile("./testdata/generated_fake.go")
+	require.NoError(t, err)
+	err = generateFake(&buff, fns)
+	require.NoError(t, err)
+	assert.Equal(t, string(exopt), buff.String())
+}
+
+func Test_generateInventoryWithoutFlatten(t *testing.T) {
+	fns := []Function{
+		Function{Entity: "Instances", Prefix: "Describe", Service: "ec2", Inventory: true},
+	}
+	err := generate(&bytes.Buffer{}, fns)
+	assert.Error(t, err)
+}
+
+func Test_regionItems(t *testing.T) {
+	t.Run("Shared", func(t *testing.T) {
+		fns := []Function{
+			Function{Entity: "Images", Service: "ec2", Flatten: "Images", FlattenItem: "Image"},
+			Function{Entity: "Vpcs", Service: "ec2"},
+			Function{Entity: "Images", Service: "ec2", Flatten: "Images", FlattenItem: "Image", FilterByOwner: "Owners"},
+		}
+		rifns, err := regionItems(fns)
+		require.NoError(t, err)
+		assert.Equal(t, fns[:1], rifns)
+	})
+	t.Run("Conflict", func(t *testing.T) {
+		fns := []Function{
+			Function{Entity: "Groups", Service: "iam", Flatten: "Groups", FlattenItem: "Group"},
+			Function{Entity: "AutoScalingGroups", Service: "autoscaling", Flatten: "AutoScalingGroups", FlattenItem: "Group"},
+		}
+		_, err := regionItems(fns)
+		assert.Error(t, err)
+	})
+}
+
+func Test_generateCLI(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		buff := bytes.Buffer{}
+		fns := []Function{
+			Function{
+				Entity:      "Instances",
+				Prefix:      "Describe",
+				Service:     "ec2",
+				Flatten:     "Reservations.Instances",
+				FlattenItem: "Instance",
+			},
+			Function{
+				FnSignature:  "DownloadObject(ctx context.Context, w io.WriterAt, input *s3.GetObjectInput, options ...func(*s3manager.Downloader)) (int64, error)",
+				NoGenerateFn: true,
+			},
+		}
+		exopt, err := ioutil.ReadFile("./testdata/generated_cli.go")
+		require.NoError(t, err)
+		err = generateCLI(&buff, fns)
+		require.NoError(t, err)
+		assert.Equal(t, string(exopt), buff.String())
+	})
+	t.Run("Conflict", func(t *testing.T) {
+		fns := []Function{
+			Function{Entity: "LoadBalancers", Prefix: "Describe", Service: "elb"},
+			Function{FnName: "GetLoadBalancersV1", Entity: "LoadBalancers", Prefix: "Describe", Service: "elb"},
+		}
+		err := generateCLI(&bytes.Buffer{}, fns)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// checkPaths checks that the paths and the fields of the fns, which
+// are walked by reflection on the generated code, like the Flatten
+// path, exist on the types of the SDK, found with the API of their
+// Service, so a typo doesn't generate a function returning nothing
+func checkPaths(fns []Function) error {
+	for _, fn := range fns {
+		if fn.Flatten == "" {
+			continue
+		}
+
+		_, out, err := fn.apiTypes()
+		if err != nil {
+			return err
+		}
+
+		item, err := pathType(out, fn.Flatten)
+		if err != nil {
+			return fmt.Errorf("the Flatten of the Function %s is invalid: %s", fn.Name(), err)
+		}
+		if fn.FlattenItem != "" && item.Name() != fn.FlattenItem {
+			return fmt.Errorf("the FlattenItem of the Function %s is %s but the items on %s are %s", fn.Name(), fn.FlattenItem, fn.Flatten, item)
+		}
+
+		if fn.Enrich == nil {
+			continue
+		}
+
+		fups, err := fn.followUps(fns)
+		if err != nil {
+			return err
+		}
+		for _, fup := range fups {
+			err := checkFollowUp(item, fup)
+			if err != nil {
+				return fmt.Errorf("the FollowUp %s of the Function %s is invalid: %s", fup.Attach, fn.EnrichName(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkFollowUp checks the fields of the fup made
+// for each one of the items, which are of the item type
+func checkFollowUp(item reflect.Type, fup followUp) error {
+	in, out, err := fup.Fn.apiTypes()
+	if err != nil {
+		return err
+	}
+
+	if _, err := pathType(item, fup.From); err != nil {
+		return fmt.Errorf("From: %s", err)
+	}
+	if _, err := pathType(in, fup.To); err != nil {
+		return fmt.Errorf("To: %s", err)
+	}
+
+	if fup.Batch == 0 {
+		return nil
+	}
+
+	res, err := pathType(out, fup.Result)
+	if err != nil {
+		return fmt.Errorf("Result: %s", err)
+	}
+	if res.Name() != fup.ResultItem {
+		return fmt.Errorf("the ResultItem is %s but the results on %s are %s", fup.ResultItem, fup.Result, res)
+	}
+	if _, err := pathType(res, fup.ResultKey); err != nil {
+		return fmt.Errorf("ResultKey: %s", err)
+	}
+
+	return nil
+}
+
+// apiTypes returns the types of the input and the output of
+// the Operation of f, found on the API of its Service
+func (f Function) apiTypes() (reflect.Type, reflect.Type, error) {
+	api := services[f.Service].API
+	if api == nil {
+		return nil, nil, fmt.Errorf("the Service %s of the Function %s is unknown", f.Service, f.Name())
+	}
+
+	m, ok := api.MethodByName(f.Operation())
+	if !ok {
+		return nil, nil, fmt.Errorf("the Operation %s of the Function %s does not exist on %s", f.Operation(), f.Name(), f.Service)
+	}
+
+	return m.Type.In(0), m.Type.Out(0), nil
+}
+
+// pathType returns the type of the values found at the end of the
+// path walking through t, in the same way than the flatten of the
+// generated code, so the pointers and the slices are walked through
+func pathType(t reflect.Type, path string) (reflect.Type, error) {
+	for _, field := range strings.Split(path, ".") {
+		t = indirect(t)
+		if t.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("%s is not a struct to find %s on", t, field)
+		}
+
+		sf, ok := t.FieldByName(field)
+		if !ok {
+			return nil, fmt.Errorf("the field %s does not exist on %s", field, t)
+		}
+		t = sf.Type
+	}
+
+	return indirect(t), nil
+}
+
+// indirect returns the type of the elements
+// of t while it's a pointer or a slice
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+
+	return t
+}
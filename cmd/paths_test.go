@@ -0,0 +1,91 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPaths(t *testing.T) {
+	// lbs returns the GetLoadBalancers Function with
+	// the tags FollowUp modified by fn
+	lbs := func(fn func(fu *FollowUp)) []Function {
+		fu := FollowUp{
+			Attach:     "Tags",
+			Function:   "GetLoadBalancersTags",
+			From:       "LoadBalancerName",
+			To:         "LoadBalancerNames",
+			Batch:      20,
+			Result:     "TagDescriptions",
+			ResultItem: "TagDescription",
+			ResultKey:  "LoadBalancerName",
+		}
+		fn(&fu)
+
+		return []Function{
+			Function{Entity: "LoadBalancers", Prefix: "Describe", Service: "elb", Flatten: "LoadBalancerDescriptions", FlattenItem: "LoadBalancerDescription",
+				Enrich: &Enrich{Name: "WithTags", Type: "LoadBalancerWithTags", FollowUps: []FollowUp{fu}},
+			},
+			Function{FnName: "GetLoadBalancersTags", Entity: "Tags", Prefix: "Describe", Service: "elb"},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		fns   []Function
+		error string
+	}{
+		{
+			name: "Valid",
+			fns: []Function{
+				Function{Entity: "Instances", Prefix: "Describe", Service: "ec2", Flatten: "Reservations.Instances", FlattenItem: "Instance"},
+				Function{Entity: "Vpcs", Prefix: "Describe", Service: "ec2"},
+			},
+		},
+		{
+			name: "ValidFollowUp",
+			fns:  lbs(func(*FollowUp) {}),
+		},
+		{
+			name:  "UnknownField",
+			fns:   []Function{Function{Entity: "Instances", Prefix: "Describe", Service: "ec2", Flatten: "Reservations.Instance", FlattenItem: "Instance"}},
+			error: "the Flatten of the Function GetInstances is invalid: the field Instance does not exist on ec2.Reservation",
+		},
+		{
+			name:  "WrongItem",
+			fns:   []Function{Function{Entity: "Instances", Prefix: "Describe", Service: "ec2", Flatten: "Reservations", FlattenItem: "Instance"}},
+			error: "the FlattenItem of the Function GetInstances is Instance but the items on Reservations are ec2.Reservation",
+		},
+		{
+			name:  "UnknownOperation",
+			fns:   []Function{Function{Entity: "Instance", Prefix: "Describe", Service: "ec2", Flatten: "Reservations.Instances", FlattenItem: "Instance"}},
+			error: "the Operation DescribeInstance of the Function GetInstance does not exist on ec2",
+		},
+		{
+			name:  "UnknownResult",
+			fns:   lbs(func(fu *FollowUp) { fu.Result = "TagDescription" }),
+			error: "the FollowUp Tags of the Function GetLoadBalancersWithTags is invalid: Result: the field TagDescription does not exist on elb.DescribeTagsOutput",
+		},
+		{
+			name:  "WrongResultItem",
+			fns:   lbs(func(fu *FollowUp) { fu.ResultItem = "Tag" }),
+			error: "the FollowUp Tags of the Function GetLoadBalancersWithTags is invalid: the ResultItem is Tag but the results on TagDescriptions are elb.TagDescription",
+		},
+		{
+			name:  "UnknownFrom",
+			fns:   lbs(func(fu *FollowUp) { fu.From = "Name" }),
+			error: "the FollowUp Tags of the Function GetLoadBalancersWithTags is invalid: From: the field Name does not exist on elb.LoadBalancerDescription",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPaths(tt.fns)
+			if tt.error == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.error)
+		})
+	}
+}
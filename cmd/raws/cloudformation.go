@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/cycloidio/raws/cloudformation"
+)
+
+// runCloudFormation runs the cloudformation command, which writes on stdout
+// the CloudFormation template of the resources of a snapshot
+func runCloudFormation(_ context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		fs      = flag.NewFlagSet("raws cloudformation", flag.ContinueOnError)
+		format  = fs.String("format", "yaml", "Format of the template: yaml or json")
+		imports = fs.String("imports", "", "File on which the resources to import are written, for 'aws cloudformation create-change-set --change-set-type IMPORT --resources-to-import file://<file>'")
+		region  = fs.String("region", "", "Region of the stack, the resources of the other regions are excluded except the global ones, like IAM. Required if the snapshot has several regions")
+	)
+
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: raws cloudformation [flags] <snapshot>\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	err := fs.Parse(args)
+	if err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if *format != "yaml" && *format != "json" {
+		return fmt.Errorf("invalid format %q", *format)
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("a snapshot is required")
+	}
+
+	s, err := readSnapshot(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	t, err := cloudformation.Generate(s.Resources, cloudformation.GenerateOptions{Region: *region})
+	if err != nil {
+		return err
+	}
+
+	if *imports != "" {
+		err = writeFile(*imports, t.WriteImports)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(t.Skipped) > 0 {
+		fmt.Fprintf(stderr, "%d resources not supported\n", len(t.Skipped))
+	}
+	if len(t.Excluded) > 0 {
+		fmt.Fprintf(stderr, "%d resources of other regions than %s excluded\n", len(t.Excluded), *region)
+	}
+
+	if *format == "json" {
+		return t.WriteJSON(stdout)
+	}
+
+	return t.WriteYAML(stdout)
+}
@@ -0,0 +1,839 @@
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53resolver"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/ses"
+	"github.com/cycloidio/raws"
+)
+
+// Code generated by github.com/cycloidio/raws/cmd; DO NOT EDIT
+
+var commands = []command{
+	{
+		service: "ec2",
+		name:    "instances",
+		fn:      "GetInstances",
+		input:   func() interface{} { return &ec2.DescribeInstancesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetInstances(ctx, input.(*ec2.DescribeInstancesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllInstances(ctx, r, input.(*ec2.DescribeInstancesInput))
+		},
+	},
+	{
+		service: "ec2",
+		name:    "vpcs",
+		fn:      "GetVpcs",
+		input:   func() interface{} { return &ec2.DescribeVpcsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetVpcs(ctx, input.(*ec2.DescribeVpcsInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllVpcs(ctx, r, input.(*ec2.DescribeVpcsInput))
+		},
+	},
+	{
+		service: "ec2",
+		name:    "images",
+		fn:      "GetImages",
+		input:   func() interface{} { return &ec2.DescribeImagesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetImages(ctx, input.(*ec2.DescribeImagesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllImages(ctx, r, input.(*ec2.DescribeImagesInput))
+		},
+	},
+	{
+		service: "ec2",
+		name:    "own-images",
+		fn:      "GetOwnImages",
+		input:   func() interface{} { return &ec2.DescribeImagesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetOwnImages(ctx, input.(*ec2.DescribeImagesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllOwnImages(ctx, r, input.(*ec2.DescribeImagesInput))
+		},
+	},
+	{
+		service: "ec2",
+		name:    "security-groups",
+		fn:      "GetSecurityGroups",
+		input:   func() interface{} { return &ec2.DescribeSecurityGroupsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetSecurityGroups(ctx, input.(*ec2.DescribeSecurityGroupsInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllSecurityGroups(ctx, r, input.(*ec2.DescribeSecurityGroupsInput))
+		},
+	},
+	{
+		service: "ec2",
+		name:    "subnets",
+		fn:      "GetSubnets",
+		input:   func() interface{} { return &ec2.DescribeSubnetsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetSubnets(ctx, input.(*ec2.DescribeSubnetsInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllSubnets(ctx, r, input.(*ec2.DescribeSubnetsInput))
+		},
+	},
+	{
+		service: "ec2",
+		name:    "volumes",
+		fn:      "GetVolumes",
+		input:   func() interface{} { return &ec2.DescribeVolumesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetVolumes(ctx, input.(*ec2.DescribeVolumesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllVolumes(ctx, r, input.(*ec2.DescribeVolumesInput))
+		},
+	},
+	{
+		service: "ec2",
+		name:    "snapshots",
+		fn:      "GetSnapshots",
+		input:   func() interface{} { return &ec2.DescribeSnapshotsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetSnapshots(ctx, input.(*ec2.DescribeSnapshotsInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllSnapshots(ctx, r, input.(*ec2.DescribeSnapshotsInput))
+		},
+	},
+	{
+		service: "ec2",
+		name:    "own-snapshots",
+		fn:      "GetOwnSnapshots",
+		input:   func() interface{} { return &ec2.DescribeSnapshotsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetOwnSnapshots(ctx, input.(*ec2.DescribeSnapshotsInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllOwnSnapshots(ctx, r, input.(*ec2.DescribeSnapshotsInput))
+		},
+	},
+	{
+		service: "ec2",
+		name:    "launch-templates",
+		fn:      "GetLaunchTemplates",
+		input:   func() interface{} { return &ec2.DescribeLaunchTemplatesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetLaunchTemplates(ctx, input.(*ec2.DescribeLaunchTemplatesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllLaunchTemplates(ctx, r, input.(*ec2.DescribeLaunchTemplatesInput))
+		},
+	},
+	{
+		service: "autoscaling",
+		name:    "auto-scaling-groups",
+		fn:      "GetAutoScalingGroups",
+		input:   func() interface{} { return &autoscaling.DescribeAutoScalingGroupsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetAutoScalingGroups(ctx, input.(*autoscaling.DescribeAutoScalingGroupsInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllAutoScalingGroups(ctx, r, input.(*autoscaling.DescribeAutoScalingGroupsInput))
+		},
+	},
+	{
+		service: "autoscaling",
+		name:    "launch-configurations",
+		fn:      "GetLaunchConfigurations",
+		input:   func() interface{} { return &autoscaling.DescribeLaunchConfigurationsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetLaunchConfigurations(ctx, input.(*autoscaling.DescribeLaunchConfigurationsInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllLaunchConfigurations(ctx, r, input.(*autoscaling.DescribeLaunchConfigurationsInput))
+		},
+	},
+	{
+		service: "elasticache",
+		name:    "cache-clusters",
+		fn:      "GetElastiCacheClusters",
+		input:   func() interface{} { return &elasticache.DescribeCacheClustersInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetElastiCacheClusters(ctx, input.(*elasticache.DescribeCacheClustersInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllElastiCacheClusters(ctx, r, input.(*elasticache.DescribeCacheClustersInput))
+		},
+	},
+	{
+		service: "elasticache",
+		name:    "tags-for-resource",
+		fn:      "GetElastiCacheTags",
+		input:   func() interface{} { return &elasticache.ListTagsForResourceInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetElastiCacheTags(ctx, input.(*elasticache.ListTagsForResourceInput))
+		},
+	},
+	{
+		service: "elb",
+		name:    "load-balancers",
+		fn:      "GetLoadBalancers",
+		input:   func() interface{} { return &elb.DescribeLoadBalancersInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetLoadBalancers(ctx, input.(*elb.DescribeLoadBalancersInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllLoadBalancers(ctx, r, input.(*elb.DescribeLoadBalancersInput))
+		},
+	},
+	{
+		service: "elb",
+		name:    "load-balancers-with-tags",
+		fn:      "GetLoadBalancersWithTags",
+		input:   func() interface{} { return &elb.DescribeLoadBalancersInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetLoadBalancersWithTags(ctx, input.(*elb.DescribeLoadBalancersInput))
+		},
+	},
+	{
+		service: "elb",
+		name:    "tags",
+		fn:      "GetLoadBalancersTags",
+		input:   func() interface{} { return &elb.DescribeTagsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetLoadBalancersTags(ctx, input.(*elb.DescribeTagsInput))
+		},
+	},
+	{
+		service: "elbv2",
+		name:    "load-balancers",
+		fn:      "GetLoadBalancersV2",
+		input:   func() interface{} { return &elbv2.DescribeLoadBalancersInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetLoadBalancersV2(ctx, input.(*elbv2.DescribeLoadBalancersInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllLoadBalancersV2(ctx, r, input.(*elbv2.DescribeLoadBalancersInput))
+		},
+	},
+	{
+		service: "elbv2",
+		name:    "load-balancers-with-tags",
+		fn:      "GetLoadBalancersV2WithTags",
+		input:   func() interface{} { return &elbv2.DescribeLoadBalancersInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetLoadBalancersV2WithTags(ctx, input.(*elbv2.DescribeLoadBalancersInput))
+		},
+	},
+	{
+		service: "elbv2",
+		name:    "tags",
+		fn:      "GetLoadBalancersV2Tags",
+		input:   func() interface{} { return &elbv2.DescribeTagsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetLoadBalancersV2Tags(ctx, input.(*elbv2.DescribeTagsInput))
+		},
+	},
+	{
+		service: "rds",
+		name:    "db-instances",
+		fn:      "GetDBInstances",
+		input:   func() interface{} { return &rds.DescribeDBInstancesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetDBInstances(ctx, input.(*rds.DescribeDBInstancesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllDBInstances(ctx, r, input.(*rds.DescribeDBInstancesInput))
+		},
+	},
+	{
+		service: "rds",
+		name:    "db-instances-with-tags",
+		fn:      "GetDBInstancesWithTags",
+		input:   func() interface{} { return &rds.DescribeDBInstancesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetDBInstancesWithTags(ctx, input.(*rds.DescribeDBInstancesInput))
+		},
+	},
+	{
+		service: "rds",
+		name:    "tags-for-resource",
+		fn:      "GetDBInstancesTags",
+		input:   func() interface{} { return &rds.ListTagsForResourceInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetDBInstancesTags(ctx, input.(*rds.ListTagsForResourceInput))
+		},
+	},
+	{
+		service: "s3",
+		name:    "bucket-policy-status",
+		fn:      "GetBucketPolicyStatus",
+		input:   func() interface{} { return &s3.GetBucketPolicyStatusInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetBucketPolicyStatus(ctx, input.(*s3.GetBucketPolicyStatusInput))
+		},
+	},
+	{
+		service: "s3",
+		name:    "bucket-acl",
+		fn:      "GetBucketAcl",
+		input:   func() interface{} { return &s3.GetBucketAclInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetBucketAcl(ctx, input.(*s3.GetBucketAclInput))
+		},
+	},
+	{
+		service: "s3",
+		name:    "public-access-block",
+		fn:      "GetPublicAccessBlock",
+		input:   func() interface{} { return &s3.GetPublicAccessBlockInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetPublicAccessBlock(ctx, input.(*s3.GetPublicAccessBlockInput))
+		},
+	},
+	{
+		service: "s3",
+		name:    "buckets",
+		fn:      "ListBuckets",
+		input:   func() interface{} { return &s3.ListBucketsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.ListBuckets(ctx, input.(*s3.ListBucketsInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllBuckets(ctx, r, input.(*s3.ListBucketsInput))
+		},
+	},
+	{
+		service: "s3",
+		name:    "bucket-tagging",
+		fn:      "GetBucketTags",
+		input:   func() interface{} { return &s3.GetBucketTaggingInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetBucketTags(ctx, input.(*s3.GetBucketTaggingInput))
+		},
+	},
+	{
+		service: "s3",
+		name:    "objects",
+		fn:      "ListObjects",
+		input:   func() interface{} { return &s3.ListObjectsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.ListObjects(ctx, input.(*s3.ListObjectsInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllObjects(ctx, r, input.(*s3.ListObjectsInput))
+		},
+	},
+	{
+		service: "s3",
+		name:    "object-tagging",
+		fn:      "GetObjectsTags",
+		input:   func() interface{} { return &s3.GetObjectTaggingInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetObjectsTags(ctx, input.(*s3.GetObjectTaggingInput))
+		},
+	},
+	{
+		service: "configservice",
+		name:    "discovered-resource-counts",
+		fn:      "GetRecordedResourceCounts",
+		input:   func() interface{} { return &configservice.GetDiscoveredResourceCountsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetRecordedResourceCounts(ctx, input.(*configservice.GetDiscoveredResourceCountsInput))
+		},
+	},
+	{
+		service: "cloudfront",
+		name:    "distributions",
+		fn:      "GetCloudFrontDistributions",
+		input:   func() interface{} { return &cloudfront.ListDistributionsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetCloudFrontDistributions(ctx, input.(*cloudfront.ListDistributionsInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllCloudFrontDistributions(ctx, r, input.(*cloudfront.ListDistributionsInput))
+		},
+	},
+	{
+		service: "cloudfront",
+		name:    "public-keys",
+		fn:      "GetCloudFrontPublicKeys",
+		input:   func() interface{} { return &cloudfront.ListPublicKeysInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetCloudFrontPublicKeys(ctx, input.(*cloudfront.ListPublicKeysInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllCloudFrontPublicKeys(ctx, r, input.(*cloudfront.ListPublicKeysInput))
+		},
+	},
+	{
+		service: "cloudfront",
+		name:    "cloud-front-origin-access-identities",
+		fn:      "GetCloudFrontOriginAccessIdentities",
+		input:   func() interface{} { return &cloudfront.ListCloudFrontOriginAccessIdentitiesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetCloudFrontOriginAccessIdentities(ctx, input.(*cloudfront.ListCloudFrontOriginAccessIdentitiesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllCloudFrontOriginAccessIdentities(ctx, r, input.(*cloudfront.ListCloudFrontOriginAccessIdentitiesInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "access-keys",
+		fn:      "GetAccessKeys",
+		input:   func() interface{} { return &iam.ListAccessKeysInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetAccessKeys(ctx, input.(*iam.ListAccessKeysInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllAccessKeys(ctx, r, input.(*iam.ListAccessKeysInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "account-aliases",
+		fn:      "GetAccountAliases",
+		input:   func() interface{} { return &iam.ListAccountAliasesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetAccountAliases(ctx, input.(*iam.ListAccountAliasesInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "account-password-policy",
+		fn:      "GetAccountPasswordPolicy",
+		input:   func() interface{} { return &iam.GetAccountPasswordPolicyInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetAccountPasswordPolicy(ctx, input.(*iam.GetAccountPasswordPolicyInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "groups",
+		fn:      "GetGroups",
+		input:   func() interface{} { return &iam.ListGroupsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetGroups(ctx, input.(*iam.ListGroupsInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllGroups(ctx, r, input.(*iam.ListGroupsInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "groups-with-policies",
+		fn:      "GetGroupsWithPolicies",
+		input:   func() interface{} { return &iam.ListGroupsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetGroupsWithPolicies(ctx, input.(*iam.ListGroupsInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "group-policies",
+		fn:      "GetGroupPolicies",
+		input:   func() interface{} { return &iam.ListGroupPoliciesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetGroupPolicies(ctx, input.(*iam.ListGroupPoliciesInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "attached-group-policies",
+		fn:      "GetAttachedGroupPolicies",
+		input:   func() interface{} { return &iam.ListAttachedGroupPoliciesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetAttachedGroupPolicies(ctx, input.(*iam.ListAttachedGroupPoliciesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllAttachedGroupPolicies(ctx, r, input.(*iam.ListAttachedGroupPoliciesInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "instance-profiles",
+		fn:      "GetInstanceProfiles",
+		input:   func() interface{} { return &iam.ListInstanceProfilesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetInstanceProfiles(ctx, input.(*iam.ListInstanceProfilesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllInstanceProfiles(ctx, r, input.(*iam.ListInstanceProfilesInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "open-id-connect-providers",
+		fn:      "GetOpenIDConnectProviders",
+		input:   func() interface{} { return &iam.ListOpenIDConnectProvidersInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetOpenIDConnectProviders(ctx, input.(*iam.ListOpenIDConnectProvidersInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllOpenIDConnectProviders(ctx, r, input.(*iam.ListOpenIDConnectProvidersInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "policies",
+		fn:      "GetPolicies",
+		input:   func() interface{} { return &iam.ListPoliciesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetPolicies(ctx, input.(*iam.ListPoliciesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllPolicies(ctx, r, input.(*iam.ListPoliciesInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "roles",
+		fn:      "GetRoles",
+		input:   func() interface{} { return &iam.ListRolesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetRoles(ctx, input.(*iam.ListRolesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllRoles(ctx, r, input.(*iam.ListRolesInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "roles-with-policies",
+		fn:      "GetRolesWithPolicies",
+		input:   func() interface{} { return &iam.ListRolesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetRolesWithPolicies(ctx, input.(*iam.ListRolesInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "role-policies",
+		fn:      "GetRolePolicies",
+		input:   func() interface{} { return &iam.ListRolePoliciesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetRolePolicies(ctx, input.(*iam.ListRolePoliciesInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "attached-role-policies",
+		fn:      "GetAttachedRolePolicies",
+		input:   func() interface{} { return &iam.ListAttachedRolePoliciesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetAttachedRolePolicies(ctx, input.(*iam.ListAttachedRolePoliciesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllAttachedRolePolicies(ctx, r, input.(*iam.ListAttachedRolePoliciesInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "role-tags",
+		fn:      "GetRoleTags",
+		input:   func() interface{} { return &iam.ListRoleTagsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetRoleTags(ctx, input.(*iam.ListRoleTagsInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "saml-providers",
+		fn:      "GetSAMLProviders",
+		input:   func() interface{} { return &iam.ListSAMLProvidersInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetSAMLProviders(ctx, input.(*iam.ListSAMLProvidersInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllSAMLProviders(ctx, r, input.(*iam.ListSAMLProvidersInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "server-certificates",
+		fn:      "GetServerCertificates",
+		input:   func() interface{} { return &iam.ListServerCertificatesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetServerCertificates(ctx, input.(*iam.ListServerCertificatesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllServerCertificates(ctx, r, input.(*iam.ListServerCertificatesInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "users",
+		fn:      "GetUsers",
+		input:   func() interface{} { return &iam.ListUsersInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetUsers(ctx, input.(*iam.ListUsersInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllUsers(ctx, r, input.(*iam.ListUsersInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "users-with-policies",
+		fn:      "GetUsersWithPolicies",
+		input:   func() interface{} { return &iam.ListUsersInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetUsersWithPolicies(ctx, input.(*iam.ListUsersInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "user-policies",
+		fn:      "GetUserPolicies",
+		input:   func() interface{} { return &iam.ListUserPoliciesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetUserPolicies(ctx, input.(*iam.ListUserPoliciesInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "attached-user-policies",
+		fn:      "GetAttachedUserPolicies",
+		input:   func() interface{} { return &iam.ListAttachedUserPoliciesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetAttachedUserPolicies(ctx, input.(*iam.ListAttachedUserPoliciesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllAttachedUserPolicies(ctx, r, input.(*iam.ListAttachedUserPoliciesInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "user-tags",
+		fn:      "GetUserTags",
+		input:   func() interface{} { return &iam.ListUserTagsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetUserTags(ctx, input.(*iam.ListUserTagsInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "mfa-devices",
+		fn:      "GetMFADevices",
+		input:   func() interface{} { return &iam.ListMFADevicesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetMFADevices(ctx, input.(*iam.ListMFADevicesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllMFADevices(ctx, r, input.(*iam.ListMFADevicesInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "account-authorization-details",
+		fn:      "GetAccountAuthorizationDetails",
+		input:   func() interface{} { return &iam.GetAccountAuthorizationDetailsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetAccountAuthorizationDetails(ctx, input.(*iam.GetAccountAuthorizationDetailsInput))
+		},
+	},
+	{
+		service: "iam",
+		name:    "ssh-public-key",
+		fn:      "GetSSHPublicKey",
+		input:   func() interface{} { return &iam.GetSSHPublicKeyInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetSSHPublicKey(ctx, input.(*iam.GetSSHPublicKeyInput))
+		},
+	},
+	{
+		service: "ses",
+		name:    "active-receipt-rule-set",
+		fn:      "GetActiveReceiptRuleSet",
+		input:   func() interface{} { return &ses.DescribeActiveReceiptRuleSetInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetActiveReceiptRuleSet(ctx, input.(*ses.DescribeActiveReceiptRuleSetInput))
+		},
+	},
+	{
+		service: "ses",
+		name:    "identities",
+		fn:      "GetIdentities",
+		input:   func() interface{} { return &ses.ListIdentitiesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetIdentities(ctx, input.(*ses.ListIdentitiesInput))
+		},
+	},
+	{
+		service: "ses",
+		name:    "receipt-filters",
+		fn:      "GetReceiptFilters",
+		input:   func() interface{} { return &ses.ListReceiptFiltersInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetReceiptFilters(ctx, input.(*ses.ListReceiptFiltersInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllReceiptFilters(ctx, r, input.(*ses.ListReceiptFiltersInput))
+		},
+	},
+	{
+		service: "ses",
+		name:    "configuration-sets",
+		fn:      "GetConfigurationSets",
+		input:   func() interface{} { return &ses.ListConfigurationSetsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetConfigurationSets(ctx, input.(*ses.ListConfigurationSetsInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllConfigurationSets(ctx, r, input.(*ses.ListConfigurationSetsInput))
+		},
+	},
+	{
+		service: "ses",
+		name:    "identity-notification-attributes",
+		fn:      "GetIdentityNotificationAttributes",
+		input:   func() interface{} { return &ses.GetIdentityNotificationAttributesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetIdentityNotificationAttributes(ctx, input.(*ses.GetIdentityNotificationAttributesInput))
+		},
+	},
+	{
+		service: "ses",
+		name:    "templates",
+		fn:      "GetTemplates",
+		input:   func() interface{} { return &ses.ListTemplatesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetTemplates(ctx, input.(*ses.ListTemplatesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllTemplates(ctx, r, input.(*ses.ListTemplatesInput))
+		},
+	},
+	{
+		service: "route53",
+		name:    "reusable-delegation-sets",
+		fn:      "GetReusableDelegationSets",
+		input:   func() interface{} { return &route53.ListReusableDelegationSetsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetReusableDelegationSets(ctx, input.(*route53.ListReusableDelegationSetsInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllReusableDelegationSets(ctx, r, input.(*route53.ListReusableDelegationSetsInput))
+		},
+	},
+	{
+		service: "route53",
+		name:    "health-checks",
+		fn:      "GetHealthChecks",
+		input:   func() interface{} { return &route53.ListHealthChecksInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetHealthChecks(ctx, input.(*route53.ListHealthChecksInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllHealthChecks(ctx, r, input.(*route53.ListHealthChecksInput))
+		},
+	},
+	{
+		service: "route53",
+		name:    "query-logging-configs",
+		fn:      "GetQueryLoggingConfigs",
+		input:   func() interface{} { return &route53.ListQueryLoggingConfigsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetQueryLoggingConfigs(ctx, input.(*route53.ListQueryLoggingConfigsInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllQueryLoggingConfigs(ctx, r, input.(*route53.ListQueryLoggingConfigsInput))
+		},
+	},
+	{
+		service: "route53",
+		name:    "resource-record-sets",
+		fn:      "GetResourceRecordSets",
+		input:   func() interface{} { return &route53.ListResourceRecordSetsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetResourceRecordSets(ctx, input.(*route53.ListResourceRecordSetsInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllResourceRecordSets(ctx, r, input.(*route53.ListResourceRecordSetsInput))
+		},
+	},
+	{
+		service: "route53",
+		name:    "hosted-zones",
+		fn:      "GetHostedZones",
+		input:   func() interface{} { return &route53.ListHostedZonesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetHostedZones(ctx, input.(*route53.ListHostedZonesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllHostedZones(ctx, r, input.(*route53.ListHostedZonesInput))
+		},
+	},
+	{
+		service: "route53",
+		name:    "vpc-association-authorizations",
+		fn:      "GetVPCAssociationAuthorizations",
+		input:   func() interface{} { return &route53.ListVPCAssociationAuthorizationsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetVPCAssociationAuthorizations(ctx, input.(*route53.ListVPCAssociationAuthorizationsInput))
+		},
+	},
+	{
+		service: "route53resolver",
+		name:    "resolver-endpoints",
+		fn:      "GetResolverEndpoints",
+		input:   func() interface{} { return &route53resolver.ListResolverEndpointsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetResolverEndpoints(ctx, input.(*route53resolver.ListResolverEndpointsInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllResolverEndpoints(ctx, r, input.(*route53resolver.ListResolverEndpointsInput))
+		},
+	},
+	{
+		service: "route53resolver",
+		name:    "resolver-rules",
+		fn:      "GetResolverRules",
+		input:   func() interface{} { return &route53resolver.ListResolverRulesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetResolverRules(ctx, input.(*route53resolver.ListResolverRulesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllResolverRules(ctx, r, input.(*route53resolver.ListResolverRulesInput))
+		},
+	},
+	{
+		service: "route53resolver",
+		name:    "resolver-rule-associations",
+		fn:      "GetResolverRuleAssociations",
+		input:   func() interface{} { return &route53resolver.ListResolverRuleAssociationsInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetResolverRuleAssociations(ctx, input.(*route53resolver.ListResolverRuleAssociationsInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllResolverRuleAssociations(ctx, r, input.(*route53resolver.ListResolverRuleAssociationsInput))
+		},
+	},
+	{
+		service: "resourcegroupstaggingapi",
+		name:    "resources",
+		fn:      "GetTaggedResources",
+		input:   func() interface{} { return &resourcegroupstaggingapi.GetResourcesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetTaggedResources(ctx, input.(*resourcegroupstaggingapi.GetResourcesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllTaggedResources(ctx, r, input.(*resourcegroupstaggingapi.GetResourcesInput))
+		},
+	},
+}
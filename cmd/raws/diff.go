@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cycloidio/raws"
+)
+
+// runDiff runs the diff command, which writes on stdout
+// the raws.SnapshotDiff between 2 snapshot files
+func runDiff(_ context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		fs     = flag.NewFlagSet("raws diff", flag.ContinueOnError)
+		ignore = fs.String("ignore", "", "Comma separated list of fields, like 'raw.State,tags.owner', ignored on top of the volatile ones")
+		all    = fs.Bool("all", false, "Compare also the volatile fields, like the launch time of the instances")
+		fail   = fs.Bool("fail", false, "Exit with an error if there are differences")
+		format = fs.String("format", "json", "Format of the result: json or yaml")
+	)
+
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: raws diff [flags] <from snapshot> <to snapshot>\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	err := fs.Parse(args)
+	if err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return errors.New("2 snapshots are required")
+	}
+
+	if *format != "json" && *format != "yaml" {
+		return fmt.Errorf("invalid format %q", *format)
+	}
+
+	from, err := readSnapshot(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	to, err := readSnapshot(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	opts := raws.DiffOptions{Ignore: []string{}}
+	if !*all {
+		opts.Ignore = append(opts.Ignore, raws.VolatileFields...)
+	}
+	if *ignore != "" {
+		opts.Ignore = append(opts.Ignore, strings.Split(*ignore, ",")...)
+	}
+
+	d, err := raws.Diff(from, to, opts)
+	if err != nil {
+		return err
+	}
+
+	p, err := newPrinter(*format, "")
+	if err != nil {
+		return err
+	}
+
+	err = p.print(stdout, nil, d)
+	if err != nil {
+		return err
+	}
+
+	if *fail && !d.Empty() {
+		return fmt.Errorf("%d added, %d removed and %d modified resources", len(d.Added), len(d.Removed), len(d.Modified))
+	}
+
+	return nil
+}
+
+// readSnapshot reads the raws.Snapshot on the path
+func readSnapshot(path string) (*raws.Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s, err := raws.ReadSnapshot(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %s", path, err)
+	}
+
+	return s, nil
+}
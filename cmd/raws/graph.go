@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/cycloidio/raws"
+)
+
+// runGraph runs the graph command, which writes on stdout the
+// raws.Graph of the resources of a snapshot file
+func runGraph(_ context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		fs     = flag.NewFlagSet("raws graph", flag.ContinueOnError)
+		format = fs.String("format", "dot", "Format of the graph: dot or json")
+		from   = fs.String("from", "", "Key (ARN or service/type/account/region/id) of the resource from which the graph starts, only the resources reachable from it are written")
+	)
+
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: raws graph [flags] <snapshot>\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	err := fs.Parse(args)
+	if err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if *format != "dot" && *format != "json" {
+		return fmt.Errorf("invalid format %q", *format)
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("a snapshot is required")
+	}
+
+	s, err := readSnapshot(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	g, err := raws.NewGraph(s.Resources)
+	if err != nil {
+		return err
+	}
+
+	if *from != "" {
+		r, ok := g.Resource(*from)
+		if !ok {
+			return fmt.Errorf("the resource %q is not on the snapshot", *from)
+		}
+
+		g, err = raws.NewGraph(append([]raws.Resource{r}, g.Reachable(*from)...))
+		if err != nil {
+			return err
+		}
+	}
+
+	if *format == "dot" {
+		return g.WriteDOT(stdout)
+	}
+
+	p, err := newPrinter("json", "")
+	if err != nil {
+		return err
+	}
+
+	return p.print(stdout, nil, g)
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+var (
+	stringType      = reflect.TypeOf((*string)(nil))
+	int64Type       = reflect.TypeOf((*int64)(nil))
+	boolType        = reflect.TypeOf((*bool)(nil))
+	stringSliceType = reflect.TypeOf([]*string(nil))
+)
+
+// fieldFlag is a flag.Value setting a field of an input, the value is
+// only set on the field when apply is called so it can be done after
+// reading the input from a file
+type fieldFlag struct {
+	field reflect.Value
+	value *string
+}
+
+func (f *fieldFlag) String() string {
+	if f.value == nil {
+		return ""
+	}
+	return *f.value
+}
+
+func (f *fieldFlag) Set(s string) error {
+	_, err := f.parse(s)
+	if err != nil {
+		return err
+	}
+
+	f.value = &s
+	return nil
+}
+
+// IsBoolFlag allows to use the flags of the boolean fields
+// without value, like "--dry-run"
+func (f *fieldFlag) IsBoolFlag() bool {
+	return f.field.IsValid() && f.field.Type() == boolType
+}
+
+// parse converts s to the type of the field
+func (f *fieldFlag) parse(s string) (reflect.Value, error) {
+	switch f.field.Type() {
+	case stringType:
+		return reflect.ValueOf(aws.String(s)), nil
+	case int64Type:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(aws.Int64(i)), nil
+	case boolType:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(aws.Bool(b)), nil
+	case stringSliceType:
+		return reflect.ValueOf(aws.StringSlice(strings.Split(s, ","))), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported type %s", f.field.Type())
+	}
+}
+
+// apply sets the value of the flag, if any, on the field
+func (f *fieldFlag) apply() error {
+	if f.value == nil {
+		return nil
+	}
+
+	v, err := f.parse(*f.value)
+	if err != nil {
+		return err
+	}
+
+	f.field.Set(v)
+	return nil
+}
+
+// inputFlags defines on fs one flag for each field of input which is a
+// string, an integer, a boolean or a list of strings, named as the field
+// in kebab case, like "--instance-ids" for "InstanceIds".
+// The values of the flags are set on the input when the returned function
+// is called.
+func inputFlags(fs *flag.FlagSet, input interface{}) func() error {
+	var (
+		flags []*fieldFlag
+		v     = reflect.ValueOf(input).Elem()
+	)
+
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		if sf.PkgPath != "" || sf.Name == "Filters" {
+			continue
+		}
+
+		switch sf.Type {
+		case stringType, int64Type, boolType, stringSliceType:
+		default:
+			continue
+		}
+
+		name := kebab(sf.Name)
+		if fs.Lookup(name) != nil {
+			continue
+		}
+
+		f := &fieldFlag{field: v.Field(i)}
+		fs.Var(f, name, fmt.Sprintf("Sets the %s of the input", sf.Name))
+		flags = append(flags, f)
+	}
+
+	return func() error {
+		for _, f := range flags {
+			err := f.apply()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// filterFlag is a flag.Value which can be repeated
+// to define multiple filters, like "tag:env=prod"
+type filterFlag []string
+
+func (f *filterFlag) String() string {
+	return strings.Join(*f, " ")
+}
+
+func (f *filterFlag) Set(s string) error {
+	if !strings.Contains(s, "=") {
+		return fmt.Errorf("invalid filter %q, the format is 'name=value1,value2'", s)
+	}
+
+	*f = append(*f, s)
+	return nil
+}
+
+// filtersField returns the Filters field of input if it's a
+// list of filters with a Name and Values, like ec2.Filter
+func filtersField(input interface{}) (reflect.Value, bool) {
+	v := reflect.ValueOf(input).Elem().FieldByName("Filters")
+	if !v.IsValid() || v.Kind() != reflect.Slice || v.Type().Elem().Kind() != reflect.Ptr {
+		return reflect.Value{}, false
+	}
+
+	ft := v.Type().Elem().Elem()
+	if n, ok := ft.FieldByName("Name"); !ok || n.Type != stringType {
+		return reflect.Value{}, false
+	}
+	if vs, ok := ft.FieldByName("Values"); !ok || vs.Type != stringSliceType {
+		return reflect.Value{}, false
+	}
+
+	return v, true
+}
+
+// hasFilters checks if the input can be filtered with filterFlag
+func hasFilters(input interface{}) bool {
+	_, ok := filtersField(input)
+	return ok
+}
+
+// setFilters adds the filters, with the format "name=value1,value2",
+// to the Filters of the input
+func setFilters(input interface{}, filters filterFlag) error {
+	if len(filters) == 0 {
+		return nil
+	}
+
+	v, ok := filtersField(input)
+	if !ok {
+		return fmt.Errorf("the input %T can't be filtered", input)
+	}
+
+	for _, f := range filters {
+		i := strings.Index(f, "=")
+		filter := reflect.New(v.Type().Elem().Elem())
+		filter.Elem().FieldByName("Name").Set(reflect.ValueOf(aws.String(f[:i])))
+		filter.Elem().FieldByName("Values").Set(reflect.ValueOf(aws.StringSlice(strings.Split(f[i+1:], ","))))
+		v.Set(reflect.Append(v, filter))
+	}
+
+	return nil
+}
+
+// kebab converts a CamelCase name to kebab-case keeping
+// the acronyms together, so "DBInstances" is "db-instances"
+func kebab(s string) string {
+	var b strings.Builder
+
+	rs := []rune(s)
+	for i, r := range rs {
+		if i > 0 && unicode.IsUpper(r) &&
+			(!unicode.IsUpper(rs[i-1]) || (i+1 < len(rs) && unicode.IsLower(rs[i+1]))) {
+			b.WriteRune('-')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInputFlags(t *testing.T) {
+	input := &ec2.DescribeInstancesInput{
+		InstanceIds: aws.StringSlice([]string{"i-0"}),
+		NextToken:   aws.String("token"),
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.String("next-token", "", "already defined")
+	set := inputFlags(fs, input)
+
+	assert.Nil(t, fs.Lookup("filters"))
+
+	err := fs.Parse([]string{"--instance-ids", "i-1,i-2", "--max-results", "5", "--dry-run", "--next-token", "other"})
+	require.NoError(t, err)
+
+	// Nothing is set until the function is called
+	assert.Equal(t, aws.StringSlice([]string{"i-0"}), input.InstanceIds)
+
+	err = set()
+	require.NoError(t, err)
+	assert.Equal(t, &ec2.DescribeInstancesInput{
+		InstanceIds: aws.StringSlice([]string{"i-1", "i-2"}),
+		MaxResults:  aws.Int64(5),
+		DryRun:      aws.Bool(true),
+		NextToken:   aws.String("token"),
+	}, input)
+
+	err = fs.Parse([]string{"--max-results", "five"})
+	assert.Error(t, err)
+}
+
+func TestSetFilters(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		input := &ec2.DescribeVpcsInput{}
+		require.True(t, hasFilters(input))
+
+		err := setFilters(input, filterFlag{"tag:env=prod", "state=pending,available"})
+		require.NoError(t, err)
+		assert.Equal(t, []*ec2.Filter{
+			{Name: aws.String("tag:env"), Values: aws.StringSlice([]string{"prod"})},
+			{Name: aws.String("state"), Values: aws.StringSlice([]string{"pending", "available"})},
+		}, input.Filters)
+	})
+
+	t.Run("NoFilters", func(t *testing.T) {
+		input := &iam.ListRolesInput{}
+		assert.False(t, hasFilters(input))
+		assert.NoError(t, setFilters(input, nil))
+		assert.Error(t, setFilters(input, filterFlag{"tag:env=prod"}))
+	})
+
+	t.Run("InvalidFilter", func(t *testing.T) {
+		var f filterFlag
+		assert.Error(t, f.Set("tag:env"))
+	})
+}
+
+func TestKebab(t *testing.T) {
+	tests := map[string]string{
+		"Instances":              "instances",
+		"DBInstances":            "db-instances",
+		"OpenIDConnectProviders": "open-id-connect-providers",
+		"SSHPublicKey":           "ssh-public-key",
+		"MaxResults":             "max-results",
+	}
+
+	for in, opt := range tests {
+		t.Run(in, func(t *testing.T) {
+			assert.Equal(t, opt, kebab(in))
+		})
+	}
+}
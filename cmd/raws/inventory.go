@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cycloidio/raws"
+)
+
+// runInventory runs the inventory command, which writes the
+// raws.Snapshot of all the resources on stdout or on the
+// --output file
+func runInventory(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		fs          = flag.NewFlagSet("raws inventory", flag.ContinueOnError)
+		reader      = readerFlags(fs)
+		services    = fs.String("services", "", "Comma separated list of services, like 'ec2,iam', to inventory, all of them by default")
+		concurrency = fs.Int("concurrency", 4, "Number of methods called at the same time")
+		output      = fs.String("output", "", "File where the snapshot is written, stdout by default")
+	)
+
+	fs.SetOutput(stderr)
+	err := fs.Parse(args)
+	if err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	opts := raws.InventoryOptions{Concurrency: *concurrency}
+	if *services != "" {
+		opts.Services = strings.Split(*services, ",")
+	}
+
+	r, err := reader(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The snapshot is written even if there is an error, as
+	// it has the resources of the calls without errors
+	s, err := raws.Inventory(ctx, r, opts)
+
+	w := stdout
+	if *output != "" {
+		f, ferr := os.Create(*output)
+		if ferr != nil {
+			return ferr
+		}
+		defer f.Close()
+		w = f
+	}
+
+	werr := s.Write(w)
+	if err != nil {
+		return err
+	}
+
+	return werr
+}
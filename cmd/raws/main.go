@@ -0,0 +1,309 @@
+// The raws command exposes every function of the AWSReader as a subcommand,
+// so the data of all the regions can be explored from the shell.
+//
+// Usage:
+//
+//	raws <service> <command> [flags]
+//
+// For example:
+//
+//	raws ec2 instances --regions 'eu-*' --filter tag:env=prod --format table
+//
+// The inventory command writes a snapshot with all the resources of all the
+// services, or of the ones given with --services, as JSON:
+//
+//	raws inventory --regions 'eu-*' --output snapshot.json
+//
+// And the diff command writes the resources added, removed and modified
+// between 2 snapshots:
+//
+//	raws diff --fail yesterday.json today.json
+//
+// The graph command writes the relationships between the resources of a
+// snapshot, in the DOT language or as JSON:
+//
+//	raws graph snapshot.json | dot -Tsvg > graph.svg
+//
+// The tags command writes the resources with the tags matching a query:
+//
+//	raws tags --regions 'eu-*' 'env=prod AND team!=data'
+//
+// The tag-audit command writes the resources, of a snapshot or of all the
+// regions, with the tags missing or invalid according to a policy:
+//
+//	raws tag-audit --policy tags.yaml --fail snapshot.json
+//
+// The security command writes the findings of the security rules, like the
+// security groups open to the Internet or the IAM users without MFA, as
+// JSON or SARIF:
+//
+//	raws security --severity high --format sarif > findings.sarif
+//
+// The custom rules of a file are checked with --rules, on the account or on
+// a snapshot, and tested on fixtures, snapshots with the findings expected,
+// with --test:
+//
+//	raws security --rules rules.yaml --snapshot snapshot.json
+//	raws security --rules rules.yaml --test fixtures/
+//
+// The terraform command writes the Terraform configuration of the resources
+// of a snapshot, with their imports, on a directory:
+//
+//	raws terraform --dir infra/ snapshot.json
+//
+// And the cloudformation command writes their CloudFormation template, and
+// the resources to import on a change set:
+//
+//	raws cloudformation --imports imports.json snapshot.json > template.yaml
+//
+// The fields of the input of the function can be set with flags, named as the
+// field in kebab case (--instance-ids i-1,i-2), or with a JSON file (--input).
+// The credentials are read from the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY
+// environment variables if they are not set by flags.
+//
+// The results can be cached on a directory with --cache, for the time given by
+// --cache-ttl, so the same calls made again by any command don't call AWS, and
+// with --offline only the cached results are used, to reproduce a report:
+//
+//	raws inventory --cache .raws-cache --output snapshot.json
+//	raws security --cache .raws-cache --offline
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cycloidio/raws"
+)
+
+// command is one of the functions of the AWSReader
+// exposed as "raws <service> <name>"
+type command struct {
+	service string
+	name    string
+
+	// fn is the name of the AWSReader function
+	fn string
+
+	// input returns a new input of the function
+	input func() interface{}
+
+	// call calls the function with the input
+	call func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error)
+
+	// list calls the ListAll function of the function, if it
+	// has one, which is used to print the items on a table
+	list func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error)
+}
+
+// newReader is the function used to create the AWSReader,
+// it's a variable so it can be replaced on tests
+var newReader = raws.NewAWSReader
+
+func main() {
+	err := run(context.Background(), os.Args[1:], os.Stdout, os.Stderr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run runs the command defined by args writing its
+// result on stdout and the usage on stderr
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "inventory":
+			return runInventory(ctx, args[1:], stdout, stderr)
+		case "diff":
+			return runDiff(ctx, args[1:], stdout, stderr)
+		case "graph":
+			return runGraph(ctx, args[1:], stdout, stderr)
+		case "tags":
+			return runTags(ctx, args[1:], stdout, stderr)
+		case "tag-audit":
+			return runTagAudit(ctx, args[1:], stdout, stderr)
+		case "security":
+			return runSecurity(ctx, args[1:], stdout, stderr)
+		case "terraform":
+			return runTerraform(ctx, args[1:], stdout, stderr)
+		case "cloudformation":
+			return runCloudFormation(ctx, args[1:], stdout, stderr)
+		}
+	}
+
+	cmd, err := findCommand(args)
+	if err != nil {
+		usage(stderr, args)
+		return err
+	}
+
+	var (
+		fs        = flag.NewFlagSet(fmt.Sprintf("raws %s %s", cmd.service, cmd.name), flag.ContinueOnError)
+		reader    = readerFlags(fs)
+		inputFile = fs.String("input", "", "JSON file with the input of the function, the flags of the input fields are applied over it")
+		format    = fs.String("format", "json", "Format of the result: json, yaml or table")
+		columns   = fs.String("columns", "", "Comma separated list of fields, like 'InstanceId,State.Name', to print on the table format")
+		filters   filterFlag
+	)
+
+	input := cmd.input()
+	if hasFilters(input) {
+		fs.Var(&filters, "filter", "Filter of the input like 'tag:env=prod' or 'instance-state-name=running,stopped', it can be repeated")
+	}
+	setInput := inputFlags(fs, input)
+
+	fs.SetOutput(stderr)
+	err = fs.Parse(args[2:])
+	if err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if *inputFile != "" {
+		b, err := ioutil.ReadFile(*inputFile)
+		if err != nil {
+			return err
+		}
+
+		err = json.Unmarshal(b, input)
+		if err != nil {
+			return fmt.Errorf("invalid input file %s: %s", *inputFile, err)
+		}
+	}
+
+	err = setInput()
+	if err != nil {
+		return err
+	}
+
+	err = setFilters(input, filters)
+	if err != nil {
+		return err
+	}
+
+	p, err := newPrinter(*format, *columns)
+	if err != nil {
+		return err
+	}
+
+	r, err := reader(ctx)
+	if err != nil {
+		return err
+	}
+
+	var res interface{}
+	if *format == "table" && cmd.list != nil {
+		res, err = cmd.list(ctx, r, input)
+	} else {
+		res, err = cmd.call(ctx, r, input)
+	}
+
+	// The results are printed even if there is an error, as
+	// it has the results of the regions without errors
+	perr := p.print(stdout, r.GetRegions(), res)
+	if err != nil {
+		return err
+	}
+
+	return perr
+}
+
+// readerFlags defines on fs the flags needed to create the AWSReader
+// and returns the function creating it, once fs has been parsed
+func readerFlags(fs *flag.FlagSet) func(ctx context.Context) (raws.AWSReader, error) {
+	var (
+		regions   = fs.String("regions", "*", "Comma separated list of regions to call, globbing is supported like 'eu-*'")
+		accessKey = fs.String("access-key", "", "AWS access key, AWS_ACCESS_KEY_ID by default")
+		secretKey = fs.String("secret-key", "", "AWS secret key, AWS_SECRET_ACCESS_KEY by default")
+		cache     = fs.String("cache", "", "Directory on which the results are cached")
+		cacheTTL  = fs.Duration("cache-ttl", time.Hour, "Time the cached results are used, 0 to use them forever")
+		offline   = fs.Bool("offline", false, "Use only the results on the --cache directory, without calling AWS")
+	)
+
+	return func(ctx context.Context) (raws.AWSReader, error) {
+		if *offline && *cache == "" {
+			return nil, errors.New("the cache directory is required to be offline")
+		}
+
+		var r raws.AWSReader
+		if !*offline {
+			if *accessKey == "" {
+				*accessKey = os.Getenv("AWS_ACCESS_KEY_ID")
+			}
+			if *secretKey == "" {
+				*secretKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+			}
+
+			var err error
+			r, err = newReader(ctx, *accessKey, *secretKey, strings.Split(*regions, ","), nil, false)
+			if err != nil || *cache == "" {
+				return r, err
+			}
+		}
+
+		return raws.NewCachedReader(r, raws.CacheOptions{
+			Store:   raws.NewDiskCache(*cache),
+			TTL:     *cacheTTL,
+			Offline: *offline,
+		})
+	}
+}
+
+// findCommand returns the command of the service and name
+// which are the 2 first elements of args
+func findCommand(args []string) (command, error) {
+	if len(args) < 2 {
+		return command{}, fmt.Errorf("a service and a command are required")
+	}
+
+	for _, c := range commands {
+		if c.service == args[0] && c.name == args[1] {
+			return c, nil
+		}
+	}
+
+	return command{}, fmt.Errorf("unknown command %q", strings.Join(args[:2], " "))
+}
+
+// usage writes on w the list of services or, if the first element of args
+// is a service, the list of its commands
+func usage(w io.Writer, args []string) {
+	var (
+		services = make(map[string][]command)
+		names    []string
+	)
+
+	for _, c := range commands {
+		if _, ok := services[c.service]; !ok {
+			names = append(names, c.service)
+		}
+		services[c.service] = append(services[c.service], c)
+	}
+
+	if len(args) > 0 {
+		if cmds, ok := services[args[0]]; ok {
+			fmt.Fprintf(w, "Usage: raws %s <command> [flags]\n\nCommands:\n", args[0])
+			for _, c := range cmds {
+				fmt.Fprintf(w, "  %-40s %s\n", c.name, c.fn)
+			}
+			return
+		}
+	}
+
+	sort.Strings(names)
+	fmt.Fprintf(w, "Usage: raws <service> <command> [flags]\n       raws inventory [flags]\n       raws diff [flags] <from snapshot> <to snapshot>\n       raws graph [flags] <snapshot>\n       raws tags [flags] <query>\n       raws tag-audit [flags] [<snapshot>]\n       raws security [flags] [<data>]\n       raws terraform [flags] <snapshot>\n       raws cloudformation [flags] <snapshot>\n\nServices:\n")
+	for _, n := range names {
+		fmt.Fprintf(w, "  %s\n", n)
+	}
+}
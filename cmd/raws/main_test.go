@@ -0,0 +1,426 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/cycloidio/raws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockReader struct {
+	raws.AWSReader
+
+	regions []string
+
+	// Mocking of GetInstances
+	gii   *ec2.DescribeInstancesInput
+	gio   map[string]ec2.DescribeInstancesOutput
+	gierr error
+
+	// Mocking of ListBuckets
+	lbo map[string]s3.ListBucketsOutput
+
+	// Mocking of GetTaggedResources
+	gtro map[string]resourcegroupstaggingapi.GetResourcesOutput
+}
+
+func (m *mockReader) GetAccountID() string {
+	return "123"
+}
+
+func (m *mockReader) GetRegions() []string {
+	return m.regions
+}
+
+func (m *mockReader) ListBuckets(_ context.Context, _ *s3.ListBucketsInput) (map[string]s3.ListBucketsOutput, error) {
+	return m.lbo, nil
+}
+
+func (m *mockReader) GetTaggedResources(_ context.Context, _ *resourcegroupstaggingapi.GetResourcesInput) (map[string]resourcegroupstaggingapi.GetResourcesOutput, error) {
+	return m.gtro, nil
+}
+
+func (m *mockReader) GetInstances(_ context.Context, input *ec2.DescribeInstancesInput) (map[string]ec2.DescribeInstancesOutput, error) {
+	m.gii = input
+	return m.gio, m.gierr
+}
+
+func TestRun(t *testing.T) {
+	m := &mockReader{
+		regions: []string{"eu-west-1", "eu-west-3"},
+		gio: map[string]ec2.DescribeInstancesOutput{
+			"eu-west-1": ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{
+					{Instances: []*ec2.Instance{
+						{InstanceId: aws.String("i-1"), InstanceType: aws.String("t2.micro")},
+					}},
+				},
+			},
+			"eu-west-3": ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{
+					{Instances: []*ec2.Instance{
+						{InstanceId: aws.String("i-2"), InstanceType: aws.String("t2.large")},
+					}},
+				},
+			},
+		},
+		lbo: map[string]s3.ListBucketsOutput{
+			"eu-west-1": s3.ListBucketsOutput{
+				Buckets: []*s3.Bucket{{Name: aws.String("bucket-1")}},
+			},
+		},
+		gtro: map[string]resourcegroupstaggingapi.GetResourcesOutput{
+			"eu-west-1": resourcegroupstaggingapi.GetResourcesOutput{
+				ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+					{
+						ResourceARN: aws.String("arn:aws:ec2:eu-west-1:123:instance/i-1"),
+						Tags:        []*resourcegroupstaggingapi.Tag{{Key: aws.String("env"), Value: aws.String("prod")}},
+					},
+				},
+			},
+		},
+	}
+
+	var regions []string
+	newReader = func(_ context.Context, _, _ string, rs []string, _ *aws.Config, _ bool) (raws.AWSReader, error) {
+		regions = rs
+		return m, nil
+	}
+	defer func() { newReader = raws.NewAWSReader }()
+
+	t.Run("Table", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		err := run(context.Background(), []string{
+			"ec2", "instances",
+			"--regions", "eu-west-1,eu-west-3",
+			"--filter", "tag:env=prod",
+			"--instance-ids", "i-1,i-2",
+			"--format", "table",
+			"--columns", "InstanceId,InstanceType",
+		}, &stdout, &stderr)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"eu-west-1", "eu-west-3"}, regions)
+		assert.Equal(t, &ec2.DescribeInstancesInput{
+			InstanceIds: aws.StringSlice([]string{"i-1", "i-2"}),
+			Filters: []*ec2.Filter{
+				{Name: aws.String("tag:env"), Values: aws.StringSlice([]string{"prod"})},
+			},
+		}, m.gii)
+		assert.Equal(t, ""+
+			"REGION     InstanceId  InstanceType\n"+
+			"eu-west-1  i-1         t2.micro\n"+
+			"eu-west-3  i-2         t2.large\n",
+			stdout.String())
+	})
+
+	t.Run("InputFile", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		dir, err := ioutil.TempDir("", "raws")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "input.json")
+		err = ioutil.WriteFile(path, []byte(`{"InstanceIds": ["i-1"], "MaxResults": 5}`), 0644)
+		require.NoError(t, err)
+
+		err = run(context.Background(), []string{
+			"ec2", "instances", "--input", path, "--max-results", "10",
+		}, &stdout, &stderr)
+		require.NoError(t, err)
+
+		assert.Equal(t, &ec2.DescribeInstancesInput{
+			InstanceIds: aws.StringSlice([]string{"i-1"}),
+			MaxResults:  aws.Int64(10),
+		}, m.gii)
+		assert.Contains(t, stdout.String(), `"InstanceId": "i-2"`)
+	})
+
+	t.Run("PartialResults", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		m.gierr = errors.New("fail")
+		defer func() { m.gierr = nil }()
+
+		err := run(context.Background(), []string{"ec2", "instances"}, &stdout, &stderr)
+		assert.EqualError(t, err, "fail")
+		assert.Contains(t, stdout.String(), `"InstanceId": "i-1"`)
+	})
+
+	t.Run("Inventory", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		dir, err := ioutil.TempDir("", "raws")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "snapshot.json")
+		err = run(context.Background(), []string{
+			"inventory", "--services", "s3", "--output", path,
+		}, &stdout, &stderr)
+		require.NoError(t, err)
+
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		defer f.Close()
+
+		s, err := raws.ReadSnapshot(f)
+		require.NoError(t, err)
+		assert.Equal(t, "123", s.AccountID)
+		require.Len(t, s.Resources, 1)
+		assert.Equal(t, "arn:aws:s3:::bucket-1", s.Resources[0].ARN)
+		assert.Empty(t, stdout.String())
+	})
+
+	t.Run("Diff", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		dir, err := ioutil.TempDir("", "raws")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		from := filepath.Join(dir, "from.json")
+		err = run(context.Background(), []string{"inventory", "--services", "s3", "--output", from}, &stdout, &stderr)
+		require.NoError(t, err)
+
+		m.lbo["eu-west-1"].Buckets[0].Name = aws.String("bucket-2")
+		defer func() { m.lbo["eu-west-1"].Buckets[0].Name = aws.String("bucket-1") }()
+
+		to := filepath.Join(dir, "to.json")
+		err = run(context.Background(), []string{"inventory", "--services", "s3", "--output", to}, &stdout, &stderr)
+		require.NoError(t, err)
+
+		err = run(context.Background(), []string{"diff", from, to}, &stdout, &stderr)
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), `"arn": "arn:aws:s3:::bucket-2"`)
+
+		err = run(context.Background(), []string{"diff", "--fail", from, to}, &stdout, &stderr)
+		assert.EqualError(t, err, "1 added, 1 removed and 0 modified resources")
+
+		err = run(context.Background(), []string{"diff", from}, &stdout, &stderr)
+		assert.EqualError(t, err, "2 snapshots are required")
+	})
+
+	t.Run("Graph", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		dir, err := ioutil.TempDir("", "raws")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "snapshot.json")
+		err = run(context.Background(), []string{"inventory", "--services", "s3", "--output", path}, &stdout, &stderr)
+		require.NoError(t, err)
+
+		err = run(context.Background(), []string{"graph", path}, &stdout, &stderr)
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), `"arn:aws:s3:::bucket-1" [label="s3 bucket\nbucket-1"];`)
+
+		stdout.Reset()
+		err = run(context.Background(), []string{"graph", "--format", "json", "--from", "arn:aws:s3:::bucket-1", path}, &stdout, &stderr)
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), `"key": "arn:aws:s3:::bucket-1"`)
+
+		err = run(context.Background(), []string{"graph", "--from", "unknown", path}, &stdout, &stderr)
+		assert.EqualError(t, err, `the resource "unknown" is not on the snapshot`)
+	})
+
+	t.Run("Tags", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		err := run(context.Background(), []string{"tags", "--services", "ec2", "--format", "yaml", "env=prod AND team!=data"}, &stdout, &stderr)
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "  arn: arn:aws:ec2:eu-west-1:123:instance/i-1\n")
+
+		stdout.Reset()
+		err = run(context.Background(), []string{"tags", "--services", "ec2", "env=dev"}, &stdout, &stderr)
+		require.NoError(t, err)
+		assert.Equal(t, "[]\n", stdout.String())
+
+		err = run(context.Background(), []string{"tags", "env="}, &stdout, &stderr)
+		assert.EqualError(t, err, "unexpected end of the tag query")
+	})
+
+	t.Run("TagAudit", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		dir, err := ioutil.TempDir("", "raws")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		policy := filepath.Join(dir, "policy.yaml")
+		err = ioutil.WriteFile(policy, []byte("rules:\n- resources: [s3/bucket]\n  required: [env]\n"), 0644)
+		require.NoError(t, err)
+
+		path := filepath.Join(dir, "snapshot.json")
+		err = run(context.Background(), []string{"inventory", "--services", "s3", "--output", path}, &stdout, &stderr)
+		require.NoError(t, err)
+
+		stdout.Reset()
+		err = run(context.Background(), []string{"tag-audit", "--policy", policy, "--format", "yaml", path}, &stdout, &stderr)
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "checked: 1\n")
+		assert.Contains(t, stdout.String(), "  missing:\n  - env\n")
+
+		stdout.Reset()
+		err = run(context.Background(), []string{"tag-audit", "--policy", policy, "--fail"}, &stdout, &stderr)
+		assert.EqualError(t, err, "1 of the 1 resources checked are non compliant")
+		assert.Contains(t, stdout.String(), `"key": "arn:aws:s3:::bucket-1"`)
+
+		err = run(context.Background(), []string{"tag-audit", path}, &stdout, &stderr)
+		assert.EqualError(t, err, "a policy is required")
+	})
+
+	t.Run("Security", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		dir, err := ioutil.TempDir("", "raws")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "data.json")
+		err = ioutil.WriteFile(path, []byte(`{"account_id": "123", "resources": [
+			{"service": "ec2", "type": "volume", "id": "vol-1", "region": "eu-west-1", "raw": {"VolumeId": "vol-1", "Encrypted": false}}
+		]}`), 0644)
+		require.NoError(t, err)
+
+		err = run(context.Background(), []string{"security", "--format", "sarif", path}, &stdout, &stderr)
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), `"ruleId": "ec2-volume-unencrypted"`)
+
+		stdout.Reset()
+		err = run(context.Background(), []string{"security", "--severity", "high", "--fail", path}, &stdout, &stderr)
+		require.NoError(t, err)
+		assert.Equal(t, "[]\n", stdout.String())
+
+		err = run(context.Background(), []string{"security", "--fail", path}, &stdout, &stderr)
+		assert.EqualError(t, err, "1 findings")
+
+		err = run(context.Background(), []string{"security", "--severity", "urgent", path}, &stdout, &stderr)
+		assert.EqualError(t, err, `invalid severity "urgent", it has to be low, medium, high or critical`)
+
+		rules := filepath.Join("..", "..", "security", "testdata", "rules.yaml")
+		stdout.Reset()
+		err = run(context.Background(), []string{"security", "--rules", rules, "--builtin=false", "--snapshot", filepath.Join("..", "..", "security", "testdata", "fixtures", "instances.json")}, &stdout, &stderr)
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), `"message": "the instance i-1 has the public IP 203.0.113.1"`)
+		assert.Equal(t, 3, strings.Count(stdout.String(), `"rule_id"`))
+
+		stdout.Reset()
+		err = run(context.Background(), []string{"security", "--rules", rules, "--test", filepath.Join("..", "..", "security", "testdata", "fixtures")}, &stdout, &stderr)
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), `"name": "instances.json"`)
+
+		err = run(context.Background(), []string{"security", "--test", filepath.Join("..", "..", "security", "testdata", "fixtures")}, &stdout, &stderr)
+		assert.EqualError(t, err, "1 of 2 fixtures failed")
+	})
+
+	t.Run("Cache", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		dir, err := ioutil.TempDir("", "raws")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		err = run(context.Background(), []string{"ec2", "instances", "--cache", dir, "--columns", "InstanceId"}, &stdout, &stderr)
+		require.NoError(t, err)
+		cached := stdout.String()
+
+		m.gii = nil
+		stdout.Reset()
+		err = run(context.Background(), []string{"ec2", "instances", "--cache", dir, "--offline", "--columns", "InstanceId"}, &stdout, &stderr)
+		require.NoError(t, err)
+		assert.Equal(t, cached, stdout.String())
+		assert.Nil(t, m.gii)
+
+		err = run(context.Background(), []string{"ec2", "instances", "--offline"}, &stdout, &stderr)
+		assert.EqualError(t, err, "the cache directory is required to be offline")
+	})
+
+	t.Run("Terraform", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		dir, err := ioutil.TempDir("", "raws")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "snapshot.json")
+		err = run(context.Background(), []string{"inventory", "--services", "s3", "--output", path}, &stdout, &stderr)
+		require.NoError(t, err)
+
+		err = run(context.Background(), []string{"terraform", "--dir", dir, "--import", "commands", "--state", path}, &stdout, &stderr)
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("1 resources written on %s, 0 resources not supported\n", dir), stdout.String())
+
+		b, err := ioutil.ReadFile(filepath.Join(dir, "main.tf"))
+		require.NoError(t, err)
+		assert.Equal(t, "resource \"aws_s3_bucket\" \"bucket-1\" {\n  provider = aws.eu_west_1\n\n  bucket = \"bucket-1\"\n}\n", string(b))
+
+		b, err = ioutil.ReadFile(filepath.Join(dir, "providers.tf"))
+		require.NoError(t, err)
+		assert.Equal(t, "provider \"aws\" {\n  alias  = \"eu_west_1\"\n  region = \"eu-west-1\"\n}\n", string(b))
+
+		b, err = ioutil.ReadFile(filepath.Join(dir, "import.sh"))
+		require.NoError(t, err)
+		assert.Equal(t, "terraform import aws_s3_bucket.bucket-1 bucket-1\n", string(b))
+
+		_, err = os.Stat(filepath.Join(dir, "terraform.tfstate"))
+		assert.NoError(t, err)
+
+		err = run(context.Background(), []string{"terraform", "--import", "unknown", path}, &stdout, &stderr)
+		assert.EqualError(t, err, `invalid import "unknown"`)
+	})
+
+	t.Run("CloudFormation", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		dir, err := ioutil.TempDir("", "raws")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "snapshot.json")
+		err = run(context.Background(), []string{"inventory", "--services", "s3", "--output", path}, &stdout, &stderr)
+		require.NoError(t, err)
+
+		imports := filepath.Join(dir, "imports.json")
+		err = run(context.Background(), []string{"cloudformation", "--imports", imports, path}, &stdout, &stderr)
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "  BucketBucket1:\n    Type: AWS::S3::Bucket\n")
+
+		b, err := ioutil.ReadFile(imports)
+		require.NoError(t, err)
+		assert.Contains(t, string(b), `"LogicalResourceId": "BucketBucket1"`)
+
+		stdout.Reset()
+		err = run(context.Background(), []string{"cloudformation", "--region", "eu-west-3", path}, &stdout, &stderr)
+		require.NoError(t, err)
+		assert.NotContains(t, stdout.String(), "BucketBucket1")
+		assert.Contains(t, stderr.String(), "1 resources of other regions than eu-west-3 excluded\n")
+
+		err = run(context.Background(), []string{"cloudformation", "--format", "xml", path}, &stdout, &stderr)
+		assert.EqualError(t, err, `invalid format "xml"`)
+	})
+
+	t.Run("UnknownCommand", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		err := run(context.Background(), []string{"ec2", "unknown"}, &stdout, &stderr)
+		assert.EqualError(t, err, `unknown command "ec2 unknown"`)
+		assert.Contains(t, stderr.String(), "Usage: raws ec2 <command> [flags]")
+	})
+}
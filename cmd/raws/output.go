@@ -0,0 +1,249 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// printer writes the results of the commands
+// in one of the supported formats
+type printer struct {
+	format  string
+	columns []string
+}
+
+func newPrinter(format, columns string) (printer, error) {
+	switch format {
+	case "json", "yaml", "table":
+	default:
+		return printer{}, fmt.Errorf("unknown format %q, it has to be json, yaml or table", format)
+	}
+
+	p := printer{format: format}
+	if columns != "" {
+		p.columns = strings.Split(columns, ",")
+	}
+
+	return p, nil
+}
+
+// print writes res to w, the regions are used to
+// sort the rows when the format is table
+func (p printer) print(w io.Writer, regions []string, res interface{}) error {
+	switch p.format {
+	case "yaml":
+		// The result goes through JSON so the fields
+		// have the same names on both formats
+		b, err := json.Marshal(res)
+		if err != nil {
+			return err
+		}
+
+		var v interface{}
+		err = json.Unmarshal(b, &v)
+		if err != nil {
+			return err
+		}
+
+		b, err = yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		_, err = w.Write(b)
+		return err
+	case "table":
+		return p.printTable(w, regions, res)
+	default:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(res)
+	}
+}
+
+// row is one of the lines of the table
+type row struct {
+	region string
+	value  reflect.Value
+}
+
+// printTable writes res as a table with one row per item.
+// The res can be the result of a ListAll function, so a list of items
+// paired with their region, or the map of the other functions in which
+// case the items are the elements of the values if they are lists or the
+// values themselves
+func (p printer) printTable(w io.Writer, regions []string, res interface{}) error {
+	rows := tableRows(regions, reflect.ValueOf(res))
+
+	columns := p.columns
+	if len(columns) == 0 && len(rows) > 0 {
+		columns = defaultColumns(rows[0].value.Type())
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "REGION\t%s\n", strings.Join(columns, "\t"))
+	for _, r := range rows {
+		cells := []string{r.region}
+		for _, c := range columns {
+			cells = append(cells, cell(r.value, strings.Split(c, ".")))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+func tableRows(regions []string, v reflect.Value) []row {
+	var rows []row
+
+	switch v.Kind() {
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			e := v.Index(i)
+			rows = append(rows, row{region: e.FieldByName("Region").String(), value: e.FieldByName("Item")})
+		}
+	case reflect.Map:
+		keys := append([]string{}, regions...)
+		for _, k := range v.MapKeys() {
+			if !contains(regions, k.String()) {
+				keys = append(keys, k.String())
+			}
+		}
+		sort.Strings(keys[len(regions):])
+
+		for _, k := range keys {
+			e := v.MapIndex(reflect.ValueOf(k))
+			if !e.IsValid() {
+				continue
+			}
+
+			if e.Kind() != reflect.Slice {
+				rows = append(rows, row{region: k, value: e})
+				continue
+			}
+
+			for i := 0; i < e.Len(); i++ {
+				rows = append(rows, row{region: k, value: e.Index(i)})
+			}
+		}
+	}
+
+	return rows
+}
+
+// defaultColumns returns the fields of t which have a scalar value,
+// if there are none the ones of its structures are used
+func defaultColumns(t reflect.Type) []string {
+	var columns, nested []string
+
+	t = indirect(t)
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		ft := indirect(f.Type)
+		if isScalar(ft) {
+			columns = append(columns, f.Name)
+			continue
+		}
+
+		if ft.Kind() == reflect.Struct {
+			for j := 0; j < ft.NumField(); j++ {
+				nf := ft.Field(j)
+				if nf.PkgPath == "" && isScalar(indirect(nf.Type)) {
+					nested = append(nested, fmt.Sprintf("%s.%s", f.Name, nf.Name))
+				}
+			}
+		}
+	}
+
+	if len(columns) == 0 {
+		return nested
+	}
+
+	return columns
+}
+
+// cell returns the value at the path of v formatted for the table
+func cell(v reflect.Value, path []string) string {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return ""
+	}
+
+	if len(path) > 0 {
+		if v.Kind() != reflect.Struct {
+			return ""
+		}
+		return cell(v.FieldByName(path[0]), path[1:])
+	}
+
+	switch {
+	case v.Type() == timeType:
+		return v.Interface().(time.Time).Format(time.RFC3339)
+	case isScalar(v.Type()):
+		return fmt.Sprintf("%v", v.Interface())
+	case v.Kind() == reflect.Slice && isScalar(indirect(v.Type().Elem())):
+		var vs []string
+		for i := 0; i < v.Len(); i++ {
+			vs = append(vs, cell(v.Index(i), nil))
+		}
+		return strings.Join(vs, ",")
+	default:
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func isScalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return t == timeType
+	}
+}
+
+func contains(ss []string, s string) bool {
+	for _, e := range ss {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
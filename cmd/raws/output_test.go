@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/cycloidio/raws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrinter(t *testing.T) {
+	res := map[string]iam.ListAccountAliasesOutput{
+		"eu-west-1": iam.ListAccountAliasesOutput{
+			AccountAliases: aws.StringSlice([]string{"a", "b"}),
+			IsTruncated:    aws.Bool(false),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		columns string
+		opt     string
+	}{
+		{
+			name:   "JSON",
+			format: "json",
+			opt: `{
+  "eu-west-1": {
+    "AccountAliases": [
+      "a",
+      "b"
+    ],
+    "IsTruncated": false,
+    "Marker": null
+  }
+}
+`,
+		},
+		{
+			name:   "YAML",
+			format: "yaml",
+			opt: `eu-west-1:
+  AccountAliases:
+  - a
+  - b
+  IsTruncated: false
+  Marker: null
+`,
+		},
+		{
+			name:   "Table",
+			format: "table",
+			opt: "" +
+				"REGION     IsTruncated  Marker\n" +
+				"eu-west-1  false        \n",
+		},
+		{
+			name:    "TableColumns",
+			format:  "table",
+			columns: "AccountAliases",
+			opt: "" +
+				"REGION     AccountAliases\n" +
+				"eu-west-1  a,b\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buff bytes.Buffer
+
+			p, err := newPrinter(tt.format, tt.columns)
+			require.NoError(t, err)
+
+			err = p.print(&buff, []string{"eu-west-1"}, res)
+			require.NoError(t, err)
+			assert.Equal(t, tt.opt, buff.String())
+		})
+	}
+
+	t.Run("UnknownFormat", func(t *testing.T) {
+		_, err := newPrinter("xml", "")
+		assert.Error(t, err)
+	})
+}
+
+func TestPrinterTableRows(t *testing.T) {
+	t.Run("ListAll", func(t *testing.T) {
+		var buff bytes.Buffer
+
+		created := time.Date(2019, 5, 11, 10, 0, 0, 0, time.UTC)
+		res := []raws.RegionRole{
+			{Region: "eu-west-1", Item: &iam.Role{RoleName: aws.String("r1"), CreateDate: &created}},
+			{Region: "eu-west-3", Item: &iam.Role{RoleName: aws.String("r2")}},
+		}
+
+		pc := printer{format: "table", columns: []string{"RoleName", "CreateDate"}}
+		err := pc.print(&buff, nil, res)
+		require.NoError(t, err)
+		assert.Equal(t, ""+
+			"REGION     RoleName  CreateDate\n"+
+			"eu-west-1  r1        2019-05-11T10:00:00Z\n"+
+			"eu-west-3  r2        \n",
+			buff.String())
+	})
+
+	t.Run("MapOfLists", func(t *testing.T) {
+		var buff bytes.Buffer
+
+		res := map[string][]raws.LoadBalancerWithTags{
+			"us-east-1": {
+				{Item: &elb.LoadBalancerDescription{LoadBalancerName: aws.String("lb-2")}},
+			},
+			"eu-west-1": {
+				{
+					Item: &elb.LoadBalancerDescription{LoadBalancerName: aws.String("lb-1")},
+					Tags: &elb.TagDescription{Tags: []*elb.Tag{{Key: aws.String("env"), Value: aws.String("prod")}}},
+				},
+			},
+		}
+
+		pc := printer{format: "table", columns: []string{"Item.LoadBalancerName", "Tags.Tags"}}
+		err := pc.print(&buff, []string{"eu-west-1"}, res)
+		require.NoError(t, err)
+		assert.Equal(t, ""+
+			"REGION     Item.LoadBalancerName  Tags.Tags\n"+
+			"eu-west-1  lb-1                   [{\"Key\":\"env\",\"Value\":\"prod\"}]\n"+
+			"us-east-1  lb-2                   \n",
+			buff.String())
+
+		assert.Contains(t, defaultColumns(reflect.TypeOf(res["eu-west-1"][0])), "Item.LoadBalancerName")
+	})
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cycloidio/raws"
+	"github.com/cycloidio/raws/security"
+)
+
+// runSecurity runs the security command, which writes on stdout the
+// findings of the security.Rules and of the custom rules of --rules on
+// the data collected with the AWSReader, read from a file written with
+// --save or from a snapshot, or the results of the rules on the
+// fixtures of --test
+func runSecurity(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		fs       = flag.NewFlagSet("raws security", flag.ContinueOnError)
+		reader   = readerFlags(fs)
+		format   = fs.String("format", "json", "Format of the findings: json, yaml or sarif")
+		severity = fs.String("severity", "low", "Minimum severity of the findings: low, medium, high or critical")
+		save     = fs.String("save", "", "File on which the data collected is written, so it can be checked again without calling AWS")
+		fail     = fs.Bool("fail", false, "Exit with an error if there are findings")
+		rules    = fs.String("rules", "", "File of custom rules checked with the built-in ones")
+		builtin  = fs.Bool("builtin", true, "Check the built-in rules")
+		snapshot = fs.Bool("snapshot", false, "The data is a snapshot written by raws inventory")
+		test     = fs.String("test", "", "Directory of fixtures on which the rules are tested instead of checking the data")
+	)
+
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: raws security [flags] [<data>]\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	err := fs.Parse(args)
+	if err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return errors.New("only one data file can be checked")
+	}
+
+	if *format != "json" && *format != "yaml" && *format != "sarif" {
+		return fmt.Errorf("invalid format %q", *format)
+	}
+
+	min, err := security.ParseSeverity(*severity)
+	if err != nil {
+		return err
+	}
+
+	var checks []security.Rule
+	if *builtin {
+		checks = append(checks, security.Rules...)
+	}
+	if *rules != "" {
+		custom, err := readRules(*rules)
+		if err != nil {
+			return err
+		}
+		checks = append(checks, custom...)
+	}
+
+	if *test != "" {
+		return testRules(stdout, checks, *test, *format)
+	}
+
+	var (
+		d    *security.Data
+		cerr error
+	)
+	if fs.NArg() == 1 && *snapshot {
+		var s *raws.Snapshot
+		s, err = readSnapshot(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		d = security.FromSnapshot(s)
+	} else if fs.NArg() == 1 {
+		d, err = readData(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+	} else {
+		r, err := reader(ctx)
+		if err != nil {
+			return err
+		}
+
+		// The findings are written even if there is an error, as
+		// they are the ones of the data of the calls without errors
+		d, cerr = security.Collect(ctx, r)
+	}
+
+	if *save != "" {
+		err = writeFile(*save, func(w io.Writer) error {
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return enc.Encode(d)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	findings := security.Run(d, checks, min)
+	if *format == "sarif" {
+		err = security.WriteSARIF(stdout, checks, findings)
+	} else {
+		var p printer
+		p, err = newPrinter(*format, "")
+		if err == nil {
+			err = p.print(stdout, nil, findings)
+		}
+	}
+
+	if cerr != nil {
+		return cerr
+	} else if err != nil {
+		return err
+	}
+
+	if *fail && len(findings) > 0 {
+		return fmt.Errorf("%d findings", len(findings))
+	}
+
+	return nil
+}
+
+// readData reads the security.Data on the path
+func readData(path string) (*security.Data, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var d security.Data
+	err = json.NewDecoder(f).Decode(&d)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data %s: %s", path, err)
+	}
+
+	return &d, nil
+}
+
+// readRules reads the custom security rules on the path
+func readRules(path string) ([]security.Rule, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rules, err := security.ReadRules(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rules %s: %s", path, err)
+	}
+
+	return rules, nil
+}
+
+// testRules writes on stdout the results of the rules on the fixtures
+// of the dir, and returns an error if any of them didn't pass
+func testRules(stdout io.Writer, rules []security.Rule, dir, format string) error {
+	if format == "sarif" {
+		return errors.New("the results of the fixtures can't be written as sarif")
+	}
+
+	results, err := security.CheckFixtures(rules, dir)
+	if err != nil {
+		return err
+	}
+
+	p, err := newPrinter(format, "")
+	if err != nil {
+		return err
+	}
+
+	err = p.print(stdout, nil, results)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, r := range results {
+		if !r.Passed() {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d fixtures failed", failed, len(results))
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cycloidio/raws"
+)
+
+// runTagAudit runs the tag-audit command, which writes on stdout the
+// raws.TagReport of a raws.TagPolicy checked against the resources of
+// a snapshot or, if none is given, the ones found with the AWSReader
+func runTagAudit(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		fs     = flag.NewFlagSet("raws tag-audit", flag.ContinueOnError)
+		reader = readerFlags(fs)
+		policy = fs.String("policy", "", "YAML or JSON file of the tag policy, see the TagPolicy documentation")
+		fail   = fs.Bool("fail", false, "Exit with an error if there are non compliant resources")
+		format = fs.String("format", "json", "Format of the result: json or yaml")
+	)
+
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: raws tag-audit [flags] [<snapshot>]\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	err := fs.Parse(args)
+	if err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if *policy == "" {
+		fs.Usage()
+		return errors.New("a policy is required")
+	}
+
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return errors.New("only one snapshot can be audited")
+	}
+
+	if *format != "json" && *format != "yaml" {
+		return fmt.Errorf("invalid format %q", *format)
+	}
+
+	f, err := os.Open(*policy)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	p, err := raws.ReadTagPolicy(f)
+	if err != nil {
+		return fmt.Errorf("invalid policy %s: %s", *policy, err)
+	}
+
+	var (
+		report *raws.TagReport
+		aerr   error
+	)
+	if fs.NArg() == 1 {
+		s, err := readSnapshot(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+
+		report, err = p.Check(s.Resources)
+		if err != nil {
+			return err
+		}
+	} else {
+		r, err := reader(ctx)
+		if err != nil {
+			return err
+		}
+
+		// The report is written even if there is an error, as it
+		// has the resources found by the calls without errors
+		report, aerr = raws.AuditTags(ctx, r, p)
+		if report == nil {
+			return aerr
+		}
+	}
+
+	pr, err := newPrinter(*format, "")
+	if err != nil {
+		return err
+	}
+
+	err = pr.print(stdout, nil, report)
+	if aerr != nil {
+		return aerr
+	} else if err != nil {
+		return err
+	}
+
+	if *fail && len(report.NonCompliant) > 0 {
+		return fmt.Errorf("%d of the %d resources checked are non compliant", len(report.NonCompliant), report.Checked)
+	}
+
+	return nil
+}
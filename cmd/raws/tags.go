@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cycloidio/raws"
+)
+
+// runTags runs the tags command, which writes on stdout the
+// resources with the tags matching a raws.TagQuery
+func runTags(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		fs           = flag.NewFlagSet("raws tags", flag.ContinueOnError)
+		reader       = readerFlags(fs)
+		services     = fs.String("services", "", "Comma separated list of services, like 'ec2,iam', of the resources to find, all of them by default")
+		noTaggingAPI = fs.Bool("no-tagging-api", false, "Find the resources with the methods of each service instead of the Resource Groups Tagging API, which only returns the resources with tags")
+		format       = fs.String("format", "json", "Format of the result: json or yaml")
+	)
+
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: raws tags [flags] <query>\n\nThe query is like 'env=prod AND team!=data', see the TagQuery documentation.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	err := fs.Parse(args)
+	if err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("a query is required")
+	}
+
+	if *format != "json" && *format != "yaml" {
+		return fmt.Errorf("invalid format %q", *format)
+	}
+
+	opts := raws.FindByTagsOptions{NoTaggingAPI: *noTaggingAPI}
+	if *services != "" {
+		opts.Services = strings.Split(*services, ",")
+	}
+
+	r, err := reader(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The resources are written even if there is an error,
+	// as they are the ones found by the calls without errors
+	res, err := raws.FindByTags(ctx, r, fs.Arg(0), opts)
+	if _, ok := err.(raws.Errors); err != nil && !ok {
+		return err
+	}
+
+	p, perr := newPrinter(*format, "")
+	if perr != nil {
+		return perr
+	}
+
+	if res == nil {
+		res = []raws.Resource{}
+	}
+
+	perr = p.print(stdout, nil, res)
+	if err != nil {
+		return err
+	}
+
+	return perr
+}
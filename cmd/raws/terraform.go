@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/cycloidio/raws/terraform"
+)
+
+// runTerraform runs the terraform command, which writes on a directory
+// the Terraform configuration, with a provider by region, and imports of
+// the resources of a snapshot
+func runTerraform(_ context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		fs      = flag.NewFlagSet("raws terraform", flag.ContinueOnError)
+		dir     = fs.String("dir", ".", "Directory on which the files are written")
+		imports = fs.String("import", "blocks", "How the resources are imported: blocks, which writes imports.tf with import blocks (Terraform 1.5 and later), or commands, which writes import.sh with terraform import commands")
+		state   = fs.Bool("state", false, "Write also the skeleton of the state on terraform.tfstate")
+	)
+
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: raws terraform [flags] <snapshot>\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	err := fs.Parse(args)
+	if err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if *imports != "blocks" && *imports != "commands" {
+		return fmt.Errorf("invalid import %q", *imports)
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("a snapshot is required")
+	}
+
+	s, err := readSnapshot(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	c, err := terraform.Generate(s.Resources)
+	if err != nil {
+		return err
+	}
+
+	files := map[string]func(io.Writer) error{"main.tf": c.WriteHCL, "providers.tf": c.WriteProviders}
+	if *imports == "blocks" {
+		files["imports.tf"] = c.WriteImportBlocks
+	} else {
+		files["import.sh"] = c.WriteImportCommands
+	}
+	if *state {
+		files["terraform.tfstate"] = c.WriteState
+	}
+
+	for name, write := range files {
+		err = writeFile(filepath.Join(*dir, name), write)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(stdout, "%d resources written on %s, %d resources not supported\n", len(c.Blocks), *dir, len(c.Skipped))
+
+	return nil
+}
+
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = write(f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// refService is a service of the reference with its methods
+type refService struct {
+	Service string
+	Name    string
+	Global  bool
+	Methods []refMethod
+}
+
+// refMethod is a method of the AWSReader on the reference
+type refMethod struct {
+	Name          string
+	Documentation string
+	Operations    []string
+	IAMActions    []string
+	Global        bool
+	Paginated     bool
+	FilterByOwner string
+	Signature     string
+	Input         string
+	Output        string
+	FlattenName   string
+	FlattenType   string
+}
+
+// generateReference returns the function writing the Markdown reference
+// of the AWSReader built from the functions, which links to the IAM
+// policy on the policy path if it's not empty
+func generateReference(policy string) func(io.Writer, []Function) error {
+	return func(opt io.Writer, fns []Function) error {
+		svcs, err := referenceServices(fns)
+		if err != nil {
+			return err
+		}
+
+		return refTmpl.Execute(opt, struct {
+			ReaderIAMActions []string
+			Policy           string
+			Services         []refService
+		}{
+			ReaderIAMActions: readerIAMActions,
+			Policy:           policy,
+			Services:         svcs,
+		})
+	}
+}
+
+// referenceServices groups the methods of fns by service, in the order
+// the services are first used on fns
+func referenceServices(fns []Function) ([]refService, error) {
+	var (
+		svcs []refService
+		idx  = make(map[string]int)
+	)
+
+	for _, fn := range fns {
+		i, ok := idx[fn.Service]
+		if !ok {
+			i = len(svcs)
+			idx[fn.Service] = i
+			svcs = append(svcs, refService{
+				Service: fn.Service,
+				Name:    services[fn.Service].Name,
+				Global:  fn.Global(),
+			})
+		}
+
+		m := refMethod{
+			Name:          fn.Name(),
+			Documentation: docText(fn.Documentation),
+			Operations:    []string{fn.Operation()},
+			IAMActions:    fn.IAMActions(),
+			Global:        fn.Global(),
+			Paginated:     fn.Paginated(),
+			FilterByOwner: fn.FilterByOwner,
+		}
+		if fn.FnSignature != "" {
+			m.Signature = fn.FnSignature
+		} else {
+			m.Input = fn.Input()
+			m.Output = fmt.Sprintf("map[string]%s", fn.Output())
+		}
+		if fn.Flatten != "" {
+			m.FlattenName = fn.FlattenName()
+			m.FlattenType = fn.FlattenType()
+		}
+		svcs[i].Methods = append(svcs[i].Methods, m)
+
+		if fn.Enrich == nil {
+			continue
+		}
+
+		fups, err := fn.followUps(fns)
+		if err != nil {
+			return nil, err
+		}
+
+		em := refMethod{
+			Name:          fn.EnrichName(),
+			Documentation: docText(fn.Enrich.Documentation),
+			Operations:    m.Operations,
+			IAMActions:    m.IAMActions,
+			Global:        m.Global,
+			Paginated:     m.Paginated,
+			FilterByOwner: m.FilterByOwner,
+			Input:         m.Input,
+			Output:        fmt.Sprintf("map[string][]%s", fn.Enrich.Type),
+		}
+		for _, fup := range fups {
+			em.Operations = appendMissing(em.Operations, fup.Fn.Operation())
+			em.IAMActions = appendMissing(em.IAMActions, fup.Fn.IAMActions()...)
+		}
+		svcs[i].Methods = append(svcs[i].Methods, em)
+	}
+
+	return svcs, nil
+}
+
+// docText returns the text of the doc comment d
+// without the comment markers and the indentation
+func docText(d string) string {
+	var lines []string
+	for _, l := range strings.Split(strings.TrimSpace(d), "\n") {
+		l = strings.TrimSpace(l)
+		l = strings.TrimSpace(strings.TrimPrefix(l, "//"))
+		lines = append(lines, l)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// appendMissing appends to s the values of vs which are not on it
+func appendMissing(s []string, vs ...string) []string {
+	r := append([]string(nil), s...)
+	for _, v := range vs {
+		if !contains(r, v) {
+			r = append(r, v)
+		}
+	}
+
+	return r
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// policy is an IAM policy document
+type policy struct {
+	Version   string
+	Statement []policyStatement
+}
+
+// policyStatement is a statement of an IAM policy document
+type policyStatement struct {
+	Sid      string
+	Effect   string
+	Action   []string
+	Resource string
+}
+
+// generatePolicy writes on opt the minimal IAM policy, in JSON,
+// needed by NewAWSReader and all the functions
+func generatePolicy(opt io.Writer, fns []Function) error {
+	actions := append([]string(nil), readerIAMActions...)
+	for _, fn := range fns {
+		actions = appendMissing(actions, fn.IAMActions()...)
+	}
+	sort.Strings(actions)
+
+	b, err := json.MarshalIndent(policy{
+		Version: "2012-10-17",
+		Statement: []policyStatement{
+			policyStatement{
+				Sid:      "RawsReadOnly",
+				Effect:   "Allow",
+				Action:   actions,
+				Resource: "*",
+			},
+		},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = opt.Write(append(b, '\n'))
+	return err
+}
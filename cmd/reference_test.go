@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var referenceFunctions = []Function{
+	Function{
+		Entity:        "Images",
+		Prefix:        "Describe",
+		Service:       "ec2",
+		FilterByOwner: "Owners",
+		Documentation: `
+		// GetOwnImages returns all EC2 AMI belonging to the Account ID based on the input given.
+		`,
+	},
+	Function{
+		Entity:      "LoadBalancers",
+		Prefix:      "Describe",
+		Service:     "elb",
+		Flatten:     "LoadBalancerDescriptions",
+		FlattenItem: "LoadBalancerDescription",
+		Documentation: `
+		// GetLoadBalancers returns a list of ELB (v1) based on the input from the different regions.
+		`,
+		Enrich: &Enrich{
+			Name: "WithTags",
+			Type: "LoadBalancerWithTags",
+			Documentation: `
+			// GetLoadBalancersWithTags returns a list of ELB (v1) with their tags.
+			`,
+			FollowUps: []FollowUp{
+				FollowUp{
+					Attach:     "Tags",
+					Function:   "GetLoadBalancersTags",
+					From:       "LoadBalancerName",
+					To:         "LoadBalancerNames",
+					Batch:      20,
+					Result:     "TagDescriptions",
+					ResultItem: "TagDescription",
+					ResultKey:  "LoadBalancerName",
+				},
+			},
+		},
+	},
+	Function{
+		FnName:  "GetLoadBalancersTags",
+		Entity:  "Tags",
+		Prefix:  "Describe",
+		Service: "elb",
+		Documentation: `
+		// GetLoadBalancersTags returns a list of Tags based on the input from the different regions.
+		`,
+	},
+	Function{
+		FnSignature:  "DownloadObject(ctx context.Context, w io.WriterAt, input *s3.GetObjectInput, options ...func(*s3manager.Downloader)) (int64, error)",
+		Entity:       "Object",
+		Prefix:       "Get",
+		Service:      "s3",
+		NoGenerateFn: true,
+		Documentation: `
+		// DownloadObject downloads an object in a bucket based on the input given
+		`,
+	},
+	Function{
+		Entity:  "Users",
+		Prefix:  "List",
+		Service: "iam",
+		Documentation: `
+		// GetUsers returns all the IAM users on the account
+		`,
+	},
+}
+
+func Test_generateReference(t *testing.T) {
+	buff := bytes.Buffer{}
+	exopt, err := ioutil.ReadFile("./testdata/reference.md")
+	require.NoError(t, err)
+	err = generateReference("iam-policy.json")(&buff, referenceFunctions)
+	require.NoError(t, err)
+	assert.Equal(t, string(exopt), buff.String())
+}
+
+func Test_generatePolicy(t *testing.T) {
+	buff := bytes.Buffer{}
+	err := generatePolicy(&buff, referenceFunctions)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{
+				"Sid": "RawsReadOnly",
+				"Effect": "Allow",
+				"Action": [
+					"ec2:DescribeImages",
+					"ec2:DescribeRegions",
+					"elasticloadbalancing:DescribeLoadBalancers",
+					"elasticloadbalancing:DescribeTags",
+					"iam:ListUsers",
+					"s3:GetObject",
+					"sts:GetCallerIdentity"
+				],
+				"Resource": "*"
+			}
+		]
+	}`, buff.String())
+}
+
+func Test_referenceServices(t *testing.T) {
+	t.Run("Grouped", func(t *testing.T) {
+		svcs, err := referenceServices(referenceFunctions)
+		require.NoError(t, err)
+		require.Len(t, svcs, 4)
+		assert.Equal(t, "elb", svcs[1].Service)
+		require.Len(t, svcs[1].Methods, 3)
+		assert.Equal(t, "GetLoadBalancersWithTags", svcs[1].Methods[1].Name)
+		assert.Equal(t, []string{"DescribeLoadBalancers", "DescribeTags"}, svcs[1].Methods[1].Operations)
+		assert.True(t, svcs[3].Global)
+	})
+	t.Run("UnknownFollowUp", func(t *testing.T) {
+		fns := []Function{referenceFunctions[1]}
+		_, err := referenceServices(fns)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"reflect"
+
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
+	"github.com/aws/aws-sdk-go/service/configservice/configserviceiface"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
+	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/aws/aws-sdk-go/service/route53resolver/route53resolveriface"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/ses/sesiface"
+)
+
+// service is the information of the AWS service of
+// a Function which is not part of the Function itself
+type service struct {
+	// Name is the name of the service on the AWS documentation
+	Name string
+
+	// IAMPrefix is the prefix of the IAM actions of the service
+	IAMPrefix string
+
+	// Global is true if the service is not regional, so
+	// every region returns the same items
+	Global bool
+
+	// API is the interface of the client of the service, used
+	// to know which operations are paginated
+	API reflect.Type
+}
+
+// services are the services used by the functions, by
+// the Function.Service
+var services = map[string]service{
+	"autoscaling": service{
+		Name:      "Amazon EC2 Auto Scaling",
+		IAMPrefix: "autoscaling",
+		API:       reflect.TypeOf((*autoscalingiface.AutoScalingAPI)(nil)).Elem(),
+	},
+	"cloudfront": service{
+		Name:      "Amazon CloudFront",
+		IAMPrefix: "cloudfront",
+		Global:    true,
+		API:       reflect.TypeOf((*cloudfrontiface.CloudFrontAPI)(nil)).Elem(),
+	},
+	"configservice": service{
+		Name:      "AWS Config",
+		IAMPrefix: "config",
+		API:       reflect.TypeOf((*configserviceiface.ConfigServiceAPI)(nil)).Elem(),
+	},
+	"ec2": service{
+		Name:      "Amazon EC2",
+		IAMPrefix: "ec2",
+		API:       reflect.TypeOf((*ec2iface.EC2API)(nil)).Elem(),
+	},
+	"elasticache": service{
+		Name:      "Amazon ElastiCache",
+		IAMPrefix: "elasticache",
+		API:       reflect.TypeOf((*elasticacheiface.ElastiCacheAPI)(nil)).Elem(),
+	},
+	"elb": service{
+		Name:      "Elastic Load Balancing (Classic)",
+		IAMPrefix: "elasticloadbalancing",
+		API:       reflect.TypeOf((*elbiface.ELBAPI)(nil)).Elem(),
+	},
+	"elbv2": service{
+		Name:      "Elastic Load Balancing (Application and Network)",
+		IAMPrefix: "elasticloadbalancing",
+		API:       reflect.TypeOf((*elbv2iface.ELBV2API)(nil)).Elem(),
+	},
+	"iam": service{
+		Name:      "AWS Identity and Access Management",
+		IAMPrefix: "iam",
+		Global:    true,
+		API:       reflect.TypeOf((*iamiface.IAMAPI)(nil)).Elem(),
+	},
+	"rds": service{
+		Name:      "Amazon RDS",
+		IAMPrefix: "rds",
+		API:       reflect.TypeOf((*rdsiface.RDSAPI)(nil)).Elem(),
+	},
+	"resourcegroupstaggingapi": service{
+		Name:      "AWS Resource Groups Tagging API",
+		IAMPrefix: "tag",
+		API:       reflect.TypeOf((*resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI)(nil)).Elem(),
+	},
+	"route53": service{
+		Name:      "Amazon Route 53",
+		IAMPrefix: "route53",
+		Global:    true,
+		API:       reflect.TypeOf((*route53iface.Route53API)(nil)).Elem(),
+	},
+	"route53resolver": service{
+		Name:      "Amazon Route 53 Resolver",
+		IAMPrefix: "route53resolver",
+		API:       reflect.TypeOf((*route53resolveriface.Route53ResolverAPI)(nil)).Elem(),
+	},
+	"s3": service{
+		Name:      "Amazon S3",
+		IAMPrefix: "s3",
+		API:       reflect.TypeOf((*s3iface.S3API)(nil)).Elem(),
+	},
+	"ses": service{
+		Name:      "Amazon SES",
+		IAMPrefix: "ses",
+		API:       reflect.TypeOf((*sesiface.SESAPI)(nil)).Elem(),
+	},
+}
+
+// readerIAMActions are the IAM actions needed by NewAWSReader
+// to get the account ID and the available regions
+var readerIAMActions = []string{
+	"ec2:DescribeRegions",
+	"sts:GetCallerIdentity",
+}
@@ -2,8 +2,10 @@ package main
 
 import (
 	"fmt"
-	"html/template"
 	"io"
+	"strings"
+	"text/template"
+	"unicode"
 
 	"github.com/pkg/errors"
 )
@@ -35,6 +37,9 @@ const (
 	// For avoiding by the callers the problem of if the returned map may be nil,
 	// the function will always return a map instance, which will be of length 0
 	// in case that there is not any successful request.
+	// When the methods are called with a context of WithResultFunc, the results
+	// are sent to its ResultFunc, region by region, instead of being returned
+	// on the map, see Stream.
 	type AWSReader interface {
 		// GetAccountID returns the current ID for the account used
 		GetAccountID() string
@@ -45,6 +50,10 @@ const (
 		{{ range . }}
 			{{ .Documentation -}}
 			{{ .Signature }}
+			{{ if .Enrich }}
+				{{ .Enrich.Documentation -}}
+				{{ .EnrichSignature }}
+			{{ end }}
 		{{ end }}
 	}
 	`
@@ -52,42 +61,519 @@ const (
 	// functionTmpl it's the implementation of a function
 	functionTmpl = `
 		func (c *connector) {{ .Signature }} {
-			var errs Errors
-			var regionsOpts = map[string]{{.Output}}{}
+			out, err := c.invokeMethod(ctx, &MethodCall{Method: "{{.Name}}", Input: input}, func(ctx context.Context) (interface{}, error) {
+				var errs Errors
+				var regionsOpts = map[string]{{.Output}}{}
+
+				{{ if ne .FilterByOwner ""}}
+					if input == nil {
+						input = &{{.Input}}{}
+					}
+					input.{{.FilterByOwner}} = append(input.{{.FilterByOwner}}, c.accountID)
+				{{ end }}
 
-			{{ if ne .FilterByOwner ""}}
-				if input == nil {
-					input = &{{.Input}}{}
+				for _, svc := range c.services(ctx) {
+					{{- if .Paginated }}
+					// The items of all the pages are returned on a single
+					// output, and sent page by page to the ResultFunc
+					{{- end }}
+					call := &Call{Method: "{{.Name}}", Operation: "{{.Operation}}", Service: {{.Service}}.ServiceName, Region: svc.region, Input: input}
+					opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+						{{- if .Paginated }}
+						var opt {{.Output}}
+						var n int
+						err := svc.{{.Service}}.{{.Prefix}}{{.Entity}}PagesWithContext(ctx, input.(*{{.Input}}), func(page *{{.Output}}, _ bool) bool {
+							n++
+							if !sendOutput(ctx, "{{.Name}}", svc.region, n, *page) {
+								appendPage(&opt, page)
+							}
+							return true
+						}, opts...)
+						return &opt, err
+						{{- else }}
+						return svc.{{.Service}}.{{.Prefix}}{{.Entity}}WithContext(ctx, input.(*{{.Input}}), opts...)
+						{{- end }}
+					})
+					if err != nil {
+						errs = append(errs, sendError(ctx, "{{.Name}}", NewError(svc.region, {{.Service}}.ServiceName, err)))
+					{{- if .Paginated }}
+					} else if resultFunc(ctx) == nil {
+					{{- else }}
+					} else if !sendOutput(ctx, "{{.Name}}", svc.region, 1, *opt.(*{{.Output}})) {
+					{{- end }}
+						regionsOpts[svc.region] = *opt.(*{{.Output}})
+					}
 				}
-				input.{{.FilterByOwner}} = append(input.{{.FilterByOwner}}, c.accountID)
-			{{ end }}
 
-			for _, svc := range c.svcs {
-				if svc.{{.Service}} == nil {
-					svc.{{.Service}} = {{.Service}}.New(svc.session)
+				if errs != nil {
+					return regionsOpts, errs
 				}
 
-				opt, err := svc.{{.Service}}.{{.Prefix}}{{.Entity}}WithContext(ctx, input)
-				if err != nil {
-					errs = append(errs, NewError(svc.region, {{.Service}}.ServiceName, err))
-				} else {
-					regionsOpts[svc.region] = *opt
+				return regionsOpts, nil
+			})
+
+			// out is nil if a MethodInterceptor doesn't call the method
+			opts, _ := out.(map[string]{{.Output}})
+
+			return opts, err
+		}
+	`
+
+	// regionItemTmpl it's the definition of the type pairing
+	// a flattened item with its region
+	regionItemTmpl = `
+		// {{ .FlattenType }} pairs a {{ .Service }}.{{ .FlattenItem }} with the region it belongs to
+		type {{ .FlattenType }} struct {
+			Region string
+			Item   *{{ .Service }}.{{ .FlattenItem }}
+		}
+	`
+
+	// flattenTmpl it's the implementation of the ListAll function
+	// of a Function with Flatten defined
+	flattenTmpl = `
+		// {{ .FlattenName }} returns the items on the {{ .Flatten }} path of the {{ .Name }}
+		// results from all the regions, in the order of r.GetRegions(), paired with the region
+		// they belong to.
+		// The error is the one returned by {{ .Name }}, so the items of the regions without
+		// errors are always returned.
+		func {{ .FlattenName }}(ctx context.Context, r AWSReader, input *{{ .Input }}) ([]{{ .FlattenType }}, error) {
+			var items []{{ .FlattenType }}
+
+			opts, err := r.{{ .Name }}(ctx, input)
+			for _, region := range r.GetRegions() {
+				opt, ok := opts[region]
+				if !ok {
+					continue
+				}
+
+				for _, v := range flatten(opt, "{{ .Flatten }}") {
+					items = append(items, {{ .FlattenType }}{Region: region, Item: v.(*{{ .Service }}.{{ .FlattenItem }})})
 				}
 			}
 
-			if errs != nil {
-				return regionsOpts, errs
+			return items, err
+		}
+	`
+
+	// cliTmpl it's the list of commands of the raws CLI,
+	// one per function which has a CLIName
+	cliTmpl = `
+	package main
+
+	// Code generated by github.com/cycloidio/raws/cmd; DO NOT EDIT
+
+	var commands = []command{
+		{{- range . }}
+			{{- if .CLIName }}
+				{
+					service: "{{ .Service }}",
+					name:    "{{ .CLIName }}",
+					fn:      "{{ .Name }}",
+					input:   func() interface{} { return &{{ .Input }}{} },
+					call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+						return r.{{ .Name }}(ctx, input.(*{{ .Input }}))
+					},
+					{{- if .Flatten }}
+					list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+						return raws.{{ .FlattenName }}(ctx, r, input.(*{{ .Input }}))
+					},
+					{{- end }}
+				},
+				{{- if .Enrich }}
+				{
+					service: "{{ .Service }}",
+					name:    "{{ .EnrichCLIName }}",
+					fn:      "{{ .EnrichName }}",
+					input:   func() interface{} { return &{{ .Input }}{} },
+					call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+						return r.{{ .EnrichName }}(ctx, input.(*{{ .Input }}))
+					},
+				},
+				{{- end }}
+			{{- end }}
+		{{- end }}
+	}
+	`
+
+	// enrichTmpl it's the implementation of the function of a Function with
+	// Enrich defined, and the definition of the type it returns
+	enrichTmpl = `
+		{{- define "call" -}}
+			&Call{Method: "{{ .Method }}", Operation: "{{ .Fn.Operation }}", Service: {{ .Fn.Service }}.ServiceName, Region: svc.region, Input: fin}
+		{{- end -}}
+		{{- define "callFn" -}}
+			func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.{{ .Fn.Service }}.{{ .Fn.Prefix }}{{ .Fn.Entity }}WithContext(ctx, input.(*{{ .Fn.Input }}), opts...)
 			}
+		{{- end -}}
+		{{- define "callPagesFn" -}}
+			func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt {{ .Fn.Output }}
+				err := svc.{{ .Fn.Service }}.{{ .Fn.Prefix }}{{ .Fn.Entity }}PagesWithContext(ctx, input.(*{{ .Fn.Input }}), func(page *{{ .Fn.Output }}, _ bool) bool {
+					appendPage(&opt, page)
+					return true
+				}, opts...)
+				return &opt, err
+			}
+		{{- end -}}
 
-			return regionsOpts, nil
+		// {{ .Enrich.Type }} is a {{ .Service }}.{{ .FlattenItem }} with the results of
+		// the calls done for it by {{ .EnrichName }}
+		type {{ .Enrich.Type }} struct {
+			Item *{{ .Service }}.{{ .FlattenItem }}
+			{{ range .FollowUps -}}
+				{{ .Attach }} *{{ .AttachType }}
+			{{ end }}
+		}
+
+		func (c *connector) {{ .EnrichSignature }} {
+			out, err := c.invokeMethod(ctx, &MethodCall{Method: "{{ .EnrichName }}", Input: input}, func(ctx context.Context) (interface{}, error) {
+				var errs Errors
+				var regionsOpts = map[string][]{{ .Enrich.Type }}{}
+
+				// The items are sent once they have the results of the calls done for them
+				opts, err := c.{{ .Name }}(WithResultFunc(ctx, nil), input)
+				if err != nil {
+					for _, e := range asErrors({{ .Service }}.ServiceName, err) {
+						errs = append(errs, sendError(ctx, "{{ .EnrichName }}", e))
+					}
+				}
+
+				for _, svc := range c.services(ctx) {
+					opt, ok := opts[svc.region]
+					if !ok {
+						continue
+					}
+
+					var items []{{ .Enrich.Type }}
+					for _, v := range flatten(opt, "{{ .Flatten }}") {
+						items = append(items, {{ .Enrich.Type }}{Item: v.(*{{ .Service }}.{{ .FlattenItem }})})
+					}
+
+					{{ range .FollowUps }}
+						{{ if .Batch }}
+							for i := 0; i < len(items); i += {{ .Batch }} {
+								batch := items[i:]
+								if len(batch) > {{ .Batch }} {
+									batch = batch[:{{ .Batch }}]
+								}
+
+								fin := &{{ .Fn.Input }}{}
+								for _, it := range batch {
+									fin.{{ .To }} = append(fin.{{ .To }}, it.Item.{{ .From }})
+								}
+
+								fopt, err := c.invoke(ctx, {{ template "call" . }}, {{ if .Fn.Paginated }}{{ template "callPagesFn" . }}{{ else }}{{ template "callFn" . }}{{ end }})
+								if err != nil {
+									errs = append(errs, sendError(ctx, "{{ .Method }}", NewError(svc.region, {{ .Fn.Service }}.ServiceName, err)))
+									continue
+								}
+
+								for _, v := range flatten(fopt, "{{ .Result }}") {
+									res := v.(*{{ .AttachType }})
+									for j, it := range batch {
+										if aws.StringValue(it.Item.{{ .From }}) == aws.StringValue(res.{{ .ResultKey }}) {
+											batch[j].{{ .Attach }} = res
+										}
+									}
+								}
+							}
+						{{ else }}
+							for i, it := range items {
+								fin := &{{ .Fn.Input }}{
+									{{ .To }}: it.Item.{{ .From }},
+								}
+
+								fopt, err := c.invoke(ctx, {{ template "call" . }}, {{ if .Fn.Paginated }}{{ template "callPagesFn" . }}{{ else }}{{ template "callFn" . }}{{ end }})
+								if err != nil {
+									errs = append(errs, sendError(ctx, "{{ .Method }}", NewError(svc.region, {{ .Fn.Service }}.ServiceName, err)))
+									continue
+								}
+
+								items[i].{{ .Attach }} = fopt.(*{{ .Fn.Output }})
+							}
+						{{ end }}
+					{{ end }}
+
+					if !sendOutput(ctx, "{{ .EnrichName }}", svc.region, 1, items) {
+						regionsOpts[svc.region] = items
+					}
+				}
+
+				if errs != nil {
+					return regionsOpts, errs
+				}
+
+				return regionsOpts, nil
+			})
+
+			// out is nil if a MethodInterceptor doesn't call the method
+			opts, _ := out.(map[string][]{{ .Enrich.Type }})
+
+			return opts, err
 		}
 	`
+
+	// inventoryTmpl it's the list of the calls made by Inventory,
+	// one per function with Inventory defined
+	inventoryTmpl = `
+	// inventoryCalls are the calls made by Inventory
+	var inventoryCalls = []inventoryCall{
+		{{- range . }}
+			{{- if .Inventory }}
+				{
+					method:  "{{ .InventoryName }}",
+					service: "{{ .Service }}",
+					call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+						{{- if .InventoryFn }}
+						return {{ .InventoryFn }}(ctx, r)
+						{{- else if .Enrich }}
+						return r.{{ .EnrichName }}(ctx, nil)
+						{{- else }}
+						return {{ .FlattenName }}(ctx, r, nil)
+						{{- end }}
+					},
+				},
+			{{- end }}
+		{{- end }}
+	}
+	`
+
+	// itemsPathsTmpl it's the list of the paths of the items of the
+	// outputs, one per function with Flatten defined
+	itemsPathsTmpl = `
+	// itemsPaths are the paths of the items of the outputs of the
+	// methods, by method, used to count the items they fetch
+	var itemsPaths = map[string]string{
+		{{- range . }}
+			{{- if and .Flatten (not .FnSignature) }}
+				"{{ .Name }}": "{{ .Flatten }}",
+			{{- end }}
+		{{- end }}
+	}
+	`
+
+	// cachedReaderTmpl it's the implementation of the methods of the CachedReader,
+	// one per function, and Enrich function, returning a map of regions
+	cachedReaderTmpl = `
+	{{- range . }}
+		{{- if not .FnSignature }}
+			func (c *CachedReader) {{ .Signature }} {
+				var opts map[string]{{ .Output }}
+				err := c.call(ctx, "{{ .Name }}", {{ .Service }}.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+					return c.reader.{{ .Name }}(ctx, input)
+				})
+				return opts, err
+			}
+			{{ if .Enrich }}
+				func (c *CachedReader) {{ .EnrichSignature }} {
+					var opts map[string][]{{ .Enrich.Type }}
+					err := c.call(ctx, "{{ .EnrichName }}", {{ .Service }}.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+						return c.reader.{{ .EnrichName }}(ctx, input)
+					})
+					return opts, err
+				}
+			{{ end }}
+		{{- end }}
+	{{- end }}
+	`
+
+	// fakeReaderTmpl it's the implementation of the methods of the rawsfake.Reader,
+	// one per function, and Enrich function, returning a map of regions, and
+	// the list of the types of the items they return
+	fakeReaderTmpl = `
+	{{- define "method" -}}
+		method{name: "{{ .Name }}", service: {{ .Service }}.ServiceName, flatten: "{{ .Flatten }}", owner: "{{ .FilterByOwner }}", global: {{ .Global }}, paginated: {{ .Paginated }}}
+	{{- end -}}
+
+	package rawsfake
+
+	// Code generated by github.com/cycloidio/raws/cmd; DO NOT EDIT
+
+	// itemTypes are the types of the items returned by the methods,
+	// used to read the resources of the fixtures
+	var itemTypes = []reflect.Type{
+		{{- range . }}
+			{{- if and .Flatten (not .FnSignature) }}
+				reflect.TypeOf((*{{ .Service }}.{{ .FlattenItem }})(nil)),
+				{{- if .Enrich }}
+					reflect.TypeOf(raws.{{ .Enrich.Type }}{}),
+				{{- end }}
+			{{- end }}
+		{{- end }}
+	}
+
+	{{ range . }}
+		{{- if not .FnSignature }}
+			func (r *Reader) {{ .Signature }} {
+				var opts map[string]{{ .Output }}
+				err := r.call(ctx, {{ template "method" .Function }}, input, &opts)
+				return opts, err
+			}
+			{{ if .Enrich }}
+				func (r *Reader) {{ .EnrichName }}(ctx context.Context, input *{{ .Input }}) (map[string][]raws.{{ .Enrich.Type }}, error) {
+					var errs raws.Errors
+					var regionsOpts = map[string][]raws.{{ .Enrich.Type }}{}
+
+					// The items are sent once they have the results of the calls done for them
+					opts, err := r.{{ .Name }}(raws.WithResultFunc(ctx, nil), input)
+					if err != nil {
+						for _, e := range asErrors({{ .Service }}.ServiceName, err) {
+							errs = append(errs, sendError(ctx, "{{ .EnrichName }}", e))
+						}
+					}
+
+					for _, region := range r.GetRegions() {
+						opt, ok := opts[region]
+						if !ok {
+							continue
+						}
+
+						var items []raws.{{ .Enrich.Type }}
+						for _, v := range flatten(opt, "{{ .Flatten }}") {
+							it, _ := r.enrichment(v).(raws.{{ .Enrich.Type }})
+							it.Item = v.(*{{ .Service }}.{{ .FlattenItem }})
+							items = append(items, it)
+						}
+
+						{{ range .FollowUps }}
+							{{ if .Batch }}
+								for i := 0; i < len(items); i += {{ .Batch }} {
+									batch := items[i:]
+									if len(batch) > {{ .Batch }} {
+										batch = batch[:{{ .Batch }}]
+									}
+
+									fin := &{{ .Fn.Input }}{}
+									for _, it := range batch {
+										if it.{{ .Attach }} == nil {
+											fin.{{ .To }} = append(fin.{{ .To }}, it.Item.{{ .From }})
+										}
+									}
+									if len(fin.{{ .To }}) == 0 {
+										continue
+									}
+
+									var fopt {{ .Fn.Output }}
+									err := r.output(ctx, {{ template "method" .ResultFn }}, region, fin, &fopt)
+									if err != nil {
+										errs = append(errs, sendError(ctx, "{{ .Method }}", raws.NewError(region, {{ .Fn.Service }}.ServiceName, err)))
+										continue
+									}
+
+									for _, v := range flatten(fopt, "{{ .Result }}") {
+										res := v.(*{{ .AttachType }})
+										for j, it := range batch {
+											if it.{{ .Attach }} == nil && aws.StringValue(it.Item.{{ .From }}) == aws.StringValue(res.{{ .ResultKey }}) {
+												batch[j].{{ .Attach }} = res
+											}
+										}
+									}
+								}
+							{{ else }}
+								for i, it := range items {
+									if it.{{ .Attach }} != nil {
+										continue
+									}
+
+									fin := &{{ .Fn.Input }}{
+										{{ .To }}: it.Item.{{ .From }},
+									}
+
+									var fopt {{ .Fn.Output }}
+									err := r.output(ctx, {{ template "method" .Fn }}, region, fin, &fopt)
+									if err != nil {
+										errs = append(errs, sendError(ctx, "{{ .Method }}", raws.NewError(region, {{ .Fn.Service }}.ServiceName, err)))
+										continue
+									}
+
+									items[i].{{ .Attach }} = &fopt
+								}
+							{{ end }}
+						{{ end }}
+
+						if !sendOutput(ctx, "{{ .EnrichName }}", region, items) {
+							regionsOpts[region] = items
+						}
+					}
+
+					if errs != nil {
+						return regionsOpts, errs
+					}
+
+					return regionsOpts, nil
+				}
+			{{ end }}
+		{{- end }}
+	{{- end }}
+	`
+
+	// referenceTmpl it's the Markdown reference of the AWSReader,
+	// with the methods grouped by service
+	referenceTmpl = `<!-- Code generated by github.com/cycloidio/raws/cmd; DO NOT EDIT -->
+
+# AWSReader reference
+
+All the methods of the ` + "`AWSReader`" + ` grouped by the AWS service they use.
+
+Unless said otherwise the methods call the AWS operations on all the regions of the
+reader, and return a map with the output of each region. A global service is not
+regional, so every region returns the same items. The methods of a paginated
+operation read all its pages, from the one requested by the input on, and return
+the items of all of them on a single output. The methods filtered by owner only
+return the items which belong to the account of the reader.
+
+Creating the reader with ` + "`NewAWSReader`" + ` needs {{ range $i, $a := .ReaderIAMActions }}{{ if $i }} and {{ end }}` + "`{{ $a }}`" + `{{ end }},
+except when using a custom endpoint.
+{{- if .Policy }} The minimal IAM policy needed by the whole
+` + "`AWSReader`" + ` is on [{{ .Policy }}]({{ .Policy }}).
+{{- end }}
+{{ range .Services }}
+* [{{ .Service }}](#{{ .Service }}): {{ .Name }}
+{{- end }}
+{{ range .Services }}
+## {{ .Service }}
+
+{{ .Name }}{{ if .Global }}, which is a global service{{ end }}.
+{{ range .Methods }}
+### {{ .Name }}
+
+{{ .Documentation }}
+
+| | |
+|---|---|
+| AWS operations | {{ range $i, $o := .Operations }}{{ if $i }}, {{ end }}` + "`{{ $o }}`" + `{{ end }} |
+| IAM actions | {{ range $i, $a := .IAMActions }}{{ if $i }}, {{ end }}` + "`{{ $a }}`" + `{{ end }} |
+| Global | {{ if .Global }}yes{{ else }}no{{ end }} |
+| Paginated | {{ if .Paginated }}yes{{ else }}no{{ end }} |
+| Filtered by owner | {{ if .FilterByOwner }}yes, with ` + "`{{ .FilterByOwner }}`" + `{{ else }}no{{ end }} |
+{{- if .Signature }}
+| Signature | ` + "`{{ .Signature }}`" + ` |
+{{- else }}
+| Input | ` + "`*{{ .Input }}`" + ` |
+| Output | ` + "`{{ .Output }}`" + ` |
+{{- end }}
+{{- if .FlattenName }}
+| All the items | ` + "`{{ .FlattenName }}`" + ` returning ` + "`[]{{ .FlattenType }}`" + ` |
+{{- end }}
+{{ end }}
+{{- end }}`
 )
 
 var (
 	fnTmpl        *template.Template
 	pkgTmpl       *template.Template
 	awsReaderTmpl *template.Template
+	riTmpl        *template.Template
+	flatTmpl      *template.Template
+	enrTmpl       *template.Template
+	cmdsTmpl      *template.Template
+	refTmpl       *template.Template
+	invTmpl       *template.Template
+	itemsTmpl     *template.Template
+	cacheTmpl     *template.Template
+	fakeTmpl      *template.Template
 )
 
 func init() {
@@ -107,6 +593,51 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+
+	riTmpl, err = template.New("test").Parse(regionItemTmpl)
+	if err != nil {
+		panic(err)
+	}
+
+	flatTmpl, err = template.New("test").Parse(flattenTmpl)
+	if err != nil {
+		panic(err)
+	}
+
+	enrTmpl, err = template.New("test").Parse(enrichTmpl)
+	if err != nil {
+		panic(err)
+	}
+
+	cmdsTmpl, err = template.New("test").Parse(cliTmpl)
+	if err != nil {
+		panic(err)
+	}
+
+	refTmpl, err = template.New("test").Parse(referenceTmpl)
+	if err != nil {
+		panic(err)
+	}
+
+	invTmpl, err = template.New("test").Parse(inventoryTmpl)
+	if err != nil {
+		panic(err)
+	}
+
+	itemsTmpl, err = template.New("test").Parse(itemsPathsTmpl)
+	if err != nil {
+		panic(err)
+	}
+
+	cacheTmpl, err = template.New("test").Parse(cachedReaderTmpl)
+	if err != nil {
+		panic(err)
+	}
+
+	fakeTmpl, err = template.New("test").Parse(fakeReaderTmpl)
+	if err != nil {
+		panic(err)
+	}
 }
 
 // Function is the definition of one of the functions
@@ -150,16 +681,157 @@ type Function struct {
 	// FilterByOwner adds the "{{.FilterByOwner}} = AccountID" to the input filter
 	// so this value has to be the correct name on the input
 	FilterByOwner string
+
+	// FnIAMActions are the IAM actions needed to call the function,
+	// if not defined the one of the Operation is used. It has to be
+	// defined when the function makes other calls or the IAM action
+	// has a different name than the operation, like "s3:ListBucket"
+	// for "ListObjects"
+	FnIAMActions []string
+
+	// Flatten is the path, separated by dots, to the list of items on
+	// the Output, like "Reservations.Instances". If defined a ListAll
+	// function is generated which returns all those items paired with
+	// their region
+	Flatten string
+
+	// FlattenItem is the type name of the items found on the Flatten path,
+	// without the pkg, like "Instance" for "ec2.Instance"
+	FlattenItem string
+
+	// FnFlattenType is the name of the type generated to pair each item
+	// with its region, if not defined "Region{{.FlattenItem}}" is used.
+	// It has to be defined when 2 services have items with the same name
+	FnFlattenType string
+
+	// Inventory adds the function to the ones called by Inventory, so
+	// it has to return all the resources without any input and to have
+	// Flatten defined. If Enrich is defined the Enrich function is the
+	// one called
+	Inventory bool
+
+	// InventoryFn is the name of the function called by Inventory, with
+	// the ctx and the AWSReader, instead of the ListAll one, for the
+	// functions which need an input, like "ListAllHostedZoneRecordSets"
+	// which calls GetResourceRecordSets for each one of the hosted zones
+	InventoryFn string
+
+	// Enrich generates a function which returns the items of the
+	// Flatten path, so it has to be defined too, with the results
+	// of the calls made for each one of them, like its tags
+	Enrich *Enrich
+}
+
+// Enrich is the definition of a function returning the items of
+// a Function with the results of follow-up calls made for each
+// one of them
+type Enrich struct {
+	// Name is added to the Function Name to build the name of
+	// the function, like "WithTags" for "GetLoadBalancersWithTags"
+	Name string
+
+	// Type is the name of the generated type containing each item and
+	// the results of the FollowUps
+	Type string
+
+	// Documentation is the documentation that will be added
+	// to the AWSReader function definition
+	Documentation string
+
+	// FollowUps are the calls made for the items
+	FollowUps []FollowUp
+}
+
+// FollowUp is the definition of a call made for the
+// items of an Enrich
+type FollowUp struct {
+	// Attach is the name of the field of the Enrich Type
+	// where the result of the call is set
+	Attach string
+
+	// Function is the Name of the Function to call, which
+	// must be one of the list of functions
+	Function string
+
+	// From is the field of the item used for the call,
+	// like "LoadBalancerName"
+	From string
+
+	// To is the field of the Function input where the From
+	// is set, like "LoadBalancerNames"
+	To string
+
+	// Batch is the maximum number of items sent on each call,
+	// in which case To must be a list and the Result and its
+	// related fields must be defined.
+	// If it's 0 one call is made per item and the Function
+	// output is the one attached
+	Batch int
+
+	// Result is the path, separated by dots, to the list of
+	// results on the Function output, so each one of them can
+	// be attached to the item it belongs
+	Result string
+
+	// ResultItem is the type name of the items on the Result
+	// path, without the pkg, like "TagDescription"
+	ResultItem string
+
+	// ResultKey is the field of the ResultItem which has the
+	// same value than the From of the item it belongs
+	ResultKey string
+}
+
+// followUp is a FollowUp with the Function it has to call
+type followUp struct {
+	FollowUp
+
+	Fn Function
+
+	// Method is the name of the enrich method making the call
+	Method string
+}
+
+// AttachType returns the type of the attached field, which is the
+// Fn output or the Fn Service ResultItem if the calls are batched
+func (f followUp) AttachType() string {
+	if f.Batch != 0 {
+		return fmt.Sprintf("%s.%s", f.Fn.Service, f.ResultItem)
+	}
+
+	return f.Fn.Output()
+}
+
+// ResultFn returns the Fn with the Result as its Flatten,
+// so the results of the batched calls can be found
+func (f followUp) ResultFn() Function {
+	fn := f.Fn
+	fn.Flatten = f.Result
+
+	return fn
+}
+
+// fakeFunction is a Function with the FollowUps
+// of its Enrich, if it has one
+type fakeFunction struct {
+	Function
+
+	FollowUps []followUp
 }
 
 // Name builds a name simply using "Get{{.Entity}}"
 // except if FnName is defined, in which case
-// only FnName is used
+// only FnName is used, or if FnSignature is defined,
+// in which case the name on it is used
 func (f Function) Name() string {
 	if f.FnName != "" {
 		return f.FnName
 	}
 
+	if i := strings.Index(f.FnSignature, "("); i > 0 {
+		return strings.TrimSpace(f.FnSignature[:i])
+	}
+
 	prefix := "Get"
 	if f.FilterByOwner != "" {
 		prefix += "Own"
@@ -194,6 +866,149 @@ func (f Function) Signature() string {
 	return fmt.Sprintf("%s (ctx context.Context, input *%s) (map[string]%s, error)", f.Name(), f.Input(), f.Output())
 }
 
+// FlattenName builds the name of the ListAll function by replacing
+// the "Get" or "List" prefix of the Name by "ListAll"
+func (f Function) FlattenName() string {
+	name := f.Name()
+	for _, p := range []string{"Get", "List"} {
+		if strings.HasPrefix(name, p) {
+			name = strings.TrimPrefix(name, p)
+			break
+		}
+	}
+
+	return fmt.Sprintf("ListAll%s", name)
+}
+
+// FlattenType builds the name of the type pairing the items with their
+// region by "Region{{.FlattenItem}}" except if FnFlattenType is defined,
+// in which case is used
+func (f Function) FlattenType() string {
+	if f.FnFlattenType != "" {
+		return f.FnFlattenType
+	}
+
+	return fmt.Sprintf("Region%s", f.FlattenItem)
+}
+
+// EnrichName builds the name of the Enrich function
+// with "{{.Name}}{{.Enrich.Name}}"
+func (f Function) EnrichName() string {
+	return fmt.Sprintf("%s%s", f.Name(), f.Enrich.Name)
+}
+
+// EnrichSignature builds the signature of the Enrich function
+func (f Function) EnrichSignature() string {
+	return fmt.Sprintf("%s (ctx context.Context, input *%s) (map[string][]%s, error)", f.EnrichName(), f.Input(), f.Enrich.Type)
+}
+
+// InventoryName returns the name of the function called
+// by Inventory, which is the EnrichName if Enrich is
+// defined or the Name otherwise
+func (f Function) InventoryName() string {
+	if f.Enrich != nil {
+		return f.EnrichName()
+	}
+
+	return f.Name()
+}
+
+// CLIName builds the name of the raws CLI command with
+// "{{.Entity}}" in kebab case, and the "own-" prefix if
+// FilterByOwner is defined. It returns an empty string
+// if FnSignature is defined as it can't be used by the CLI
+func (f Function) CLIName() string {
+	if f.FnSignature != "" {
+		return ""
+	}
+
+	name := f.Entity
+	if f.FilterByOwner != "" {
+		name = "Own" + name
+	}
+
+	return kebab(name)
+}
+
+// EnrichCLIName builds the name of the raws CLI command of
+// the Enrich function with "{{.CLIName}}-{{.Enrich.Name}}"
+// in kebab case
+func (f Function) EnrichCLIName() string {
+	return fmt.Sprintf("%s-%s", f.CLIName(), kebab(f.Enrich.Name))
+}
+
+// kebab converts a CamelCase name to kebab-case keeping
+// the acronyms together, so "DBInstances" is "db-instances"
+func kebab(s string) string {
+	var b strings.Builder
+
+	rs := []rune(s)
+	for i, r := range rs {
+		if i > 0 && unicode.IsUpper(r) &&
+			(!unicode.IsUpper(rs[i-1]) || (i+1 < len(rs) && unicode.IsLower(rs[i+1]))) {
+			b.WriteRune('-')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return b.String()
+}
+
+// Operation returns the AWS operation called by
+// the function with "{{.Prefix}}{{.Entity}}"
+func (f Function) Operation() string {
+	return fmt.Sprintf("%s%s", f.Prefix, f.Entity)
+}
+
+// IAMActions returns the IAM actions needed to call the function with
+// "{{.Service IAMPrefix}}:{{.Operation}}" except if FnIAMActions is
+// defined, in which case is used
+func (f Function) IAMActions() []string {
+	if f.FnIAMActions != nil {
+		return f.FnIAMActions
+	}
+
+	return []string{fmt.Sprintf("%s:%s", services[f.Service].IAMPrefix, f.Operation())}
+}
+
+// Global returns if the Service is not regional
+func (f Function) Global() bool {
+	return services[f.Service].Global
+}
+
+// Paginated returns if the Operation is paginated by AWS, which
+// is known by the "{{.Operation}}Pages" method of the Service API
+func (f Function) Paginated() bool {
+	api := services[f.Service].API
+	if api == nil {
+		return false
+	}
+
+	_, ok := api.MethodByName(fmt.Sprintf("%sPages", f.Operation()))
+	return ok
+}
+
+// followUps returns the FollowUps of the Enrich with the
+// Function, looked for on fns, they call
+func (f Function) followUps(fns []Function) ([]followUp, error) {
+	var fups []followUp
+	for _, fu := range f.Enrich.FollowUps {
+		fup := followUp{FollowUp: fu, Method: f.EnrichName()}
+		for _, fn := range fns {
+			if fn.Name() == fu.Function {
+				fup.Fn = fn
+				break
+			}
+		}
+		if fup.Fn.Name() != fu.Function {
+			return nil, fmt.Errorf("the Function %s used by %s does not exist", fu.Function, f.EnrichName())
+		}
+		fups = append(fups, fup)
+	}
+
+	return fups, nil
+}
+
 // Execute uses the fnTmpl to interpolate f
 // and write the result to w
 func (f Function) Execute(w io.Writer) error {
@@ -208,3 +1023,66 @@ func (f Function) Execute(w io.Writer) error {
 
 	return nil
 }
+
+// ExecuteFlatten uses the flatTmpl to interpolate f
+// and write the result to w, nothing is written if
+// f has no Flatten defined
+func (f Function) ExecuteFlatten(w io.Writer) error {
+	if f.Flatten == "" {
+		return nil
+	}
+
+	err := flatTmpl.Execute(w, f)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ExecuteFlatten with Function %+v", f)
+	}
+
+	return nil
+}
+
+// ExecuteRegionItem uses the riTmpl to interpolate f
+// and write the result to w, nothing is written if
+// f has no Flatten defined
+func (f Function) ExecuteRegionItem(w io.Writer) error {
+	if f.Flatten == "" {
+		return nil
+	}
+
+	err := riTmpl.Execute(w, f)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ExecuteRegionItem with Function %+v", f)
+	}
+
+	return nil
+}
+
+// ExecuteEnrich uses the enrTmpl to interpolate f, looking
+// for the FollowUps Function on fns, and write the result
+// to w, nothing is written if f has no Enrich defined
+func (f Function) ExecuteEnrich(w io.Writer, fns []Function) error {
+	if f.Enrich == nil {
+		return nil
+	}
+
+	if f.Flatten == "" {
+		return fmt.Errorf("the Function %s has Enrich defined without Flatten", f.Name())
+	}
+
+	fups, err := f.followUps(fns)
+	if err != nil {
+		return err
+	}
+
+	err = enrTmpl.Execute(w, struct {
+		Function
+		FollowUps []followUp
+	}{
+		Function:  f,
+		FollowUps: fups,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to ExecuteEnrich with Function %+v", f)
+	}
+
+	return nil
+}
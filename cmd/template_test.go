@@ -37,6 +37,13 @@ func TestTemplateName(t *testing.T) {
 			},
 			opt: "GetOwnEntity",
 		},
+		{
+			name: "FnSignature",
+			tmp: Function{
+				FnSignature: "DownloadObject(ctx context.Context) (int64, error)",
+			},
+			opt: "DownloadObject",
+		},
 	}
 
 	for _, tt := range tests {
@@ -133,6 +140,256 @@ func TestTemplateSignature(t *testing.T) {
 	}
 }
 
+func TestTemplateFlattenName(t *testing.T) {
+	tests := []struct {
+		name string
+		tmp  Function
+		opt  string
+	}{
+		{
+			name: "Basic",
+			tmp: Function{
+				Entity: "Entity",
+			},
+			opt: "ListAllEntity",
+		},
+		{
+			name: "FnName",
+			tmp: Function{
+				FnName: "ListEntity",
+			},
+			opt: "ListAllEntity",
+		},
+		{
+			name: "FilterByOwner",
+			tmp: Function{
+				Entity:        "Entity",
+				FilterByOwner: "not-relevant",
+			},
+			opt: "ListAllOwnEntity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.opt, tt.tmp.FlattenName())
+		})
+	}
+}
+
+func TestTemplateFlattenType(t *testing.T) {
+	tests := []struct {
+		name string
+		tmp  Function
+		opt  string
+	}{
+		{
+			name: "Basic",
+			tmp: Function{
+				FlattenItem: "Item",
+			},
+			opt: "RegionItem",
+		},
+		{
+			name: "FnFlattenType",
+			tmp: Function{
+				FlattenItem:   "Item",
+				FnFlattenType: "RegionServiceItem",
+			},
+			opt: "RegionServiceItem",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.opt, tt.tmp.FlattenType())
+		})
+	}
+}
+
+func TestTemplateExecuteFlatten(t *testing.T) {
+	tests := []struct {
+		name string
+		tmp  Function
+		opt  string
+	}{
+		{
+			name: "Basic",
+			tmp: Function{
+				Service:     "Service",
+				Entity:      "Entities",
+				Prefix:      "Prefix",
+				Flatten:     "List.Entities",
+				FlattenItem: "Entity",
+			},
+			opt: `
+			// ListAllEntities returns the items on the List.Entities path of the GetEntities
+			// results from all the regions, in the order of r.GetRegions(), paired with the region
+			// they belong to.
+			// The error is the one returned by GetEntities, so the items of the regions without
+			// errors are always returned.
+			func ListAllEntities(ctx context.Context, r AWSReader, input *Service.PrefixEntitiesInput) ([]RegionEntity, error) {
+				var items []RegionEntity
+
+				opts, err := r.GetEntities(ctx, input)
+				for _, region := range r.GetRegions() {
+					opt, ok := opts[region]
+					if !ok {
+						continue
+					}
+
+					for _, v := range flatten(opt, "List.Entities") {
+						items = append(items, RegionEntity{Region: region, Item: v.(*Service.Entity)})
+					}
+				}
+
+				return items, err
+			}`,
+		},
+		{
+			name: "NoFlatten",
+			tmp: Function{
+				Service: "Service",
+				Entity:  "Entities",
+				Prefix:  "Prefix",
+			},
+			opt: ``,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buff := bytes.Buffer{}
+			err := tt.tmp.ExecuteFlatten(&buff)
+			require.NoError(t, err)
+			ttopt := strings.Join(strings.Fields(tt.opt), " ")
+			buffs := strings.Join(strings.Fields(buff.String()), " ")
+			assert.Equal(t, ttopt, buffs)
+		})
+	}
+}
+
+func TestTemplateCLIName(t *testing.T) {
+	tests := []struct {
+		name string
+		tmp  Function
+		opt  string
+	}{
+		{
+			name: "Basic",
+			tmp: Function{
+				Entity: "DBInstances",
+			},
+			opt: "db-instances",
+		},
+		{
+			name: "FilterByOwner",
+			tmp: Function{
+				Entity:        "Images",
+				FilterByOwner: "not-relevant",
+			},
+			opt: "own-images",
+		},
+		{
+			name: "FnSignature",
+			tmp: Function{
+				FnSignature: "SomeSignature",
+			},
+			opt: "",
+		},
+		{
+			name: "Enrich",
+			tmp: Function{
+				Entity: "LoadBalancers",
+				Enrich: &Enrich{Name: "WithTags"},
+			},
+			opt: "load-balancers",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.opt, tt.tmp.CLIName())
+		})
+	}
+
+	f := Function{Entity: "LoadBalancers", Enrich: &Enrich{Name: "WithTags"}}
+	assert.Equal(t, "load-balancers-with-tags", f.EnrichCLIName())
+}
+
+func TestTemplateIAMActions(t *testing.T) {
+	tests := []struct {
+		name string
+		tmp  Function
+		opt  []string
+	}{
+		{
+			name: "Basic",
+			tmp: Function{
+				Entity:  "Instances",
+				Prefix:  "Describe",
+				Service: "ec2",
+			},
+			opt: []string{"ec2:DescribeInstances"},
+		},
+		{
+			name: "IAMPrefix",
+			tmp: Function{
+				Entity:  "LoadBalancers",
+				Prefix:  "Describe",
+				Service: "elbv2",
+			},
+			opt: []string{"elasticloadbalancing:DescribeLoadBalancers"},
+		},
+		{
+			name: "FnIAMActions",
+			tmp: Function{
+				Entity:       "Objects",
+				Prefix:       "List",
+				Service:      "s3",
+				FnIAMActions: []string{"s3:ListBucket"},
+			},
+			opt: []string{"s3:ListBucket"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.opt, tt.tmp.IAMActions())
+		})
+	}
+}
+
+func TestTemplatePaginated(t *testing.T) {
+	tests := []struct {
+		name string
+		tmp  Function
+		opt  bool
+	}{
+		{
+			name: "Paginated",
+			tmp:  Function{Entity: "Instances", Prefix: "Describe", Service: "ec2"},
+			opt:  true,
+		},
+		{
+			name: "NotPaginated",
+			tmp:  Function{Entity: "Images", Prefix: "Describe", Service: "ec2"},
+			opt:  false,
+		},
+		{
+			name: "UnknownService",
+			tmp:  Function{Entity: "Instances", Prefix: "Describe", Service: "unknown"},
+			opt:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.opt, tt.tmp.Paginated())
+		})
+	}
+}
+
 func TestTemplateExecute(t *testing.T) {
 	tests := []struct {
 		name string
@@ -149,27 +406,33 @@ func TestTemplateExecute(t *testing.T) {
 			},
 			opt: `
 			func (c *connector) Signature {
-				var errs Errors
-				var regionsOpts = map[string]Service.PrefixEntityOutput{}
+				out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetEntity", Input: input}, func(ctx context.Context) (interface{}, error) {
+					var errs Errors
+					var regionsOpts = map[string]Service.PrefixEntityOutput{}
 
-				for _, svc := range c.svcs {
-					if svc.Service == nil {
-						svc.Service = Service.New(svc.session)
+					for _, svc := range c.services(ctx) {
+						call := &Call{Method: "GetEntity", Operation: "PrefixEntity", Service: Service.ServiceName, Region: svc.region, Input: input}
+						opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+							return svc.Service.PrefixEntityWithContext(ctx, input.(*Service.PrefixEntityInput), opts...)
+						})
+						if err != nil {
+							errs = append(errs, sendError(ctx, "GetEntity", NewError(svc.region, Service.ServiceName, err)))
+						} else if !sendOutput(ctx, "GetEntity", svc.region, 1, *opt.(*Service.PrefixEntityOutput)) {
+							regionsOpts[svc.region] = *opt.(*Service.PrefixEntityOutput)
+						}
 					}
 
-					opt, err := svc.Service.PrefixEntityWithContext(ctx, input)
-					if err != nil {
-						errs = append(errs, NewError(svc.region, Service.ServiceName, err))
-					} else {
-						regionsOpts[svc.region] = *opt
+					if errs != nil {
+						return regionsOpts, errs
 					}
-				}
 
-				if errs != nil {
-					return regionsOpts, errs
-				}
+					return regionsOpts, nil
+				})
 
-				return regionsOpts, nil
+				// out is nil if a MethodInterceptor doesn't call the method
+				opts, _ := out.(map[string]Service.PrefixEntityOutput)
+
+				return opts, err
 			}`,
 		},
 		{
@@ -183,32 +446,38 @@ func TestTemplateExecute(t *testing.T) {
 			},
 			opt: `
 			func (c *connector) Signature {
-				var errs Errors
-				var regionsOpts = map[string]Service.PrefixEntityOutput{}
+				out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetOwnEntity", Input: input}, func(ctx context.Context) (interface{}, error) {
+					var errs Errors
+					var regionsOpts = map[string]Service.PrefixEntityOutput{}
 
-				if input == nil {
-					input = &Service.PrefixEntityInput{}
-				}
-				input.OwnerField = append(input.OwnerField, c.accountID)
+					if input == nil {
+						input = &Service.PrefixEntityInput{}
+					}
+					input.OwnerField = append(input.OwnerField, c.accountID)
 
-				for _, svc := range c.svcs {
-					if svc.Service == nil {
-						svc.Service = Service.New(svc.session)
+					for _, svc := range c.services(ctx) {
+						call := &Call{Method: "GetOwnEntity", Operation: "PrefixEntity", Service: Service.ServiceName, Region: svc.region, Input: input}
+						opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+							return svc.Service.PrefixEntityWithContext(ctx, input.(*Service.PrefixEntityInput), opts...)
+						})
+						if err != nil {
+							errs = append(errs, sendError(ctx, "GetOwnEntity", NewError(svc.region, Service.ServiceName, err)))
+						} else if !sendOutput(ctx, "GetOwnEntity", svc.region, 1, *opt.(*Service.PrefixEntityOutput)) {
+							regionsOpts[svc.region] = *opt.(*Service.PrefixEntityOutput)
+						}
 					}
 
-					opt, err := svc.Service.PrefixEntityWithContext(ctx, input)
-					if err != nil {
-						errs = append(errs, NewError(svc.region, Service.ServiceName, err))
-					} else {
-						regionsOpts[svc.region] = *opt
+					if errs != nil {
+						return regionsOpts, errs
 					}
-				}
 
-				if errs != nil {
-					return regionsOpts, errs
-				}
+					return regionsOpts, nil
+				})
 
-				return regionsOpts, nil
+				// out is nil if a MethodInterceptor doesn't call the method
+				opts, _ := out.(map[string]Service.PrefixEntityOutput)
+
+				return opts, err
 			}`,
 		},
 		{
@@ -231,3 +500,173 @@ func TestTemplateExecute(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateExecuteEnrich(t *testing.T) {
+	fns := []Function{
+		Function{
+			Entity:  "EntityTags",
+			Prefix:  "List",
+			Service: "Service",
+		},
+	}
+
+	t.Run("Basic", func(t *testing.T) {
+		tmp := Function{
+			Service:     "Service",
+			Entity:      "Entities",
+			Prefix:      "Prefix",
+			Flatten:     "Entities",
+			FlattenItem: "Entity",
+			Enrich: &Enrich{
+				Name: "WithTags",
+				Type: "EntityWithTags",
+				FollowUps: []FollowUp{
+					FollowUp{
+						Attach:   "Tags",
+						Function: "GetEntityTags",
+						From:     "Arn",
+						To:       "ResourceArn",
+					},
+				},
+			},
+		}
+		opt := `
+		// EntityWithTags is a Service.Entity with the results of
+		// the calls done for it by GetEntitiesWithTags
+		type EntityWithTags struct {
+			Item *Service.Entity
+			Tags *Service.ListEntityTagsOutput
+		}
+
+		func (c *connector) GetEntitiesWithTags (ctx context.Context, input *Service.PrefixEntitiesInput) (map[string][]EntityWithTags, error) {
+			out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetEntitiesWithTags", Input: input}, func(ctx context.Context) (interface{}, error) {
+				var errs Errors
+				var regionsOpts = map[string][]EntityWithTags{}
+
+				// The items are sent once they have the results of the calls done for them
+				opts, err := c.GetEntities(WithResultFunc(ctx, nil), input)
+				if err != nil {
+					for _, e := range asErrors(Service.ServiceName, err) {
+						errs = append(errs, sendError(ctx, "GetEntitiesWithTags", e))
+					}
+				}
+
+				for _, svc := range c.services(ctx) {
+					opt, ok := opts[svc.region]
+					if !ok {
+						continue
+					}
+
+					var items []EntityWithTags
+					for _, v := range flatten(opt, "Entities") {
+						items = append(items, EntityWithTags{Item: v.(*Service.Entity)})
+					}
+
+					for i, it := range items {
+						fin := &Service.ListEntityTagsInput{
+							ResourceArn: it.Item.Arn,
+						}
+
+						fopt, err := c.invoke(ctx, &Call{Method: "GetEntitiesWithTags", Operation: "ListEntityTags", Service: Service.ServiceName, Region: svc.region, Input: fin}, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+							return svc.Service.ListEntityTagsWithContext(ctx, input.(*Service.ListEntityTagsInput), opts...)
+						})
+						if err != nil {
+							errs = append(errs, sendError(ctx, "GetEntitiesWithTags", NewError(svc.region, Service.ServiceName, err)))
+							continue
+						}
+
+						items[i].Tags = fopt.(*Service.ListEntityTagsOutput)
+					}
+
+					if !sendOutput(ctx, "GetEntitiesWithTags", svc.region, 1, items) {
+						regionsOpts[svc.region] = items
+					}
+				}
+
+				if errs != nil {
+					return regionsOpts, errs
+				}
+
+				return regionsOpts, nil
+			})
+
+			// out is nil if a MethodInterceptor doesn't call the method
+			opts, _ := out.(map[string][]EntityWithTags)
+
+			return opts, err
+		}`
+
+		buff := bytes.Buffer{}
+		err := tmp.ExecuteEnrich(&buff, fns)
+		require.NoError(t, err)
+		ttopt := strings.Join(strings.Fields(opt), " ")
+		buffs := strings.Join(strings.Fields(buff.String()), " ")
+		assert.Equal(t, ttopt, buffs)
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		tmp := Function{
+			Service:     "Service",
+			Entity:      "Entities",
+			Prefix:      "Prefix",
+			Flatten:     "Entities",
+			FlattenItem: "Entity",
+			Enrich: &Enrich{
+				Name: "WithTags",
+				Type: "EntityWithTags",
+				FollowUps: []FollowUp{
+					FollowUp{
+						Attach:     "Tags",
+						Function:   "GetEntityTags",
+						From:       "Name",
+						To:         "Names",
+						Batch:      10,
+						Result:     "Descriptions",
+						ResultItem: "Description",
+						ResultKey:  "Name",
+					},
+				},
+			},
+		}
+
+		buff := bytes.Buffer{}
+		err := tmp.ExecuteEnrich(&buff, fns)
+		require.NoError(t, err)
+		assert.Contains(t, buff.String(), "Tags *Service.Description")
+		assert.Contains(t, buff.String(), "for i := 0; i < len(items); i += 10 {")
+		assert.Contains(t, buff.String(), "fin.Names = append(fin.Names, it.Item.Name)")
+		assert.Contains(t, buff.String(), "if aws.StringValue(it.Item.Name) == aws.StringValue(res.Name) {")
+	})
+
+	t.Run("NoEnrich", func(t *testing.T) {
+		buff := bytes.Buffer{}
+		err := Function{}.ExecuteEnrich(&buff, fns)
+		require.NoError(t, err)
+		assert.Empty(t, buff.String())
+	})
+
+	t.Run("NoFlatten", func(t *testing.T) {
+		tmp := Function{
+			Entity: "Entities",
+			Enrich: &Enrich{Name: "WithTags"},
+		}
+		err := tmp.ExecuteEnrich(&bytes.Buffer{}, fns)
+		assert.Error(t, err)
+	})
+
+	t.Run("UnknownFunction", func(t *testing.T) {
+		tmp := Function{
+			Entity:      "Entities",
+			Flatten:     "Entities",
+			FlattenItem: "Entity",
+			Enrich: &Enrich{
+				Name: "WithTags",
+				FollowUps: []FollowUp{
+					FollowUp{Function: "GetUnknown"},
+				},
+			},
+		}
+		err := tmp.ExecuteEnrich(&bytes.Buffer{}, fns)
+		assert.Error(t, err)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
@@ -28,6 +29,9 @@ import (
 // For avoiding by the callers the problem of if the returned map may be nil,
 // the function will always return a map instance, which will be of length 0
 // in case that there is not any successful request.
+// When the methods are called with a context of WithResultFunc, the results
+// are sent to its ResultFunc, region by region, instead of being returned
+// on the map, see Stream.
 type AWSReader interface {
 	// GetAccountID returns the current ID for the account used
 	GetAccountID() string
@@ -44,25 +48,96 @@ type AWSReader interface {
 }
 
 func (c *connector) GetInstances(ctx context.Context, input *ec2.DescribeInstancesInput) (map[string]ec2.DescribeInstancesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]ec2.DescribeInstancesOutput{}
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetInstances", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]ec2.DescribeInstancesOutput{}
 
-	for _, svc := range c.svcs {
-		if svc.ec2 == nil {
-			svc.ec2 = ec2.New(svc.session)
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetInstances", Operation: "DescribeInstances", Service: ec2.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt ec2.DescribeInstancesOutput
+				var n int
+				err := svc.ec2.DescribeInstancesPagesWithContext(ctx, input.(*ec2.DescribeInstancesInput), func(page *ec2.DescribeInstancesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetInstances", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetInstances", NewError(svc.region, ec2.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*ec2.DescribeInstancesOutput)
+			}
 		}
 
-		opt, err := svc.ec2.DescribeInstancesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, ec2.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]ec2.DescribeInstancesOutput)
+
+	return opts, err
+}
+
+// RegionInstance pairs a ec2.Instance with the region it belongs to
+type RegionInstance struct {
+	Region string
+	Item   *ec2.Instance
+}
+
+// ListAllInstances returns the items on the Reservations.Instances path of the GetInstances
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetInstances, so the items of the regions without
+// errors are always returned.
+func ListAllInstances(ctx context.Context, r AWSReader, input *ec2.DescribeInstancesInput) ([]RegionInstance, error) {
+	var items []RegionInstance
+
+	opts, err := r.GetInstances(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "Reservations.Instances") {
+			items = append(items, RegionInstance{Region: region, Item: v.(*ec2.Instance)})
+		}
 	}
 
-	return regionsOpts, nil
+	return items, err
+}
+
+// inventoryCalls are the calls made by Inventory
+var inventoryCalls = []inventoryCall{
+	{
+		method:  "GetInstances",
+		service: "ec2",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllInstances(ctx, r, nil)
+		},
+	},
+}
+
+// itemsPaths are the paths of the items of the outputs of the
+// methods, by method, used to count the items they fetch
+var itemsPaths = map[string]string{
+	"GetInstances": "Reservations.Instances",
+}
+
+func (c *CachedReader) GetInstances(ctx context.Context, input *ec2.DescribeInstancesInput) (map[string]ec2.DescribeInstancesOutput, error) {
+	var opts map[string]ec2.DescribeInstancesOutput
+	err := c.call(ctx, "GetInstances", ec2.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetInstances(ctx, input)
+	})
+	return opts, err
 }
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/cycloidio/raws"
+)
+
+// Code generated by github.com/cycloidio/raws/cmd; DO NOT EDIT
+
+var commands = []command{
+	{
+		service: "ec2",
+		name:    "instances",
+		fn:      "GetInstances",
+		input:   func() interface{} { return &ec2.DescribeInstancesInput{} },
+		call: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return r.GetInstances(ctx, input.(*ec2.DescribeInstancesInput))
+		},
+		list: func(ctx context.Context, r raws.AWSReader, input interface{}) (interface{}, error) {
+			return raws.ListAllInstances(ctx, r, input.(*ec2.DescribeInstancesInput))
+		},
+	},
+}
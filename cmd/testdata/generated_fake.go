@@ -0,0 +1,22 @@
+package rawsfake
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// Code generated by github.com/cycloidio/raws/cmd; DO NOT EDIT
+
+// itemTypes are the types of the items returned by the methods,
+// used to read the resources of the fixtures
+var itemTypes = []reflect.Type{
+	reflect.TypeOf((*ec2.Instance)(nil)),
+}
+
+func (r *Reader) GetInstances(ctx context.Context, input *ec2.DescribeInstancesInput) (map[string]ec2.DescribeInstancesOutput, error) {
+	var opts map[string]ec2.DescribeInstancesOutput
+	err := r.call(ctx, method{name: "GetInstances", service: ec2.ServiceName, flatten: "Reservations.Instances", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
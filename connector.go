@@ -5,30 +5,47 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
 	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
+	"github.com/aws/aws-sdk-go/service/configservice"
 	"github.com/aws/aws-sdk-go/service/configservice/configserviceiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elasticache"
 	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
+	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/aws/aws-sdk-go/service/route53resolver"
 	"github.com/aws/aws-sdk-go/service/route53resolver/route53resolveriface"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/aws/aws-sdk-go/service/ses"
 	"github.com/aws/aws-sdk-go/service/ses/sesiface"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 )
 
-//go:generate go run ./cmd/ -output generate.go
+//go:generate go run ./cmd/ -output generate.go -cli-output cmd/raws/commands.go -doc-output docs/reference.md -policy-output docs/iam-policy.json -fake-output rawsfake/generate.go
 
 // NewAWSReader returns an object which also contains the accountID and extend the different regions to use.
 //
@@ -40,8 +57,12 @@ import (
 // When calls are done through the Connector instance, then all regions will be called for those services.
 // Thus making requests to AWS much easier than through the different connectors/regions of its go SDK.
 //
-// The connections are not all established while instancing, but the various sessions are, this way connections are only
-// made for services that are called, otherwise only the sessions remain.
+// The connections are not all established while instancing, but the various sessions and the clients of all the
+// services are, as creating a client doesn't make any connection, this way connections are only made for services that
+// are called, and the methods can be called concurrently.
+//
+// The config is the one of the sessions of the services, and its HTTPClient is also used by the calls made to create
+// the reader, so they can be recorded and replayed with the recorder package.
 //
 // By setting customEndpoint to true indicate we are not using aws services but custom endpoint like min.io.
 // Function getting datas from aws like ec2.DescribeRegionsWithContext will be skipped.
@@ -54,9 +75,24 @@ import (
 //  * https://docs.aws.amazon.com/STS/latest/APIReference/CommonErrors.html
 func NewAWSReader(
 	ctx context.Context, accessKey string, secretKey string, regions []string, config *aws.Config, customEndpoint bool) (AWSReader, error) {
-	var c = connector{}
+	return NewAWSReaderWithOptions(ctx, accessKey, secretKey, regions, config, customEndpoint, ReaderOptions{})
+}
+
+// NewAWSReaderWithOptions returns the same AWSReader than NewAWSReader with the opts,
+// which are also used by the calls made to create it
+func NewAWSReaderWithOptions(
+	ctx context.Context, accessKey string, secretKey string, regions []string, config *aws.Config, customEndpoint bool, opts ReaderOptions,
+) (AWSReader, error) {
+	var c = connector{
+		interceptors:       opts.Interceptors,
+		methodInterceptors: opts.MethodInterceptors,
+		logger:             opts.Logger,
+		progress:           opts.Progress,
+		timeout:            opts.Timeout,
+		regionTimeout:      opts.RegionTimeout,
+	}
 
-	creds, ec2s, sts, err := configureAWS(accessKey, secretKey)
+	creds, ec2s, stss, err := configureAWS(accessKey, secretKey, config)
 	if err != nil {
 		return nil, err
 	}
@@ -65,8 +101,9 @@ func NewAWSReader(
 	// if customEndpoint true do not use aws services.
 	if customEndpoint {
 		c.regions = regions
+		c.log().Info("regions enabled on the custom endpoint", "regions", c.regions)
 	} else {
-		if err := c.setAccountID(ctx, sts); err != nil {
+		if err := c.setAccountID(ctx, stss); err != nil {
 			return nil, err
 		}
 		if err := c.setRegions(ctx, ec2s, regions); err != nil {
@@ -88,6 +125,13 @@ type connector struct {
 	svcs      []*serviceConnector
 	creds     *credentials.Credentials
 	accountID *string
+
+	interceptors       []Interceptor
+	methodInterceptors []MethodInterceptor
+	logger             Logger
+	progress           ProgressFunc
+	timeout            time.Duration
+	regionTimeout      time.Duration
 }
 
 func (c *connector) GetAccountID() string {
@@ -115,22 +159,50 @@ type serviceConnector struct {
 	route53         route53iface.Route53API
 	route53resolver route53resolveriface.Route53ResolverAPI
 	autoscaling     autoscalingiface.AutoScalingAPI
+	tagging         resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+}
+
+// newServiceConnector returns the serviceConnector of the region with
+// the clients of all the services created with the sess, so they aren't
+// created on the first calls, which could be made at the same time
+func newServiceConnector(region string, sess *session.Session) *serviceConnector {
+	return &serviceConnector{
+		region:          region,
+		session:         sess,
+		ec2:             ec2.New(sess),
+		elb:             elb.New(sess),
+		elbv2:           elbv2.New(sess),
+		rds:             rds.New(sess),
+		s3:              s3.New(sess),
+		s3downloader:    s3manager.NewDownloader(sess),
+		elasticache:     elasticache.New(sess),
+		configservice:   configservice.New(sess),
+		cloudfront:      cloudfront.New(sess),
+		iam:             iam.New(sess),
+		ses:             ses.New(sess),
+		route53:         route53.New(sess),
+		route53resolver: route53resolver.New(sess),
+		autoscaling:     autoscaling.New(sess),
+		tagging:         resourcegroupstaggingapi.New(sess),
+	}
 }
 
+/* The default region is only used to (1) get the list of region and
+ * (2) get the account ID associated with the credentials.
+ *
+ * It is not used as a default region for services, therefore if no
+ * region is specified when instantiating the connector, then it will
+ * not try to establish any connections with AWS services.
+ */
+const defaultRegion string = "eu-west-1"
+
 // configureAWS creates a new static credential with the passed accessKey and
 // secretKey and with it, a sessions which is used to create a EC2 client and
-// a Security Token Service client.
+// a Security Token Service client, which use the HTTPClient of the config
+// if it has one.
 // The only AWS error code that this function return is
 // * EmptyStaticCreds
-func configureAWS(accessKey string, secretKey string) (*credentials.Credentials, ec2iface.EC2API, stsiface.STSAPI, error) {
-	/* The default region is only used to (1) get the list of region and
-	 * (2) get the account ID associated with the credentials.
-	 *
-	 * It is not used as a default region for services, therefore if no
-	 * region is specified when instantiating the connector, then it will
-	 * not try to establish any connections with AWS services.
-	 */
-	const defaultRegion string = "eu-west-1"
+func configureAWS(accessKey string, secretKey string, config *aws.Config) (*credentials.Credentials, ec2iface.EC2API, stsiface.STSAPI, error) {
 	var token = ""
 
 	creds := credentials.NewStaticCredentials(accessKey, secretKey, token)
@@ -138,14 +210,16 @@ func configureAWS(accessKey string, secretKey string) (*credentials.Credentials,
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	sess := session.Must(
-		session.NewSession(&aws.Config{
-			Region:      aws.String(defaultRegion),
-			DisableSSL:  aws.Bool(false),
-			MaxRetries:  aws.Int(3),
-			Credentials: creds,
-		}),
-	)
+	cfg := &aws.Config{
+		Region:      aws.String(defaultRegion),
+		DisableSSL:  aws.Bool(false),
+		MaxRetries:  aws.Int(3),
+		Credentials: creds,
+	}
+	if config != nil && config.HTTPClient != nil {
+		cfg.HTTPClient = config.HTTPClient
+	}
+	sess := session.Must(session.NewSession(cfg))
 	return creds, ec2.New(sess), sts.New(sess), nil
 }
 
@@ -155,14 +229,20 @@ func configureAWS(accessKey string, secretKey string) (*credentials.Credentials,
 // A AWS error can be returned with one of the common error codes or a standard
 // go error if enabledRegions is empty or if 0 AWS regions has been matched.
 // See https://docs.aws.amazon.com/AWSEC2/latest/APIReference/errors-overview.html#CommonErrors
-func (c *connector) setRegions(ctx context.Context, ec2 ec2iface.EC2API, enabledRegions []string) error {
+func (c *connector) setRegions(ctx context.Context, svc ec2iface.EC2API, enabledRegions []string) error {
 	if len(enabledRegions) == 0 {
+		c.log().Error("no regions to enable")
 		return errors.New("at least one region name is required")
 	}
-	regions, err := ec2.DescribeRegionsWithContext(ctx, nil)
+	out, err := c.invoke(ctx, &Call{Method: "NewAWSReader", Operation: "DescribeRegions", Service: ec2.ServiceName, Region: defaultRegion, Input: (*ec2.DescribeRegionsInput)(nil)},
+		func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+			return svc.DescribeRegionsWithContext(ctx, input.(*ec2.DescribeRegionsInput), opts...)
+		})
 	if err != nil {
+		c.log().Error("failed to get the regions", "code", ErrorCode(err), "error", err)
 		return err
 	}
+	regions := out.(*ec2.DescribeRegionsOutput)
 	for _, enabledRegion := range enabledRegions {
 		for _, region := range regions.Regions {
 			if match, _ := filepath.Match(enabledRegion, *region.RegionName); match {
@@ -171,8 +251,10 @@ func (c *connector) setRegions(ctx context.Context, ec2 ec2iface.EC2API, enabled
 		}
 	}
 	if len(c.regions) == 0 {
+		c.log().Error("no regions matched", "enabled_regions", enabledRegions, "available_regions", len(regions.Regions))
 		return fmt.Errorf("found 0 regions matching: %v", enabledRegions)
 	}
+	c.log().Info("regions enabled", "regions", c.regions, "enabled_regions", enabledRegions)
 	return nil
 }
 
@@ -180,12 +262,17 @@ func (c *connector) setRegions(ctx context.Context, ec2 ec2iface.EC2API, enabled
 // it in the connector.
 // An AWS error can be returned with one of the common error codes.
 // See https://docs.aws.amazon.com/STS/latest/APIReference/CommonErrors.html
-func (c *connector) setAccountID(ctx context.Context, sts stsiface.STSAPI) error {
-	resp, err := sts.GetCallerIdentityWithContext(ctx, nil)
+func (c *connector) setAccountID(ctx context.Context, svc stsiface.STSAPI) error {
+	out, err := c.invoke(ctx, &Call{Method: "NewAWSReader", Operation: "GetCallerIdentity", Service: sts.ServiceName, Region: defaultRegion, Input: (*sts.GetCallerIdentityInput)(nil)},
+		func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+			return svc.GetCallerIdentityWithContext(ctx, input.(*sts.GetCallerIdentityInput), opts...)
+		})
 	if err != nil {
+		c.log().Error("failed to get the account", "code", ErrorCode(err), "error", err)
 		return err
 	}
-	c.accountID = resp.Account
+	c.accountID = out.(*sts.GetCallerIdentityOutput).Account
+	c.log().Info("account found", "account_id", aws.StringValue(c.accountID))
 	return nil
 }
 
@@ -202,10 +289,6 @@ func (c *connector) setServices(config *aws.Config) {
 	for _, region := range c.regions {
 		config.Region = aws.String(region)
 		sess := session.Must(session.NewSession(config))
-		svc := &serviceConnector{
-			region:  region,
-			session: sess,
-		}
-		c.svcs = append(c.svcs, svc)
+		c.svcs = append(c.svcs, newServiceConnector(region, sess))
 	}
 }
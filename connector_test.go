@@ -33,6 +33,10 @@ type mockEC2 struct {
 	// Mocking of DescribeRegions
 	dro   *ec2.DescribeRegionsOutput
 	drerr error
+
+	// Mocking of DescribeInstances
+	dio   *ec2.DescribeInstancesOutput
+	dierr error
 }
 
 func (m mockEC2) DescribeRegionsWithContext(
@@ -41,6 +45,17 @@ func (m mockEC2) DescribeRegionsWithContext(
 	return m.dro, m.drerr
 }
 
+func (m mockEC2) DescribeInstancesPagesWithContext(
+	_ aws.Context, _ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option,
+) error {
+	if m.dierr != nil {
+		return m.dierr
+	}
+
+	fn(m.dio, true)
+	return nil
+}
+
 func TestConnector_setRegion(t *testing.T) {
 	var ec2Regions = &ec2.DescribeRegionsOutput{
 		Regions: []*ec2.Region{
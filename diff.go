@@ -0,0 +1,415 @@
+package raws
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// VolatileFields are the fields of the resources which change without any
+// change of their configuration, like the launch time of the instances when
+// they are stopped and started, so they are ignored by default by Diff.
+// The fields are the paths, separated by dots, of the JSON of the Resource,
+// where the lists are walked element by element, so
+// "raw.NetworkInterfaces.Attachment.AttachTime" is the AttachTime of all
+// the network interfaces
+var VolatileFields = []string{
+	"created_at",
+	"raw.LaunchTime",
+	"raw.StateTransitionReason",
+	"raw.StateReason",
+	"raw.NetworkInterfaces.Attachment.AttachTime",
+	"raw.BlockDeviceMappings.Ebs.AttachTime",
+	"raw.Attachments.AttachTime",
+	"raw.AvailableIpAddressCount",
+	"raw.Progress",
+	"raw.PasswordLastUsed",
+	"raw.LatestRestorableTime",
+	"raw.AttachmentCount",
+	"raw.ResourceRecordSetCount",
+}
+
+// ListKeys are the fields identifying the elements of the lists of the
+// resources, by the path of the list, with the same format than
+// VolatileFields, so the elements with the same values on them are
+// compared with each other, like the rules of the security groups by
+// their protocol and ports, and only the ones added, removed or changed
+// are reported by Diff
+var ListKeys = map[string][]string{
+	"raw.Tags":                                 {"Key"},
+	"raw.IpPermissions":                        {"IpProtocol", "FromPort", "ToPort"},
+	"raw.IpPermissions.IpRanges":               {"CidrIp"},
+	"raw.IpPermissions.Ipv6Ranges":             {"CidrIpv6"},
+	"raw.IpPermissions.PrefixListIds":          {"PrefixListId"},
+	"raw.IpPermissions.UserIdGroupPairs":       {"UserId", "GroupId"},
+	"raw.IpPermissionsEgress":                  {"IpProtocol", "FromPort", "ToPort"},
+	"raw.IpPermissionsEgress.IpRanges":         {"CidrIp"},
+	"raw.IpPermissionsEgress.Ipv6Ranges":       {"CidrIpv6"},
+	"raw.IpPermissionsEgress.PrefixListIds":    {"PrefixListId"},
+	"raw.IpPermissionsEgress.UserIdGroupPairs": {"UserId", "GroupId"},
+	"raw.BlockDeviceMappings":                  {"DeviceName"},
+	"raw.NetworkInterfaces":                    {"NetworkInterfaceId"},
+	"raw.SecurityGroups":                       {"GroupId"},
+}
+
+// DiffOptions are the options of Diff
+type DiffOptions struct {
+	// Ignore are the fields, with the same format than VolatileFields,
+	// which are not compared. If it's nil VolatileFields is used, so an
+	// empty list has to be used to compare all the fields
+	Ignore []string
+
+	// ListKeys are the fields identifying the elements of the lists,
+	// with the same format than the ListKeys variable, which is used
+	// if it's nil
+	ListKeys map[string][]string
+}
+
+// SnapshotDiff is the difference between 2 Snapshots of the same account
+type SnapshotDiff struct {
+	AccountID string             `json:"account_id"`
+	From      time.Time          `json:"from"`
+	To        time.Time          `json:"to"`
+	Added     []Resource         `json:"added"`
+	Removed   []Resource         `json:"removed"`
+	Modified  []ResourceModified `json:"modified"`
+}
+
+// ResourceModified is a Resource which is on both
+// Snapshots with the changes of its fields
+type ResourceModified struct {
+	Resource Resource      `json:"resource"`
+	Changes  []FieldChange `json:"changes"`
+}
+
+// FieldChange is the change of a field of a Resource, Path is the path
+// of the field on the JSON of the Resource, like "tags.env" or
+// "raw.InstanceType", and From and To are its values, as generic JSON
+// values, nil if the field did not exist.
+// The lists are compared without taking into account the order of their
+// elements, at any depth. The elements identified by the ListKeys have
+// their values on the path, like "raw.IpPermissions[IpProtocol=tcp,
+// FromPort=22,ToPort=22].IpRanges[CidrIp=10.0.0.0/8]", so only the fields
+// changed of the elements on both lists are reported. The other elements
+// are reported on the path of the list, with only From if they have been
+// removed or only To if they have been added.
+type FieldChange struct {
+	Path string      `json:"path"`
+	From interface{} `json:"from,omitempty"`
+	To   interface{} `json:"to,omitempty"`
+}
+
+// Empty returns if there are no differences
+func (d *SnapshotDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// Diff returns the resources added, removed and modified on the Snapshot to
+// since the Snapshot from, identifying them by their Key.
+// The Resources returned on Modified are the ones of to.
+// An error is returned if the Snapshots are not from the same account.
+func Diff(from, to *Snapshot, opts DiffOptions) (*SnapshotDiff, error) {
+	if from.AccountID != to.AccountID {
+		return nil, fmt.Errorf("the snapshots are from different accounts: %s and %s", from.AccountID, to.AccountID)
+	}
+
+	ignore := opts.Ignore
+	if ignore == nil {
+		ignore = VolatileFields
+	}
+
+	keys := opts.ListKeys
+	if keys == nil {
+		keys = ListKeys
+	}
+
+	d := &SnapshotDiff{
+		AccountID: to.AccountID,
+		From:      from.StartedAt,
+		To:        to.StartedAt,
+	}
+
+	fres := resourcesByKey(from.Resources)
+	tres := resourcesByKey(to.Resources)
+
+	for _, k := range sortedKeys(fres) {
+		if _, ok := tres[k]; !ok {
+			d.Removed = append(d.Removed, fres[k])
+		}
+	}
+
+	for _, k := range sortedKeys(tres) {
+		fr, ok := fres[k]
+		if !ok {
+			d.Added = append(d.Added, tres[k])
+			continue
+		}
+
+		fv, err := genericResource(fr, ignore)
+		if err != nil {
+			return nil, err
+		}
+
+		tv, err := genericResource(tres[k], ignore)
+		if err != nil {
+			return nil, err
+		}
+
+		var changes []FieldChange
+		diffValues("", "", fv, tv, keys, &changes)
+		if len(changes) > 0 {
+			d.Modified = append(d.Modified, ResourceModified{Resource: tres[k], Changes: changes})
+		}
+	}
+
+	return d, nil
+}
+
+func resourcesByKey(res []Resource) map[string]Resource {
+	m := make(map[string]Resource, len(res))
+	for _, r := range res {
+		m[r.Key()] = r
+	}
+
+	return m
+}
+
+func sortedKeys(m map[string]Resource) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// genericResource returns r as a generic JSON value, so the resources
+// read from a Snapshot and the ones built from the SDK items can be
+// compared, without the ignore fields
+func genericResource(r Resource, ignore []string) (interface{}, error) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	err = json.Unmarshal(b, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range ignore {
+		removePath(v, strings.Split(p, "."))
+	}
+
+	return v, nil
+}
+
+// removePath removes from the generic JSON value v the field
+// on the path, walking all the elements of the lists
+func removePath(v interface{}, path []string) {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			delete(vv, path[0])
+			return
+		}
+		removePath(vv[path[0]], path[1:])
+	case []interface{}:
+		for _, e := range vv {
+			removePath(e, path)
+		}
+	}
+}
+
+// diffValues appends to changes the differences between the generic
+// JSON values a and b, which are on the path, and on the field, which is
+// the path without the values of the elements of the lists, used to find
+// the keys of the lists
+func diffValues(path, field string, a, b interface{}, keys map[string][]string, changes *[]FieldChange) {
+	am, aok := a.(map[string]interface{})
+	bm, bok := b.(map[string]interface{})
+	if aok && bok {
+		fields := make(map[string]struct{})
+		for k := range am {
+			fields[k] = struct{}{}
+		}
+		for k := range bm {
+			fields[k] = struct{}{}
+		}
+
+		sorted := make([]string, 0, len(fields))
+		for k := range fields {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+
+		for _, k := range sorted {
+			p, f := k, k
+			if path != "" {
+				p, f = path+"."+k, field+"."+k
+			}
+			diffValues(p, f, am[k], bm[k], keys, changes)
+		}
+		return
+	}
+
+	al, aok := a.([]interface{})
+	bl, bok := b.([]interface{})
+	if aok && bok {
+		diffLists(path, field, al, bl, keys, changes)
+		return
+	}
+
+	if canonical(a) != canonical(b) {
+		*changes = append(*changes, FieldChange{Path: path, From: a, To: b})
+	}
+}
+
+// diffLists appends to changes the differences between the elements of
+// the lists a and b, in any order, which are on the path and the field.
+// The elements with the keys of the field are compared with the element
+// of the other list with the same values on them, and the others with
+// their canonical JSON, so they are only added or removed
+func diffLists(path, field string, a, b []interface{}, keys map[string][]string, changes *[]FieldChange) {
+	var (
+		aids    = make(map[string]interface{})
+		bids    = make(map[string]interface{})
+		arest   = make(map[string]int)
+		brest   = make(map[string]int)
+		avalues = make(map[string]interface{})
+		bvalues = make(map[string]interface{})
+	)
+	classify := func(l []interface{}, ids map[string]interface{}, rest map[string]int, values map[string]interface{}) {
+		for _, e := range l {
+			if id, ok := elementID(e, keys[field]); ok {
+				if _, dup := ids[id]; !dup {
+					ids[id] = e
+					continue
+				}
+			}
+			c := canonical(e)
+			rest[c]++
+			values[c] = e
+		}
+	}
+	classify(a, aids, arest, avalues)
+	classify(b, bids, brest, bvalues)
+
+	for _, id := range sortedIDs(aids, bids) {
+		ae, aok := aids[id]
+		be, bok := bids[id]
+		p := path + "[" + id + "]"
+		switch {
+		case !bok:
+			*changes = append(*changes, FieldChange{Path: p, From: ae})
+		case !aok:
+			*changes = append(*changes, FieldChange{Path: p, To: be})
+		default:
+			diffValues(p, field, ae, be, keys, changes)
+		}
+	}
+
+	for _, c := range sortedCanonicals(arest) {
+		for n := brest[c]; n < arest[c]; n++ {
+			*changes = append(*changes, FieldChange{Path: path, From: avalues[c]})
+		}
+	}
+	for _, c := range sortedCanonicals(brest) {
+		for n := arest[c]; n < brest[c]; n++ {
+			*changes = append(*changes, FieldChange{Path: path, To: bvalues[c]})
+		}
+	}
+}
+
+// elementID returns the values of the keys on the element e, like
+// "IpProtocol=tcp,FromPort=22,ToPort=22", which has to be an object
+// with at least one of the keys
+func elementID(e interface{}, keys []string) (string, bool) {
+	m, ok := e.(map[string]interface{})
+	if !ok || len(keys) == 0 {
+		return "", false
+	}
+
+	var parts []string
+	for _, k := range keys {
+		v, ok := m[k]
+		if !ok || v == nil {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s=%v", k, v))
+	}
+
+	return strings.Join(parts, ","), len(parts) > 0
+}
+
+func sortedIDs(a, b map[string]interface{}) []string {
+	ids := make([]string, 0, len(a)+len(b))
+	for id := range a {
+		ids = append(ids, id)
+	}
+	for id := range b {
+		if _, ok := a[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+func sortedCanonicals(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// canonical returns the JSON of the generic JSON value v where the
+// elements of the lists, at any depth, are sorted by their own
+// canonical JSON, so the values equal in any order have the same one
+func canonical(v interface{}) string {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(vv))
+		for k := range vv {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var b strings.Builder
+		b.WriteString("{")
+		for i, k := range keys {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			// The keys can always be marshaled
+			kb, _ := json.Marshal(k)
+			b.Write(kb)
+			b.WriteString(":")
+			b.WriteString(canonical(vv[k]))
+		}
+		b.WriteString("}")
+
+		return b.String()
+	case []interface{}:
+		es := make([]string, 0, len(vv))
+		for _, e := range vv {
+			es = append(es, canonical(e))
+		}
+		sort.Strings(es)
+
+		return "[" + strings.Join(es, ",") + "]"
+	default:
+		// The generic JSON values can always be marshaled
+		b, _ := json.Marshal(v)
+
+		return string(b)
+	}
+}
@@ -0,0 +1,244 @@
+package raws
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestDiff(t *testing.T) {
+	var (
+		t1 = time.Date(2019, 6, 7, 10, 0, 0, 0, time.UTC)
+		t2 = time.Date(2019, 6, 8, 10, 0, 0, 0, time.UTC)
+	)
+
+	instance := func(id, typ string, launch time.Time, tags map[string]string) Resource {
+		i := &ec2.Instance{
+			InstanceId:   aws.String(id),
+			InstanceType: aws.String(typ),
+			LaunchTime:   &launch,
+		}
+		for k, v := range tags {
+			i.Tags = append(i.Tags, &ec2.Tag{Key: aws.String(k), Value: aws.String(v)})
+		}
+		r, _ := NewResource("123", "eu-west-1", i)
+		return r
+	}
+
+	sg := func(id string, ports ...int64) Resource {
+		g := &ec2.SecurityGroup{GroupId: aws.String(id)}
+		for _, p := range ports {
+			g.IpPermissions = append(g.IpPermissions, &ec2.IpPermission{FromPort: aws.Int64(p), ToPort: aws.Int64(p)})
+		}
+		r, _ := NewResource("123", "eu-west-1", g)
+		return r
+	}
+
+	from := &Snapshot{
+		AccountID: "123",
+		StartedAt: t1,
+		Resources: []Resource{
+			instance("i-1", "t2.micro", t1, map[string]string{"env": "prod"}),
+			instance("i-2", "t2.micro", t1, nil),
+			sg("sg-1", 22, 443),
+			sg("sg-2", 22),
+		},
+	}
+
+	to := &Snapshot{
+		AccountID: "123",
+		StartedAt: t2,
+		Resources: []Resource{
+			instance("i-1", "t2.large", t2, map[string]string{"env": "dev", "team": "data"}),
+			instance("i-3", "t2.micro", t2, nil),
+			sg("sg-1", 443, 22),
+			sg("sg-2", 22, 80),
+		},
+	}
+
+	tests := []struct {
+		name             string
+		opts             DiffOptions
+		expectedAdded    []string
+		expectedRemoved  []string
+		expectedModified map[string][]string
+	}{
+		{name: "volatile fields ignored",
+			opts:            DiffOptions{},
+			expectedAdded:   []string{"i-3"},
+			expectedRemoved: []string{"i-2"},
+			expectedModified: map[string][]string{
+				"i-1":  {"raw.InstanceType", "raw.Tags[Key=env].Value", "raw.Tags[Key=team]", "tags.env", "tags.team"},
+				"sg-2": {"raw.IpPermissions[FromPort=80,ToPort=80]"},
+			},
+		},
+		{name: "all the fields",
+			opts:            DiffOptions{Ignore: []string{}},
+			expectedAdded:   []string{"i-3"},
+			expectedRemoved: []string{"i-2"},
+			expectedModified: map[string][]string{
+				"i-1":  {"created_at", "raw.InstanceType", "raw.LaunchTime", "raw.Tags[Key=env].Value", "raw.Tags[Key=team]", "tags.env", "tags.team"},
+				"sg-2": {"raw.IpPermissions[FromPort=80,ToPort=80]"},
+			},
+		}}
+
+	for i, tt := range tests {
+		d, err := Diff(from, to, tt.opts)
+		checkErrors(t, tt.name, i, err, nil)
+
+		var added, removed []string
+		for _, r := range d.Added {
+			added = append(added, r.ID)
+		}
+		for _, r := range d.Removed {
+			removed = append(removed, r.ID)
+		}
+		modified := make(map[string][]string)
+		for _, m := range d.Modified {
+			for _, c := range m.Changes {
+				modified[m.Resource.ID] = append(modified[m.Resource.ID], c.Path)
+			}
+		}
+
+		if !reflect.DeepEqual(added, tt.expectedAdded) {
+			t.Errorf("%s [%d] - added: received=%+v | expected=%+v", tt.name, i, added, tt.expectedAdded)
+		}
+		if !reflect.DeepEqual(removed, tt.expectedRemoved) {
+			t.Errorf("%s [%d] - removed: received=%+v | expected=%+v", tt.name, i, removed, tt.expectedRemoved)
+		}
+		if !reflect.DeepEqual(modified, tt.expectedModified) {
+			t.Errorf("%s [%d] - modified: received=%+v | expected=%+v", tt.name, i, modified, tt.expectedModified)
+		}
+	}
+
+	// The resources read from a snapshot are equal to the
+	// ones they have been written from
+	var buff bytes.Buffer
+	from.Version = SnapshotVersion
+	if err := from.Write(&buff); err != nil {
+		t.Fatalf("write - errors: received=%+v | expected=nil", err)
+	}
+	read, err := ReadSnapshot(&buff)
+	if err != nil {
+		t.Fatalf("read - errors: received=%+v | expected=nil", err)
+	}
+	d, err := Diff(from, read, DiffOptions{})
+	if err != nil || !d.Empty() {
+		t.Errorf("read - diff: received=%+v | expected=empty", d)
+	}
+
+	_, err = Diff(from, &Snapshot{AccountID: "456"}, DiffOptions{})
+	if err == nil {
+		t.Errorf("accounts - errors: received=nil | expected=error")
+	}
+}
+
+func TestDiff_lists(t *testing.T) {
+	rule := func(proto string, port int64, cidrs []string, groups ...string) *ec2.IpPermission {
+		p := &ec2.IpPermission{IpProtocol: aws.String(proto), FromPort: aws.Int64(port), ToPort: aws.Int64(port)}
+		for _, c := range cidrs {
+			p.IpRanges = append(p.IpRanges, &ec2.IpRange{CidrIp: aws.String(c)})
+		}
+		for _, g := range groups {
+			p.UserIdGroupPairs = append(p.UserIdGroupPairs, &ec2.UserIdGroupPair{GroupId: aws.String(g), UserId: aws.String("123")})
+		}
+		return p
+	}
+
+	snapshot := func(rules ...*ec2.IpPermission) *Snapshot {
+		g := &ec2.SecurityGroup{
+			GroupId:       aws.String("sg-1"),
+			IpPermissions: rules,
+		}
+		r, _ := NewResource("123", "eu-west-1", g)
+		return &Snapshot{AccountID: "123", Resources: []Resource{r}}
+	}
+
+	from := snapshot(
+		rule("tcp", 22, []string{"10.0.0.0/8", "192.168.0.0/16"}, "sg-2", "sg-3"),
+		rule("tcp", 443, []string{"0.0.0.0/0"}),
+	)
+
+	tests := []struct {
+		name     string
+		to       *Snapshot
+		expected []FieldChange
+	}{
+		{name: "reordered nested lists",
+			to: snapshot(
+				rule("tcp", 443, []string{"0.0.0.0/0"}),
+				rule("tcp", 22, []string{"192.168.0.0/16", "10.0.0.0/8"}, "sg-3", "sg-2"),
+			),
+		},
+		{name: "changed rule",
+			to: snapshot(
+				rule("tcp", 22, []string{"10.0.0.0/8"}, "sg-3", "sg-2"),
+				rule("tcp", 443, []string{"0.0.0.0/0"}),
+			),
+			expected: []FieldChange{
+				{
+					Path: "raw.IpPermissions[IpProtocol=tcp,FromPort=22,ToPort=22].IpRanges[CidrIp=192.168.0.0/16]",
+					From: map[string]interface{}{"CidrIp": "192.168.0.0/16"},
+				},
+			},
+		},
+		{name: "added and removed rules",
+			to: snapshot(
+				rule("tcp", 22, []string{"10.0.0.0/8", "192.168.0.0/16"}, "sg-2", "sg-3"),
+				rule("udp", 53, []string{"10.0.0.0/8"}),
+			),
+			expected: []FieldChange{
+				{
+					Path: "raw.IpPermissions[IpProtocol=tcp,FromPort=443,ToPort=443]",
+					From: map[string]interface{}{
+						"IpProtocol": "tcp", "FromPort": float64(443), "ToPort": float64(443),
+						"IpRanges": []interface{}{map[string]interface{}{"CidrIp": "0.0.0.0/0"}},
+					},
+				},
+				{
+					Path: "raw.IpPermissions[IpProtocol=udp,FromPort=53,ToPort=53]",
+					To: map[string]interface{}{
+						"IpProtocol": "udp", "FromPort": float64(53), "ToPort": float64(53),
+						"IpRanges": []interface{}{map[string]interface{}{"CidrIp": "10.0.0.0/8"}},
+					},
+				},
+			},
+		},
+		{name: "lists without keys",
+			to: func() *Snapshot {
+				s := snapshot(
+					rule("tcp", 22, []string{"10.0.0.0/8", "192.168.0.0/16"}, "sg-2", "sg-3"),
+					rule("tcp", 443, []string{"0.0.0.0/0"}),
+				)
+				s.Resources[0].Raw.(*ec2.SecurityGroup).IpPermissions[0].IpRanges[0].CidrIp = nil
+				return s
+			}(),
+			expected: []FieldChange{
+				{
+					Path: "raw.IpPermissions[IpProtocol=tcp,FromPort=22,ToPort=22].IpRanges[CidrIp=10.0.0.0/8]",
+					From: map[string]interface{}{"CidrIp": "10.0.0.0/8"},
+				},
+				{
+					Path: "raw.IpPermissions[IpProtocol=tcp,FromPort=22,ToPort=22].IpRanges",
+					To:   map[string]interface{}{},
+				},
+			},
+		}}
+
+	for i, tt := range tests {
+		d, err := Diff(from, tt.to, DiffOptions{})
+		checkErrors(t, tt.name, i, err, nil)
+
+		var changes []FieldChange
+		for _, m := range d.Modified {
+			changes = append(changes, m.Changes...)
+		}
+		if !reflect.DeepEqual(changes, tt.expected) {
+			t.Errorf("%s [%d] - changes: received=%+v | expected=%+v", tt.name, i, changes, tt.expected)
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package raws
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
 )
 
 // Error is a type which satisfied the standard error interface, but provides
@@ -45,6 +48,23 @@ func (e Error) Service() string {
 	return e.service
 }
 
+// Unwrap returns the error returned by the AWS SDK, so its
+// awserr.Error can be found with errors.As
+func (e Error) Unwrap() error {
+	return e.err
+}
+
+// ErrorCode returns the code of the AWS error err, which can be wrapped
+// on an Error, or an empty string if it isn't an AWS error
+func ErrorCode(err error) string {
+	var aerr awserr.Error
+	if errors.As(err, &aerr) {
+		return aerr.Code()
+	}
+
+	return ""
+}
+
 // Errors type satisfies the standard error interface, thus allowing us to return
 // an error when doing multiple call via the Go AWS SDK, even though multiple errors
 // are met.
@@ -67,6 +87,17 @@ func (e Errors) Error() string {
 	return fmt.Sprintf("%d error(s) occurred.\n\t%s", len(e), strings.Join(details, "\n\t"))
 }
 
+// asErrors returns err if it's Errors, or otherwise err as the only
+// Error of the service, without region, like the errors returned by
+// the MethodInterceptors which don't return Errors
+func asErrors(service string, err error) Errors {
+	if errs, ok := err.(Errors); ok {
+		return errs
+	}
+
+	return Errors{NewError("", service, err)}
+}
+
 // ErrorIn inspects err to find if there is an error in the region and returns it
 // or them (in case of multiple), otherwise it returns nil.
 // err must be of the type Error or Errors in order to be able to find if there
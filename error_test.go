@@ -3,6 +3,8 @@ package raws
 import (
 	"errors"
 	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
 )
 
 func TestErrors_Error(t *testing.T) {
@@ -142,6 +144,61 @@ func TestError_Service(t *testing.T) {
 	}
 }
 
+func TestError_Unwrap(t *testing.T) {
+	var (
+		awsErr       = awserr.New("NoSuchEntity", "not found", nil)
+		err    error = NewError("region-1", "service-1", awsErr)
+		target awserr.Error
+	)
+
+	if !errors.As(err, &target) || target != awsErr {
+		t.Errorf("unwrap - error: received=%+v | expected=%+v", target, awsErr)
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    error
+		expected string
+	}{
+		{
+			name:     "nil error",
+			input:    nil,
+			expected: "",
+		},
+		{
+			name:     "not an AWS error",
+			input:    errors.New("error"),
+			expected: "",
+		},
+		{
+			name:     "AWS error",
+			input:    awserr.New("Throttling", "rate exceeded", nil),
+			expected: "Throttling",
+		},
+		{
+			name:     "AWS error on an Error",
+			input:    NewError("region-1", "service-1", awserr.New("NoSuchEntity", "not found", nil)),
+			expected: "NoSuchEntity",
+		},
+		{
+			name: "Errors",
+			input: Errors{
+				NewError("region-1", "service-1", awserr.New("AccessDenied", "denied", nil)),
+			},
+			expected: "",
+		},
+	}
+
+	for i, tt := range tests {
+		if code := ErrorCode(tt.input); code != tt.expected {
+			t.Errorf("%s [%d] - code: received=%+v | expected=%+v",
+				tt.name, i, code, tt.expected)
+		}
+	}
+}
+
 func TestErrorIn(t *testing.T) {
 	t.Run("when the input error is nil", func(t *testing.T) {
 		var err = ErrorIn("some-region", nil)
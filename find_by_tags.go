@@ -0,0 +1,209 @@
+package raws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// taggingAPIUnsupported are the services whose resources
+// are not returned by the Resource Groups Tagging API
+var taggingAPIUnsupported = []string{"iam"}
+
+// FindByTagsOptions are the options of FindByTags
+type FindByTagsOptions struct {
+	// Services are the services, like "ec2" or "iam", of the resources
+	// to find, if it's empty the resources of all the services are found
+	Services []string
+
+	// NoTaggingAPI disables the Resource Groups Tagging API, so
+	// all the resources are found with the per service methods
+	NoTaggingAPI bool
+}
+
+// FindByTags returns the resources of all the regions of r with the
+// tags matching the query, which is parsed with ParseTagQuery.
+//
+// The resources are found with the Resource Groups Tagging API
+// (GetTaggedResources), which returns the tags of the resources of most of
+// the services with a single call per region, filtering by the conditions of
+// the query which must always be true. The resources of the services which it
+// doesn't support, like IAM, and all the resources of the regions on which it
+// fails, like when it's not allowed by the IAM policy, are found with the same
+// methods used by Inventory and the tags they return, or, for the S3 buckets,
+// ElastiCache clusters and IAM users and roles, whose methods don't return
+// them, the tags returned by GetBucketTags, GetElastiCacheTags, GetUserTags
+// and GetRoleTags.
+//
+// The Resource Groups Tagging API only returns the resources which have, or
+// had, tags, and the Raw of their Resource is the
+// *resourcegroupstaggingapi.ResourceTagMapping with the ARN and the tags, so
+// NoTaggingAPI has to be used to find resources without any tag, like
+// "NOT env", or to have the SDK items of the resources.
+//
+// The resources found are always returned, with the errors of the calls as
+// Errors, as Inventory does.
+func FindByTags(ctx context.Context, r AWSReader, query string, opts FindByTagsOptions) ([]Resource, error) {
+	q, err := ParseTagQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		res  []Resource
+		errs Errors
+		seen = make(map[string]bool)
+
+		// fallback are the regions on which the resources
+		// are found with the per service methods
+		fallback = make(map[string]bool)
+	)
+
+	add := func(rs ...Resource) {
+		for _, rr := range rs {
+			if seen[rr.Key()] || !q.Match(rr.Tags) {
+				continue
+			}
+			if len(opts.Services) > 0 && !containsString(opts.Services, rr.Service) {
+				continue
+			}
+
+			seen[rr.Key()] = true
+			res = append(res, rr)
+		}
+	}
+
+	if opts.NoTaggingAPI {
+		for _, region := range r.GetRegions() {
+			fallback[region] = true
+		}
+	} else {
+		items, err := ListAllTaggedResources(ctx, r, &resourcegroupstaggingapi.GetResourcesInput{
+			TagFilters: q.tagFilters(),
+		})
+		if err != nil {
+			rerrs, ok := err.(Errors)
+			if !ok {
+				return nil, err
+			}
+
+			for _, e := range rerrs {
+				fallback[e.Region()] = true
+			}
+		}
+
+		for _, i := range items {
+			rr, err := NewResource(r.GetAccountID(), i.Region, i.Item)
+			if err != nil {
+				// The ARNs not supported by the SDK
+				// can not be converted to Resources
+				continue
+			}
+			add(rr)
+		}
+	}
+
+	// The methods are only called for the services
+	// and regions not covered by the tagging API
+	services := opts.Services
+	if len(fallback) == 0 {
+		services = nil
+		for _, s := range taggingAPIUnsupported {
+			if len(opts.Services) == 0 || containsString(opts.Services, s) {
+				services = append(services, s)
+			}
+		}
+
+		if len(services) == 0 {
+			return res, nil
+		}
+	}
+
+	s, err := Inventory(ctx, r, InventoryOptions{Services: services})
+	if err != nil {
+		ierrs, ok := err.(Errors)
+		if !ok {
+			return res, err
+		}
+		errs = append(errs, ierrs...)
+	}
+
+	for _, rr := range s.Resources {
+		if seen[rr.Key()] {
+			continue
+		}
+		if fallback[rr.Region] || rr.Region == "" || containsString(taggingAPIUnsupported, rr.Service) {
+			tags, err := resourceTags(ctx, r, rr)
+			if err != nil {
+				errs = append(errs, asErrors(rr.Service, err)...)
+				continue
+			}
+			rr.Tags = tags
+			add(rr)
+		}
+	}
+
+	if errs != nil {
+		return res, errs
+	}
+
+	return res, nil
+}
+
+// resourceTags returns the tags of rr, which are read with the tag method
+// of its service for the Resources whose items don't have them, or the
+// tags of rr otherwise
+func resourceTags(ctx context.Context, r AWSReader, rr Resource) (map[string]string, error) {
+	if rr.Tags != nil {
+		return rr.Tags, nil
+	}
+
+	// The resources of the global services don't have a
+	// region, so their calls are made on the first one
+	region := rr.Region
+	if region == "" {
+		regions := ContextRegions(ctx, r.GetRegions())
+		if len(regions) == 0 {
+			return nil, nil
+		}
+		region = regions[0]
+	}
+	ctx = WithRegions(ctx, region)
+
+	switch rr.Service + ":" + rr.Type {
+	case "s3:bucket":
+		out, err := r.GetBucketTags(ctx, &s3.GetBucketTaggingInput{Bucket: aws.String(rr.ID)})
+		if err != nil {
+			// The buckets without tags don't have a tag set
+			if errs, ok := err.(Errors); ok && len(errs) == 1 && ErrorCode(errs[0]) == "NoSuchTagSet" {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return tagsOf(out[region].TagSet), nil
+	case "elasticache:cache-cluster":
+		out, err := r.GetElastiCacheTags(ctx, &elasticache.ListTagsForResourceInput{ResourceName: aws.String(rr.ARN)})
+		if err != nil {
+			return nil, err
+		}
+		return tagsOf(out[region].TagList), nil
+	case "iam:user":
+		out, err := r.GetUserTags(ctx, &iam.ListUserTagsInput{UserName: aws.String(rr.Name)})
+		if err != nil {
+			return nil, err
+		}
+		return tagsOf(out[region].Tags), nil
+	case "iam:role":
+		out, err := r.GetRoleTags(ctx, &iam.ListRoleTagsInput{RoleName: aws.String(rr.Name)})
+		if err != nil {
+			return nil, err
+		}
+		return tagsOf(out[region].Tags), nil
+	}
+
+	return rr.Tags, nil
+}
@@ -0,0 +1,289 @@
+package raws
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+type mockTagging struct {
+	resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+
+	// Mocking of GetResourcesPages, which returns each
+	// output as a page, and keeps the inputs on gri
+	gri   *[]*resourcegroupstaggingapi.GetResourcesInput
+	gro   []*resourcegroupstaggingapi.GetResourcesOutput
+	grerr error
+}
+
+func (m mockTagging) GetResourcesPagesWithContext(
+	_ aws.Context, input *resourcegroupstaggingapi.GetResourcesInput,
+	fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool, _ ...request.Option,
+) error {
+	*m.gri = append(*m.gri, input)
+	if m.grerr != nil {
+		return m.grerr
+	}
+
+	for i, o := range m.gro {
+		if !fn(o, i == len(m.gro)-1) {
+			break
+		}
+	}
+
+	return nil
+}
+
+type mockS3 struct {
+	s3iface.S3API
+
+	// Mocking of GetBucketTagging, which returns the tags by
+	// the name of the bucket, or NoSuchTagSet if it has none
+	gbto map[string][]*s3.Tag
+}
+
+func (m mockS3) GetBucketTaggingWithContext(_ aws.Context, input *s3.GetBucketTaggingInput, _ ...request.Option) (*s3.GetBucketTaggingOutput, error) {
+	tags, ok := m.gbto[aws.StringValue(input.Bucket)]
+	if !ok {
+		return nil, awserr.New("NoSuchTagSet", "The TagSet does not exist", nil)
+	}
+
+	return &s3.GetBucketTaggingOutput{TagSet: tags}, nil
+}
+
+type mockElastiCache struct {
+	elasticacheiface.ElastiCacheAPI
+
+	// Mocking of ListTagsForResource, which
+	// returns the tags by the ARN of the resource
+	ltfro map[string][]*elasticache.Tag
+}
+
+func (m mockElastiCache) ListTagsForResourceWithContext(
+	_ aws.Context, input *elasticache.ListTagsForResourceInput, _ ...request.Option,
+) (*elasticache.TagListMessage, error) {
+	return &elasticache.TagListMessage{TagList: m.ltfro[aws.StringValue(input.ResourceName)]}, nil
+}
+
+func TestFindByTags(t *testing.T) {
+	var (
+		inputs []*resourcegroupstaggingapi.GetResourcesInput
+		tag    = func(k, v string) []*resourcegroupstaggingapi.Tag {
+			return []*resourcegroupstaggingapi.Tag{{Key: aws.String(k), Value: aws.String(v)}}
+		}
+		tagging = mockTagging{
+			gri: &inputs,
+			gro: []*resourcegroupstaggingapi.GetResourcesOutput{
+				{ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+					{ResourceARN: aws.String("arn:aws:ec2:eu-west-1:123:instance/i-1"), Tags: tag("env", "prod")},
+				}},
+				{ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+					{ResourceARN: aws.String("arn:aws:rds:eu-west-1:123:db:db-1"), Tags: tag("env", "prod")},
+					{ResourceARN: aws.String("arn:aws:ec2:eu-west-1:123:instance/i-2"), Tags: tag("env", "dev")},
+				}},
+			},
+		}
+		r = &connector{
+			regions:   []string{"eu-west-1", "eu-west-3"},
+			accountID: aws.String("123"),
+			svcs: []*serviceConnector{
+				{region: "eu-west-1", tagging: tagging},
+				{region: "eu-west-3", tagging: mockTagging{gri: &inputs, grerr: errors.New("AccessDenied")}},
+			},
+		}
+		ec2Tags = []*ec2.Tag{{Key: aws.String("env"), Value: aws.String("prod")}}
+		err1    = NewError("eu-west-3", "iam", errors.New("error"))
+	)
+
+	calls := inventoryCalls
+	defer func() { inventoryCalls = calls }()
+	inventoryCalls = []inventoryCall{
+		{
+			method:  "GetInstances",
+			service: "ec2",
+			call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+				return []RegionInstance{
+					{Region: "eu-west-1", Item: &ec2.Instance{InstanceId: aws.String("i-1"), Tags: ec2Tags}},
+					{Region: "eu-west-3", Item: &ec2.Instance{InstanceId: aws.String("i-3"), Tags: ec2Tags}},
+				}, nil
+			},
+		},
+		{
+			method:  "GetRolesWithPolicies",
+			service: "iam",
+			call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+				role := &iam.Role{RoleId: aws.String("AROA1"), Arn: aws.String("arn:aws:iam::123:role/admin"), Tags: []*iam.Tag{{Key: aws.String("env"), Value: aws.String("prod")}}}
+				return map[string][]RoleWithPolicies{"eu-west-1": {{Item: role}}}, Errors{err1}
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		opts          FindByTagsOptions
+		expectedIDs   []string
+		expectedError error
+	}{
+		{name: "tagging API with fallback",
+			opts:          FindByTagsOptions{},
+			expectedIDs:   []string{"i-1", "db-1", "i-3", "AROA1"},
+			expectedError: Errors{err1},
+		},
+		{name: "some services",
+			opts:        FindByTagsOptions{Services: []string{"ec2"}},
+			expectedIDs: []string{"i-1", "i-3"},
+		},
+		{name: "without the tagging API",
+			opts:          FindByTagsOptions{NoTaggingAPI: true},
+			expectedIDs:   []string{"i-1", "i-3", "AROA1"},
+			expectedError: Errors{err1},
+		}}
+
+	for i, tt := range tests {
+		inputs = nil
+
+		res, err := FindByTags(context.Background(), r, "env=prod", tt.opts)
+		checkErrors(t, tt.name, i, err, tt.expectedError)
+
+		var ids []string
+		for _, rr := range res {
+			ids = append(ids, rr.ID)
+		}
+		if !reflect.DeepEqual(ids, tt.expectedIDs) {
+			t.Errorf("%s [%d] - IDs: received=%+v | expected=%+v", tt.name, i, ids, tt.expectedIDs)
+		}
+
+		for _, in := range inputs {
+			expected := []*resourcegroupstaggingapi.TagFilter{{Key: aws.String("env"), Values: []*string{aws.String("prod")}}}
+			if !reflect.DeepEqual(in.TagFilters, expected) {
+				t.Errorf("%s [%d] - filters: received=%+v | expected=%+v", tt.name, i, in.TagFilters, expected)
+			}
+		}
+		if tt.opts.NoTaggingAPI && len(inputs) > 0 {
+			t.Errorf("%s [%d] - tagging API: received=%d calls | expected=0", tt.name, i, len(inputs))
+		}
+	}
+
+	_, err := FindByTags(context.Background(), r, "env=", FindByTagsOptions{})
+	if err == nil {
+		t.Errorf("query - errors: received=nil | expected=error")
+	}
+}
+
+func TestFindByTags_tags(t *testing.T) {
+	var (
+		prod = map[string]*string{"env": aws.String("prod")}
+		r    = &connector{
+			regions:   []string{"eu-west-1", "eu-west-3"},
+			accountID: aws.String("123"),
+			svcs: []*serviceConnector{
+				{
+					region: "eu-west-1",
+					s3: mockS3{gbto: map[string][]*s3.Tag{
+						"bucket-1": {{Key: aws.String("env"), Value: prod["env"]}},
+					}},
+					elasticache: mockElastiCache{ltfro: map[string][]*elasticache.Tag{
+						"arn:aws:elasticache:eu-west-1:123:cluster:cluster-1": {{Key: aws.String("env"), Value: prod["env"]}},
+					}},
+					iam: mockIAM{
+						lrto: map[string][]*iam.Tag{"admin": {{Key: aws.String("env"), Value: prod["env"]}}},
+						luto: map[string][]*iam.Tag{"alice": {{Key: aws.String("env"), Value: prod["env"]}}},
+					},
+				},
+				{
+					region: "eu-west-3",
+					s3: mockS3{gbto: map[string][]*s3.Tag{
+						"bucket-3": {{Key: aws.String("env"), Value: aws.String("dev")}},
+					}},
+				},
+			},
+		}
+	)
+
+	calls := inventoryCalls
+	defer func() { inventoryCalls = calls }()
+	inventoryCalls = []inventoryCall{
+		{
+			method:  "GetBuckets",
+			service: "s3",
+			call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+				return map[string][]*s3.Bucket{
+					"eu-west-1": {{Name: aws.String("bucket-1")}, {Name: aws.String("bucket-2")}},
+					"eu-west-3": {{Name: aws.String("bucket-3")}},
+				}, nil
+			},
+		},
+		{
+			method:  "GetElastiCacheCluster",
+			service: "elasticache",
+			call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+				return map[string][]*elasticache.CacheCluster{
+					"eu-west-1": {{CacheClusterId: aws.String("cluster-1")}, {CacheClusterId: aws.String("cluster-2")}},
+				}, nil
+			},
+		},
+		{
+			method:  "GetUsers",
+			service: "iam",
+			call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+				return map[string][]*iam.User{
+					"eu-west-1": {{UserId: aws.String("AIDA1"), UserName: aws.String("alice")}, {UserId: aws.String("AIDA2"), UserName: aws.String("bob")}},
+				}, nil
+			},
+		},
+		{
+			method:  "GetRoles",
+			service: "iam",
+			call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+				return map[string][]*iam.Role{
+					"eu-west-1": {{RoleId: aws.String("AROA1"), RoleName: aws.String("admin")}},
+				}, nil
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		expectedIDs []string
+	}{
+		{name: "with the tags",
+			query:       "env=prod",
+			expectedIDs: []string{"bucket-1", "cluster-1", "AIDA1", "AROA1"},
+		},
+		{name: "with other tags",
+			query:       "env=dev",
+			expectedIDs: []string{"bucket-3"},
+		},
+		{name: "without the tags",
+			query:       "NOT env",
+			expectedIDs: []string{"bucket-2", "cluster-2", "AIDA2"},
+		},
+	}
+
+	for i, tt := range tests {
+		res, err := FindByTags(context.Background(), r, tt.query, FindByTagsOptions{NoTaggingAPI: true})
+		checkErrors(t, tt.name, i, err, nil)
+
+		var ids []string
+		for _, rr := range res {
+			ids = append(ids, rr.ID)
+		}
+		if !reflect.DeepEqual(ids, tt.expectedIDs) {
+			t.Errorf("%s [%d] - IDs: received=%+v | expected=%+v", tt.name, i, ids, tt.expectedIDs)
+		}
+	}
+}
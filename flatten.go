@@ -0,0 +1,86 @@
+package raws
+
+import (
+	"reflect"
+	"strings"
+)
+
+// flatten returns all the values found at the end of the path, which is a
+// list of field names separated by dots, walking through v.
+// Every slice found on the way is walked element by element and pointers are
+// dereferenced, so for example "Reservations.Instances" on an
+// ec2.DescribeInstancesOutput returns all the *ec2.Instance of all the
+// reservations.
+// Nil values and fields which don't exist are ignored.
+func flatten(v interface{}, path string) []interface{} {
+	var items []interface{}
+
+	walk(reflect.ValueOf(v), strings.Split(path, "."), &items)
+
+	return items
+}
+
+func walk(v reflect.Value, fields []string, items *[]interface{}) {
+	if !v.IsValid() {
+		return
+	}
+
+	if len(fields) == 0 {
+		if v.Kind() != reflect.Slice {
+			if !isNil(v) {
+				*items = append(*items, v.Interface())
+			}
+			return
+		}
+
+		for i := 0; i < v.Len(); i++ {
+			if !isNil(v.Index(i)) {
+				*items = append(*items, v.Index(i).Interface())
+			}
+		}
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		walk(v.Elem(), fields, items)
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			walk(v.Index(i), fields, items)
+		}
+	case reflect.Struct:
+		walk(v.FieldByName(fields[0]), fields[1:], items)
+	}
+}
+
+// appendPage merges page into dst, both pointers to the same output
+// type, so the slices of page are appended to the ones of dst and the
+// other fields of dst, like the pagination markers, are the ones of page
+func appendPage(dst, page interface{}) {
+	dv := reflect.ValueOf(dst).Elem()
+	pv := reflect.ValueOf(page).Elem()
+
+	for i := 0; i < dv.NumField(); i++ {
+		f := dv.Field(i)
+		if !f.CanSet() {
+			continue
+		}
+		if f.Kind() == reflect.Slice {
+			f.Set(reflect.AppendSlice(f, pv.Field(i)))
+			continue
+		}
+		f.Set(pv.Field(i))
+	}
+}
+
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
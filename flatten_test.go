@@ -0,0 +1,132 @@
+package raws
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestFlatten(t *testing.T) {
+	var (
+		i1 = &ec2.Instance{InstanceId: aws.String("i-1")}
+		i2 = &ec2.Instance{InstanceId: aws.String("i-2")}
+		i3 = &ec2.Instance{InstanceId: aws.String("i-3")}
+		d1 = &cloudfront.DistributionSummary{Id: aws.String("d-1")}
+	)
+
+	tests := []struct {
+		name          string
+		input         interface{}
+		path          string
+		expectedItems []interface{}
+	}{
+		{name: "nested lists",
+			input: ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{
+					{Instances: []*ec2.Instance{i1, i2}},
+					{Instances: []*ec2.Instance{i3}},
+				},
+			},
+			path:          "Reservations.Instances",
+			expectedItems: []interface{}{i1, i2, i3},
+		},
+		{name: "nil values are ignored",
+			input: ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{
+					nil,
+					{Instances: []*ec2.Instance{nil, i1}},
+				},
+			},
+			path:          "Reservations.Instances",
+			expectedItems: []interface{}{i1},
+		},
+		{name: "through a structure",
+			input: cloudfront.ListDistributionsOutput{
+				DistributionList: &cloudfront.DistributionList{
+					Items: []*cloudfront.DistributionSummary{d1},
+				},
+			},
+			path:          "DistributionList.Items",
+			expectedItems: []interface{}{d1},
+		},
+		{name: "nil structure",
+			input:         cloudfront.ListDistributionsOutput{},
+			path:          "DistributionList.Items",
+			expectedItems: nil,
+		},
+		{name: "unknown field",
+			input: ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{
+					{Instances: []*ec2.Instance{i1}},
+				},
+			},
+			path:          "Reservations.Unknown",
+			expectedItems: nil,
+		}}
+
+	for i, tt := range tests {
+		items := flatten(tt.input, tt.path)
+		if !reflect.DeepEqual(items, tt.expectedItems) {
+			t.Errorf("%s [%d] - items: received=%+v | expected=%+v",
+				tt.name, i, items, tt.expectedItems)
+		}
+	}
+}
+
+func TestListAllInstances(t *testing.T) {
+	var (
+		i1 = &ec2.Instance{InstanceId: aws.String("i-1")}
+		i2 = &ec2.Instance{InstanceId: aws.String("i-2")}
+		i3 = &ec2.Instance{InstanceId: aws.String("i-3")}
+	)
+
+	c := &connector{
+		regions: []string{"eu-west-1", "eu-west-2", "eu-west-3"},
+		svcs: []*serviceConnector{
+			{
+				region: "eu-west-1",
+				ec2: mockEC2{
+					dio: &ec2.DescribeInstancesOutput{
+						Reservations: []*ec2.Reservation{
+							{Instances: []*ec2.Instance{i1, i2}},
+						},
+					},
+				},
+			},
+			{
+				region: "eu-west-2",
+				ec2: mockEC2{
+					dierr: errors.New("fail"),
+				},
+			},
+			{
+				region: "eu-west-3",
+				ec2: mockEC2{
+					dio: &ec2.DescribeInstancesOutput{
+						Reservations: []*ec2.Reservation{
+							{Instances: []*ec2.Instance{i3}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expectedItems := []RegionInstance{
+		{Region: "eu-west-1", Item: i1},
+		{Region: "eu-west-1", Item: i2},
+		{Region: "eu-west-3", Item: i3},
+	}
+	expectedError := Errors{NewError("eu-west-2", ec2.ServiceName, errors.New("fail"))}
+
+	items, err := ListAllInstances(context.Background(), c, nil)
+	checkErrors(t, "list all instances", -1, err, expectedError)
+	if !reflect.DeepEqual(items, expectedItems) {
+		t.Errorf("list all instances - items: received=%+v | expected=%+v", items, expectedItems)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"io"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/cloudfront"
 	"github.com/aws/aws-sdk-go/service/configservice"
@@ -13,6 +15,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/route53resolver"
 	"github.com/aws/aws-sdk-go/service/s3"
@@ -39,6 +42,9 @@ import (
 // For avoiding by the callers the problem of if the returned map may be nil,
 // the function will always return a map instance, which will be of length 0
 // in case that there is not any successful request.
+// When the methods are called with a context of WithResultFunc, the results
+// are sent to its ResultFunc, region by region, instead of being returned
+// on the map, see Stream.
 type AWSReader interface {
 	// GetAccountID returns the current ID for the account used
 	GetAccountID() string
@@ -106,6 +112,11 @@ type AWSReader interface {
 	// Returned values are commented in the interface doc comment block.
 	GetLoadBalancers(ctx context.Context, input *elb.DescribeLoadBalancersInput) (map[string]elb.DescribeLoadBalancersOutput, error)
 
+	// GetLoadBalancersWithTags returns a list of ELB (v1) based on the input from the different regions
+	// with their tags.
+	// Returned values are commented in the interface doc comment block.
+	GetLoadBalancersWithTags(ctx context.Context, input *elb.DescribeLoadBalancersInput) (map[string][]LoadBalancerWithTags, error)
+
 	// GetLoadBalancersTags returns a list of Tags based on the input from the different regions.
 	// Returned values are commented in the interface doc comment block.
 	GetLoadBalancersTags(ctx context.Context, input *elb.DescribeTagsInput) (map[string]elb.DescribeTagsOutput, error)
@@ -114,6 +125,11 @@ type AWSReader interface {
 	// Returned values are commented in the interface doc comment block.
 	GetLoadBalancersV2(ctx context.Context, input *elbv2.DescribeLoadBalancersInput) (map[string]elbv2.DescribeLoadBalancersOutput, error)
 
+	// GetLoadBalancersV2WithTags returns a list of ELB (v2) - also known as ALB - based on the input
+	// from the different regions with their tags.
+	// Returned values are commented in the interface doc comment block.
+	GetLoadBalancersV2WithTags(ctx context.Context, input *elbv2.DescribeLoadBalancersInput) (map[string][]LoadBalancerV2WithTags, error)
+
 	// GetLoadBalancersV2Tags returns a list of Tags based on the input from the different regions.
 	// Returned values are commented in the interface doc comment block.
 	GetLoadBalancersV2Tags(ctx context.Context, input *elbv2.DescribeTagsInput) (map[string]elbv2.DescribeTagsOutput, error)
@@ -122,10 +138,26 @@ type AWSReader interface {
 	// Returned values are commented in the interface doc comment block.
 	GetDBInstances(ctx context.Context, input *rds.DescribeDBInstancesInput) (map[string]rds.DescribeDBInstancesOutput, error)
 
+	// GetDBInstancesWithTags returns all DB instances based on the input given with their tags.
+	// Returned values are commented in the interface doc comment block.
+	GetDBInstancesWithTags(ctx context.Context, input *rds.DescribeDBInstancesInput) (map[string][]DBInstanceWithTags, error)
+
 	// GetDBInstancesTags returns a list of tags from an ARN, extra filters for tags can also be provided.
 	// Returned values are commented in the interface doc comment block.
 	GetDBInstancesTags(ctx context.Context, input *rds.ListTagsForResourceInput) (map[string]rds.ListTagsForResourceOutput, error)
 
+	// GetBucketPolicyStatus returns if the policy of a S3 bucket makes it public based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetBucketPolicyStatus(ctx context.Context, input *s3.GetBucketPolicyStatusInput) (map[string]s3.GetBucketPolicyStatusOutput, error)
+
+	// GetBucketAcl returns the access control list of a S3 bucket based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetBucketAcl(ctx context.Context, input *s3.GetBucketAclInput) (map[string]s3.GetBucketAclOutput, error)
+
+	// GetPublicAccessBlock returns the public access block configuration of a S3 bucket based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetPublicAccessBlock(ctx context.Context, input *s3.GetPublicAccessBlockInput) (map[string]s3.GetPublicAccessBlockOutput, error)
+
 	// ListBuckets returns all S3 buckets based on the input given and specifically
 	// filtering by Location as ListBuckets does not do it by itself
 	// Returned values are commented in the interface doc comment block.
@@ -181,6 +213,11 @@ type AWSReader interface {
 	// Returned values are commented in the interface doc comment block.
 	GetGroups(ctx context.Context, input *iam.ListGroupsInput) (map[string]iam.ListGroupsOutput, error)
 
+	// GetGroupsWithPolicies returns all the IAM Groups on the given input with the names of
+	// their inline policies and their attached policies.
+	// Returned values are commented in the interface doc comment block.
+	GetGroupsWithPolicies(ctx context.Context, input *iam.ListGroupsInput) (map[string][]GroupWithPolicies, error)
+
 	// GetGroupPolicies returns the IAM GroupPolicies on the given input
 	// Returned values are commented in the interface doc comment block.
 	GetGroupPolicies(ctx context.Context, input *iam.ListGroupPoliciesInput) (map[string]iam.ListGroupPoliciesOutput, error)
@@ -205,6 +242,11 @@ type AWSReader interface {
 	// Returned values are commented in the interface doc comment block.
 	GetRoles(ctx context.Context, input *iam.ListRolesInput) (map[string]iam.ListRolesOutput, error)
 
+	// GetRolesWithPolicies returns all the IAM Roles on the given input with the names of
+	// their inline policies and their attached policies.
+	// Returned values are commented in the interface doc comment block.
+	GetRolesWithPolicies(ctx context.Context, input *iam.ListRolesInput) (map[string][]RoleWithPolicies, error)
+
 	// GetRolePolicies returns the IAM RolePolicies on the given input
 	// Returned values are commented in the interface doc comment block.
 	GetRolePolicies(ctx context.Context, input *iam.ListRolePoliciesInput) (map[string]iam.ListRolePoliciesOutput, error)
@@ -213,6 +255,10 @@ type AWSReader interface {
 	// Returned values are commented in the interface doc comment block.
 	GetAttachedRolePolicies(ctx context.Context, input *iam.ListAttachedRolePoliciesInput) (map[string]iam.ListAttachedRolePoliciesOutput, error)
 
+	// GetRoleTags returns the IAM RoleTags on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetRoleTags(ctx context.Context, input *iam.ListRoleTagsInput) (map[string]iam.ListRoleTagsOutput, error)
+
 	// GetSAMLProviders returns the IAM SAMLProviders on the given input
 	// Returned values are commented in the interface doc comment block.
 	GetSAMLProviders(ctx context.Context, input *iam.ListSAMLProvidersInput) (map[string]iam.ListSAMLProvidersOutput, error)
@@ -225,6 +271,11 @@ type AWSReader interface {
 	// Returned values are commented in the interface doc comment block.
 	GetUsers(ctx context.Context, input *iam.ListUsersInput) (map[string]iam.ListUsersOutput, error)
 
+	// GetUsersWithPolicies returns all the IAM Users on the given input with the names of
+	// their inline policies and their attached policies.
+	// Returned values are commented in the interface doc comment block.
+	GetUsersWithPolicies(ctx context.Context, input *iam.ListUsersInput) (map[string][]UserWithPolicies, error)
+
 	// GetUserPolicies returns the IAM UserPolicies on the given input
 	// Returned values are commented in the interface doc comment block.
 	GetUserPolicies(ctx context.Context, input *iam.ListUserPoliciesInput) (map[string]iam.ListUserPoliciesOutput, error)
@@ -233,6 +284,20 @@ type AWSReader interface {
 	// Returned values are commented in the interface doc comment block.
 	GetAttachedUserPolicies(ctx context.Context, input *iam.ListAttachedUserPoliciesInput) (map[string]iam.ListAttachedUserPoliciesOutput, error)
 
+	// GetUserTags returns the IAM UserTags on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetUserTags(ctx context.Context, input *iam.ListUserTagsInput) (map[string]iam.ListUserTagsOutput, error)
+
+	// GetMFADevices returns the IAM MFADevices on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetMFADevices(ctx context.Context, input *iam.ListMFADevicesInput) (map[string]iam.ListMFADevicesOutput, error)
+
+	// GetAccountAuthorizationDetails returns the IAM users, groups, roles and policies, with
+	// their policy documents, on the given input.
+	// All the pages are requested, so the output of each region has all the details.
+	// Returned values are commented in the interface doc comment block.
+	GetAccountAuthorizationDetails(ctx context.Context, input *iam.GetAccountAuthorizationDetailsInput) (map[string]iam.GetAccountAuthorizationDetailsOutput, error)
+
 	// GetSSHPublicKey returns the IAM SSHPublicKey on the given input
 	// Returned values are commented in the interface doc comment block.
 	GetSSHPublicKey(ctx context.Context, input *iam.GetSSHPublicKeyInput) (map[string]iam.GetSSHPublicKeyOutput, error)
@@ -296,1454 +361,5150 @@ type AWSReader interface {
 	// GetResolverRuleAssociations returns the Route53Resolver ResolverRuleAssociations on the given input
 	// Returned values are commented in the interface doc comment block.
 	GetResolverRuleAssociations(ctx context.Context, input *route53resolver.ListResolverRuleAssociationsInput) (map[string]route53resolver.ListResolverRuleAssociationsOutput, error)
+
+	// GetTaggedResources returns the ARN and the tags of all the tagged resources, of all
+	// the services supported by the Resource Groups Tagging API, based on the input given.
+	// All the pages are requested, so the output of each region has all the resources.
+	// Returned values are commented in the interface doc comment block.
+	GetTaggedResources(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput) (map[string]resourcegroupstaggingapi.GetResourcesOutput, error)
 }
 
 func (c *connector) GetInstances(ctx context.Context, input *ec2.DescribeInstancesInput) (map[string]ec2.DescribeInstancesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]ec2.DescribeInstancesOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.ec2 == nil {
-			svc.ec2 = ec2.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetInstances", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]ec2.DescribeInstancesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetInstances", Operation: "DescribeInstances", Service: ec2.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt ec2.DescribeInstancesOutput
+				var n int
+				err := svc.ec2.DescribeInstancesPagesWithContext(ctx, input.(*ec2.DescribeInstancesInput), func(page *ec2.DescribeInstancesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetInstances", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetInstances", NewError(svc.region, ec2.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*ec2.DescribeInstancesOutput)
+			}
 		}
 
-		opt, err := svc.ec2.DescribeInstancesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, ec2.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]ec2.DescribeInstancesOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetVpcs(ctx context.Context, input *ec2.DescribeVpcsInput) (map[string]ec2.DescribeVpcsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]ec2.DescribeVpcsOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.ec2 == nil {
-			svc.ec2 = ec2.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetVpcs", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]ec2.DescribeVpcsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetVpcs", Operation: "DescribeVpcs", Service: ec2.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt ec2.DescribeVpcsOutput
+				var n int
+				err := svc.ec2.DescribeVpcsPagesWithContext(ctx, input.(*ec2.DescribeVpcsInput), func(page *ec2.DescribeVpcsOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetVpcs", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetVpcs", NewError(svc.region, ec2.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*ec2.DescribeVpcsOutput)
+			}
 		}
 
-		opt, err := svc.ec2.DescribeVpcsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, ec2.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]ec2.DescribeVpcsOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetImages(ctx context.Context, input *ec2.DescribeImagesInput) (map[string]ec2.DescribeImagesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]ec2.DescribeImagesOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.ec2 == nil {
-			svc.ec2 = ec2.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetImages", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]ec2.DescribeImagesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetImages", Operation: "DescribeImages", Service: ec2.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.ec2.DescribeImagesWithContext(ctx, input.(*ec2.DescribeImagesInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetImages", NewError(svc.region, ec2.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetImages", svc.region, 1, *opt.(*ec2.DescribeImagesOutput)) {
+				regionsOpts[svc.region] = *opt.(*ec2.DescribeImagesOutput)
+			}
 		}
 
-		opt, err := svc.ec2.DescribeImagesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, ec2.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]ec2.DescribeImagesOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetOwnImages(ctx context.Context, input *ec2.DescribeImagesInput) (map[string]ec2.DescribeImagesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]ec2.DescribeImagesOutput{}
-
-	if input == nil {
-		input = &ec2.DescribeImagesInput{}
-	}
-	input.Owners = append(input.Owners, c.accountID)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetOwnImages", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]ec2.DescribeImagesOutput{}
 
-	for _, svc := range c.svcs {
-		if svc.ec2 == nil {
-			svc.ec2 = ec2.New(svc.session)
+		if input == nil {
+			input = &ec2.DescribeImagesInput{}
+		}
+		input.Owners = append(input.Owners, c.accountID)
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetOwnImages", Operation: "DescribeImages", Service: ec2.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.ec2.DescribeImagesWithContext(ctx, input.(*ec2.DescribeImagesInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetOwnImages", NewError(svc.region, ec2.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetOwnImages", svc.region, 1, *opt.(*ec2.DescribeImagesOutput)) {
+				regionsOpts[svc.region] = *opt.(*ec2.DescribeImagesOutput)
+			}
 		}
 
-		opt, err := svc.ec2.DescribeImagesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, ec2.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]ec2.DescribeImagesOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetSecurityGroups(ctx context.Context, input *ec2.DescribeSecurityGroupsInput) (map[string]ec2.DescribeSecurityGroupsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]ec2.DescribeSecurityGroupsOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.ec2 == nil {
-			svc.ec2 = ec2.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetSecurityGroups", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]ec2.DescribeSecurityGroupsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetSecurityGroups", Operation: "DescribeSecurityGroups", Service: ec2.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt ec2.DescribeSecurityGroupsOutput
+				var n int
+				err := svc.ec2.DescribeSecurityGroupsPagesWithContext(ctx, input.(*ec2.DescribeSecurityGroupsInput), func(page *ec2.DescribeSecurityGroupsOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetSecurityGroups", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetSecurityGroups", NewError(svc.region, ec2.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*ec2.DescribeSecurityGroupsOutput)
+			}
 		}
 
-		opt, err := svc.ec2.DescribeSecurityGroupsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, ec2.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]ec2.DescribeSecurityGroupsOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput) (map[string]ec2.DescribeSubnetsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]ec2.DescribeSubnetsOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.ec2 == nil {
-			svc.ec2 = ec2.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetSubnets", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]ec2.DescribeSubnetsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetSubnets", Operation: "DescribeSubnets", Service: ec2.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt ec2.DescribeSubnetsOutput
+				var n int
+				err := svc.ec2.DescribeSubnetsPagesWithContext(ctx, input.(*ec2.DescribeSubnetsInput), func(page *ec2.DescribeSubnetsOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetSubnets", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetSubnets", NewError(svc.region, ec2.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*ec2.DescribeSubnetsOutput)
+			}
 		}
 
-		opt, err := svc.ec2.DescribeSubnetsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, ec2.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]ec2.DescribeSubnetsOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetVolumes(ctx context.Context, input *ec2.DescribeVolumesInput) (map[string]ec2.DescribeVolumesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]ec2.DescribeVolumesOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.ec2 == nil {
-			svc.ec2 = ec2.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetVolumes", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]ec2.DescribeVolumesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetVolumes", Operation: "DescribeVolumes", Service: ec2.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt ec2.DescribeVolumesOutput
+				var n int
+				err := svc.ec2.DescribeVolumesPagesWithContext(ctx, input.(*ec2.DescribeVolumesInput), func(page *ec2.DescribeVolumesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetVolumes", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetVolumes", NewError(svc.region, ec2.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*ec2.DescribeVolumesOutput)
+			}
 		}
 
-		opt, err := svc.ec2.DescribeVolumesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, ec2.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]ec2.DescribeVolumesOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetSnapshots(ctx context.Context, input *ec2.DescribeSnapshotsInput) (map[string]ec2.DescribeSnapshotsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]ec2.DescribeSnapshotsOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.ec2 == nil {
-			svc.ec2 = ec2.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetSnapshots", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]ec2.DescribeSnapshotsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetSnapshots", Operation: "DescribeSnapshots", Service: ec2.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt ec2.DescribeSnapshotsOutput
+				var n int
+				err := svc.ec2.DescribeSnapshotsPagesWithContext(ctx, input.(*ec2.DescribeSnapshotsInput), func(page *ec2.DescribeSnapshotsOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetSnapshots", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetSnapshots", NewError(svc.region, ec2.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*ec2.DescribeSnapshotsOutput)
+			}
 		}
 
-		opt, err := svc.ec2.DescribeSnapshotsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, ec2.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]ec2.DescribeSnapshotsOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetOwnSnapshots(ctx context.Context, input *ec2.DescribeSnapshotsInput) (map[string]ec2.DescribeSnapshotsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]ec2.DescribeSnapshotsOutput{}
-
-	if input == nil {
-		input = &ec2.DescribeSnapshotsInput{}
-	}
-	input.OwnerIds = append(input.OwnerIds, c.accountID)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetOwnSnapshots", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]ec2.DescribeSnapshotsOutput{}
 
-	for _, svc := range c.svcs {
-		if svc.ec2 == nil {
-			svc.ec2 = ec2.New(svc.session)
+		if input == nil {
+			input = &ec2.DescribeSnapshotsInput{}
+		}
+		input.OwnerIds = append(input.OwnerIds, c.accountID)
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetOwnSnapshots", Operation: "DescribeSnapshots", Service: ec2.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt ec2.DescribeSnapshotsOutput
+				var n int
+				err := svc.ec2.DescribeSnapshotsPagesWithContext(ctx, input.(*ec2.DescribeSnapshotsInput), func(page *ec2.DescribeSnapshotsOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetOwnSnapshots", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetOwnSnapshots", NewError(svc.region, ec2.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*ec2.DescribeSnapshotsOutput)
+			}
 		}
 
-		opt, err := svc.ec2.DescribeSnapshotsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, ec2.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]ec2.DescribeSnapshotsOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetLaunchTemplates(ctx context.Context, input *ec2.DescribeLaunchTemplatesInput) (map[string]ec2.DescribeLaunchTemplatesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]ec2.DescribeLaunchTemplatesOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.ec2 == nil {
-			svc.ec2 = ec2.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetLaunchTemplates", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]ec2.DescribeLaunchTemplatesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetLaunchTemplates", Operation: "DescribeLaunchTemplates", Service: ec2.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt ec2.DescribeLaunchTemplatesOutput
+				var n int
+				err := svc.ec2.DescribeLaunchTemplatesPagesWithContext(ctx, input.(*ec2.DescribeLaunchTemplatesInput), func(page *ec2.DescribeLaunchTemplatesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetLaunchTemplates", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetLaunchTemplates", NewError(svc.region, ec2.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*ec2.DescribeLaunchTemplatesOutput)
+			}
 		}
 
-		opt, err := svc.ec2.DescribeLaunchTemplatesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, ec2.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]ec2.DescribeLaunchTemplatesOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetAutoScalingGroups(ctx context.Context, input *autoscaling.DescribeAutoScalingGroupsInput) (map[string]autoscaling.DescribeAutoScalingGroupsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]autoscaling.DescribeAutoScalingGroupsOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.autoscaling == nil {
-			svc.autoscaling = autoscaling.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetAutoScalingGroups", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]autoscaling.DescribeAutoScalingGroupsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetAutoScalingGroups", Operation: "DescribeAutoScalingGroups", Service: autoscaling.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt autoscaling.DescribeAutoScalingGroupsOutput
+				var n int
+				err := svc.autoscaling.DescribeAutoScalingGroupsPagesWithContext(ctx, input.(*autoscaling.DescribeAutoScalingGroupsInput), func(page *autoscaling.DescribeAutoScalingGroupsOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetAutoScalingGroups", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetAutoScalingGroups", NewError(svc.region, autoscaling.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*autoscaling.DescribeAutoScalingGroupsOutput)
+			}
 		}
 
-		opt, err := svc.autoscaling.DescribeAutoScalingGroupsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, autoscaling.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]autoscaling.DescribeAutoScalingGroupsOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetLaunchConfigurations(ctx context.Context, input *autoscaling.DescribeLaunchConfigurationsInput) (map[string]autoscaling.DescribeLaunchConfigurationsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]autoscaling.DescribeLaunchConfigurationsOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.autoscaling == nil {
-			svc.autoscaling = autoscaling.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetLaunchConfigurations", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]autoscaling.DescribeLaunchConfigurationsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetLaunchConfigurations", Operation: "DescribeLaunchConfigurations", Service: autoscaling.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt autoscaling.DescribeLaunchConfigurationsOutput
+				var n int
+				err := svc.autoscaling.DescribeLaunchConfigurationsPagesWithContext(ctx, input.(*autoscaling.DescribeLaunchConfigurationsInput), func(page *autoscaling.DescribeLaunchConfigurationsOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetLaunchConfigurations", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetLaunchConfigurations", NewError(svc.region, autoscaling.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*autoscaling.DescribeLaunchConfigurationsOutput)
+			}
 		}
 
-		opt, err := svc.autoscaling.DescribeLaunchConfigurationsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, autoscaling.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]autoscaling.DescribeLaunchConfigurationsOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetElastiCacheClusters(ctx context.Context, input *elasticache.DescribeCacheClustersInput) (map[string]elasticache.DescribeCacheClustersOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]elasticache.DescribeCacheClustersOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.elasticache == nil {
-			svc.elasticache = elasticache.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetElastiCacheClusters", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]elasticache.DescribeCacheClustersOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetElastiCacheClusters", Operation: "DescribeCacheClusters", Service: elasticache.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt elasticache.DescribeCacheClustersOutput
+				var n int
+				err := svc.elasticache.DescribeCacheClustersPagesWithContext(ctx, input.(*elasticache.DescribeCacheClustersInput), func(page *elasticache.DescribeCacheClustersOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetElastiCacheClusters", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetElastiCacheClusters", NewError(svc.region, elasticache.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*elasticache.DescribeCacheClustersOutput)
+			}
 		}
 
-		opt, err := svc.elasticache.DescribeCacheClustersWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, elasticache.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]elasticache.DescribeCacheClustersOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetElastiCacheTags(ctx context.Context, input *elasticache.ListTagsForResourceInput) (map[string]elasticache.TagListMessage, error) {
-	var errs Errors
-	var regionsOpts = map[string]elasticache.TagListMessage{}
-
-	for _, svc := range c.svcs {
-		if svc.elasticache == nil {
-			svc.elasticache = elasticache.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetElastiCacheTags", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]elasticache.TagListMessage{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetElastiCacheTags", Operation: "ListTagsForResource", Service: elasticache.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.elasticache.ListTagsForResourceWithContext(ctx, input.(*elasticache.ListTagsForResourceInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetElastiCacheTags", NewError(svc.region, elasticache.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetElastiCacheTags", svc.region, 1, *opt.(*elasticache.TagListMessage)) {
+				regionsOpts[svc.region] = *opt.(*elasticache.TagListMessage)
+			}
 		}
 
-		opt, err := svc.elasticache.ListTagsForResourceWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, elasticache.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]elasticache.TagListMessage)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetLoadBalancers(ctx context.Context, input *elb.DescribeLoadBalancersInput) (map[string]elb.DescribeLoadBalancersOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]elb.DescribeLoadBalancersOutput{}
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetLoadBalancers", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]elb.DescribeLoadBalancersOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetLoadBalancers", Operation: "DescribeLoadBalancers", Service: elb.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt elb.DescribeLoadBalancersOutput
+				var n int
+				err := svc.elb.DescribeLoadBalancersPagesWithContext(ctx, input.(*elb.DescribeLoadBalancersInput), func(page *elb.DescribeLoadBalancersOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetLoadBalancers", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetLoadBalancers", NewError(svc.region, elb.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*elb.DescribeLoadBalancersOutput)
+			}
+		}
 
-	for _, svc := range c.svcs {
-		if svc.elb == nil {
-			svc.elb = elb.New(svc.session)
+		if errs != nil {
+			return regionsOpts, errs
 		}
 
-		opt, err := svc.elb.DescribeLoadBalancersWithContext(ctx, input)
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]elb.DescribeLoadBalancersOutput)
+
+	return opts, err
+}
+
+// LoadBalancerWithTags is a elb.LoadBalancerDescription with the results of
+// the calls done for it by GetLoadBalancersWithTags
+type LoadBalancerWithTags struct {
+	Item *elb.LoadBalancerDescription
+	Tags *elb.TagDescription
+}
+
+func (c *connector) GetLoadBalancersWithTags(ctx context.Context, input *elb.DescribeLoadBalancersInput) (map[string][]LoadBalancerWithTags, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetLoadBalancersWithTags", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string][]LoadBalancerWithTags{}
+
+		// The items are sent once they have the results of the calls done for them
+		opts, err := c.GetLoadBalancers(WithResultFunc(ctx, nil), input)
 		if err != nil {
-			errs = append(errs, NewError(svc.region, elb.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+			for _, e := range asErrors(elb.ServiceName, err) {
+				errs = append(errs, sendError(ctx, "GetLoadBalancersWithTags", e))
+			}
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		for _, svc := range c.services(ctx) {
+			opt, ok := opts[svc.region]
+			if !ok {
+				continue
+			}
+
+			var items []LoadBalancerWithTags
+			for _, v := range flatten(opt, "LoadBalancerDescriptions") {
+				items = append(items, LoadBalancerWithTags{Item: v.(*elb.LoadBalancerDescription)})
+			}
+
+			for i := 0; i < len(items); i += 20 {
+				batch := items[i:]
+				if len(batch) > 20 {
+					batch = batch[:20]
+				}
+
+				fin := &elb.DescribeTagsInput{}
+				for _, it := range batch {
+					fin.LoadBalancerNames = append(fin.LoadBalancerNames, it.Item.LoadBalancerName)
+				}
+
+				fopt, err := c.invoke(ctx, &Call{Method: "GetLoadBalancersWithTags", Operation: "DescribeTags", Service: elb.ServiceName, Region: svc.region, Input: fin}, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+					return svc.elb.DescribeTagsWithContext(ctx, input.(*elb.DescribeTagsInput), opts...)
+				})
+				if err != nil {
+					errs = append(errs, sendError(ctx, "GetLoadBalancersWithTags", NewError(svc.region, elb.ServiceName, err)))
+					continue
+				}
+
+				for _, v := range flatten(fopt, "TagDescriptions") {
+					res := v.(*elb.TagDescription)
+					for j, it := range batch {
+						if aws.StringValue(it.Item.LoadBalancerName) == aws.StringValue(res.LoadBalancerName) {
+							batch[j].Tags = res
+						}
+					}
+				}
+			}
+
+			if !sendOutput(ctx, "GetLoadBalancersWithTags", svc.region, 1, items) {
+				regionsOpts[svc.region] = items
+			}
+		}
+
+		if errs != nil {
+			return regionsOpts, errs
+		}
+
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string][]LoadBalancerWithTags)
+
+	return opts, err
 }
 
 func (c *connector) GetLoadBalancersTags(ctx context.Context, input *elb.DescribeTagsInput) (map[string]elb.DescribeTagsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]elb.DescribeTagsOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.elb == nil {
-			svc.elb = elb.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetLoadBalancersTags", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]elb.DescribeTagsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetLoadBalancersTags", Operation: "DescribeTags", Service: elb.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.elb.DescribeTagsWithContext(ctx, input.(*elb.DescribeTagsInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetLoadBalancersTags", NewError(svc.region, elb.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetLoadBalancersTags", svc.region, 1, *opt.(*elb.DescribeTagsOutput)) {
+				regionsOpts[svc.region] = *opt.(*elb.DescribeTagsOutput)
+			}
 		}
 
-		opt, err := svc.elb.DescribeTagsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, elb.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]elb.DescribeTagsOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetLoadBalancersV2(ctx context.Context, input *elbv2.DescribeLoadBalancersInput) (map[string]elbv2.DescribeLoadBalancersOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]elbv2.DescribeLoadBalancersOutput{}
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetLoadBalancersV2", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]elbv2.DescribeLoadBalancersOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetLoadBalancersV2", Operation: "DescribeLoadBalancers", Service: elbv2.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt elbv2.DescribeLoadBalancersOutput
+				var n int
+				err := svc.elbv2.DescribeLoadBalancersPagesWithContext(ctx, input.(*elbv2.DescribeLoadBalancersInput), func(page *elbv2.DescribeLoadBalancersOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetLoadBalancersV2", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetLoadBalancersV2", NewError(svc.region, elbv2.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*elbv2.DescribeLoadBalancersOutput)
+			}
+		}
 
-	for _, svc := range c.svcs {
-		if svc.elbv2 == nil {
-			svc.elbv2 = elbv2.New(svc.session)
+		if errs != nil {
+			return regionsOpts, errs
 		}
 
-		opt, err := svc.elbv2.DescribeLoadBalancersWithContext(ctx, input)
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]elbv2.DescribeLoadBalancersOutput)
+
+	return opts, err
+}
+
+// LoadBalancerV2WithTags is a elbv2.LoadBalancer with the results of
+// the calls done for it by GetLoadBalancersV2WithTags
+type LoadBalancerV2WithTags struct {
+	Item *elbv2.LoadBalancer
+	Tags *elbv2.TagDescription
+}
+
+func (c *connector) GetLoadBalancersV2WithTags(ctx context.Context, input *elbv2.DescribeLoadBalancersInput) (map[string][]LoadBalancerV2WithTags, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetLoadBalancersV2WithTags", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string][]LoadBalancerV2WithTags{}
+
+		// The items are sent once they have the results of the calls done for them
+		opts, err := c.GetLoadBalancersV2(WithResultFunc(ctx, nil), input)
 		if err != nil {
-			errs = append(errs, NewError(svc.region, elbv2.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+			for _, e := range asErrors(elbv2.ServiceName, err) {
+				errs = append(errs, sendError(ctx, "GetLoadBalancersV2WithTags", e))
+			}
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		for _, svc := range c.services(ctx) {
+			opt, ok := opts[svc.region]
+			if !ok {
+				continue
+			}
+
+			var items []LoadBalancerV2WithTags
+			for _, v := range flatten(opt, "LoadBalancers") {
+				items = append(items, LoadBalancerV2WithTags{Item: v.(*elbv2.LoadBalancer)})
+			}
+
+			for i := 0; i < len(items); i += 20 {
+				batch := items[i:]
+				if len(batch) > 20 {
+					batch = batch[:20]
+				}
+
+				fin := &elbv2.DescribeTagsInput{}
+				for _, it := range batch {
+					fin.ResourceArns = append(fin.ResourceArns, it.Item.LoadBalancerArn)
+				}
+
+				fopt, err := c.invoke(ctx, &Call{Method: "GetLoadBalancersV2WithTags", Operation: "DescribeTags", Service: elbv2.ServiceName, Region: svc.region, Input: fin}, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+					return svc.elbv2.DescribeTagsWithContext(ctx, input.(*elbv2.DescribeTagsInput), opts...)
+				})
+				if err != nil {
+					errs = append(errs, sendError(ctx, "GetLoadBalancersV2WithTags", NewError(svc.region, elbv2.ServiceName, err)))
+					continue
+				}
+
+				for _, v := range flatten(fopt, "TagDescriptions") {
+					res := v.(*elbv2.TagDescription)
+					for j, it := range batch {
+						if aws.StringValue(it.Item.LoadBalancerArn) == aws.StringValue(res.ResourceArn) {
+							batch[j].Tags = res
+						}
+					}
+				}
+			}
+
+			if !sendOutput(ctx, "GetLoadBalancersV2WithTags", svc.region, 1, items) {
+				regionsOpts[svc.region] = items
+			}
+		}
+
+		if errs != nil {
+			return regionsOpts, errs
+		}
+
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string][]LoadBalancerV2WithTags)
+
+	return opts, err
 }
 
 func (c *connector) GetLoadBalancersV2Tags(ctx context.Context, input *elbv2.DescribeTagsInput) (map[string]elbv2.DescribeTagsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]elbv2.DescribeTagsOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.elbv2 == nil {
-			svc.elbv2 = elbv2.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetLoadBalancersV2Tags", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]elbv2.DescribeTagsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetLoadBalancersV2Tags", Operation: "DescribeTags", Service: elbv2.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.elbv2.DescribeTagsWithContext(ctx, input.(*elbv2.DescribeTagsInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetLoadBalancersV2Tags", NewError(svc.region, elbv2.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetLoadBalancersV2Tags", svc.region, 1, *opt.(*elbv2.DescribeTagsOutput)) {
+				regionsOpts[svc.region] = *opt.(*elbv2.DescribeTagsOutput)
+			}
 		}
 
-		opt, err := svc.elbv2.DescribeTagsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, elbv2.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]elbv2.DescribeTagsOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetDBInstances(ctx context.Context, input *rds.DescribeDBInstancesInput) (map[string]rds.DescribeDBInstancesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]rds.DescribeDBInstancesOutput{}
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetDBInstances", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]rds.DescribeDBInstancesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetDBInstances", Operation: "DescribeDBInstances", Service: rds.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt rds.DescribeDBInstancesOutput
+				var n int
+				err := svc.rds.DescribeDBInstancesPagesWithContext(ctx, input.(*rds.DescribeDBInstancesInput), func(page *rds.DescribeDBInstancesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetDBInstances", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetDBInstances", NewError(svc.region, rds.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*rds.DescribeDBInstancesOutput)
+			}
+		}
 
-	for _, svc := range c.svcs {
-		if svc.rds == nil {
-			svc.rds = rds.New(svc.session)
+		if errs != nil {
+			return regionsOpts, errs
 		}
 
-		opt, err := svc.rds.DescribeDBInstancesWithContext(ctx, input)
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]rds.DescribeDBInstancesOutput)
+
+	return opts, err
+}
+
+// DBInstanceWithTags is a rds.DBInstance with the results of
+// the calls done for it by GetDBInstancesWithTags
+type DBInstanceWithTags struct {
+	Item *rds.DBInstance
+	Tags *rds.ListTagsForResourceOutput
+}
+
+func (c *connector) GetDBInstancesWithTags(ctx context.Context, input *rds.DescribeDBInstancesInput) (map[string][]DBInstanceWithTags, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetDBInstancesWithTags", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string][]DBInstanceWithTags{}
+
+		// The items are sent once they have the results of the calls done for them
+		opts, err := c.GetDBInstances(WithResultFunc(ctx, nil), input)
 		if err != nil {
-			errs = append(errs, NewError(svc.region, rds.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+			for _, e := range asErrors(rds.ServiceName, err) {
+				errs = append(errs, sendError(ctx, "GetDBInstancesWithTags", e))
+			}
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		for _, svc := range c.services(ctx) {
+			opt, ok := opts[svc.region]
+			if !ok {
+				continue
+			}
+
+			var items []DBInstanceWithTags
+			for _, v := range flatten(opt, "DBInstances") {
+				items = append(items, DBInstanceWithTags{Item: v.(*rds.DBInstance)})
+			}
+
+			for i, it := range items {
+				fin := &rds.ListTagsForResourceInput{
+					ResourceName: it.Item.DBInstanceArn,
+				}
+
+				fopt, err := c.invoke(ctx, &Call{Method: "GetDBInstancesWithTags", Operation: "ListTagsForResource", Service: rds.ServiceName, Region: svc.region, Input: fin}, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+					return svc.rds.ListTagsForResourceWithContext(ctx, input.(*rds.ListTagsForResourceInput), opts...)
+				})
+				if err != nil {
+					errs = append(errs, sendError(ctx, "GetDBInstancesWithTags", NewError(svc.region, rds.ServiceName, err)))
+					continue
+				}
+
+				items[i].Tags = fopt.(*rds.ListTagsForResourceOutput)
+			}
+
+			if !sendOutput(ctx, "GetDBInstancesWithTags", svc.region, 1, items) {
+				regionsOpts[svc.region] = items
+			}
+		}
+
+		if errs != nil {
+			return regionsOpts, errs
+		}
+
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string][]DBInstanceWithTags)
+
+	return opts, err
 }
 
 func (c *connector) GetDBInstancesTags(ctx context.Context, input *rds.ListTagsForResourceInput) (map[string]rds.ListTagsForResourceOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]rds.ListTagsForResourceOutput{}
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetDBInstancesTags", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]rds.ListTagsForResourceOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetDBInstancesTags", Operation: "ListTagsForResource", Service: rds.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.rds.ListTagsForResourceWithContext(ctx, input.(*rds.ListTagsForResourceInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetDBInstancesTags", NewError(svc.region, rds.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetDBInstancesTags", svc.region, 1, *opt.(*rds.ListTagsForResourceOutput)) {
+				regionsOpts[svc.region] = *opt.(*rds.ListTagsForResourceOutput)
+			}
+		}
 
-	for _, svc := range c.svcs {
-		if svc.rds == nil {
-			svc.rds = rds.New(svc.session)
+		if errs != nil {
+			return regionsOpts, errs
 		}
 
-		opt, err := svc.rds.ListTagsForResourceWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, rds.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]rds.ListTagsForResourceOutput)
+
+	return opts, err
+}
+
+func (c *connector) GetBucketPolicyStatus(ctx context.Context, input *s3.GetBucketPolicyStatusInput) (map[string]s3.GetBucketPolicyStatusOutput, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetBucketPolicyStatus", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]s3.GetBucketPolicyStatusOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetBucketPolicyStatus", Operation: "GetBucketPolicyStatus", Service: s3.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.s3.GetBucketPolicyStatusWithContext(ctx, input.(*s3.GetBucketPolicyStatusInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetBucketPolicyStatus", NewError(svc.region, s3.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetBucketPolicyStatus", svc.region, 1, *opt.(*s3.GetBucketPolicyStatusOutput)) {
+				regionsOpts[svc.region] = *opt.(*s3.GetBucketPolicyStatusOutput)
+			}
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		if errs != nil {
+			return regionsOpts, errs
+		}
+
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]s3.GetBucketPolicyStatusOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
-func (c *connector) GetBucketTags(ctx context.Context, input *s3.GetBucketTaggingInput) (map[string]s3.GetBucketTaggingOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]s3.GetBucketTaggingOutput{}
+func (c *connector) GetBucketAcl(ctx context.Context, input *s3.GetBucketAclInput) (map[string]s3.GetBucketAclOutput, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetBucketAcl", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]s3.GetBucketAclOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetBucketAcl", Operation: "GetBucketAcl", Service: s3.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.s3.GetBucketAclWithContext(ctx, input.(*s3.GetBucketAclInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetBucketAcl", NewError(svc.region, s3.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetBucketAcl", svc.region, 1, *opt.(*s3.GetBucketAclOutput)) {
+				regionsOpts[svc.region] = *opt.(*s3.GetBucketAclOutput)
+			}
+		}
 
-	for _, svc := range c.svcs {
-		if svc.s3 == nil {
-			svc.s3 = s3.New(svc.session)
+		if errs != nil {
+			return regionsOpts, errs
 		}
 
-		opt, err := svc.s3.GetBucketTaggingWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, s3.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]s3.GetBucketAclOutput)
+
+	return opts, err
+}
+
+func (c *connector) GetPublicAccessBlock(ctx context.Context, input *s3.GetPublicAccessBlockInput) (map[string]s3.GetPublicAccessBlockOutput, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetPublicAccessBlock", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]s3.GetPublicAccessBlockOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetPublicAccessBlock", Operation: "GetPublicAccessBlock", Service: s3.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.s3.GetPublicAccessBlockWithContext(ctx, input.(*s3.GetPublicAccessBlockInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetPublicAccessBlock", NewError(svc.region, s3.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetPublicAccessBlock", svc.region, 1, *opt.(*s3.GetPublicAccessBlockOutput)) {
+				regionsOpts[svc.region] = *opt.(*s3.GetPublicAccessBlockOutput)
+			}
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		if errs != nil {
+			return regionsOpts, errs
+		}
+
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]s3.GetPublicAccessBlockOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
-func (c *connector) ListObjects(ctx context.Context, input *s3.ListObjectsInput) (map[string]s3.ListObjectsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]s3.ListObjectsOutput{}
+func (c *connector) GetBucketTags(ctx context.Context, input *s3.GetBucketTaggingInput) (map[string]s3.GetBucketTaggingOutput, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetBucketTags", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]s3.GetBucketTaggingOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetBucketTags", Operation: "GetBucketTagging", Service: s3.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.s3.GetBucketTaggingWithContext(ctx, input.(*s3.GetBucketTaggingInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetBucketTags", NewError(svc.region, s3.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetBucketTags", svc.region, 1, *opt.(*s3.GetBucketTaggingOutput)) {
+				regionsOpts[svc.region] = *opt.(*s3.GetBucketTaggingOutput)
+			}
+		}
 
-	for _, svc := range c.svcs {
-		if svc.s3 == nil {
-			svc.s3 = s3.New(svc.session)
+		if errs != nil {
+			return regionsOpts, errs
 		}
 
-		opt, err := svc.s3.ListObjectsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, s3.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]s3.GetBucketTaggingOutput)
+
+	return opts, err
+}
+
+func (c *connector) ListObjects(ctx context.Context, input *s3.ListObjectsInput) (map[string]s3.ListObjectsOutput, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "ListObjects", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]s3.ListObjectsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "ListObjects", Operation: "ListObjects", Service: s3.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt s3.ListObjectsOutput
+				var n int
+				err := svc.s3.ListObjectsPagesWithContext(ctx, input.(*s3.ListObjectsInput), func(page *s3.ListObjectsOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "ListObjects", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "ListObjects", NewError(svc.region, s3.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*s3.ListObjectsOutput)
+			}
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		if errs != nil {
+			return regionsOpts, errs
+		}
 
-	return regionsOpts, nil
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]s3.ListObjectsOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetObjectsTags(ctx context.Context, input *s3.GetObjectTaggingInput) (map[string]s3.GetObjectTaggingOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]s3.GetObjectTaggingOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.s3 == nil {
-			svc.s3 = s3.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetObjectsTags", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]s3.GetObjectTaggingOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetObjectsTags", Operation: "GetObjectTagging", Service: s3.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.s3.GetObjectTaggingWithContext(ctx, input.(*s3.GetObjectTaggingInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetObjectsTags", NewError(svc.region, s3.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetObjectsTags", svc.region, 1, *opt.(*s3.GetObjectTaggingOutput)) {
+				regionsOpts[svc.region] = *opt.(*s3.GetObjectTaggingOutput)
+			}
 		}
 
-		opt, err := svc.s3.GetObjectTaggingWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, s3.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]s3.GetObjectTaggingOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetRecordedResourceCounts(ctx context.Context, input *configservice.GetDiscoveredResourceCountsInput) (map[string]configservice.GetDiscoveredResourceCountsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]configservice.GetDiscoveredResourceCountsOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.configservice == nil {
-			svc.configservice = configservice.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetRecordedResourceCounts", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]configservice.GetDiscoveredResourceCountsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetRecordedResourceCounts", Operation: "GetDiscoveredResourceCounts", Service: configservice.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.configservice.GetDiscoveredResourceCountsWithContext(ctx, input.(*configservice.GetDiscoveredResourceCountsInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetRecordedResourceCounts", NewError(svc.region, configservice.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetRecordedResourceCounts", svc.region, 1, *opt.(*configservice.GetDiscoveredResourceCountsOutput)) {
+				regionsOpts[svc.region] = *opt.(*configservice.GetDiscoveredResourceCountsOutput)
+			}
 		}
 
-		opt, err := svc.configservice.GetDiscoveredResourceCountsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, configservice.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]configservice.GetDiscoveredResourceCountsOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetCloudFrontDistributions(ctx context.Context, input *cloudfront.ListDistributionsInput) (map[string]cloudfront.ListDistributionsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]cloudfront.ListDistributionsOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.cloudfront == nil {
-			svc.cloudfront = cloudfront.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetCloudFrontDistributions", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]cloudfront.ListDistributionsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetCloudFrontDistributions", Operation: "ListDistributions", Service: cloudfront.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt cloudfront.ListDistributionsOutput
+				var n int
+				err := svc.cloudfront.ListDistributionsPagesWithContext(ctx, input.(*cloudfront.ListDistributionsInput), func(page *cloudfront.ListDistributionsOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetCloudFrontDistributions", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetCloudFrontDistributions", NewError(svc.region, cloudfront.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*cloudfront.ListDistributionsOutput)
+			}
 		}
 
-		opt, err := svc.cloudfront.ListDistributionsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, cloudfront.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]cloudfront.ListDistributionsOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetCloudFrontPublicKeys(ctx context.Context, input *cloudfront.ListPublicKeysInput) (map[string]cloudfront.ListPublicKeysOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]cloudfront.ListPublicKeysOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.cloudfront == nil {
-			svc.cloudfront = cloudfront.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetCloudFrontPublicKeys", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]cloudfront.ListPublicKeysOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetCloudFrontPublicKeys", Operation: "ListPublicKeys", Service: cloudfront.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.cloudfront.ListPublicKeysWithContext(ctx, input.(*cloudfront.ListPublicKeysInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetCloudFrontPublicKeys", NewError(svc.region, cloudfront.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetCloudFrontPublicKeys", svc.region, 1, *opt.(*cloudfront.ListPublicKeysOutput)) {
+				regionsOpts[svc.region] = *opt.(*cloudfront.ListPublicKeysOutput)
+			}
 		}
 
-		opt, err := svc.cloudfront.ListPublicKeysWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, cloudfront.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]cloudfront.ListPublicKeysOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetCloudFrontOriginAccessIdentities(ctx context.Context, input *cloudfront.ListCloudFrontOriginAccessIdentitiesInput) (map[string]cloudfront.ListCloudFrontOriginAccessIdentitiesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]cloudfront.ListCloudFrontOriginAccessIdentitiesOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.cloudfront == nil {
-			svc.cloudfront = cloudfront.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetCloudFrontOriginAccessIdentities", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]cloudfront.ListCloudFrontOriginAccessIdentitiesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetCloudFrontOriginAccessIdentities", Operation: "ListCloudFrontOriginAccessIdentities", Service: cloudfront.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt cloudfront.ListCloudFrontOriginAccessIdentitiesOutput
+				var n int
+				err := svc.cloudfront.ListCloudFrontOriginAccessIdentitiesPagesWithContext(ctx, input.(*cloudfront.ListCloudFrontOriginAccessIdentitiesInput), func(page *cloudfront.ListCloudFrontOriginAccessIdentitiesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetCloudFrontOriginAccessIdentities", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetCloudFrontOriginAccessIdentities", NewError(svc.region, cloudfront.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*cloudfront.ListCloudFrontOriginAccessIdentitiesOutput)
+			}
 		}
 
-		opt, err := svc.cloudfront.ListCloudFrontOriginAccessIdentitiesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, cloudfront.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]cloudfront.ListCloudFrontOriginAccessIdentitiesOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetAccessKeys(ctx context.Context, input *iam.ListAccessKeysInput) (map[string]iam.ListAccessKeysOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]iam.ListAccessKeysOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.iam == nil {
-			svc.iam = iam.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetAccessKeys", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListAccessKeysOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetAccessKeys", Operation: "ListAccessKeys", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt iam.ListAccessKeysOutput
+				var n int
+				err := svc.iam.ListAccessKeysPagesWithContext(ctx, input.(*iam.ListAccessKeysInput), func(page *iam.ListAccessKeysOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetAccessKeys", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetAccessKeys", NewError(svc.region, iam.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*iam.ListAccessKeysOutput)
+			}
 		}
 
-		opt, err := svc.iam.ListAccessKeysWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, iam.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListAccessKeysOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetAccountAliases(ctx context.Context, input *iam.ListAccountAliasesInput) (map[string]iam.ListAccountAliasesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]iam.ListAccountAliasesOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.iam == nil {
-			svc.iam = iam.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetAccountAliases", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListAccountAliasesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetAccountAliases", Operation: "ListAccountAliases", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt iam.ListAccountAliasesOutput
+				var n int
+				err := svc.iam.ListAccountAliasesPagesWithContext(ctx, input.(*iam.ListAccountAliasesInput), func(page *iam.ListAccountAliasesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetAccountAliases", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetAccountAliases", NewError(svc.region, iam.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*iam.ListAccountAliasesOutput)
+			}
 		}
 
-		opt, err := svc.iam.ListAccountAliasesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, iam.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListAccountAliasesOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetAccountPasswordPolicy(ctx context.Context, input *iam.GetAccountPasswordPolicyInput) (map[string]iam.GetAccountPasswordPolicyOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]iam.GetAccountPasswordPolicyOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.iam == nil {
-			svc.iam = iam.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetAccountPasswordPolicy", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.GetAccountPasswordPolicyOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetAccountPasswordPolicy", Operation: "GetAccountPasswordPolicy", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.iam.GetAccountPasswordPolicyWithContext(ctx, input.(*iam.GetAccountPasswordPolicyInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetAccountPasswordPolicy", NewError(svc.region, iam.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetAccountPasswordPolicy", svc.region, 1, *opt.(*iam.GetAccountPasswordPolicyOutput)) {
+				regionsOpts[svc.region] = *opt.(*iam.GetAccountPasswordPolicyOutput)
+			}
 		}
 
-		opt, err := svc.iam.GetAccountPasswordPolicyWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, iam.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.GetAccountPasswordPolicyOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetGroups(ctx context.Context, input *iam.ListGroupsInput) (map[string]iam.ListGroupsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]iam.ListGroupsOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.iam == nil {
-			svc.iam = iam.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetGroups", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListGroupsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetGroups", Operation: "ListGroups", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt iam.ListGroupsOutput
+				var n int
+				err := svc.iam.ListGroupsPagesWithContext(ctx, input.(*iam.ListGroupsInput), func(page *iam.ListGroupsOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetGroups", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetGroups", NewError(svc.region, iam.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*iam.ListGroupsOutput)
+			}
 		}
 
-		opt, err := svc.iam.ListGroupsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, iam.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListGroupsOutput)
+
+	return opts, err
 }
 
-func (c *connector) GetGroupPolicies(ctx context.Context, input *iam.ListGroupPoliciesInput) (map[string]iam.ListGroupPoliciesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]iam.ListGroupPoliciesOutput{}
+// GroupWithPolicies is a iam.Group with the results of
+// the calls done for it by GetGroupsWithPolicies
+type GroupWithPolicies struct {
+	Item             *iam.Group
+	Policies         *iam.ListGroupPoliciesOutput
+	AttachedPolicies *iam.ListAttachedGroupPoliciesOutput
+}
 
-	for _, svc := range c.svcs {
-		if svc.iam == nil {
-			svc.iam = iam.New(svc.session)
-		}
+func (c *connector) GetGroupsWithPolicies(ctx context.Context, input *iam.ListGroupsInput) (map[string][]GroupWithPolicies, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetGroupsWithPolicies", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string][]GroupWithPolicies{}
 
-		opt, err := svc.iam.ListGroupPoliciesWithContext(ctx, input)
+		// The items are sent once they have the results of the calls done for them
+		opts, err := c.GetGroups(WithResultFunc(ctx, nil), input)
 		if err != nil {
-			errs = append(errs, NewError(svc.region, iam.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+			for _, e := range asErrors(iam.ServiceName, err) {
+				errs = append(errs, sendError(ctx, "GetGroupsWithPolicies", e))
+			}
 		}
-	}
-
-	if errs != nil {
-		return regionsOpts, errs
-	}
-
-	return regionsOpts, nil
-}
-
-func (c *connector) GetAttachedGroupPolicies(ctx context.Context, input *iam.ListAttachedGroupPoliciesInput) (map[string]iam.ListAttachedGroupPoliciesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]iam.ListAttachedGroupPoliciesOutput{}
 
-	for _, svc := range c.svcs {
-		if svc.iam == nil {
-			svc.iam = iam.New(svc.session)
+		for _, svc := range c.services(ctx) {
+			opt, ok := opts[svc.region]
+			if !ok {
+				continue
+			}
+
+			var items []GroupWithPolicies
+			for _, v := range flatten(opt, "Groups") {
+				items = append(items, GroupWithPolicies{Item: v.(*iam.Group)})
+			}
+
+			for i, it := range items {
+				fin := &iam.ListGroupPoliciesInput{
+					GroupName: it.Item.GroupName,
+				}
+
+				fopt, err := c.invoke(ctx, &Call{Method: "GetGroupsWithPolicies", Operation: "ListGroupPolicies", Service: iam.ServiceName, Region: svc.region, Input: fin}, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+					var opt iam.ListGroupPoliciesOutput
+					err := svc.iam.ListGroupPoliciesPagesWithContext(ctx, input.(*iam.ListGroupPoliciesInput), func(page *iam.ListGroupPoliciesOutput, _ bool) bool {
+						appendPage(&opt, page)
+						return true
+					}, opts...)
+					return &opt, err
+				})
+				if err != nil {
+					errs = append(errs, sendError(ctx, "GetGroupsWithPolicies", NewError(svc.region, iam.ServiceName, err)))
+					continue
+				}
+
+				items[i].Policies = fopt.(*iam.ListGroupPoliciesOutput)
+			}
+
+			for i, it := range items {
+				fin := &iam.ListAttachedGroupPoliciesInput{
+					GroupName: it.Item.GroupName,
+				}
+
+				fopt, err := c.invoke(ctx, &Call{Method: "GetGroupsWithPolicies", Operation: "ListAttachedGroupPolicies", Service: iam.ServiceName, Region: svc.region, Input: fin}, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+					var opt iam.ListAttachedGroupPoliciesOutput
+					err := svc.iam.ListAttachedGroupPoliciesPagesWithContext(ctx, input.(*iam.ListAttachedGroupPoliciesInput), func(page *iam.ListAttachedGroupPoliciesOutput, _ bool) bool {
+						appendPage(&opt, page)
+						return true
+					}, opts...)
+					return &opt, err
+				})
+				if err != nil {
+					errs = append(errs, sendError(ctx, "GetGroupsWithPolicies", NewError(svc.region, iam.ServiceName, err)))
+					continue
+				}
+
+				items[i].AttachedPolicies = fopt.(*iam.ListAttachedGroupPoliciesOutput)
+			}
+
+			if !sendOutput(ctx, "GetGroupsWithPolicies", svc.region, 1, items) {
+				regionsOpts[svc.region] = items
+			}
 		}
 
-		opt, err := svc.iam.ListAttachedGroupPoliciesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, iam.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string][]GroupWithPolicies)
+
+	return opts, err
 }
 
-func (c *connector) GetInstanceProfiles(ctx context.Context, input *iam.ListInstanceProfilesInput) (map[string]iam.ListInstanceProfilesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]iam.ListInstanceProfilesOutput{}
+func (c *connector) GetGroupPolicies(ctx context.Context, input *iam.ListGroupPoliciesInput) (map[string]iam.ListGroupPoliciesOutput, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetGroupPolicies", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListGroupPoliciesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetGroupPolicies", Operation: "ListGroupPolicies", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt iam.ListGroupPoliciesOutput
+				var n int
+				err := svc.iam.ListGroupPoliciesPagesWithContext(ctx, input.(*iam.ListGroupPoliciesInput), func(page *iam.ListGroupPoliciesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetGroupPolicies", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetGroupPolicies", NewError(svc.region, iam.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*iam.ListGroupPoliciesOutput)
+			}
+		}
 
-	for _, svc := range c.svcs {
-		if svc.iam == nil {
-			svc.iam = iam.New(svc.session)
+		if errs != nil {
+			return regionsOpts, errs
 		}
 
-		opt, err := svc.iam.ListInstanceProfilesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, iam.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListGroupPoliciesOutput)
+
+	return opts, err
+}
+
+func (c *connector) GetAttachedGroupPolicies(ctx context.Context, input *iam.ListAttachedGroupPoliciesInput) (map[string]iam.ListAttachedGroupPoliciesOutput, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetAttachedGroupPolicies", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListAttachedGroupPoliciesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetAttachedGroupPolicies", Operation: "ListAttachedGroupPolicies", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt iam.ListAttachedGroupPoliciesOutput
+				var n int
+				err := svc.iam.ListAttachedGroupPoliciesPagesWithContext(ctx, input.(*iam.ListAttachedGroupPoliciesInput), func(page *iam.ListAttachedGroupPoliciesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetAttachedGroupPolicies", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetAttachedGroupPolicies", NewError(svc.region, iam.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*iam.ListAttachedGroupPoliciesOutput)
+			}
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		if errs != nil {
+			return regionsOpts, errs
+		}
+
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListAttachedGroupPoliciesOutput)
+
+	return opts, err
 }
 
-func (c *connector) GetOpenIDConnectProviders(ctx context.Context, input *iam.ListOpenIDConnectProvidersInput) (map[string]iam.ListOpenIDConnectProvidersOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]iam.ListOpenIDConnectProvidersOutput{}
+func (c *connector) GetInstanceProfiles(ctx context.Context, input *iam.ListInstanceProfilesInput) (map[string]iam.ListInstanceProfilesOutput, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetInstanceProfiles", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListInstanceProfilesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetInstanceProfiles", Operation: "ListInstanceProfiles", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt iam.ListInstanceProfilesOutput
+				var n int
+				err := svc.iam.ListInstanceProfilesPagesWithContext(ctx, input.(*iam.ListInstanceProfilesInput), func(page *iam.ListInstanceProfilesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetInstanceProfiles", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetInstanceProfiles", NewError(svc.region, iam.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*iam.ListInstanceProfilesOutput)
+			}
+		}
 
-	for _, svc := range c.svcs {
-		if svc.iam == nil {
-			svc.iam = iam.New(svc.session)
+		if errs != nil {
+			return regionsOpts, errs
 		}
 
-		opt, err := svc.iam.ListOpenIDConnectProvidersWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, iam.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListInstanceProfilesOutput)
+
+	return opts, err
+}
+
+func (c *connector) GetOpenIDConnectProviders(ctx context.Context, input *iam.ListOpenIDConnectProvidersInput) (map[string]iam.ListOpenIDConnectProvidersOutput, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetOpenIDConnectProviders", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListOpenIDConnectProvidersOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetOpenIDConnectProviders", Operation: "ListOpenIDConnectProviders", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.iam.ListOpenIDConnectProvidersWithContext(ctx, input.(*iam.ListOpenIDConnectProvidersInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetOpenIDConnectProviders", NewError(svc.region, iam.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetOpenIDConnectProviders", svc.region, 1, *opt.(*iam.ListOpenIDConnectProvidersOutput)) {
+				regionsOpts[svc.region] = *opt.(*iam.ListOpenIDConnectProvidersOutput)
+			}
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		if errs != nil {
+			return regionsOpts, errs
+		}
+
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListOpenIDConnectProvidersOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetPolicies(ctx context.Context, input *iam.ListPoliciesInput) (map[string]iam.ListPoliciesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]iam.ListPoliciesOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.iam == nil {
-			svc.iam = iam.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetPolicies", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListPoliciesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetPolicies", Operation: "ListPolicies", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt iam.ListPoliciesOutput
+				var n int
+				err := svc.iam.ListPoliciesPagesWithContext(ctx, input.(*iam.ListPoliciesInput), func(page *iam.ListPoliciesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetPolicies", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetPolicies", NewError(svc.region, iam.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*iam.ListPoliciesOutput)
+			}
 		}
 
-		opt, err := svc.iam.ListPoliciesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, iam.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListPoliciesOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetRoles(ctx context.Context, input *iam.ListRolesInput) (map[string]iam.ListRolesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]iam.ListRolesOutput{}
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetRoles", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListRolesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetRoles", Operation: "ListRoles", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt iam.ListRolesOutput
+				var n int
+				err := svc.iam.ListRolesPagesWithContext(ctx, input.(*iam.ListRolesInput), func(page *iam.ListRolesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetRoles", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetRoles", NewError(svc.region, iam.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*iam.ListRolesOutput)
+			}
+		}
 
-	for _, svc := range c.svcs {
-		if svc.iam == nil {
-			svc.iam = iam.New(svc.session)
+		if errs != nil {
+			return regionsOpts, errs
 		}
 
-		opt, err := svc.iam.ListRolesWithContext(ctx, input)
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListRolesOutput)
+
+	return opts, err
+}
+
+// RoleWithPolicies is a iam.Role with the results of
+// the calls done for it by GetRolesWithPolicies
+type RoleWithPolicies struct {
+	Item             *iam.Role
+	Policies         *iam.ListRolePoliciesOutput
+	AttachedPolicies *iam.ListAttachedRolePoliciesOutput
+}
+
+func (c *connector) GetRolesWithPolicies(ctx context.Context, input *iam.ListRolesInput) (map[string][]RoleWithPolicies, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetRolesWithPolicies", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string][]RoleWithPolicies{}
+
+		// The items are sent once they have the results of the calls done for them
+		opts, err := c.GetRoles(WithResultFunc(ctx, nil), input)
 		if err != nil {
-			errs = append(errs, NewError(svc.region, iam.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+			for _, e := range asErrors(iam.ServiceName, err) {
+				errs = append(errs, sendError(ctx, "GetRolesWithPolicies", e))
+			}
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		for _, svc := range c.services(ctx) {
+			opt, ok := opts[svc.region]
+			if !ok {
+				continue
+			}
+
+			var items []RoleWithPolicies
+			for _, v := range flatten(opt, "Roles") {
+				items = append(items, RoleWithPolicies{Item: v.(*iam.Role)})
+			}
+
+			for i, it := range items {
+				fin := &iam.ListRolePoliciesInput{
+					RoleName: it.Item.RoleName,
+				}
+
+				fopt, err := c.invoke(ctx, &Call{Method: "GetRolesWithPolicies", Operation: "ListRolePolicies", Service: iam.ServiceName, Region: svc.region, Input: fin}, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+					var opt iam.ListRolePoliciesOutput
+					err := svc.iam.ListRolePoliciesPagesWithContext(ctx, input.(*iam.ListRolePoliciesInput), func(page *iam.ListRolePoliciesOutput, _ bool) bool {
+						appendPage(&opt, page)
+						return true
+					}, opts...)
+					return &opt, err
+				})
+				if err != nil {
+					errs = append(errs, sendError(ctx, "GetRolesWithPolicies", NewError(svc.region, iam.ServiceName, err)))
+					continue
+				}
+
+				items[i].Policies = fopt.(*iam.ListRolePoliciesOutput)
+			}
+
+			for i, it := range items {
+				fin := &iam.ListAttachedRolePoliciesInput{
+					RoleName: it.Item.RoleName,
+				}
+
+				fopt, err := c.invoke(ctx, &Call{Method: "GetRolesWithPolicies", Operation: "ListAttachedRolePolicies", Service: iam.ServiceName, Region: svc.region, Input: fin}, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+					var opt iam.ListAttachedRolePoliciesOutput
+					err := svc.iam.ListAttachedRolePoliciesPagesWithContext(ctx, input.(*iam.ListAttachedRolePoliciesInput), func(page *iam.ListAttachedRolePoliciesOutput, _ bool) bool {
+						appendPage(&opt, page)
+						return true
+					}, opts...)
+					return &opt, err
+				})
+				if err != nil {
+					errs = append(errs, sendError(ctx, "GetRolesWithPolicies", NewError(svc.region, iam.ServiceName, err)))
+					continue
+				}
+
+				items[i].AttachedPolicies = fopt.(*iam.ListAttachedRolePoliciesOutput)
+			}
+
+			if !sendOutput(ctx, "GetRolesWithPolicies", svc.region, 1, items) {
+				regionsOpts[svc.region] = items
+			}
+		}
+
+		if errs != nil {
+			return regionsOpts, errs
+		}
+
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string][]RoleWithPolicies)
+
+	return opts, err
 }
 
 func (c *connector) GetRolePolicies(ctx context.Context, input *iam.ListRolePoliciesInput) (map[string]iam.ListRolePoliciesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]iam.ListRolePoliciesOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.iam == nil {
-			svc.iam = iam.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetRolePolicies", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListRolePoliciesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetRolePolicies", Operation: "ListRolePolicies", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt iam.ListRolePoliciesOutput
+				var n int
+				err := svc.iam.ListRolePoliciesPagesWithContext(ctx, input.(*iam.ListRolePoliciesInput), func(page *iam.ListRolePoliciesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetRolePolicies", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetRolePolicies", NewError(svc.region, iam.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*iam.ListRolePoliciesOutput)
+			}
 		}
 
-		opt, err := svc.iam.ListRolePoliciesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, iam.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListRolePoliciesOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetAttachedRolePolicies(ctx context.Context, input *iam.ListAttachedRolePoliciesInput) (map[string]iam.ListAttachedRolePoliciesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]iam.ListAttachedRolePoliciesOutput{}
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetAttachedRolePolicies", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListAttachedRolePoliciesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetAttachedRolePolicies", Operation: "ListAttachedRolePolicies", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt iam.ListAttachedRolePoliciesOutput
+				var n int
+				err := svc.iam.ListAttachedRolePoliciesPagesWithContext(ctx, input.(*iam.ListAttachedRolePoliciesInput), func(page *iam.ListAttachedRolePoliciesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetAttachedRolePolicies", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetAttachedRolePolicies", NewError(svc.region, iam.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*iam.ListAttachedRolePoliciesOutput)
+			}
+		}
 
-	for _, svc := range c.svcs {
-		if svc.iam == nil {
-			svc.iam = iam.New(svc.session)
+		if errs != nil {
+			return regionsOpts, errs
 		}
 
-		opt, err := svc.iam.ListAttachedRolePoliciesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, iam.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListAttachedRolePoliciesOutput)
+
+	return opts, err
+}
+
+func (c *connector) GetRoleTags(ctx context.Context, input *iam.ListRoleTagsInput) (map[string]iam.ListRoleTagsOutput, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetRoleTags", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListRoleTagsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetRoleTags", Operation: "ListRoleTags", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.iam.ListRoleTagsWithContext(ctx, input.(*iam.ListRoleTagsInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetRoleTags", NewError(svc.region, iam.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetRoleTags", svc.region, 1, *opt.(*iam.ListRoleTagsOutput)) {
+				regionsOpts[svc.region] = *opt.(*iam.ListRoleTagsOutput)
+			}
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		if errs != nil {
+			return regionsOpts, errs
+		}
+
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListRoleTagsOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetSAMLProviders(ctx context.Context, input *iam.ListSAMLProvidersInput) (map[string]iam.ListSAMLProvidersOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]iam.ListSAMLProvidersOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.iam == nil {
-			svc.iam = iam.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetSAMLProviders", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListSAMLProvidersOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetSAMLProviders", Operation: "ListSAMLProviders", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.iam.ListSAMLProvidersWithContext(ctx, input.(*iam.ListSAMLProvidersInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetSAMLProviders", NewError(svc.region, iam.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetSAMLProviders", svc.region, 1, *opt.(*iam.ListSAMLProvidersOutput)) {
+				regionsOpts[svc.region] = *opt.(*iam.ListSAMLProvidersOutput)
+			}
 		}
 
-		opt, err := svc.iam.ListSAMLProvidersWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, iam.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListSAMLProvidersOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetServerCertificates(ctx context.Context, input *iam.ListServerCertificatesInput) (map[string]iam.ListServerCertificatesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]iam.ListServerCertificatesOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.iam == nil {
-			svc.iam = iam.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetServerCertificates", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListServerCertificatesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetServerCertificates", Operation: "ListServerCertificates", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt iam.ListServerCertificatesOutput
+				var n int
+				err := svc.iam.ListServerCertificatesPagesWithContext(ctx, input.(*iam.ListServerCertificatesInput), func(page *iam.ListServerCertificatesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetServerCertificates", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetServerCertificates", NewError(svc.region, iam.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*iam.ListServerCertificatesOutput)
+			}
 		}
 
-		opt, err := svc.iam.ListServerCertificatesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, iam.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListServerCertificatesOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetUsers(ctx context.Context, input *iam.ListUsersInput) (map[string]iam.ListUsersOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]iam.ListUsersOutput{}
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetUsers", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListUsersOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetUsers", Operation: "ListUsers", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt iam.ListUsersOutput
+				var n int
+				err := svc.iam.ListUsersPagesWithContext(ctx, input.(*iam.ListUsersInput), func(page *iam.ListUsersOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetUsers", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetUsers", NewError(svc.region, iam.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*iam.ListUsersOutput)
+			}
+		}
 
-	for _, svc := range c.svcs {
-		if svc.iam == nil {
-			svc.iam = iam.New(svc.session)
+		if errs != nil {
+			return regionsOpts, errs
 		}
 
-		opt, err := svc.iam.ListUsersWithContext(ctx, input)
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListUsersOutput)
+
+	return opts, err
+}
+
+// UserWithPolicies is a iam.User with the results of
+// the calls done for it by GetUsersWithPolicies
+type UserWithPolicies struct {
+	Item             *iam.User
+	Policies         *iam.ListUserPoliciesOutput
+	AttachedPolicies *iam.ListAttachedUserPoliciesOutput
+}
+
+func (c *connector) GetUsersWithPolicies(ctx context.Context, input *iam.ListUsersInput) (map[string][]UserWithPolicies, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetUsersWithPolicies", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string][]UserWithPolicies{}
+
+		// The items are sent once they have the results of the calls done for them
+		opts, err := c.GetUsers(WithResultFunc(ctx, nil), input)
 		if err != nil {
-			errs = append(errs, NewError(svc.region, iam.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+			for _, e := range asErrors(iam.ServiceName, err) {
+				errs = append(errs, sendError(ctx, "GetUsersWithPolicies", e))
+			}
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		for _, svc := range c.services(ctx) {
+			opt, ok := opts[svc.region]
+			if !ok {
+				continue
+			}
+
+			var items []UserWithPolicies
+			for _, v := range flatten(opt, "Users") {
+				items = append(items, UserWithPolicies{Item: v.(*iam.User)})
+			}
+
+			for i, it := range items {
+				fin := &iam.ListUserPoliciesInput{
+					UserName: it.Item.UserName,
+				}
+
+				fopt, err := c.invoke(ctx, &Call{Method: "GetUsersWithPolicies", Operation: "ListUserPolicies", Service: iam.ServiceName, Region: svc.region, Input: fin}, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+					var opt iam.ListUserPoliciesOutput
+					err := svc.iam.ListUserPoliciesPagesWithContext(ctx, input.(*iam.ListUserPoliciesInput), func(page *iam.ListUserPoliciesOutput, _ bool) bool {
+						appendPage(&opt, page)
+						return true
+					}, opts...)
+					return &opt, err
+				})
+				if err != nil {
+					errs = append(errs, sendError(ctx, "GetUsersWithPolicies", NewError(svc.region, iam.ServiceName, err)))
+					continue
+				}
+
+				items[i].Policies = fopt.(*iam.ListUserPoliciesOutput)
+			}
+
+			for i, it := range items {
+				fin := &iam.ListAttachedUserPoliciesInput{
+					UserName: it.Item.UserName,
+				}
+
+				fopt, err := c.invoke(ctx, &Call{Method: "GetUsersWithPolicies", Operation: "ListAttachedUserPolicies", Service: iam.ServiceName, Region: svc.region, Input: fin}, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+					var opt iam.ListAttachedUserPoliciesOutput
+					err := svc.iam.ListAttachedUserPoliciesPagesWithContext(ctx, input.(*iam.ListAttachedUserPoliciesInput), func(page *iam.ListAttachedUserPoliciesOutput, _ bool) bool {
+						appendPage(&opt, page)
+						return true
+					}, opts...)
+					return &opt, err
+				})
+				if err != nil {
+					errs = append(errs, sendError(ctx, "GetUsersWithPolicies", NewError(svc.region, iam.ServiceName, err)))
+					continue
+				}
+
+				items[i].AttachedPolicies = fopt.(*iam.ListAttachedUserPoliciesOutput)
+			}
+
+			if !sendOutput(ctx, "GetUsersWithPolicies", svc.region, 1, items) {
+				regionsOpts[svc.region] = items
+			}
+		}
+
+		if errs != nil {
+			return regionsOpts, errs
+		}
 
-	return regionsOpts, nil
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string][]UserWithPolicies)
+
+	return opts, err
 }
 
 func (c *connector) GetUserPolicies(ctx context.Context, input *iam.ListUserPoliciesInput) (map[string]iam.ListUserPoliciesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]iam.ListUserPoliciesOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.iam == nil {
-			svc.iam = iam.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetUserPolicies", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListUserPoliciesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetUserPolicies", Operation: "ListUserPolicies", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt iam.ListUserPoliciesOutput
+				var n int
+				err := svc.iam.ListUserPoliciesPagesWithContext(ctx, input.(*iam.ListUserPoliciesInput), func(page *iam.ListUserPoliciesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetUserPolicies", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetUserPolicies", NewError(svc.region, iam.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*iam.ListUserPoliciesOutput)
+			}
 		}
 
-		opt, err := svc.iam.ListUserPoliciesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, iam.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListUserPoliciesOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetAttachedUserPolicies(ctx context.Context, input *iam.ListAttachedUserPoliciesInput) (map[string]iam.ListAttachedUserPoliciesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]iam.ListAttachedUserPoliciesOutput{}
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetAttachedUserPolicies", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListAttachedUserPoliciesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetAttachedUserPolicies", Operation: "ListAttachedUserPolicies", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt iam.ListAttachedUserPoliciesOutput
+				var n int
+				err := svc.iam.ListAttachedUserPoliciesPagesWithContext(ctx, input.(*iam.ListAttachedUserPoliciesInput), func(page *iam.ListAttachedUserPoliciesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetAttachedUserPolicies", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetAttachedUserPolicies", NewError(svc.region, iam.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*iam.ListAttachedUserPoliciesOutput)
+			}
+		}
 
-	for _, svc := range c.svcs {
-		if svc.iam == nil {
-			svc.iam = iam.New(svc.session)
+		if errs != nil {
+			return regionsOpts, errs
 		}
 
-		opt, err := svc.iam.ListAttachedUserPoliciesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, iam.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListAttachedUserPoliciesOutput)
+
+	return opts, err
+}
+
+func (c *connector) GetUserTags(ctx context.Context, input *iam.ListUserTagsInput) (map[string]iam.ListUserTagsOutput, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetUserTags", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListUserTagsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetUserTags", Operation: "ListUserTags", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.iam.ListUserTagsWithContext(ctx, input.(*iam.ListUserTagsInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetUserTags", NewError(svc.region, iam.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetUserTags", svc.region, 1, *opt.(*iam.ListUserTagsOutput)) {
+				regionsOpts[svc.region] = *opt.(*iam.ListUserTagsOutput)
+			}
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		if errs != nil {
+			return regionsOpts, errs
+		}
 
-	return regionsOpts, nil
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListUserTagsOutput)
+
+	return opts, err
 }
 
-func (c *connector) GetSSHPublicKey(ctx context.Context, input *iam.GetSSHPublicKeyInput) (map[string]iam.GetSSHPublicKeyOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]iam.GetSSHPublicKeyOutput{}
+func (c *connector) GetMFADevices(ctx context.Context, input *iam.ListMFADevicesInput) (map[string]iam.ListMFADevicesOutput, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetMFADevices", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.ListMFADevicesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetMFADevices", Operation: "ListMFADevices", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt iam.ListMFADevicesOutput
+				var n int
+				err := svc.iam.ListMFADevicesPagesWithContext(ctx, input.(*iam.ListMFADevicesInput), func(page *iam.ListMFADevicesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetMFADevices", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetMFADevices", NewError(svc.region, iam.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*iam.ListMFADevicesOutput)
+			}
+		}
 
-	for _, svc := range c.svcs {
-		if svc.iam == nil {
-			svc.iam = iam.New(svc.session)
+		if errs != nil {
+			return regionsOpts, errs
 		}
 
-		opt, err := svc.iam.GetSSHPublicKeyWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, iam.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.ListMFADevicesOutput)
+
+	return opts, err
+}
+
+func (c *connector) GetSSHPublicKey(ctx context.Context, input *iam.GetSSHPublicKeyInput) (map[string]iam.GetSSHPublicKeyOutput, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetSSHPublicKey", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.GetSSHPublicKeyOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetSSHPublicKey", Operation: "GetSSHPublicKey", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.iam.GetSSHPublicKeyWithContext(ctx, input.(*iam.GetSSHPublicKeyInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetSSHPublicKey", NewError(svc.region, iam.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetSSHPublicKey", svc.region, 1, *opt.(*iam.GetSSHPublicKeyOutput)) {
+				regionsOpts[svc.region] = *opt.(*iam.GetSSHPublicKeyOutput)
+			}
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		if errs != nil {
+			return regionsOpts, errs
+		}
+
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.GetSSHPublicKeyOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetActiveReceiptRuleSet(ctx context.Context, input *ses.DescribeActiveReceiptRuleSetInput) (map[string]ses.DescribeActiveReceiptRuleSetOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]ses.DescribeActiveReceiptRuleSetOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.ses == nil {
-			svc.ses = ses.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetActiveReceiptRuleSet", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]ses.DescribeActiveReceiptRuleSetOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetActiveReceiptRuleSet", Operation: "DescribeActiveReceiptRuleSet", Service: ses.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.ses.DescribeActiveReceiptRuleSetWithContext(ctx, input.(*ses.DescribeActiveReceiptRuleSetInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetActiveReceiptRuleSet", NewError(svc.region, ses.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetActiveReceiptRuleSet", svc.region, 1, *opt.(*ses.DescribeActiveReceiptRuleSetOutput)) {
+				regionsOpts[svc.region] = *opt.(*ses.DescribeActiveReceiptRuleSetOutput)
+			}
 		}
 
-		opt, err := svc.ses.DescribeActiveReceiptRuleSetWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, ses.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]ses.DescribeActiveReceiptRuleSetOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetIdentities(ctx context.Context, input *ses.ListIdentitiesInput) (map[string]ses.ListIdentitiesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]ses.ListIdentitiesOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.ses == nil {
-			svc.ses = ses.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetIdentities", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]ses.ListIdentitiesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetIdentities", Operation: "ListIdentities", Service: ses.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt ses.ListIdentitiesOutput
+				var n int
+				err := svc.ses.ListIdentitiesPagesWithContext(ctx, input.(*ses.ListIdentitiesInput), func(page *ses.ListIdentitiesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetIdentities", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetIdentities", NewError(svc.region, ses.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*ses.ListIdentitiesOutput)
+			}
 		}
 
-		opt, err := svc.ses.ListIdentitiesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, ses.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]ses.ListIdentitiesOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetReceiptFilters(ctx context.Context, input *ses.ListReceiptFiltersInput) (map[string]ses.ListReceiptFiltersOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]ses.ListReceiptFiltersOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.ses == nil {
-			svc.ses = ses.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetReceiptFilters", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]ses.ListReceiptFiltersOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetReceiptFilters", Operation: "ListReceiptFilters", Service: ses.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.ses.ListReceiptFiltersWithContext(ctx, input.(*ses.ListReceiptFiltersInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetReceiptFilters", NewError(svc.region, ses.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetReceiptFilters", svc.region, 1, *opt.(*ses.ListReceiptFiltersOutput)) {
+				regionsOpts[svc.region] = *opt.(*ses.ListReceiptFiltersOutput)
+			}
 		}
 
-		opt, err := svc.ses.ListReceiptFiltersWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, ses.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]ses.ListReceiptFiltersOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetConfigurationSets(ctx context.Context, input *ses.ListConfigurationSetsInput) (map[string]ses.ListConfigurationSetsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]ses.ListConfigurationSetsOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.ses == nil {
-			svc.ses = ses.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetConfigurationSets", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]ses.ListConfigurationSetsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetConfigurationSets", Operation: "ListConfigurationSets", Service: ses.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.ses.ListConfigurationSetsWithContext(ctx, input.(*ses.ListConfigurationSetsInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetConfigurationSets", NewError(svc.region, ses.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetConfigurationSets", svc.region, 1, *opt.(*ses.ListConfigurationSetsOutput)) {
+				regionsOpts[svc.region] = *opt.(*ses.ListConfigurationSetsOutput)
+			}
 		}
 
-		opt, err := svc.ses.ListConfigurationSetsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, ses.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]ses.ListConfigurationSetsOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetIdentityNotificationAttributes(ctx context.Context, input *ses.GetIdentityNotificationAttributesInput) (map[string]ses.GetIdentityNotificationAttributesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]ses.GetIdentityNotificationAttributesOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.ses == nil {
-			svc.ses = ses.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetIdentityNotificationAttributes", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]ses.GetIdentityNotificationAttributesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetIdentityNotificationAttributes", Operation: "GetIdentityNotificationAttributes", Service: ses.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.ses.GetIdentityNotificationAttributesWithContext(ctx, input.(*ses.GetIdentityNotificationAttributesInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetIdentityNotificationAttributes", NewError(svc.region, ses.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetIdentityNotificationAttributes", svc.region, 1, *opt.(*ses.GetIdentityNotificationAttributesOutput)) {
+				regionsOpts[svc.region] = *opt.(*ses.GetIdentityNotificationAttributesOutput)
+			}
 		}
 
-		opt, err := svc.ses.GetIdentityNotificationAttributesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, ses.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]ses.GetIdentityNotificationAttributesOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetTemplates(ctx context.Context, input *ses.ListTemplatesInput) (map[string]ses.ListTemplatesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]ses.ListTemplatesOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.ses == nil {
-			svc.ses = ses.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetTemplates", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]ses.ListTemplatesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetTemplates", Operation: "ListTemplates", Service: ses.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.ses.ListTemplatesWithContext(ctx, input.(*ses.ListTemplatesInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetTemplates", NewError(svc.region, ses.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetTemplates", svc.region, 1, *opt.(*ses.ListTemplatesOutput)) {
+				regionsOpts[svc.region] = *opt.(*ses.ListTemplatesOutput)
+			}
 		}
 
-		opt, err := svc.ses.ListTemplatesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, ses.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]ses.ListTemplatesOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetReusableDelegationSets(ctx context.Context, input *route53.ListReusableDelegationSetsInput) (map[string]route53.ListReusableDelegationSetsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]route53.ListReusableDelegationSetsOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.route53 == nil {
-			svc.route53 = route53.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetReusableDelegationSets", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]route53.ListReusableDelegationSetsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetReusableDelegationSets", Operation: "ListReusableDelegationSets", Service: route53.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.route53.ListReusableDelegationSetsWithContext(ctx, input.(*route53.ListReusableDelegationSetsInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetReusableDelegationSets", NewError(svc.region, route53.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetReusableDelegationSets", svc.region, 1, *opt.(*route53.ListReusableDelegationSetsOutput)) {
+				regionsOpts[svc.region] = *opt.(*route53.ListReusableDelegationSetsOutput)
+			}
 		}
 
-		opt, err := svc.route53.ListReusableDelegationSetsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, route53.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]route53.ListReusableDelegationSetsOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetHealthChecks(ctx context.Context, input *route53.ListHealthChecksInput) (map[string]route53.ListHealthChecksOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]route53.ListHealthChecksOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.route53 == nil {
-			svc.route53 = route53.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetHealthChecks", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]route53.ListHealthChecksOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetHealthChecks", Operation: "ListHealthChecks", Service: route53.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt route53.ListHealthChecksOutput
+				var n int
+				err := svc.route53.ListHealthChecksPagesWithContext(ctx, input.(*route53.ListHealthChecksInput), func(page *route53.ListHealthChecksOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetHealthChecks", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetHealthChecks", NewError(svc.region, route53.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*route53.ListHealthChecksOutput)
+			}
 		}
 
-		opt, err := svc.route53.ListHealthChecksWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, route53.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]route53.ListHealthChecksOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetQueryLoggingConfigs(ctx context.Context, input *route53.ListQueryLoggingConfigsInput) (map[string]route53.ListQueryLoggingConfigsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]route53.ListQueryLoggingConfigsOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.route53 == nil {
-			svc.route53 = route53.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetQueryLoggingConfigs", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]route53.ListQueryLoggingConfigsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetQueryLoggingConfigs", Operation: "ListQueryLoggingConfigs", Service: route53.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.route53.ListQueryLoggingConfigsWithContext(ctx, input.(*route53.ListQueryLoggingConfigsInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetQueryLoggingConfigs", NewError(svc.region, route53.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetQueryLoggingConfigs", svc.region, 1, *opt.(*route53.ListQueryLoggingConfigsOutput)) {
+				regionsOpts[svc.region] = *opt.(*route53.ListQueryLoggingConfigsOutput)
+			}
 		}
 
-		opt, err := svc.route53.ListQueryLoggingConfigsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, route53.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]route53.ListQueryLoggingConfigsOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetResourceRecordSets(ctx context.Context, input *route53.ListResourceRecordSetsInput) (map[string]route53.ListResourceRecordSetsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]route53.ListResourceRecordSetsOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.route53 == nil {
-			svc.route53 = route53.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetResourceRecordSets", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]route53.ListResourceRecordSetsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetResourceRecordSets", Operation: "ListResourceRecordSets", Service: route53.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt route53.ListResourceRecordSetsOutput
+				var n int
+				err := svc.route53.ListResourceRecordSetsPagesWithContext(ctx, input.(*route53.ListResourceRecordSetsInput), func(page *route53.ListResourceRecordSetsOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetResourceRecordSets", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetResourceRecordSets", NewError(svc.region, route53.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*route53.ListResourceRecordSetsOutput)
+			}
 		}
 
-		opt, err := svc.route53.ListResourceRecordSetsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, route53.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]route53.ListResourceRecordSetsOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetHostedZones(ctx context.Context, input *route53.ListHostedZonesInput) (map[string]route53.ListHostedZonesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]route53.ListHostedZonesOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.route53 == nil {
-			svc.route53 = route53.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetHostedZones", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]route53.ListHostedZonesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetHostedZones", Operation: "ListHostedZones", Service: route53.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt route53.ListHostedZonesOutput
+				var n int
+				err := svc.route53.ListHostedZonesPagesWithContext(ctx, input.(*route53.ListHostedZonesInput), func(page *route53.ListHostedZonesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetHostedZones", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetHostedZones", NewError(svc.region, route53.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*route53.ListHostedZonesOutput)
+			}
 		}
 
-		opt, err := svc.route53.ListHostedZonesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, route53.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]route53.ListHostedZonesOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetVPCAssociationAuthorizations(ctx context.Context, input *route53.ListVPCAssociationAuthorizationsInput) (map[string]route53.ListVPCAssociationAuthorizationsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]route53.ListVPCAssociationAuthorizationsOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.route53 == nil {
-			svc.route53 = route53.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetVPCAssociationAuthorizations", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]route53.ListVPCAssociationAuthorizationsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "GetVPCAssociationAuthorizations", Operation: "ListVPCAssociationAuthorizations", Service: route53.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.route53.ListVPCAssociationAuthorizationsWithContext(ctx, input.(*route53.ListVPCAssociationAuthorizationsInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetVPCAssociationAuthorizations", NewError(svc.region, route53.ServiceName, err)))
+			} else if !sendOutput(ctx, "GetVPCAssociationAuthorizations", svc.region, 1, *opt.(*route53.ListVPCAssociationAuthorizationsOutput)) {
+				regionsOpts[svc.region] = *opt.(*route53.ListVPCAssociationAuthorizationsOutput)
+			}
 		}
 
-		opt, err := svc.route53.ListVPCAssociationAuthorizationsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, route53.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]route53.ListVPCAssociationAuthorizationsOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetResolverEndpoints(ctx context.Context, input *route53resolver.ListResolverEndpointsInput) (map[string]route53resolver.ListResolverEndpointsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]route53resolver.ListResolverEndpointsOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.route53resolver == nil {
-			svc.route53resolver = route53resolver.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetResolverEndpoints", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]route53resolver.ListResolverEndpointsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetResolverEndpoints", Operation: "ListResolverEndpoints", Service: route53resolver.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt route53resolver.ListResolverEndpointsOutput
+				var n int
+				err := svc.route53resolver.ListResolverEndpointsPagesWithContext(ctx, input.(*route53resolver.ListResolverEndpointsInput), func(page *route53resolver.ListResolverEndpointsOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetResolverEndpoints", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetResolverEndpoints", NewError(svc.region, route53resolver.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*route53resolver.ListResolverEndpointsOutput)
+			}
 		}
 
-		opt, err := svc.route53resolver.ListResolverEndpointsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, route53resolver.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]route53resolver.ListResolverEndpointsOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
 
 func (c *connector) GetResolverRules(ctx context.Context, input *route53resolver.ListResolverRulesInput) (map[string]route53resolver.ListResolverRulesOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]route53resolver.ListResolverRulesOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.route53resolver == nil {
-			svc.route53resolver = route53resolver.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetResolverRules", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]route53resolver.ListResolverRulesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetResolverRules", Operation: "ListResolverRules", Service: route53resolver.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt route53resolver.ListResolverRulesOutput
+				var n int
+				err := svc.route53resolver.ListResolverRulesPagesWithContext(ctx, input.(*route53resolver.ListResolverRulesInput), func(page *route53resolver.ListResolverRulesOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetResolverRules", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetResolverRules", NewError(svc.region, route53resolver.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*route53resolver.ListResolverRulesOutput)
+			}
 		}
 
-		opt, err := svc.route53resolver.ListResolverRulesWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, route53resolver.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
 
-	return regionsOpts, nil
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]route53resolver.ListResolverRulesOutput)
+
+	return opts, err
 }
 
 func (c *connector) GetResolverRuleAssociations(ctx context.Context, input *route53resolver.ListResolverRuleAssociationsInput) (map[string]route53resolver.ListResolverRuleAssociationsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]route53resolver.ListResolverRuleAssociationsOutput{}
-
-	for _, svc := range c.svcs {
-		if svc.route53resolver == nil {
-			svc.route53resolver = route53resolver.New(svc.session)
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetResolverRuleAssociations", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]route53resolver.ListResolverRuleAssociationsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The items of all the pages are returned on a single
+			// output, and sent page by page to the ResultFunc
+			call := &Call{Method: "GetResolverRuleAssociations", Operation: "ListResolverRuleAssociations", Service: route53resolver.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt route53resolver.ListResolverRuleAssociationsOutput
+				var n int
+				err := svc.route53resolver.ListResolverRuleAssociationsPagesWithContext(ctx, input.(*route53resolver.ListResolverRuleAssociationsInput), func(page *route53resolver.ListResolverRuleAssociationsOutput, _ bool) bool {
+					n++
+					if !sendOutput(ctx, "GetResolverRuleAssociations", svc.region, n, *page) {
+						appendPage(&opt, page)
+					}
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetResolverRuleAssociations", NewError(svc.region, route53resolver.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*route53resolver.ListResolverRuleAssociationsOutput)
+			}
 		}
 
-		opt, err := svc.route53resolver.ListResolverRuleAssociationsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, route53resolver.ServiceName, err))
-		} else {
-			regionsOpts[svc.region] = *opt
+		if errs != nil {
+			return regionsOpts, errs
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]route53resolver.ListResolverRuleAssociationsOutput)
+
+	return opts, err
+}
+
+// RegionInstance pairs a ec2.Instance with the region it belongs to
+type RegionInstance struct {
+	Region string
+	Item   *ec2.Instance
+}
+
+// RegionVpc pairs a ec2.Vpc with the region it belongs to
+type RegionVpc struct {
+	Region string
+	Item   *ec2.Vpc
+}
+
+// RegionImage pairs a ec2.Image with the region it belongs to
+type RegionImage struct {
+	Region string
+	Item   *ec2.Image
+}
+
+// RegionSecurityGroup pairs a ec2.SecurityGroup with the region it belongs to
+type RegionSecurityGroup struct {
+	Region string
+	Item   *ec2.SecurityGroup
+}
+
+// RegionSubnet pairs a ec2.Subnet with the region it belongs to
+type RegionSubnet struct {
+	Region string
+	Item   *ec2.Subnet
+}
+
+// RegionVolume pairs a ec2.Volume with the region it belongs to
+type RegionVolume struct {
+	Region string
+	Item   *ec2.Volume
+}
+
+// RegionSnapshot pairs a ec2.Snapshot with the region it belongs to
+type RegionSnapshot struct {
+	Region string
+	Item   *ec2.Snapshot
+}
+
+// RegionLaunchTemplate pairs a ec2.LaunchTemplate with the region it belongs to
+type RegionLaunchTemplate struct {
+	Region string
+	Item   *ec2.LaunchTemplate
+}
+
+// RegionAutoScalingGroup pairs a autoscaling.Group with the region it belongs to
+type RegionAutoScalingGroup struct {
+	Region string
+	Item   *autoscaling.Group
+}
+
+// RegionLaunchConfiguration pairs a autoscaling.LaunchConfiguration with the region it belongs to
+type RegionLaunchConfiguration struct {
+	Region string
+	Item   *autoscaling.LaunchConfiguration
+}
+
+// RegionCacheCluster pairs a elasticache.CacheCluster with the region it belongs to
+type RegionCacheCluster struct {
+	Region string
+	Item   *elasticache.CacheCluster
+}
+
+// RegionLoadBalancerDescription pairs a elb.LoadBalancerDescription with the region it belongs to
+type RegionLoadBalancerDescription struct {
+	Region string
+	Item   *elb.LoadBalancerDescription
+}
+
+// RegionLoadBalancer pairs a elbv2.LoadBalancer with the region it belongs to
+type RegionLoadBalancer struct {
+	Region string
+	Item   *elbv2.LoadBalancer
+}
+
+// RegionDBInstance pairs a rds.DBInstance with the region it belongs to
+type RegionDBInstance struct {
+	Region string
+	Item   *rds.DBInstance
+}
+
+// RegionBucket pairs a s3.Bucket with the region it belongs to
+type RegionBucket struct {
+	Region string
+	Item   *s3.Bucket
+}
+
+// RegionObject pairs a s3.Object with the region it belongs to
+type RegionObject struct {
+	Region string
+	Item   *s3.Object
+}
+
+// RegionDistributionSummary pairs a cloudfront.DistributionSummary with the region it belongs to
+type RegionDistributionSummary struct {
+	Region string
+	Item   *cloudfront.DistributionSummary
+}
+
+// RegionPublicKeySummary pairs a cloudfront.PublicKeySummary with the region it belongs to
+type RegionPublicKeySummary struct {
+	Region string
+	Item   *cloudfront.PublicKeySummary
+}
+
+// RegionOriginAccessIdentitySummary pairs a cloudfront.OriginAccessIdentitySummary with the region it belongs to
+type RegionOriginAccessIdentitySummary struct {
+	Region string
+	Item   *cloudfront.OriginAccessIdentitySummary
+}
+
+// RegionAccessKeyMetadata pairs a iam.AccessKeyMetadata with the region it belongs to
+type RegionAccessKeyMetadata struct {
+	Region string
+	Item   *iam.AccessKeyMetadata
+}
+
+// RegionGroup pairs a iam.Group with the region it belongs to
+type RegionGroup struct {
+	Region string
+	Item   *iam.Group
+}
+
+// RegionAttachedPolicy pairs a iam.AttachedPolicy with the region it belongs to
+type RegionAttachedPolicy struct {
+	Region string
+	Item   *iam.AttachedPolicy
+}
+
+// RegionInstanceProfile pairs a iam.InstanceProfile with the region it belongs to
+type RegionInstanceProfile struct {
+	Region string
+	Item   *iam.InstanceProfile
+}
+
+// RegionOpenIDConnectProviderListEntry pairs a iam.OpenIDConnectProviderListEntry with the region it belongs to
+type RegionOpenIDConnectProviderListEntry struct {
+	Region string
+	Item   *iam.OpenIDConnectProviderListEntry
+}
+
+// RegionPolicy pairs a iam.Policy with the region it belongs to
+type RegionPolicy struct {
+	Region string
+	Item   *iam.Policy
+}
+
+// RegionRole pairs a iam.Role with the region it belongs to
+type RegionRole struct {
+	Region string
+	Item   *iam.Role
+}
+
+// RegionSAMLProviderListEntry pairs a iam.SAMLProviderListEntry with the region it belongs to
+type RegionSAMLProviderListEntry struct {
+	Region string
+	Item   *iam.SAMLProviderListEntry
+}
+
+// RegionServerCertificateMetadata pairs a iam.ServerCertificateMetadata with the region it belongs to
+type RegionServerCertificateMetadata struct {
+	Region string
+	Item   *iam.ServerCertificateMetadata
+}
+
+// RegionUser pairs a iam.User with the region it belongs to
+type RegionUser struct {
+	Region string
+	Item   *iam.User
+}
+
+// RegionMFADevice pairs a iam.MFADevice with the region it belongs to
+type RegionMFADevice struct {
+	Region string
+	Item   *iam.MFADevice
+}
+
+// RegionReceiptFilter pairs a ses.ReceiptFilter with the region it belongs to
+type RegionReceiptFilter struct {
+	Region string
+	Item   *ses.ReceiptFilter
+}
+
+// RegionConfigurationSet pairs a ses.ConfigurationSet with the region it belongs to
+type RegionConfigurationSet struct {
+	Region string
+	Item   *ses.ConfigurationSet
+}
+
+// RegionTemplateMetadata pairs a ses.TemplateMetadata with the region it belongs to
+type RegionTemplateMetadata struct {
+	Region string
+	Item   *ses.TemplateMetadata
+}
+
+// RegionDelegationSet pairs a route53.DelegationSet with the region it belongs to
+type RegionDelegationSet struct {
+	Region string
+	Item   *route53.DelegationSet
+}
+
+// RegionHealthCheck pairs a route53.HealthCheck with the region it belongs to
+type RegionHealthCheck struct {
+	Region string
+	Item   *route53.HealthCheck
+}
+
+// RegionQueryLoggingConfig pairs a route53.QueryLoggingConfig with the region it belongs to
+type RegionQueryLoggingConfig struct {
+	Region string
+	Item   *route53.QueryLoggingConfig
+}
+
+// RegionResourceRecordSet pairs a route53.ResourceRecordSet with the region it belongs to
+type RegionResourceRecordSet struct {
+	Region string
+	Item   *route53.ResourceRecordSet
+}
+
+// RegionHostedZone pairs a route53.HostedZone with the region it belongs to
+type RegionHostedZone struct {
+	Region string
+	Item   *route53.HostedZone
+}
+
+// RegionResolverEndpoint pairs a route53resolver.ResolverEndpoint with the region it belongs to
+type RegionResolverEndpoint struct {
+	Region string
+	Item   *route53resolver.ResolverEndpoint
+}
+
+// RegionResolverRule pairs a route53resolver.ResolverRule with the region it belongs to
+type RegionResolverRule struct {
+	Region string
+	Item   *route53resolver.ResolverRule
+}
+
+// RegionResolverRuleAssociation pairs a route53resolver.ResolverRuleAssociation with the region it belongs to
+type RegionResolverRuleAssociation struct {
+	Region string
+	Item   *route53resolver.ResolverRuleAssociation
+}
+
+// RegionResourceTagMapping pairs a resourcegroupstaggingapi.ResourceTagMapping with the region it belongs to
+type RegionResourceTagMapping struct {
+	Region string
+	Item   *resourcegroupstaggingapi.ResourceTagMapping
+}
+
+// ListAllInstances returns the items on the Reservations.Instances path of the GetInstances
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetInstances, so the items of the regions without
+// errors are always returned.
+func ListAllInstances(ctx context.Context, r AWSReader, input *ec2.DescribeInstancesInput) ([]RegionInstance, error) {
+	var items []RegionInstance
+
+	opts, err := r.GetInstances(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "Reservations.Instances") {
+			items = append(items, RegionInstance{Region: region, Item: v.(*ec2.Instance)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllVpcs returns the items on the Vpcs path of the GetVpcs
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetVpcs, so the items of the regions without
+// errors are always returned.
+func ListAllVpcs(ctx context.Context, r AWSReader, input *ec2.DescribeVpcsInput) ([]RegionVpc, error) {
+	var items []RegionVpc
+
+	opts, err := r.GetVpcs(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "Vpcs") {
+			items = append(items, RegionVpc{Region: region, Item: v.(*ec2.Vpc)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllImages returns the items on the Images path of the GetImages
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetImages, so the items of the regions without
+// errors are always returned.
+func ListAllImages(ctx context.Context, r AWSReader, input *ec2.DescribeImagesInput) ([]RegionImage, error) {
+	var items []RegionImage
+
+	opts, err := r.GetImages(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "Images") {
+			items = append(items, RegionImage{Region: region, Item: v.(*ec2.Image)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllOwnImages returns the items on the Images path of the GetOwnImages
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetOwnImages, so the items of the regions without
+// errors are always returned.
+func ListAllOwnImages(ctx context.Context, r AWSReader, input *ec2.DescribeImagesInput) ([]RegionImage, error) {
+	var items []RegionImage
+
+	opts, err := r.GetOwnImages(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "Images") {
+			items = append(items, RegionImage{Region: region, Item: v.(*ec2.Image)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllSecurityGroups returns the items on the SecurityGroups path of the GetSecurityGroups
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetSecurityGroups, so the items of the regions without
+// errors are always returned.
+func ListAllSecurityGroups(ctx context.Context, r AWSReader, input *ec2.DescribeSecurityGroupsInput) ([]RegionSecurityGroup, error) {
+	var items []RegionSecurityGroup
+
+	opts, err := r.GetSecurityGroups(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "SecurityGroups") {
+			items = append(items, RegionSecurityGroup{Region: region, Item: v.(*ec2.SecurityGroup)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllSubnets returns the items on the Subnets path of the GetSubnets
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetSubnets, so the items of the regions without
+// errors are always returned.
+func ListAllSubnets(ctx context.Context, r AWSReader, input *ec2.DescribeSubnetsInput) ([]RegionSubnet, error) {
+	var items []RegionSubnet
+
+	opts, err := r.GetSubnets(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "Subnets") {
+			items = append(items, RegionSubnet{Region: region, Item: v.(*ec2.Subnet)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllVolumes returns the items on the Volumes path of the GetVolumes
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetVolumes, so the items of the regions without
+// errors are always returned.
+func ListAllVolumes(ctx context.Context, r AWSReader, input *ec2.DescribeVolumesInput) ([]RegionVolume, error) {
+	var items []RegionVolume
+
+	opts, err := r.GetVolumes(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "Volumes") {
+			items = append(items, RegionVolume{Region: region, Item: v.(*ec2.Volume)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllSnapshots returns the items on the Snapshots path of the GetSnapshots
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetSnapshots, so the items of the regions without
+// errors are always returned.
+func ListAllSnapshots(ctx context.Context, r AWSReader, input *ec2.DescribeSnapshotsInput) ([]RegionSnapshot, error) {
+	var items []RegionSnapshot
+
+	opts, err := r.GetSnapshots(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "Snapshots") {
+			items = append(items, RegionSnapshot{Region: region, Item: v.(*ec2.Snapshot)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllOwnSnapshots returns the items on the Snapshots path of the GetOwnSnapshots
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetOwnSnapshots, so the items of the regions without
+// errors are always returned.
+func ListAllOwnSnapshots(ctx context.Context, r AWSReader, input *ec2.DescribeSnapshotsInput) ([]RegionSnapshot, error) {
+	var items []RegionSnapshot
+
+	opts, err := r.GetOwnSnapshots(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "Snapshots") {
+			items = append(items, RegionSnapshot{Region: region, Item: v.(*ec2.Snapshot)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllLaunchTemplates returns the items on the LaunchTemplates path of the GetLaunchTemplates
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetLaunchTemplates, so the items of the regions without
+// errors are always returned.
+func ListAllLaunchTemplates(ctx context.Context, r AWSReader, input *ec2.DescribeLaunchTemplatesInput) ([]RegionLaunchTemplate, error) {
+	var items []RegionLaunchTemplate
+
+	opts, err := r.GetLaunchTemplates(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "LaunchTemplates") {
+			items = append(items, RegionLaunchTemplate{Region: region, Item: v.(*ec2.LaunchTemplate)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllAutoScalingGroups returns the items on the AutoScalingGroups path of the GetAutoScalingGroups
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetAutoScalingGroups, so the items of the regions without
+// errors are always returned.
+func ListAllAutoScalingGroups(ctx context.Context, r AWSReader, input *autoscaling.DescribeAutoScalingGroupsInput) ([]RegionAutoScalingGroup, error) {
+	var items []RegionAutoScalingGroup
+
+	opts, err := r.GetAutoScalingGroups(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "AutoScalingGroups") {
+			items = append(items, RegionAutoScalingGroup{Region: region, Item: v.(*autoscaling.Group)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllLaunchConfigurations returns the items on the LaunchConfigurations path of the GetLaunchConfigurations
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetLaunchConfigurations, so the items of the regions without
+// errors are always returned.
+func ListAllLaunchConfigurations(ctx context.Context, r AWSReader, input *autoscaling.DescribeLaunchConfigurationsInput) ([]RegionLaunchConfiguration, error) {
+	var items []RegionLaunchConfiguration
+
+	opts, err := r.GetLaunchConfigurations(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "LaunchConfigurations") {
+			items = append(items, RegionLaunchConfiguration{Region: region, Item: v.(*autoscaling.LaunchConfiguration)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllElastiCacheClusters returns the items on the CacheClusters path of the GetElastiCacheClusters
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetElastiCacheClusters, so the items of the regions without
+// errors are always returned.
+func ListAllElastiCacheClusters(ctx context.Context, r AWSReader, input *elasticache.DescribeCacheClustersInput) ([]RegionCacheCluster, error) {
+	var items []RegionCacheCluster
+
+	opts, err := r.GetElastiCacheClusters(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "CacheClusters") {
+			items = append(items, RegionCacheCluster{Region: region, Item: v.(*elasticache.CacheCluster)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllLoadBalancers returns the items on the LoadBalancerDescriptions path of the GetLoadBalancers
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetLoadBalancers, so the items of the regions without
+// errors are always returned.
+func ListAllLoadBalancers(ctx context.Context, r AWSReader, input *elb.DescribeLoadBalancersInput) ([]RegionLoadBalancerDescription, error) {
+	var items []RegionLoadBalancerDescription
+
+	opts, err := r.GetLoadBalancers(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "LoadBalancerDescriptions") {
+			items = append(items, RegionLoadBalancerDescription{Region: region, Item: v.(*elb.LoadBalancerDescription)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllLoadBalancersV2 returns the items on the LoadBalancers path of the GetLoadBalancersV2
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetLoadBalancersV2, so the items of the regions without
+// errors are always returned.
+func ListAllLoadBalancersV2(ctx context.Context, r AWSReader, input *elbv2.DescribeLoadBalancersInput) ([]RegionLoadBalancer, error) {
+	var items []RegionLoadBalancer
+
+	opts, err := r.GetLoadBalancersV2(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "LoadBalancers") {
+			items = append(items, RegionLoadBalancer{Region: region, Item: v.(*elbv2.LoadBalancer)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllDBInstances returns the items on the DBInstances path of the GetDBInstances
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetDBInstances, so the items of the regions without
+// errors are always returned.
+func ListAllDBInstances(ctx context.Context, r AWSReader, input *rds.DescribeDBInstancesInput) ([]RegionDBInstance, error) {
+	var items []RegionDBInstance
+
+	opts, err := r.GetDBInstances(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "DBInstances") {
+			items = append(items, RegionDBInstance{Region: region, Item: v.(*rds.DBInstance)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllBuckets returns the items on the Buckets path of the ListBuckets
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by ListBuckets, so the items of the regions without
+// errors are always returned.
+func ListAllBuckets(ctx context.Context, r AWSReader, input *s3.ListBucketsInput) ([]RegionBucket, error) {
+	var items []RegionBucket
+
+	opts, err := r.ListBuckets(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "Buckets") {
+			items = append(items, RegionBucket{Region: region, Item: v.(*s3.Bucket)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllObjects returns the items on the Contents path of the ListObjects
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by ListObjects, so the items of the regions without
+// errors are always returned.
+func ListAllObjects(ctx context.Context, r AWSReader, input *s3.ListObjectsInput) ([]RegionObject, error) {
+	var items []RegionObject
+
+	opts, err := r.ListObjects(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "Contents") {
+			items = append(items, RegionObject{Region: region, Item: v.(*s3.Object)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllCloudFrontDistributions returns the items on the DistributionList.Items path of the GetCloudFrontDistributions
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetCloudFrontDistributions, so the items of the regions without
+// errors are always returned.
+func ListAllCloudFrontDistributions(ctx context.Context, r AWSReader, input *cloudfront.ListDistributionsInput) ([]RegionDistributionSummary, error) {
+	var items []RegionDistributionSummary
+
+	opts, err := r.GetCloudFrontDistributions(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "DistributionList.Items") {
+			items = append(items, RegionDistributionSummary{Region: region, Item: v.(*cloudfront.DistributionSummary)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllCloudFrontPublicKeys returns the items on the PublicKeyList.Items path of the GetCloudFrontPublicKeys
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetCloudFrontPublicKeys, so the items of the regions without
+// errors are always returned.
+func ListAllCloudFrontPublicKeys(ctx context.Context, r AWSReader, input *cloudfront.ListPublicKeysInput) ([]RegionPublicKeySummary, error) {
+	var items []RegionPublicKeySummary
+
+	opts, err := r.GetCloudFrontPublicKeys(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "PublicKeyList.Items") {
+			items = append(items, RegionPublicKeySummary{Region: region, Item: v.(*cloudfront.PublicKeySummary)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllCloudFrontOriginAccessIdentities returns the items on the CloudFrontOriginAccessIdentityList.Items path of the GetCloudFrontOriginAccessIdentities
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetCloudFrontOriginAccessIdentities, so the items of the regions without
+// errors are always returned.
+func ListAllCloudFrontOriginAccessIdentities(ctx context.Context, r AWSReader, input *cloudfront.ListCloudFrontOriginAccessIdentitiesInput) ([]RegionOriginAccessIdentitySummary, error) {
+	var items []RegionOriginAccessIdentitySummary
+
+	opts, err := r.GetCloudFrontOriginAccessIdentities(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "CloudFrontOriginAccessIdentityList.Items") {
+			items = append(items, RegionOriginAccessIdentitySummary{Region: region, Item: v.(*cloudfront.OriginAccessIdentitySummary)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllAccessKeys returns the items on the AccessKeyMetadata path of the GetAccessKeys
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetAccessKeys, so the items of the regions without
+// errors are always returned.
+func ListAllAccessKeys(ctx context.Context, r AWSReader, input *iam.ListAccessKeysInput) ([]RegionAccessKeyMetadata, error) {
+	var items []RegionAccessKeyMetadata
+
+	opts, err := r.GetAccessKeys(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "AccessKeyMetadata") {
+			items = append(items, RegionAccessKeyMetadata{Region: region, Item: v.(*iam.AccessKeyMetadata)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllGroups returns the items on the Groups path of the GetGroups
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetGroups, so the items of the regions without
+// errors are always returned.
+func ListAllGroups(ctx context.Context, r AWSReader, input *iam.ListGroupsInput) ([]RegionGroup, error) {
+	var items []RegionGroup
+
+	opts, err := r.GetGroups(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "Groups") {
+			items = append(items, RegionGroup{Region: region, Item: v.(*iam.Group)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllAttachedGroupPolicies returns the items on the AttachedPolicies path of the GetAttachedGroupPolicies
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetAttachedGroupPolicies, so the items of the regions without
+// errors are always returned.
+func ListAllAttachedGroupPolicies(ctx context.Context, r AWSReader, input *iam.ListAttachedGroupPoliciesInput) ([]RegionAttachedPolicy, error) {
+	var items []RegionAttachedPolicy
+
+	opts, err := r.GetAttachedGroupPolicies(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "AttachedPolicies") {
+			items = append(items, RegionAttachedPolicy{Region: region, Item: v.(*iam.AttachedPolicy)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllInstanceProfiles returns the items on the InstanceProfiles path of the GetInstanceProfiles
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetInstanceProfiles, so the items of the regions without
+// errors are always returned.
+func ListAllInstanceProfiles(ctx context.Context, r AWSReader, input *iam.ListInstanceProfilesInput) ([]RegionInstanceProfile, error) {
+	var items []RegionInstanceProfile
+
+	opts, err := r.GetInstanceProfiles(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "InstanceProfiles") {
+			items = append(items, RegionInstanceProfile{Region: region, Item: v.(*iam.InstanceProfile)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllOpenIDConnectProviders returns the items on the OpenIDConnectProviderList path of the GetOpenIDConnectProviders
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetOpenIDConnectProviders, so the items of the regions without
+// errors are always returned.
+func ListAllOpenIDConnectProviders(ctx context.Context, r AWSReader, input *iam.ListOpenIDConnectProvidersInput) ([]RegionOpenIDConnectProviderListEntry, error) {
+	var items []RegionOpenIDConnectProviderListEntry
+
+	opts, err := r.GetOpenIDConnectProviders(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "OpenIDConnectProviderList") {
+			items = append(items, RegionOpenIDConnectProviderListEntry{Region: region, Item: v.(*iam.OpenIDConnectProviderListEntry)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllPolicies returns the items on the Policies path of the GetPolicies
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetPolicies, so the items of the regions without
+// errors are always returned.
+func ListAllPolicies(ctx context.Context, r AWSReader, input *iam.ListPoliciesInput) ([]RegionPolicy, error) {
+	var items []RegionPolicy
+
+	opts, err := r.GetPolicies(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "Policies") {
+			items = append(items, RegionPolicy{Region: region, Item: v.(*iam.Policy)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllRoles returns the items on the Roles path of the GetRoles
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetRoles, so the items of the regions without
+// errors are always returned.
+func ListAllRoles(ctx context.Context, r AWSReader, input *iam.ListRolesInput) ([]RegionRole, error) {
+	var items []RegionRole
+
+	opts, err := r.GetRoles(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "Roles") {
+			items = append(items, RegionRole{Region: region, Item: v.(*iam.Role)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllAttachedRolePolicies returns the items on the AttachedPolicies path of the GetAttachedRolePolicies
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetAttachedRolePolicies, so the items of the regions without
+// errors are always returned.
+func ListAllAttachedRolePolicies(ctx context.Context, r AWSReader, input *iam.ListAttachedRolePoliciesInput) ([]RegionAttachedPolicy, error) {
+	var items []RegionAttachedPolicy
+
+	opts, err := r.GetAttachedRolePolicies(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "AttachedPolicies") {
+			items = append(items, RegionAttachedPolicy{Region: region, Item: v.(*iam.AttachedPolicy)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllSAMLProviders returns the items on the SAMLProviderList path of the GetSAMLProviders
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetSAMLProviders, so the items of the regions without
+// errors are always returned.
+func ListAllSAMLProviders(ctx context.Context, r AWSReader, input *iam.ListSAMLProvidersInput) ([]RegionSAMLProviderListEntry, error) {
+	var items []RegionSAMLProviderListEntry
+
+	opts, err := r.GetSAMLProviders(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "SAMLProviderList") {
+			items = append(items, RegionSAMLProviderListEntry{Region: region, Item: v.(*iam.SAMLProviderListEntry)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllServerCertificates returns the items on the ServerCertificateMetadataList path of the GetServerCertificates
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetServerCertificates, so the items of the regions without
+// errors are always returned.
+func ListAllServerCertificates(ctx context.Context, r AWSReader, input *iam.ListServerCertificatesInput) ([]RegionServerCertificateMetadata, error) {
+	var items []RegionServerCertificateMetadata
+
+	opts, err := r.GetServerCertificates(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "ServerCertificateMetadataList") {
+			items = append(items, RegionServerCertificateMetadata{Region: region, Item: v.(*iam.ServerCertificateMetadata)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllUsers returns the items on the Users path of the GetUsers
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetUsers, so the items of the regions without
+// errors are always returned.
+func ListAllUsers(ctx context.Context, r AWSReader, input *iam.ListUsersInput) ([]RegionUser, error) {
+	var items []RegionUser
+
+	opts, err := r.GetUsers(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "Users") {
+			items = append(items, RegionUser{Region: region, Item: v.(*iam.User)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllAttachedUserPolicies returns the items on the AttachedPolicies path of the GetAttachedUserPolicies
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetAttachedUserPolicies, so the items of the regions without
+// errors are always returned.
+func ListAllAttachedUserPolicies(ctx context.Context, r AWSReader, input *iam.ListAttachedUserPoliciesInput) ([]RegionAttachedPolicy, error) {
+	var items []RegionAttachedPolicy
+
+	opts, err := r.GetAttachedUserPolicies(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "AttachedPolicies") {
+			items = append(items, RegionAttachedPolicy{Region: region, Item: v.(*iam.AttachedPolicy)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllMFADevices returns the items on the MFADevices path of the GetMFADevices
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetMFADevices, so the items of the regions without
+// errors are always returned.
+func ListAllMFADevices(ctx context.Context, r AWSReader, input *iam.ListMFADevicesInput) ([]RegionMFADevice, error) {
+	var items []RegionMFADevice
+
+	opts, err := r.GetMFADevices(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "MFADevices") {
+			items = append(items, RegionMFADevice{Region: region, Item: v.(*iam.MFADevice)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllReceiptFilters returns the items on the Filters path of the GetReceiptFilters
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetReceiptFilters, so the items of the regions without
+// errors are always returned.
+func ListAllReceiptFilters(ctx context.Context, r AWSReader, input *ses.ListReceiptFiltersInput) ([]RegionReceiptFilter, error) {
+	var items []RegionReceiptFilter
+
+	opts, err := r.GetReceiptFilters(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "Filters") {
+			items = append(items, RegionReceiptFilter{Region: region, Item: v.(*ses.ReceiptFilter)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllConfigurationSets returns the items on the ConfigurationSets path of the GetConfigurationSets
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetConfigurationSets, so the items of the regions without
+// errors are always returned.
+func ListAllConfigurationSets(ctx context.Context, r AWSReader, input *ses.ListConfigurationSetsInput) ([]RegionConfigurationSet, error) {
+	var items []RegionConfigurationSet
+
+	opts, err := r.GetConfigurationSets(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "ConfigurationSets") {
+			items = append(items, RegionConfigurationSet{Region: region, Item: v.(*ses.ConfigurationSet)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllTemplates returns the items on the TemplatesMetadata path of the GetTemplates
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetTemplates, so the items of the regions without
+// errors are always returned.
+func ListAllTemplates(ctx context.Context, r AWSReader, input *ses.ListTemplatesInput) ([]RegionTemplateMetadata, error) {
+	var items []RegionTemplateMetadata
+
+	opts, err := r.GetTemplates(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "TemplatesMetadata") {
+			items = append(items, RegionTemplateMetadata{Region: region, Item: v.(*ses.TemplateMetadata)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllReusableDelegationSets returns the items on the DelegationSets path of the GetReusableDelegationSets
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetReusableDelegationSets, so the items of the regions without
+// errors are always returned.
+func ListAllReusableDelegationSets(ctx context.Context, r AWSReader, input *route53.ListReusableDelegationSetsInput) ([]RegionDelegationSet, error) {
+	var items []RegionDelegationSet
+
+	opts, err := r.GetReusableDelegationSets(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "DelegationSets") {
+			items = append(items, RegionDelegationSet{Region: region, Item: v.(*route53.DelegationSet)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllHealthChecks returns the items on the HealthChecks path of the GetHealthChecks
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetHealthChecks, so the items of the regions without
+// errors are always returned.
+func ListAllHealthChecks(ctx context.Context, r AWSReader, input *route53.ListHealthChecksInput) ([]RegionHealthCheck, error) {
+	var items []RegionHealthCheck
+
+	opts, err := r.GetHealthChecks(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "HealthChecks") {
+			items = append(items, RegionHealthCheck{Region: region, Item: v.(*route53.HealthCheck)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllQueryLoggingConfigs returns the items on the QueryLoggingConfigs path of the GetQueryLoggingConfigs
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetQueryLoggingConfigs, so the items of the regions without
+// errors are always returned.
+func ListAllQueryLoggingConfigs(ctx context.Context, r AWSReader, input *route53.ListQueryLoggingConfigsInput) ([]RegionQueryLoggingConfig, error) {
+	var items []RegionQueryLoggingConfig
+
+	opts, err := r.GetQueryLoggingConfigs(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "QueryLoggingConfigs") {
+			items = append(items, RegionQueryLoggingConfig{Region: region, Item: v.(*route53.QueryLoggingConfig)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllResourceRecordSets returns the items on the ResourceRecordSets path of the GetResourceRecordSets
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetResourceRecordSets, so the items of the regions without
+// errors are always returned.
+func ListAllResourceRecordSets(ctx context.Context, r AWSReader, input *route53.ListResourceRecordSetsInput) ([]RegionResourceRecordSet, error) {
+	var items []RegionResourceRecordSet
+
+	opts, err := r.GetResourceRecordSets(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "ResourceRecordSets") {
+			items = append(items, RegionResourceRecordSet{Region: region, Item: v.(*route53.ResourceRecordSet)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllHostedZones returns the items on the HostedZones path of the GetHostedZones
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetHostedZones, so the items of the regions without
+// errors are always returned.
+func ListAllHostedZones(ctx context.Context, r AWSReader, input *route53.ListHostedZonesInput) ([]RegionHostedZone, error) {
+	var items []RegionHostedZone
+
+	opts, err := r.GetHostedZones(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "HostedZones") {
+			items = append(items, RegionHostedZone{Region: region, Item: v.(*route53.HostedZone)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllResolverEndpoints returns the items on the ResolverEndpoints path of the GetResolverEndpoints
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetResolverEndpoints, so the items of the regions without
+// errors are always returned.
+func ListAllResolverEndpoints(ctx context.Context, r AWSReader, input *route53resolver.ListResolverEndpointsInput) ([]RegionResolverEndpoint, error) {
+	var items []RegionResolverEndpoint
+
+	opts, err := r.GetResolverEndpoints(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "ResolverEndpoints") {
+			items = append(items, RegionResolverEndpoint{Region: region, Item: v.(*route53resolver.ResolverEndpoint)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllResolverRules returns the items on the ResolverRules path of the GetResolverRules
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetResolverRules, so the items of the regions without
+// errors are always returned.
+func ListAllResolverRules(ctx context.Context, r AWSReader, input *route53resolver.ListResolverRulesInput) ([]RegionResolverRule, error) {
+	var items []RegionResolverRule
+
+	opts, err := r.GetResolverRules(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "ResolverRules") {
+			items = append(items, RegionResolverRule{Region: region, Item: v.(*route53resolver.ResolverRule)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllResolverRuleAssociations returns the items on the ResolverRuleAssociations path of the GetResolverRuleAssociations
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetResolverRuleAssociations, so the items of the regions without
+// errors are always returned.
+func ListAllResolverRuleAssociations(ctx context.Context, r AWSReader, input *route53resolver.ListResolverRuleAssociationsInput) ([]RegionResolverRuleAssociation, error) {
+	var items []RegionResolverRuleAssociation
+
+	opts, err := r.GetResolverRuleAssociations(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "ResolverRuleAssociations") {
+			items = append(items, RegionResolverRuleAssociation{Region: region, Item: v.(*route53resolver.ResolverRuleAssociation)})
+		}
+	}
+
+	return items, err
+}
+
+// ListAllTaggedResources returns the items on the ResourceTagMappingList path of the GetTaggedResources
+// results from all the regions, in the order of r.GetRegions(), paired with the region
+// they belong to.
+// The error is the one returned by GetTaggedResources, so the items of the regions without
+// errors are always returned.
+func ListAllTaggedResources(ctx context.Context, r AWSReader, input *resourcegroupstaggingapi.GetResourcesInput) ([]RegionResourceTagMapping, error) {
+	var items []RegionResourceTagMapping
+
+	opts, err := r.GetTaggedResources(ctx, input)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		for _, v := range flatten(opt, "ResourceTagMappingList") {
+			items = append(items, RegionResourceTagMapping{Region: region, Item: v.(*resourcegroupstaggingapi.ResourceTagMapping)})
+		}
+	}
+
+	return items, err
+}
+
+// inventoryCalls are the calls made by Inventory
+var inventoryCalls = []inventoryCall{
+	{
+		method:  "GetInstances",
+		service: "ec2",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllInstances(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetVpcs",
+		service: "ec2",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllVpcs(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetOwnImages",
+		service: "ec2",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllOwnImages(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetSecurityGroups",
+		service: "ec2",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllSecurityGroups(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetSubnets",
+		service: "ec2",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllSubnets(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetVolumes",
+		service: "ec2",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllVolumes(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetOwnSnapshots",
+		service: "ec2",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllOwnSnapshots(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetLaunchTemplates",
+		service: "ec2",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllLaunchTemplates(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetAutoScalingGroups",
+		service: "autoscaling",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllAutoScalingGroups(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetLaunchConfigurations",
+		service: "autoscaling",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllLaunchConfigurations(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetElastiCacheClusters",
+		service: "elasticache",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllElastiCacheClusters(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetLoadBalancersWithTags",
+		service: "elb",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return r.GetLoadBalancersWithTags(ctx, nil)
+		},
+	},
+	{
+		method:  "GetLoadBalancersV2WithTags",
+		service: "elbv2",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return r.GetLoadBalancersV2WithTags(ctx, nil)
+		},
+	},
+	{
+		method:  "GetDBInstancesWithTags",
+		service: "rds",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return r.GetDBInstancesWithTags(ctx, nil)
+		},
+	},
+	{
+		method:  "ListBuckets",
+		service: "s3",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllBuckets(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetCloudFrontDistributions",
+		service: "cloudfront",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllCloudFrontDistributions(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetCloudFrontPublicKeys",
+		service: "cloudfront",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllCloudFrontPublicKeys(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetCloudFrontOriginAccessIdentities",
+		service: "cloudfront",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllCloudFrontOriginAccessIdentities(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetAccessKeys",
+		service: "iam",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllAccessKeys(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetGroupsWithPolicies",
+		service: "iam",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return r.GetGroupsWithPolicies(ctx, nil)
+		},
+	},
+	{
+		method:  "GetInstanceProfiles",
+		service: "iam",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllInstanceProfiles(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetOpenIDConnectProviders",
+		service: "iam",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllOpenIDConnectProviders(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetRolesWithPolicies",
+		service: "iam",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return r.GetRolesWithPolicies(ctx, nil)
+		},
+	},
+	{
+		method:  "GetSAMLProviders",
+		service: "iam",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllSAMLProviders(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetServerCertificates",
+		service: "iam",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllServerCertificates(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetUsersWithPolicies",
+		service: "iam",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return r.GetUsersWithPolicies(ctx, nil)
+		},
+	},
+	{
+		method:  "GetReceiptFilters",
+		service: "ses",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllReceiptFilters(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetConfigurationSets",
+		service: "ses",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllConfigurationSets(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetTemplates",
+		service: "ses",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllTemplates(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetReusableDelegationSets",
+		service: "route53",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllReusableDelegationSets(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetHealthChecks",
+		service: "route53",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllHealthChecks(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetQueryLoggingConfigs",
+		service: "route53",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllQueryLoggingConfigs(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetResourceRecordSets",
+		service: "route53",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllHostedZoneRecordSets(ctx, r)
+		},
+	},
+	{
+		method:  "GetHostedZones",
+		service: "route53",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllHostedZones(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetResolverEndpoints",
+		service: "route53resolver",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllResolverEndpoints(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetResolverRules",
+		service: "route53resolver",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllResolverRules(ctx, r, nil)
+		},
+	},
+	{
+		method:  "GetResolverRuleAssociations",
+		service: "route53resolver",
+		call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+			return ListAllResolverRuleAssociations(ctx, r, nil)
+		},
+	},
+}
+
+// itemsPaths are the paths of the items of the outputs of the
+// methods, by method, used to count the items they fetch
+var itemsPaths = map[string]string{
+	"GetInstances":                        "Reservations.Instances",
+	"GetVpcs":                             "Vpcs",
+	"GetImages":                           "Images",
+	"GetOwnImages":                        "Images",
+	"GetSecurityGroups":                   "SecurityGroups",
+	"GetSubnets":                          "Subnets",
+	"GetVolumes":                          "Volumes",
+	"GetSnapshots":                        "Snapshots",
+	"GetOwnSnapshots":                     "Snapshots",
+	"GetLaunchTemplates":                  "LaunchTemplates",
+	"GetAutoScalingGroups":                "AutoScalingGroups",
+	"GetLaunchConfigurations":             "LaunchConfigurations",
+	"GetElastiCacheClusters":              "CacheClusters",
+	"GetLoadBalancers":                    "LoadBalancerDescriptions",
+	"GetLoadBalancersV2":                  "LoadBalancers",
+	"GetDBInstances":                      "DBInstances",
+	"ListBuckets":                         "Buckets",
+	"ListObjects":                         "Contents",
+	"GetCloudFrontDistributions":          "DistributionList.Items",
+	"GetCloudFrontPublicKeys":             "PublicKeyList.Items",
+	"GetCloudFrontOriginAccessIdentities": "CloudFrontOriginAccessIdentityList.Items",
+	"GetAccessKeys":                       "AccessKeyMetadata",
+	"GetGroups":                           "Groups",
+	"GetAttachedGroupPolicies":            "AttachedPolicies",
+	"GetInstanceProfiles":                 "InstanceProfiles",
+	"GetOpenIDConnectProviders":           "OpenIDConnectProviderList",
+	"GetPolicies":                         "Policies",
+	"GetRoles":                            "Roles",
+	"GetAttachedRolePolicies":             "AttachedPolicies",
+	"GetSAMLProviders":                    "SAMLProviderList",
+	"GetServerCertificates":               "ServerCertificateMetadataList",
+	"GetUsers":                            "Users",
+	"GetAttachedUserPolicies":             "AttachedPolicies",
+	"GetMFADevices":                       "MFADevices",
+	"GetReceiptFilters":                   "Filters",
+	"GetConfigurationSets":                "ConfigurationSets",
+	"GetTemplates":                        "TemplatesMetadata",
+	"GetReusableDelegationSets":           "DelegationSets",
+	"GetHealthChecks":                     "HealthChecks",
+	"GetQueryLoggingConfigs":              "QueryLoggingConfigs",
+	"GetResourceRecordSets":               "ResourceRecordSets",
+	"GetHostedZones":                      "HostedZones",
+	"GetResolverEndpoints":                "ResolverEndpoints",
+	"GetResolverRules":                    "ResolverRules",
+	"GetResolverRuleAssociations":         "ResolverRuleAssociations",
+	"GetTaggedResources":                  "ResourceTagMappingList",
+}
+
+func (c *CachedReader) GetInstances(ctx context.Context, input *ec2.DescribeInstancesInput) (map[string]ec2.DescribeInstancesOutput, error) {
+	var opts map[string]ec2.DescribeInstancesOutput
+	err := c.call(ctx, "GetInstances", ec2.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetInstances(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetVpcs(ctx context.Context, input *ec2.DescribeVpcsInput) (map[string]ec2.DescribeVpcsOutput, error) {
+	var opts map[string]ec2.DescribeVpcsOutput
+	err := c.call(ctx, "GetVpcs", ec2.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetVpcs(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetImages(ctx context.Context, input *ec2.DescribeImagesInput) (map[string]ec2.DescribeImagesOutput, error) {
+	var opts map[string]ec2.DescribeImagesOutput
+	err := c.call(ctx, "GetImages", ec2.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetImages(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetOwnImages(ctx context.Context, input *ec2.DescribeImagesInput) (map[string]ec2.DescribeImagesOutput, error) {
+	var opts map[string]ec2.DescribeImagesOutput
+	err := c.call(ctx, "GetOwnImages", ec2.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetOwnImages(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetSecurityGroups(ctx context.Context, input *ec2.DescribeSecurityGroupsInput) (map[string]ec2.DescribeSecurityGroupsOutput, error) {
+	var opts map[string]ec2.DescribeSecurityGroupsOutput
+	err := c.call(ctx, "GetSecurityGroups", ec2.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetSecurityGroups(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput) (map[string]ec2.DescribeSubnetsOutput, error) {
+	var opts map[string]ec2.DescribeSubnetsOutput
+	err := c.call(ctx, "GetSubnets", ec2.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetSubnets(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetVolumes(ctx context.Context, input *ec2.DescribeVolumesInput) (map[string]ec2.DescribeVolumesOutput, error) {
+	var opts map[string]ec2.DescribeVolumesOutput
+	err := c.call(ctx, "GetVolumes", ec2.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetVolumes(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetSnapshots(ctx context.Context, input *ec2.DescribeSnapshotsInput) (map[string]ec2.DescribeSnapshotsOutput, error) {
+	var opts map[string]ec2.DescribeSnapshotsOutput
+	err := c.call(ctx, "GetSnapshots", ec2.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetSnapshots(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetOwnSnapshots(ctx context.Context, input *ec2.DescribeSnapshotsInput) (map[string]ec2.DescribeSnapshotsOutput, error) {
+	var opts map[string]ec2.DescribeSnapshotsOutput
+	err := c.call(ctx, "GetOwnSnapshots", ec2.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetOwnSnapshots(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetLaunchTemplates(ctx context.Context, input *ec2.DescribeLaunchTemplatesInput) (map[string]ec2.DescribeLaunchTemplatesOutput, error) {
+	var opts map[string]ec2.DescribeLaunchTemplatesOutput
+	err := c.call(ctx, "GetLaunchTemplates", ec2.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetLaunchTemplates(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetAutoScalingGroups(ctx context.Context, input *autoscaling.DescribeAutoScalingGroupsInput) (map[string]autoscaling.DescribeAutoScalingGroupsOutput, error) {
+	var opts map[string]autoscaling.DescribeAutoScalingGroupsOutput
+	err := c.call(ctx, "GetAutoScalingGroups", autoscaling.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetAutoScalingGroups(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetLaunchConfigurations(ctx context.Context, input *autoscaling.DescribeLaunchConfigurationsInput) (map[string]autoscaling.DescribeLaunchConfigurationsOutput, error) {
+	var opts map[string]autoscaling.DescribeLaunchConfigurationsOutput
+	err := c.call(ctx, "GetLaunchConfigurations", autoscaling.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetLaunchConfigurations(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetElastiCacheClusters(ctx context.Context, input *elasticache.DescribeCacheClustersInput) (map[string]elasticache.DescribeCacheClustersOutput, error) {
+	var opts map[string]elasticache.DescribeCacheClustersOutput
+	err := c.call(ctx, "GetElastiCacheClusters", elasticache.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetElastiCacheClusters(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetElastiCacheTags(ctx context.Context, input *elasticache.ListTagsForResourceInput) (map[string]elasticache.TagListMessage, error) {
+	var opts map[string]elasticache.TagListMessage
+	err := c.call(ctx, "GetElastiCacheTags", elasticache.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetElastiCacheTags(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetLoadBalancers(ctx context.Context, input *elb.DescribeLoadBalancersInput) (map[string]elb.DescribeLoadBalancersOutput, error) {
+	var opts map[string]elb.DescribeLoadBalancersOutput
+	err := c.call(ctx, "GetLoadBalancers", elb.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetLoadBalancers(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetLoadBalancersWithTags(ctx context.Context, input *elb.DescribeLoadBalancersInput) (map[string][]LoadBalancerWithTags, error) {
+	var opts map[string][]LoadBalancerWithTags
+	err := c.call(ctx, "GetLoadBalancersWithTags", elb.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetLoadBalancersWithTags(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetLoadBalancersTags(ctx context.Context, input *elb.DescribeTagsInput) (map[string]elb.DescribeTagsOutput, error) {
+	var opts map[string]elb.DescribeTagsOutput
+	err := c.call(ctx, "GetLoadBalancersTags", elb.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetLoadBalancersTags(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetLoadBalancersV2(ctx context.Context, input *elbv2.DescribeLoadBalancersInput) (map[string]elbv2.DescribeLoadBalancersOutput, error) {
+	var opts map[string]elbv2.DescribeLoadBalancersOutput
+	err := c.call(ctx, "GetLoadBalancersV2", elbv2.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetLoadBalancersV2(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetLoadBalancersV2WithTags(ctx context.Context, input *elbv2.DescribeLoadBalancersInput) (map[string][]LoadBalancerV2WithTags, error) {
+	var opts map[string][]LoadBalancerV2WithTags
+	err := c.call(ctx, "GetLoadBalancersV2WithTags", elbv2.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetLoadBalancersV2WithTags(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetLoadBalancersV2Tags(ctx context.Context, input *elbv2.DescribeTagsInput) (map[string]elbv2.DescribeTagsOutput, error) {
+	var opts map[string]elbv2.DescribeTagsOutput
+	err := c.call(ctx, "GetLoadBalancersV2Tags", elbv2.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetLoadBalancersV2Tags(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetDBInstances(ctx context.Context, input *rds.DescribeDBInstancesInput) (map[string]rds.DescribeDBInstancesOutput, error) {
+	var opts map[string]rds.DescribeDBInstancesOutput
+	err := c.call(ctx, "GetDBInstances", rds.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetDBInstances(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetDBInstancesWithTags(ctx context.Context, input *rds.DescribeDBInstancesInput) (map[string][]DBInstanceWithTags, error) {
+	var opts map[string][]DBInstanceWithTags
+	err := c.call(ctx, "GetDBInstancesWithTags", rds.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetDBInstancesWithTags(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetDBInstancesTags(ctx context.Context, input *rds.ListTagsForResourceInput) (map[string]rds.ListTagsForResourceOutput, error) {
+	var opts map[string]rds.ListTagsForResourceOutput
+	err := c.call(ctx, "GetDBInstancesTags", rds.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetDBInstancesTags(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetBucketPolicyStatus(ctx context.Context, input *s3.GetBucketPolicyStatusInput) (map[string]s3.GetBucketPolicyStatusOutput, error) {
+	var opts map[string]s3.GetBucketPolicyStatusOutput
+	err := c.call(ctx, "GetBucketPolicyStatus", s3.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetBucketPolicyStatus(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetBucketAcl(ctx context.Context, input *s3.GetBucketAclInput) (map[string]s3.GetBucketAclOutput, error) {
+	var opts map[string]s3.GetBucketAclOutput
+	err := c.call(ctx, "GetBucketAcl", s3.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetBucketAcl(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetPublicAccessBlock(ctx context.Context, input *s3.GetPublicAccessBlockInput) (map[string]s3.GetPublicAccessBlockOutput, error) {
+	var opts map[string]s3.GetPublicAccessBlockOutput
+	err := c.call(ctx, "GetPublicAccessBlock", s3.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetPublicAccessBlock(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) ListBuckets(ctx context.Context, input *s3.ListBucketsInput) (map[string]s3.ListBucketsOutput, error) {
+	var opts map[string]s3.ListBucketsOutput
+	err := c.call(ctx, "ListBuckets", s3.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.ListBuckets(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetBucketTags(ctx context.Context, input *s3.GetBucketTaggingInput) (map[string]s3.GetBucketTaggingOutput, error) {
+	var opts map[string]s3.GetBucketTaggingOutput
+	err := c.call(ctx, "GetBucketTags", s3.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetBucketTags(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) ListObjects(ctx context.Context, input *s3.ListObjectsInput) (map[string]s3.ListObjectsOutput, error) {
+	var opts map[string]s3.ListObjectsOutput
+	err := c.call(ctx, "ListObjects", s3.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.ListObjects(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetObjectsTags(ctx context.Context, input *s3.GetObjectTaggingInput) (map[string]s3.GetObjectTaggingOutput, error) {
+	var opts map[string]s3.GetObjectTaggingOutput
+	err := c.call(ctx, "GetObjectsTags", s3.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetObjectsTags(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetRecordedResourceCounts(ctx context.Context, input *configservice.GetDiscoveredResourceCountsInput) (map[string]configservice.GetDiscoveredResourceCountsOutput, error) {
+	var opts map[string]configservice.GetDiscoveredResourceCountsOutput
+	err := c.call(ctx, "GetRecordedResourceCounts", configservice.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetRecordedResourceCounts(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetCloudFrontDistributions(ctx context.Context, input *cloudfront.ListDistributionsInput) (map[string]cloudfront.ListDistributionsOutput, error) {
+	var opts map[string]cloudfront.ListDistributionsOutput
+	err := c.call(ctx, "GetCloudFrontDistributions", cloudfront.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetCloudFrontDistributions(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetCloudFrontPublicKeys(ctx context.Context, input *cloudfront.ListPublicKeysInput) (map[string]cloudfront.ListPublicKeysOutput, error) {
+	var opts map[string]cloudfront.ListPublicKeysOutput
+	err := c.call(ctx, "GetCloudFrontPublicKeys", cloudfront.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetCloudFrontPublicKeys(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetCloudFrontOriginAccessIdentities(ctx context.Context, input *cloudfront.ListCloudFrontOriginAccessIdentitiesInput) (map[string]cloudfront.ListCloudFrontOriginAccessIdentitiesOutput, error) {
+	var opts map[string]cloudfront.ListCloudFrontOriginAccessIdentitiesOutput
+	err := c.call(ctx, "GetCloudFrontOriginAccessIdentities", cloudfront.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetCloudFrontOriginAccessIdentities(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetAccessKeys(ctx context.Context, input *iam.ListAccessKeysInput) (map[string]iam.ListAccessKeysOutput, error) {
+	var opts map[string]iam.ListAccessKeysOutput
+	err := c.call(ctx, "GetAccessKeys", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetAccessKeys(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetAccountAliases(ctx context.Context, input *iam.ListAccountAliasesInput) (map[string]iam.ListAccountAliasesOutput, error) {
+	var opts map[string]iam.ListAccountAliasesOutput
+	err := c.call(ctx, "GetAccountAliases", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetAccountAliases(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetAccountPasswordPolicy(ctx context.Context, input *iam.GetAccountPasswordPolicyInput) (map[string]iam.GetAccountPasswordPolicyOutput, error) {
+	var opts map[string]iam.GetAccountPasswordPolicyOutput
+	err := c.call(ctx, "GetAccountPasswordPolicy", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetAccountPasswordPolicy(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetGroups(ctx context.Context, input *iam.ListGroupsInput) (map[string]iam.ListGroupsOutput, error) {
+	var opts map[string]iam.ListGroupsOutput
+	err := c.call(ctx, "GetGroups", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetGroups(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetGroupsWithPolicies(ctx context.Context, input *iam.ListGroupsInput) (map[string][]GroupWithPolicies, error) {
+	var opts map[string][]GroupWithPolicies
+	err := c.call(ctx, "GetGroupsWithPolicies", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetGroupsWithPolicies(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetGroupPolicies(ctx context.Context, input *iam.ListGroupPoliciesInput) (map[string]iam.ListGroupPoliciesOutput, error) {
+	var opts map[string]iam.ListGroupPoliciesOutput
+	err := c.call(ctx, "GetGroupPolicies", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetGroupPolicies(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetAttachedGroupPolicies(ctx context.Context, input *iam.ListAttachedGroupPoliciesInput) (map[string]iam.ListAttachedGroupPoliciesOutput, error) {
+	var opts map[string]iam.ListAttachedGroupPoliciesOutput
+	err := c.call(ctx, "GetAttachedGroupPolicies", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetAttachedGroupPolicies(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetInstanceProfiles(ctx context.Context, input *iam.ListInstanceProfilesInput) (map[string]iam.ListInstanceProfilesOutput, error) {
+	var opts map[string]iam.ListInstanceProfilesOutput
+	err := c.call(ctx, "GetInstanceProfiles", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetInstanceProfiles(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetOpenIDConnectProviders(ctx context.Context, input *iam.ListOpenIDConnectProvidersInput) (map[string]iam.ListOpenIDConnectProvidersOutput, error) {
+	var opts map[string]iam.ListOpenIDConnectProvidersOutput
+	err := c.call(ctx, "GetOpenIDConnectProviders", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetOpenIDConnectProviders(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetPolicies(ctx context.Context, input *iam.ListPoliciesInput) (map[string]iam.ListPoliciesOutput, error) {
+	var opts map[string]iam.ListPoliciesOutput
+	err := c.call(ctx, "GetPolicies", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetPolicies(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetRoles(ctx context.Context, input *iam.ListRolesInput) (map[string]iam.ListRolesOutput, error) {
+	var opts map[string]iam.ListRolesOutput
+	err := c.call(ctx, "GetRoles", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetRoles(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetRolesWithPolicies(ctx context.Context, input *iam.ListRolesInput) (map[string][]RoleWithPolicies, error) {
+	var opts map[string][]RoleWithPolicies
+	err := c.call(ctx, "GetRolesWithPolicies", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetRolesWithPolicies(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetRolePolicies(ctx context.Context, input *iam.ListRolePoliciesInput) (map[string]iam.ListRolePoliciesOutput, error) {
+	var opts map[string]iam.ListRolePoliciesOutput
+	err := c.call(ctx, "GetRolePolicies", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetRolePolicies(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetAttachedRolePolicies(ctx context.Context, input *iam.ListAttachedRolePoliciesInput) (map[string]iam.ListAttachedRolePoliciesOutput, error) {
+	var opts map[string]iam.ListAttachedRolePoliciesOutput
+	err := c.call(ctx, "GetAttachedRolePolicies", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetAttachedRolePolicies(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetRoleTags(ctx context.Context, input *iam.ListRoleTagsInput) (map[string]iam.ListRoleTagsOutput, error) {
+	var opts map[string]iam.ListRoleTagsOutput
+	err := c.call(ctx, "GetRoleTags", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetRoleTags(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetSAMLProviders(ctx context.Context, input *iam.ListSAMLProvidersInput) (map[string]iam.ListSAMLProvidersOutput, error) {
+	var opts map[string]iam.ListSAMLProvidersOutput
+	err := c.call(ctx, "GetSAMLProviders", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetSAMLProviders(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetServerCertificates(ctx context.Context, input *iam.ListServerCertificatesInput) (map[string]iam.ListServerCertificatesOutput, error) {
+	var opts map[string]iam.ListServerCertificatesOutput
+	err := c.call(ctx, "GetServerCertificates", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetServerCertificates(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetUsers(ctx context.Context, input *iam.ListUsersInput) (map[string]iam.ListUsersOutput, error) {
+	var opts map[string]iam.ListUsersOutput
+	err := c.call(ctx, "GetUsers", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetUsers(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetUsersWithPolicies(ctx context.Context, input *iam.ListUsersInput) (map[string][]UserWithPolicies, error) {
+	var opts map[string][]UserWithPolicies
+	err := c.call(ctx, "GetUsersWithPolicies", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetUsersWithPolicies(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetUserPolicies(ctx context.Context, input *iam.ListUserPoliciesInput) (map[string]iam.ListUserPoliciesOutput, error) {
+	var opts map[string]iam.ListUserPoliciesOutput
+	err := c.call(ctx, "GetUserPolicies", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetUserPolicies(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetAttachedUserPolicies(ctx context.Context, input *iam.ListAttachedUserPoliciesInput) (map[string]iam.ListAttachedUserPoliciesOutput, error) {
+	var opts map[string]iam.ListAttachedUserPoliciesOutput
+	err := c.call(ctx, "GetAttachedUserPolicies", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetAttachedUserPolicies(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetUserTags(ctx context.Context, input *iam.ListUserTagsInput) (map[string]iam.ListUserTagsOutput, error) {
+	var opts map[string]iam.ListUserTagsOutput
+	err := c.call(ctx, "GetUserTags", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetUserTags(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetMFADevices(ctx context.Context, input *iam.ListMFADevicesInput) (map[string]iam.ListMFADevicesOutput, error) {
+	var opts map[string]iam.ListMFADevicesOutput
+	err := c.call(ctx, "GetMFADevices", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetMFADevices(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetAccountAuthorizationDetails(ctx context.Context, input *iam.GetAccountAuthorizationDetailsInput) (map[string]iam.GetAccountAuthorizationDetailsOutput, error) {
+	var opts map[string]iam.GetAccountAuthorizationDetailsOutput
+	err := c.call(ctx, "GetAccountAuthorizationDetails", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetAccountAuthorizationDetails(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetSSHPublicKey(ctx context.Context, input *iam.GetSSHPublicKeyInput) (map[string]iam.GetSSHPublicKeyOutput, error) {
+	var opts map[string]iam.GetSSHPublicKeyOutput
+	err := c.call(ctx, "GetSSHPublicKey", iam.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetSSHPublicKey(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetActiveReceiptRuleSet(ctx context.Context, input *ses.DescribeActiveReceiptRuleSetInput) (map[string]ses.DescribeActiveReceiptRuleSetOutput, error) {
+	var opts map[string]ses.DescribeActiveReceiptRuleSetOutput
+	err := c.call(ctx, "GetActiveReceiptRuleSet", ses.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetActiveReceiptRuleSet(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetIdentities(ctx context.Context, input *ses.ListIdentitiesInput) (map[string]ses.ListIdentitiesOutput, error) {
+	var opts map[string]ses.ListIdentitiesOutput
+	err := c.call(ctx, "GetIdentities", ses.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetIdentities(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetReceiptFilters(ctx context.Context, input *ses.ListReceiptFiltersInput) (map[string]ses.ListReceiptFiltersOutput, error) {
+	var opts map[string]ses.ListReceiptFiltersOutput
+	err := c.call(ctx, "GetReceiptFilters", ses.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetReceiptFilters(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetConfigurationSets(ctx context.Context, input *ses.ListConfigurationSetsInput) (map[string]ses.ListConfigurationSetsOutput, error) {
+	var opts map[string]ses.ListConfigurationSetsOutput
+	err := c.call(ctx, "GetConfigurationSets", ses.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetConfigurationSets(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetIdentityNotificationAttributes(ctx context.Context, input *ses.GetIdentityNotificationAttributesInput) (map[string]ses.GetIdentityNotificationAttributesOutput, error) {
+	var opts map[string]ses.GetIdentityNotificationAttributesOutput
+	err := c.call(ctx, "GetIdentityNotificationAttributes", ses.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetIdentityNotificationAttributes(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetTemplates(ctx context.Context, input *ses.ListTemplatesInput) (map[string]ses.ListTemplatesOutput, error) {
+	var opts map[string]ses.ListTemplatesOutput
+	err := c.call(ctx, "GetTemplates", ses.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetTemplates(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetReusableDelegationSets(ctx context.Context, input *route53.ListReusableDelegationSetsInput) (map[string]route53.ListReusableDelegationSetsOutput, error) {
+	var opts map[string]route53.ListReusableDelegationSetsOutput
+	err := c.call(ctx, "GetReusableDelegationSets", route53.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetReusableDelegationSets(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetHealthChecks(ctx context.Context, input *route53.ListHealthChecksInput) (map[string]route53.ListHealthChecksOutput, error) {
+	var opts map[string]route53.ListHealthChecksOutput
+	err := c.call(ctx, "GetHealthChecks", route53.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetHealthChecks(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetQueryLoggingConfigs(ctx context.Context, input *route53.ListQueryLoggingConfigsInput) (map[string]route53.ListQueryLoggingConfigsOutput, error) {
+	var opts map[string]route53.ListQueryLoggingConfigsOutput
+	err := c.call(ctx, "GetQueryLoggingConfigs", route53.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetQueryLoggingConfigs(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetResourceRecordSets(ctx context.Context, input *route53.ListResourceRecordSetsInput) (map[string]route53.ListResourceRecordSetsOutput, error) {
+	var opts map[string]route53.ListResourceRecordSetsOutput
+	err := c.call(ctx, "GetResourceRecordSets", route53.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetResourceRecordSets(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetHostedZones(ctx context.Context, input *route53.ListHostedZonesInput) (map[string]route53.ListHostedZonesOutput, error) {
+	var opts map[string]route53.ListHostedZonesOutput
+	err := c.call(ctx, "GetHostedZones", route53.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetHostedZones(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetVPCAssociationAuthorizations(ctx context.Context, input *route53.ListVPCAssociationAuthorizationsInput) (map[string]route53.ListVPCAssociationAuthorizationsOutput, error) {
+	var opts map[string]route53.ListVPCAssociationAuthorizationsOutput
+	err := c.call(ctx, "GetVPCAssociationAuthorizations", route53.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetVPCAssociationAuthorizations(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetResolverEndpoints(ctx context.Context, input *route53resolver.ListResolverEndpointsInput) (map[string]route53resolver.ListResolverEndpointsOutput, error) {
+	var opts map[string]route53resolver.ListResolverEndpointsOutput
+	err := c.call(ctx, "GetResolverEndpoints", route53resolver.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetResolverEndpoints(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetResolverRules(ctx context.Context, input *route53resolver.ListResolverRulesInput) (map[string]route53resolver.ListResolverRulesOutput, error) {
+	var opts map[string]route53resolver.ListResolverRulesOutput
+	err := c.call(ctx, "GetResolverRules", route53resolver.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetResolverRules(ctx, input)
+	})
+	return opts, err
+}
+
+func (c *CachedReader) GetResolverRuleAssociations(ctx context.Context, input *route53resolver.ListResolverRuleAssociationsInput) (map[string]route53resolver.ListResolverRuleAssociationsOutput, error) {
+	var opts map[string]route53resolver.ListResolverRuleAssociationsOutput
+	err := c.call(ctx, "GetResolverRuleAssociations", route53resolver.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetResolverRuleAssociations(ctx, input)
+	})
+	return opts, err
+}
 
-	return regionsOpts, nil
+func (c *CachedReader) GetTaggedResources(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput) (map[string]resourcegroupstaggingapi.GetResourcesOutput, error) {
+	var opts map[string]resourcegroupstaggingapi.GetResourcesOutput
+	err := c.call(ctx, "GetTaggedResources", resourcegroupstaggingapi.ServiceName, input, &opts, func(ctx context.Context) (interface{}, error) {
+		return c.reader.GetTaggedResources(ctx, input)
+	})
+	return opts, err
 }
@@ -0,0 +1,301 @@
+package raws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+)
+
+type mockELB struct {
+	elbiface.ELBAPI
+
+	// Mocking of DescribeLoadBalancers
+	dlbo   *elb.DescribeLoadBalancersOutput
+	dlberr error
+
+	// Mocking of DescribeTags, which returns one
+	// TagDescription per name except for the ones
+	// on dtmissing, and keeps the inputs on dti
+	dti       *[]*elb.DescribeTagsInput
+	dtmissing string
+	dterr     error
+}
+
+func (m mockELB) DescribeLoadBalancersPagesWithContext(
+	_ aws.Context, _ *elb.DescribeLoadBalancersInput, fn func(*elb.DescribeLoadBalancersOutput, bool) bool, _ ...request.Option,
+) error {
+	if m.dlberr != nil {
+		return m.dlberr
+	}
+
+	fn(m.dlbo, true)
+	return nil
+}
+
+func (m mockELB) DescribeTagsWithContext(
+	_ aws.Context, input *elb.DescribeTagsInput, _ ...request.Option,
+) (*elb.DescribeTagsOutput, error) {
+	*m.dti = append(*m.dti, input)
+	if m.dterr != nil {
+		return nil, m.dterr
+	}
+
+	var opt elb.DescribeTagsOutput
+	for _, n := range input.LoadBalancerNames {
+		if *n == m.dtmissing {
+			continue
+		}
+		opt.TagDescriptions = append(opt.TagDescriptions, &elb.TagDescription{
+			LoadBalancerName: n,
+			Tags:             []*elb.Tag{{Key: aws.String("name"), Value: n}},
+		})
+	}
+
+	return &opt, nil
+}
+
+func TestGetLoadBalancersWithTags(t *testing.T) {
+	var (
+		lbs []*elb.LoadBalancerDescription
+		dti []*elb.DescribeTagsInput
+	)
+
+	for i := 0; i < 25; i++ {
+		lbs = append(lbs, &elb.LoadBalancerDescription{LoadBalancerName: aws.String(fmt.Sprintf("lb-%d", i))})
+	}
+
+	c := &connector{
+		regions: []string{"eu-west-1", "eu-west-2"},
+		svcs: []*serviceConnector{
+			{
+				region: "eu-west-1",
+				elb: mockELB{
+					dlbo:      &elb.DescribeLoadBalancersOutput{LoadBalancerDescriptions: lbs},
+					dti:       &dti,
+					dtmissing: "lb-3",
+				},
+			},
+			{
+				region: "eu-west-2",
+				elb: mockELB{
+					dlberr: errors.New("fail"),
+					dti:    &dti,
+				},
+			},
+		},
+	}
+
+	opts, err := c.GetLoadBalancersWithTags(context.Background(), nil)
+	checkErrors(t, "load balancers with tags", -1, err, Errors{NewError("eu-west-2", elb.ServiceName, errors.New("fail"))})
+
+	if len(dti) != 2 || len(dti[0].LoadBalancerNames) != 20 || len(dti[1].LoadBalancerNames) != 5 {
+		t.Fatalf("load balancers with tags - batches: received=%+v | expected=2 batches of 20 and 5 names", dti)
+	}
+
+	if _, ok := opts["eu-west-2"]; ok {
+		t.Errorf("load balancers with tags - region with error: received=%+v | expected=none", opts["eu-west-2"])
+	}
+
+	items := opts["eu-west-1"]
+	if len(items) != len(lbs) {
+		t.Fatalf("load balancers with tags - items: received=%d | expected=%d", len(items), len(lbs))
+	}
+
+	for i, it := range items {
+		if it.Item != lbs[i] {
+			t.Errorf("load balancers with tags [%d] - item: received=%+v | expected=%+v", i, it.Item, lbs[i])
+		}
+
+		if i == 3 {
+			if it.Tags != nil {
+				t.Errorf("load balancers with tags [%d] - tags: received=%+v | expected=nil", i, it.Tags)
+			}
+			continue
+		}
+
+		expected := []*elb.Tag{{Key: aws.String("name"), Value: lbs[i].LoadBalancerName}}
+		if it.Tags == nil || !reflect.DeepEqual(it.Tags.Tags, expected) {
+			t.Errorf("load balancers with tags [%d] - tags: received=%+v | expected=%+v", i, it.Tags, expected)
+		}
+	}
+}
+
+type mockIAM struct {
+	iamiface.IAMAPI
+
+	// Mocking of ListRoles, which returns
+	// each one of the pages to the fn
+	lro []*iam.ListRolesOutput
+
+	// Mocking of ListRolePolicies and ListAttachedRolePolicies,
+	// which return each one of the pages to the fn
+	lrpo  []*iam.ListRolePoliciesOutput
+	larpo []*iam.ListAttachedRolePoliciesOutput
+
+	// Mocking of ListRoleTags and ListUserTags,
+	// which return the tags by the name of the role or user
+	lrto map[string][]*iam.Tag
+	luto map[string][]*iam.Tag
+}
+
+func (m mockIAM) ListRolesPagesWithContext(
+	_ aws.Context, _ *iam.ListRolesInput, fn func(*iam.ListRolesOutput, bool) bool, _ ...request.Option,
+) error {
+	for i, p := range m.lro {
+		if !fn(p, i == len(m.lro)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+func (m mockIAM) ListRolePoliciesPagesWithContext(
+	_ aws.Context, _ *iam.ListRolePoliciesInput, fn func(*iam.ListRolePoliciesOutput, bool) bool, _ ...request.Option,
+) error {
+	for i, p := range m.lrpo {
+		if !fn(p, i == len(m.lrpo)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+func (m mockIAM) ListAttachedRolePoliciesPagesWithContext(
+	_ aws.Context, _ *iam.ListAttachedRolePoliciesInput, fn func(*iam.ListAttachedRolePoliciesOutput, bool) bool, _ ...request.Option,
+) error {
+	for i, p := range m.larpo {
+		if !fn(p, i == len(m.larpo)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+func (m mockIAM) ListRoleTagsWithContext(_ aws.Context, input *iam.ListRoleTagsInput, _ ...request.Option) (*iam.ListRoleTagsOutput, error) {
+	return &iam.ListRoleTagsOutput{Tags: m.lrto[aws.StringValue(input.RoleName)]}, nil
+}
+
+func (m mockIAM) ListUserTagsWithContext(_ aws.Context, input *iam.ListUserTagsInput, _ ...request.Option) (*iam.ListUserTagsOutput, error) {
+	return &iam.ListUserTagsOutput{Tags: m.luto[aws.StringValue(input.UserName)]}, nil
+}
+
+func TestGetRolesWithPolicies(t *testing.T) {
+	role := &iam.Role{RoleName: aws.String("role")}
+	m := mockIAM{
+		lro: []*iam.ListRolesOutput{{Roles: []*iam.Role{role}}},
+		lrpo: []*iam.ListRolePoliciesOutput{
+			{PolicyNames: aws.StringSlice([]string{"p1", "p2"}), IsTruncated: aws.Bool(true), Marker: aws.String("m")},
+			{PolicyNames: aws.StringSlice([]string{"p3"}), IsTruncated: aws.Bool(false)},
+		},
+		larpo: []*iam.ListAttachedRolePoliciesOutput{
+			{AttachedPolicies: []*iam.AttachedPolicy{{PolicyName: aws.String("a1")}}, IsTruncated: aws.Bool(true), Marker: aws.String("m")},
+			{AttachedPolicies: []*iam.AttachedPolicy{{PolicyName: aws.String("a2")}}, IsTruncated: aws.Bool(false)},
+		},
+	}
+
+	t.Run("Pages", func(t *testing.T) {
+		c := &connector{
+			regions: []string{"eu-west-1"},
+			svcs:    []*serviceConnector{{region: "eu-west-1", iam: m}},
+		}
+
+		opts, err := c.GetRolesWithPolicies(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("roles with policies - error: received=%+v | expected=nil", err)
+		}
+
+		items := opts["eu-west-1"]
+		if len(items) != 1 || items[0].Item != role {
+			t.Fatalf("roles with policies - items: received=%+v | expected=%+v", items, role)
+		}
+
+		expected := &iam.ListRolePoliciesOutput{PolicyNames: aws.StringSlice([]string{"p1", "p2", "p3"}), IsTruncated: aws.Bool(false)}
+		if !reflect.DeepEqual(items[0].Policies, expected) {
+			t.Errorf("roles with policies - policies: received=%+v | expected=%+v", items[0].Policies, expected)
+		}
+
+		expectedAttached := &iam.ListAttachedRolePoliciesOutput{
+			AttachedPolicies: []*iam.AttachedPolicy{{PolicyName: aws.String("a1")}, {PolicyName: aws.String("a2")}},
+			IsTruncated:      aws.Bool(false),
+		}
+		if !reflect.DeepEqual(items[0].AttachedPolicies, expectedAttached) {
+			t.Errorf("roles with policies - attached policies: received=%+v | expected=%+v", items[0].AttachedPolicies, expectedAttached)
+		}
+	})
+
+	t.Run("MethodInterceptorError", func(t *testing.T) {
+		c := &connector{
+			regions: []string{"eu-west-1"},
+			svcs:    []*serviceConnector{{region: "eu-west-1", iam: m}},
+			methodInterceptors: []MethodInterceptor{
+				func(ctx context.Context, call *MethodCall, next MethodInvoker) error {
+					if call.Method == "GetRoles" {
+						return errors.New("denied")
+					}
+					return next(ctx, call)
+				},
+			},
+		}
+
+		opts, err := c.GetRolesWithPolicies(context.Background(), nil)
+		checkErrors(t, "roles with policies", -1, err, Errors{NewError("", iam.ServiceName, errors.New("denied"))})
+
+		if len(opts) != 0 {
+			t.Errorf("roles with policies - outputs: received=%+v | expected=none", opts)
+		}
+	})
+}
+
+func TestGetRoles(t *testing.T) {
+	var (
+		r1 = &iam.Role{RoleName: aws.String("r1")}
+		r2 = &iam.Role{RoleName: aws.String("r2")}
+		r3 = &iam.Role{RoleName: aws.String("r3")}
+		m  = mockIAM{
+			lro: []*iam.ListRolesOutput{
+				{Roles: []*iam.Role{r1, r2}, IsTruncated: aws.Bool(true), Marker: aws.String("m")},
+				{Roles: []*iam.Role{r3}, IsTruncated: aws.Bool(false)},
+			},
+		}
+		c = &connector{
+			regions: []string{"eu-west-1"},
+			svcs:    []*serviceConnector{{region: "eu-west-1", iam: m}},
+		}
+	)
+
+	t.Run("Pages", func(t *testing.T) {
+		opts, err := c.GetRoles(context.Background(), nil)
+		checkErrors(t, "roles", -1, err, nil)
+
+		expected := iam.ListRolesOutput{Roles: []*iam.Role{r1, r2, r3}, IsTruncated: aws.Bool(false)}
+		if !reflect.DeepEqual(opts["eu-west-1"], expected) {
+			t.Errorf("roles - output: received=%+v | expected=%+v", opts["eu-west-1"], expected)
+		}
+	})
+
+	t.Run("ResultFunc", func(t *testing.T) {
+		var pages []int
+		ctx := WithResultFunc(context.Background(), func(res Result) {
+			pages = append(pages, len(res.Output.(iam.ListRolesOutput).Roles))
+		})
+
+		opts, err := c.GetRoles(ctx, nil)
+		checkErrors(t, "roles", -1, err, nil)
+
+		if len(opts) != 0 {
+			t.Errorf("roles - outputs: received=%+v | expected=none", opts)
+		}
+		if !reflect.DeepEqual(pages, []int{2, 1}) {
+			t.Errorf("roles - pages: received=%+v | expected=%+v", pages, []int{2, 1})
+		}
+	})
+}
@@ -0,0 +1,458 @@
+package raws
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Graph is the graph of the relationships between resources, like an
+// instance and its subnet, where the nodes are the resources, identified
+// by their Key, and the edges go from a resource to the resources it uses.
+type Graph struct {
+	nodes map[string]Resource
+	out   map[string][]Edge
+	in    map[string][]Edge
+}
+
+// Edge is a relationship from the resource with the key From to
+// the resource with the key To, Relation is its name, like "subnet"
+type Edge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relation string `json:"relation"`
+}
+
+// relation is the definition of the relationships of the resources of
+// a type with the resources of another type, found on a field of them
+type relation struct {
+	// service and typ are the Service and Type of the resources
+	service, typ string
+
+	// name is the Relation of the Edges
+	name string
+
+	// path is the path, separated by dots, to the field with the
+	// values identifying the related resources, on the item of the
+	// raw JSON, which is the "Item" for the types with follow-up
+	// calls like RoleWithPolicies, or on the raw JSON itself if root
+	// is true. The lists found on the way are walked element by element
+	path string
+	root bool
+
+	// sep is the separator of the values, if the field is a string
+	// with a list of values, like "subnet-1,subnet-2"
+	sep string
+
+	// toService and toType are the Service and Type of the
+	// related resources, and by is what the values are, "id",
+	// "name", "arn" or "dns" for the DNS name
+	toService, toType, by string
+}
+
+// relations are all the relationships found by NewGraph
+var relations = []relation{
+	{service: "ec2", typ: "instance", name: "subnet", path: "SubnetId", toService: "ec2", toType: "subnet", by: "id"},
+	{service: "ec2", typ: "instance", name: "vpc", path: "VpcId", toService: "ec2", toType: "vpc", by: "id"},
+	{service: "ec2", typ: "instance", name: "security-group", path: "SecurityGroups.GroupId", toService: "ec2", toType: "security-group", by: "id"},
+	{service: "ec2", typ: "instance", name: "volume", path: "BlockDeviceMappings.Ebs.VolumeId", toService: "ec2", toType: "volume", by: "id"},
+	{service: "ec2", typ: "instance", name: "image", path: "ImageId", toService: "ec2", toType: "image", by: "id"},
+	{service: "ec2", typ: "instance", name: "instance-profile", path: "IamInstanceProfile.Arn", toService: "iam", toType: "instance-profile", by: "arn"},
+	{service: "ec2", typ: "subnet", name: "vpc", path: "VpcId", toService: "ec2", toType: "vpc", by: "id"},
+	{service: "ec2", typ: "security-group", name: "vpc", path: "VpcId", toService: "ec2", toType: "vpc", by: "id"},
+	{service: "ec2", typ: "volume", name: "snapshot", path: "SnapshotId", toService: "ec2", toType: "snapshot", by: "id"},
+
+	{service: "iam", typ: "instance-profile", name: "role", path: "Roles.Arn", toService: "iam", toType: "role", by: "arn"},
+	{service: "iam", typ: "role", name: "policy", path: "AttachedPolicies.AttachedPolicies.PolicyArn", root: true, toService: "iam", toType: "policy", by: "arn"},
+	{service: "iam", typ: "user", name: "policy", path: "AttachedPolicies.AttachedPolicies.PolicyArn", root: true, toService: "iam", toType: "policy", by: "arn"},
+	{service: "iam", typ: "group", name: "policy", path: "AttachedPolicies.AttachedPolicies.PolicyArn", root: true, toService: "iam", toType: "policy", by: "arn"},
+	{service: "iam", typ: "access-key", name: "user", path: "UserName", toService: "iam", toType: "user", by: "name"},
+
+	{service: "elb", typ: "load-balancer", name: "instance", path: "Instances.InstanceId", toService: "ec2", toType: "instance", by: "id"},
+	{service: "elb", typ: "load-balancer", name: "security-group", path: "SecurityGroups", toService: "ec2", toType: "security-group", by: "id"},
+	{service: "elb", typ: "load-balancer", name: "subnet", path: "Subnets", toService: "ec2", toType: "subnet", by: "id"},
+	{service: "elb", typ: "load-balancer", name: "vpc", path: "VPCId", toService: "ec2", toType: "vpc", by: "id"},
+	{service: "elbv2", typ: "load-balancer", name: "security-group", path: "SecurityGroups", toService: "ec2", toType: "security-group", by: "id"},
+	{service: "elbv2", typ: "load-balancer", name: "subnet", path: "AvailabilityZones.SubnetId", toService: "ec2", toType: "subnet", by: "id"},
+	{service: "elbv2", typ: "load-balancer", name: "vpc", path: "VpcId", toService: "ec2", toType: "vpc", by: "id"},
+
+	{service: "autoscaling", typ: "auto-scaling-group", name: "launch-configuration", path: "LaunchConfigurationName", toService: "autoscaling", toType: "launch-configuration", by: "name"},
+	{service: "autoscaling", typ: "auto-scaling-group", name: "launch-template", path: "LaunchTemplate.LaunchTemplateId", toService: "ec2", toType: "launch-template", by: "id"},
+	{service: "autoscaling", typ: "auto-scaling-group", name: "instance", path: "Instances.InstanceId", toService: "ec2", toType: "instance", by: "id"},
+	{service: "autoscaling", typ: "auto-scaling-group", name: "load-balancer", path: "LoadBalancerNames", toService: "elb", toType: "load-balancer", by: "name"},
+	{service: "autoscaling", typ: "auto-scaling-group", name: "subnet", path: "VPCZoneIdentifier", sep: ",", toService: "ec2", toType: "subnet", by: "id"},
+	{service: "autoscaling", typ: "launch-configuration", name: "security-group", path: "SecurityGroups", toService: "ec2", toType: "security-group", by: "id"},
+	{service: "autoscaling", typ: "launch-configuration", name: "image", path: "ImageId", toService: "ec2", toType: "image", by: "id"},
+
+	{service: "rds", typ: "db-instance", name: "subnet", path: "DBSubnetGroup.Subnets.SubnetIdentifier", toService: "ec2", toType: "subnet", by: "id"},
+	{service: "rds", typ: "db-instance", name: "vpc", path: "DBSubnetGroup.VpcId", toService: "ec2", toType: "vpc", by: "id"},
+	{service: "rds", typ: "db-instance", name: "security-group", path: "VpcSecurityGroups.VpcSecurityGroupId", toService: "ec2", toType: "security-group", by: "id"},
+	{service: "elasticache", typ: "cache-cluster", name: "security-group", path: "SecurityGroups.SecurityGroupId", toService: "ec2", toType: "security-group", by: "id"},
+
+	{service: "route53", typ: "record-set", name: "alias", path: "AliasTarget.DNSName", toService: "elb", toType: "load-balancer", by: "dns"},
+	{service: "route53", typ: "record-set", name: "alias", path: "AliasTarget.DNSName", toService: "elbv2", toType: "load-balancer", by: "dns"},
+	{service: "route53", typ: "record-set", name: "alias", path: "AliasTarget.DNSName", toService: "cloudfront", toType: "distribution", by: "dns"},
+	{service: "route53resolver", typ: "resolver-endpoint", name: "vpc", path: "HostVPCId", toService: "ec2", toType: "vpc", by: "id"},
+	{service: "route53resolver", typ: "resolver-endpoint", name: "security-group", path: "SecurityGroupIds", toService: "ec2", toType: "security-group", by: "id"},
+	{service: "route53resolver", typ: "resolver-rule-association", name: "vpc", path: "VPCId", toService: "ec2", toType: "vpc", by: "id"},
+	{service: "route53resolver", typ: "resolver-rule-association", name: "resolver-rule", path: "ResolverRuleId", toService: "route53resolver", toType: "resolver-rule", by: "id"},
+}
+
+// NewGraph builds the Graph of the relationships between the resources,
+// which can be the ones of a Snapshot, built by Inventory or read with
+// ReadSnapshot.
+// The relationships are found on the Raw of the resources, so the ones
+// which need follow-up calls, like the policies attached to a role, are
+// only found if the resources have been built from the types with them,
+// like RoleWithPolicies, as Inventory does.
+// The related resources which are identified by an ARN, like the policies,
+// are added to the Graph, with only the ARN, Service, Type and ID, if they
+// aren't on the resources, while the relationships with the other ones
+// which aren't on the resources are ignored.
+func NewGraph(resources []Resource) (*Graph, error) {
+	g := &Graph{
+		nodes: make(map[string]Resource),
+		out:   make(map[string][]Edge),
+		in:    make(map[string][]Edge),
+	}
+
+	var (
+		index    = make(map[string]string)
+		rawByKey = make(map[string]interface{})
+	)
+
+	for _, r := range resources {
+		k := r.Key()
+		g.nodes[k] = r
+
+		raw, err := genericRaw(r)
+		if err != nil {
+			return nil, err
+		}
+		rawByKey[k] = raw
+
+		index[indexKey(r.Service, r.Type, r.Region, "id", r.ID)] = k
+		if r.Name != "" {
+			index[indexKey(r.Service, r.Type, r.Region, "name", r.Name)] = k
+		}
+		if r.ARN != "" {
+			index[indexKey(r.Service, r.Type, "", "arn", r.ARN)] = k
+		}
+		for _, dns := range jsonValues(item(raw), []string{"DNSName"}, "") {
+			index[indexKey(r.Service, r.Type, "", "dns", normalizeDNS(dns))] = k
+		}
+		for _, dns := range jsonValues(item(raw), []string{"DomainName"}, "") {
+			index[indexKey(r.Service, r.Type, "", "dns", normalizeDNS(dns))] = k
+		}
+	}
+
+	for _, k := range g.keys() {
+		r := g.nodes[k]
+		for _, rel := range relations {
+			if rel.service != r.Service || rel.typ != r.Type {
+				continue
+			}
+
+			v := item(rawByKey[k])
+			if rel.root {
+				v = rawByKey[k]
+			}
+
+			for _, val := range jsonValues(v, strings.Split(rel.path, "."), rel.sep) {
+				to, ok := g.lookup(index, rel, r, val)
+				if !ok {
+					continue
+				}
+
+				g.addEdge(Edge{From: k, To: to, Relation: rel.name})
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// lookup returns the key of the resource related to r by rel
+// with the value val, adding it to g if it's identified by
+// an ARN and it's not on g already
+func (g *Graph) lookup(index map[string]string, rel relation, r Resource, val string) (string, bool) {
+	switch rel.by {
+	case "arn":
+		if k, ok := index[indexKey(rel.toService, rel.toType, "", "arn", val)]; ok {
+			return k, true
+		}
+
+		n := Resource{ARN: val, Service: rel.toService, Type: rel.toType, ID: val}
+		g.nodes[n.Key()] = n
+		index[indexKey(rel.toService, rel.toType, "", "arn", val)] = n.Key()
+
+		return n.Key(), true
+	case "dns":
+		k, ok := index[indexKey(rel.toService, rel.toType, "", "dns", normalizeDNS(val))]
+		return k, ok
+	default:
+		// The resources of global services have no region
+		for _, region := range []string{r.Region, ""} {
+			if k, ok := index[indexKey(rel.toService, rel.toType, region, rel.by, val)]; ok {
+				return k, true
+			}
+		}
+
+		return "", false
+	}
+}
+
+func (g *Graph) addEdge(e Edge) {
+	for _, oe := range g.out[e.From] {
+		if oe == e {
+			return
+		}
+	}
+
+	g.out[e.From] = append(g.out[e.From], e)
+	g.in[e.To] = append(g.in[e.To], e)
+}
+
+// keys returns the keys of all the nodes sorted
+func (g *Graph) keys() []string {
+	keys := make([]string, 0, len(g.nodes))
+	for k := range g.nodes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Resource returns the resource with the key and if it's on g
+func (g *Graph) Resource(key string) (Resource, bool) {
+	r, ok := g.nodes[key]
+	return r, ok
+}
+
+// Resources returns all the resources of g sorted by their Key
+func (g *Graph) Resources() []Resource {
+	var res []Resource
+	for _, k := range g.keys() {
+		res = append(res, g.nodes[k])
+	}
+
+	return res
+}
+
+// Edges returns all the edges of g sorted by their From and To
+func (g *Graph) Edges() []Edge {
+	var edges []Edge
+	for _, k := range g.keys() {
+		edges = append(edges, g.out[k]...)
+	}
+
+	return edges
+}
+
+// Neighbors returns the resources directly related to the resource with
+// the key, the ones it uses and the ones using it, sorted by their Key
+func (g *Graph) Neighbors(key string) []Resource {
+	keys := make(map[string]struct{})
+	for _, e := range g.out[key] {
+		keys[e.To] = struct{}{}
+	}
+	for _, e := range g.in[key] {
+		keys[e.From] = struct{}{}
+	}
+
+	return g.sorted(keys)
+}
+
+// Reachable returns the resources which can be reached from the resource
+// with the key following the edges, so all the resources it uses directly
+// or indirectly, like the policies of the role of the instance profile of
+// an instance, sorted by their Key
+func (g *Graph) Reachable(key string) []Resource {
+	var (
+		keys  = make(map[string]struct{})
+		queue = []string{key}
+	)
+
+	for len(queue) > 0 {
+		k := queue[0]
+		queue = queue[1:]
+
+		for _, e := range g.out[k] {
+			if _, ok := keys[e.To]; ok || e.To == key {
+				continue
+			}
+			keys[e.To] = struct{}{}
+			queue = append(queue, e.To)
+		}
+	}
+
+	return g.sorted(keys)
+}
+
+func (g *Graph) sorted(keys map[string]struct{}) []Resource {
+	sk := make([]string, 0, len(keys))
+	for k := range keys {
+		sk = append(sk, k)
+	}
+	sort.Strings(sk)
+
+	res := make([]Resource, 0, len(sk))
+	for _, k := range sk {
+		res = append(res, g.nodes[k])
+	}
+
+	return res
+}
+
+// graphNode is a node of the Graph on its JSON
+type graphNode struct {
+	Key     string `json:"key"`
+	Service string `json:"service"`
+	Type    string `json:"type"`
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Region  string `json:"region,omitempty"`
+}
+
+// MarshalJSON marshals g with its nodes, without the Raw
+// and the other details of the resources, and its edges
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	nodes := make([]graphNode, 0, len(g.nodes))
+	for _, r := range g.Resources() {
+		nodes = append(nodes, graphNode{
+			Key:     r.Key(),
+			Service: r.Service,
+			Type:    r.Type,
+			ID:      r.ID,
+			Name:    r.Name,
+			Region:  r.Region,
+		})
+	}
+
+	edges := g.Edges()
+	if edges == nil {
+		edges = []Edge{}
+	}
+
+	return json.Marshal(struct {
+		Nodes []graphNode `json:"nodes"`
+		Edges []Edge      `json:"edges"`
+	}{
+		Nodes: nodes,
+		Edges: edges,
+	})
+}
+
+// WriteDOT writes g on w in the DOT language of Graphviz, with
+// the resources labeled with their type and name, or ID if they
+// don't have a name, and the edges labeled with their Relation
+func (g *Graph) WriteDOT(w io.Writer) error {
+	_, err := fmt.Fprintln(w, "digraph raws {")
+	if err != nil {
+		return err
+	}
+
+	for _, r := range g.Resources() {
+		label := r.Name
+		if label == "" {
+			label = r.ID
+		}
+
+		_, err = fmt.Fprintf(w, "  %q [label=%q];\n", r.Key(), fmt.Sprintf("%s %s\n%s", r.Service, r.Type, label))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, e := range g.Edges() {
+		_, err = fmt.Fprintf(w, "  %q -> %q [label=%q];\n", e.From, e.To, e.Relation)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintln(w, "}")
+	return err
+}
+
+// genericRaw returns the Raw of r as a generic JSON value, so the
+// resources built from the SDK items and the ones read from a
+// Snapshot are handled in the same way
+func genericRaw(r Resource) (interface{}, error) {
+	if r.Raw == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(r.Raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	err = json.Unmarshal(b, &v)
+
+	return v, err
+}
+
+// item returns the "Item" of the generic JSON value raw if it has it,
+// as the types with follow-up calls have the SDK item on it, or raw
+func item(raw interface{}) interface{} {
+	if m, ok := raw.(map[string]interface{}); ok {
+		if i, ok := m["Item"]; ok {
+			return i
+		}
+	}
+
+	return raw
+}
+
+// jsonValues returns the strings at the end of the path on the generic
+// JSON value v, walking the lists element by element, split by sep if
+// it's not empty
+func jsonValues(v interface{}, path []string, sep string) []string {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		if len(path) == 0 {
+			return nil
+		}
+		return jsonValues(vv[path[0]], path[1:], sep)
+	case []interface{}:
+		var values []string
+		for _, e := range vv {
+			values = append(values, jsonValues(e, path, sep)...)
+		}
+		return values
+	case string:
+		if len(path) != 0 || vv == "" {
+			return nil
+		}
+		if sep == "" {
+			return []string{vv}
+		}
+
+		var values []string
+		for _, s := range strings.Split(vv, sep) {
+			if s = strings.TrimSpace(s); s != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+func indexKey(service, typ, region, by, value string) string {
+	return strings.Join([]string{service, typ, region, by, value}, "|")
+}
+
+// normalizeDNS returns the DNS name in lower case, without the final
+// dot and the "dualstack." prefix, which is added to the ELB DNS names
+// on the Route 53 alias records
+func normalizeDNS(dns string) string {
+	dns = strings.TrimSuffix(strings.ToLower(dns), ".")
+	return strings.TrimPrefix(dns, "dualstack.")
+}
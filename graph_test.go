@@ -0,0 +1,350 @@
+package raws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+)
+
+func graphResources(t *testing.T) []Resource {
+	t.Helper()
+
+	items := []interface{}{
+		&ec2.Vpc{VpcId: aws.String("vpc-1")},
+		&ec2.Subnet{SubnetId: aws.String("subnet-1"), VpcId: aws.String("vpc-1")},
+		&ec2.SecurityGroup{GroupId: aws.String("sg-1"), VpcId: aws.String("vpc-1")},
+		&ec2.Instance{
+			InstanceId:         aws.String("i-1"),
+			SubnetId:           aws.String("subnet-1"),
+			VpcId:              aws.String("vpc-1"),
+			SecurityGroups:     []*ec2.GroupIdentifier{{GroupId: aws.String("sg-1")}},
+			IamInstanceProfile: &ec2.IamInstanceProfile{Arn: aws.String("arn:aws:iam::123:instance-profile/web")},
+			ImageId:            aws.String("ami-unknown"),
+		},
+		&iam.InstanceProfile{
+			InstanceProfileId: aws.String("AIPA1"),
+			Arn:               aws.String("arn:aws:iam::123:instance-profile/web"),
+			Roles:             []*iam.Role{{Arn: aws.String("arn:aws:iam::123:role/web")}},
+		},
+		RoleWithPolicies{
+			Item: &iam.Role{RoleId: aws.String("AROA1"), Arn: aws.String("arn:aws:iam::123:role/web")},
+			AttachedPolicies: &iam.ListAttachedRolePoliciesOutput{
+				AttachedPolicies: []*iam.AttachedPolicy{{PolicyArn: aws.String("arn:aws:iam::aws:policy/ReadOnlyAccess")}},
+			},
+		},
+		LoadBalancerWithTags{
+			Item: &elb.LoadBalancerDescription{
+				LoadBalancerName: aws.String("lb-1"),
+				DNSName:          aws.String("lb-1-123.eu-west-1.elb.amazonaws.com"),
+				Instances:        []*elb.Instance{{InstanceId: aws.String("i-1")}},
+			},
+		},
+		&route53.ResourceRecordSet{
+			Name:        aws.String("www.example.com."),
+			Type:        aws.String("A"),
+			AliasTarget: &route53.AliasTarget{DNSName: aws.String("dualstack.lb-1-123.eu-west-1.elb.amazonaws.com.")},
+		},
+	}
+
+	var res []Resource
+	for _, i := range items {
+		r, err := NewResource("123", "eu-west-1", i)
+		if err != nil {
+			t.Fatalf("resource - errors: received=%+v | expected=nil", err)
+		}
+		res = append(res, r)
+	}
+
+	return res
+}
+
+func TestNewGraph(t *testing.T) {
+	var (
+		instance = "arn:aws:ec2:eu-west-1:123:instance/i-1"
+		lb       = "arn:aws:elasticloadbalancing:eu-west-1:123:loadbalancer/lb-1"
+		policy   = "arn:aws:iam::aws:policy/ReadOnlyAccess"
+	)
+
+	res := graphResources(t)
+
+	// The relationships are the same with the resources
+	// built from the items and read from a snapshot
+	var buff bytes.Buffer
+	err := (&Snapshot{Version: SnapshotVersion, Resources: res}).Write(&buff)
+	if err != nil {
+		t.Fatalf("write - errors: received=%+v | expected=nil", err)
+	}
+	s, err := ReadSnapshot(&buff)
+	if err != nil {
+		t.Fatalf("read - errors: received=%+v | expected=nil", err)
+	}
+
+	tests := []struct {
+		name      string
+		resources []Resource
+	}{
+		{name: "items", resources: res},
+		{name: "snapshot", resources: s.Resources},
+	}
+
+	for i, tt := range tests {
+		g, err := NewGraph(tt.resources)
+		checkErrors(t, tt.name, i, err, nil)
+
+		var neighbors []string
+		for _, r := range g.Neighbors(instance) {
+			neighbors = append(neighbors, r.ID)
+		}
+		expectedNeighbors := []string{"sg-1", "subnet-1", "vpc-1", "lb-1", "AIPA1"}
+		if !reflect.DeepEqual(neighbors, expectedNeighbors) {
+			t.Errorf("%s [%d] - neighbors: received=%+v | expected=%+v", tt.name, i, neighbors, expectedNeighbors)
+		}
+
+		var reachable []string
+		for _, r := range g.Reachable(lb) {
+			reachable = append(reachable, r.Key())
+		}
+		expectedReachable := []string{
+			instance,
+			"arn:aws:ec2:eu-west-1:123:security-group/sg-1",
+			"arn:aws:ec2:eu-west-1:123:subnet/subnet-1",
+			"arn:aws:ec2:eu-west-1:123:vpc/vpc-1",
+			"arn:aws:iam::123:instance-profile/web",
+			"arn:aws:iam::123:role/web",
+			policy,
+		}
+		if !reflect.DeepEqual(reachable, expectedReachable) {
+			t.Errorf("%s [%d] - reachable: received=%+v | expected=%+v", tt.name, i, reachable, expectedReachable)
+		}
+
+		var aliases []string
+		for _, e := range g.Edges() {
+			if e.Relation == "alias" {
+				aliases = append(aliases, e.To)
+			}
+		}
+		if !reflect.DeepEqual(aliases, []string{lb}) {
+			t.Errorf("%s [%d] - aliases: received=%+v | expected=%+v", tt.name, i, aliases, []string{lb})
+		}
+
+		if r, ok := g.Resource(policy); !ok || r.Type != "policy" {
+			t.Errorf("%s [%d] - policy: received=%+v | expected=policy", tt.name, i, r)
+		}
+	}
+}
+
+func TestGraphExport(t *testing.T) {
+	g, err := NewGraph(graphResources(t))
+	if err != nil {
+		t.Fatalf("graph - errors: received=%+v | expected=nil", err)
+	}
+
+	var dot bytes.Buffer
+	err = g.WriteDOT(&dot)
+	checkErrors(t, "dot", -1, err, nil)
+
+	expectedEdge := `"arn:aws:ec2:eu-west-1:123:subnet/subnet-1" -> "arn:aws:ec2:eu-west-1:123:vpc/vpc-1" [label="vpc"];`
+	if !strings.HasPrefix(dot.String(), "digraph raws {") || !strings.Contains(dot.String(), expectedEdge) {
+		t.Errorf("dot - output: received=%s | expected=%s", dot.String(), expectedEdge)
+	}
+
+	b, err := json.Marshal(g)
+	checkErrors(t, "json", -1, err, nil)
+
+	var v struct {
+		Nodes []graphNode `json:"nodes"`
+		Edges []Edge      `json:"edges"`
+	}
+	err = json.Unmarshal(b, &v)
+	checkErrors(t, "json", -1, err, nil)
+
+	if len(v.Nodes) != len(g.Resources()) || len(v.Edges) != len(g.Edges()) {
+		t.Errorf("json - output: received=%s", b)
+	}
+}
+
+type mockRoute53 struct {
+	route53iface.Route53API
+
+	// Mocking of ListHostedZones
+	lhzo *route53.ListHostedZonesOutput
+
+	// Mocking of ListResourceRecordSets, which returns each
+	// one of the pages of the zone to the fn, and keeps the
+	// inputs on lrrsi
+	lrrso map[string][]*route53.ListResourceRecordSetsOutput
+	lrrsi *[]*route53.ListResourceRecordSetsInput
+}
+
+func (m mockRoute53) ListHostedZonesPagesWithContext(
+	_ aws.Context, _ *route53.ListHostedZonesInput, fn func(*route53.ListHostedZonesOutput, bool) bool, _ ...request.Option,
+) error {
+	fn(m.lhzo, true)
+	return nil
+}
+
+func (m mockRoute53) ListResourceRecordSetsPagesWithContext(
+	_ aws.Context, input *route53.ListResourceRecordSetsInput, fn func(*route53.ListResourceRecordSetsOutput, bool) bool, _ ...request.Option,
+) error {
+	*m.lrrsi = append(*m.lrrsi, input)
+
+	pages := m.lrrso[aws.StringValue(input.HostedZoneId)]
+	for i, p := range pages {
+		if !fn(p, i == len(pages)-1) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (m mockRoute53) ListReusableDelegationSetsWithContext(
+	_ aws.Context, _ *route53.ListReusableDelegationSetsInput, _ ...request.Option,
+) (*route53.ListReusableDelegationSetsOutput, error) {
+	return &route53.ListReusableDelegationSetsOutput{}, nil
+}
+
+func (m mockRoute53) ListHealthChecksPagesWithContext(
+	_ aws.Context, _ *route53.ListHealthChecksInput, fn func(*route53.ListHealthChecksOutput, bool) bool, _ ...request.Option,
+) error {
+	fn(&route53.ListHealthChecksOutput{}, true)
+	return nil
+}
+
+func (m mockRoute53) ListQueryLoggingConfigsWithContext(
+	_ aws.Context, _ *route53.ListQueryLoggingConfigsInput, _ ...request.Option,
+) (*route53.ListQueryLoggingConfigsOutput, error) {
+	return &route53.ListQueryLoggingConfigsOutput{}, nil
+}
+
+type mockCloudFront struct {
+	cloudfrontiface.CloudFrontAPI
+
+	// Mocking of ListDistributions
+	ldo *cloudfront.ListDistributionsOutput
+}
+
+func (m mockCloudFront) ListDistributionsPagesWithContext(
+	_ aws.Context, _ *cloudfront.ListDistributionsInput, fn func(*cloudfront.ListDistributionsOutput, bool) bool, _ ...request.Option,
+) error {
+	fn(m.ldo, true)
+	return nil
+}
+
+func (m mockCloudFront) ListPublicKeysWithContext(
+	_ aws.Context, _ *cloudfront.ListPublicKeysInput, _ ...request.Option,
+) (*cloudfront.ListPublicKeysOutput, error) {
+	return &cloudfront.ListPublicKeysOutput{}, nil
+}
+
+func (m mockCloudFront) ListCloudFrontOriginAccessIdentitiesPagesWithContext(
+	_ aws.Context, _ *cloudfront.ListCloudFrontOriginAccessIdentitiesInput, fn func(*cloudfront.ListCloudFrontOriginAccessIdentitiesOutput, bool) bool, _ ...request.Option,
+) error {
+	fn(&cloudfront.ListCloudFrontOriginAccessIdentitiesOutput{}, true)
+	return nil
+}
+
+func TestInventoryGraphAliases(t *testing.T) {
+	var (
+		dti   []*elb.DescribeTagsInput
+		lrrsi []*route53.ListResourceRecordSetsInput
+
+		lb           = "arn:aws:elasticloadbalancing:eu-west-1:123:loadbalancer/lb-1"
+		distribution = "arn:aws:cloudfront::123:distribution/E1"
+		alias        = func(dns string) *route53.AliasTarget {
+			return &route53.AliasTarget{DNSName: aws.String(dns)}
+		}
+	)
+
+	c := &connector{
+		regions:   []string{"eu-west-1"},
+		accountID: aws.String("123"),
+		svcs: []*serviceConnector{
+			{
+				region: "eu-west-1",
+				elb: mockELB{
+					dlbo: &elb.DescribeLoadBalancersOutput{LoadBalancerDescriptions: []*elb.LoadBalancerDescription{{
+						LoadBalancerName: aws.String("lb-1"),
+						DNSName:          aws.String("lb-1-123.eu-west-1.elb.amazonaws.com"),
+					}}},
+					dti: &dti,
+				},
+				cloudfront: mockCloudFront{
+					ldo: &cloudfront.ListDistributionsOutput{DistributionList: &cloudfront.DistributionList{
+						Items: []*cloudfront.DistributionSummary{{
+							Id:         aws.String("E1"),
+							ARN:        aws.String(distribution),
+							DomainName: aws.String("d1.cloudfront.net"),
+						}},
+					}},
+				},
+				route53: mockRoute53{
+					lhzo: &route53.ListHostedZonesOutput{HostedZones: []*route53.HostedZone{
+						{Id: aws.String("/hostedzone/Z1"), Name: aws.String("example.com.")},
+					}},
+					lrrso: map[string][]*route53.ListResourceRecordSetsOutput{
+						"/hostedzone/Z1": {
+							{
+								ResourceRecordSets: []*route53.ResourceRecordSet{
+									{Name: aws.String("lb.example.com."), Type: aws.String("A"), AliasTarget: alias("dualstack.lb-1-123.eu-west-1.elb.amazonaws.com.")},
+								},
+								IsTruncated:    aws.Bool(true),
+								NextRecordName: aws.String("www.example.com."),
+								NextRecordType: aws.String("A"),
+							},
+							{
+								ResourceRecordSets: []*route53.ResourceRecordSet{
+									{Name: aws.String("www.example.com."), Type: aws.String("A"), AliasTarget: alias("d1.cloudfront.net.")},
+								},
+								IsTruncated: aws.Bool(false),
+							},
+						},
+					},
+					lrrsi: &lrrsi,
+				},
+			},
+		},
+	}
+
+	s, err := Inventory(context.Background(), c, InventoryOptions{Services: []string{"route53", "elb", "cloudfront"}})
+	checkErrors(t, "inventory", -1, err, nil)
+
+	if len(lrrsi) != 1 || aws.StringValue(lrrsi[0].HostedZoneId) != "/hostedzone/Z1" {
+		t.Errorf("inventory - record sets calls: received=%+v | expected=1 call of /hostedzone/Z1", lrrsi)
+	}
+
+	g, err := NewGraph(s.Resources)
+	checkErrors(t, "graph", -1, err, nil)
+
+	tests := []struct {
+		name     string
+		key      string
+		expected string
+	}{
+		{name: "load balancer", key: "route53/record-set/123//Z1_lb.example.com._A", expected: lb},
+		{name: "distribution", key: "route53/record-set/123//Z1_www.example.com._A", expected: distribution},
+	}
+
+	for i, tt := range tests {
+		var neighbors []string
+		for _, r := range g.Neighbors(tt.key) {
+			neighbors = append(neighbors, r.Key())
+		}
+		if !reflect.DeepEqual(neighbors, []string{tt.expected}) {
+			t.Errorf("%s [%d] - neighbors: received=%+v | expected=%+v", tt.name, i, neighbors, []string{tt.expected})
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package raws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/iam"
+)
+
+func (c *connector) GetAccountAuthorizationDetails(ctx context.Context, input *iam.GetAccountAuthorizationDetailsInput) (map[string]iam.GetAccountAuthorizationDetailsOutput, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetAccountAuthorizationDetails", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]iam.GetAccountAuthorizationDetailsOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The details of all the pages are returned on a
+			// single output, without the Marker
+			call := &Call{Method: "GetAccountAuthorizationDetails", Operation: "GetAccountAuthorizationDetails", Service: iam.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt iam.GetAccountAuthorizationDetailsOutput
+				var n int
+				err := svc.iam.GetAccountAuthorizationDetailsPagesWithContext(ctx, input.(*iam.GetAccountAuthorizationDetailsInput), func(page *iam.GetAccountAuthorizationDetailsOutput, _ bool) bool {
+					n++
+					if sendOutput(ctx, "GetAccountAuthorizationDetails", svc.region, n, *page) {
+						return true
+					}
+					opt.UserDetailList = append(opt.UserDetailList, page.UserDetailList...)
+					opt.GroupDetailList = append(opt.GroupDetailList, page.GroupDetailList...)
+					opt.RoleDetailList = append(opt.RoleDetailList, page.RoleDetailList...)
+					opt.Policies = append(opt.Policies, page.Policies...)
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetAccountAuthorizationDetails", NewError(svc.region, iam.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*iam.GetAccountAuthorizationDetailsOutput)
+			}
+		}
+
+		if errs != nil {
+			return regionsOpts, errs
+		}
+
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]iam.GetAccountAuthorizationDetailsOutput)
+
+	return opts, err
+}
@@ -0,0 +1,206 @@
+package raws
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// Call is a call made by the AWSReader to an AWS service on a region,
+// which the Interceptors receive before and after it's made
+type Call struct {
+	// Method is the AWSReader method making the call, like
+	// "GetInstances", or "NewAWSReader" for the calls made
+	// to create the reader
+	Method string
+
+	// Operation is the AWS operation called, like "DescribeInstances"
+	Operation string
+
+	// Service is the AWS service called, like "ec2"
+	Service string
+
+	// Region is the region the call is made to
+	Region string
+
+	// AccountID is the account of the reader, which is
+	// empty for the calls made before it's known
+	AccountID string
+
+	// Input is the input of the Operation, like an *ec2.DescribeInstancesInput,
+	// which can be replaced by the Interceptors by another one of the same type
+	Input interface{}
+
+	// Output is the output of the Operation, like an *ec2.DescribeInstancesOutput,
+	// set once the call has been made. The calls of the paginated operations made
+	// by the methods returning all the pages have the output with all of them
+	Output interface{}
+
+	// Err is the error returned by the call
+	Err error
+
+	// Duration is the time the call took, without the Interceptors
+	Duration time.Duration
+
+	// RequestOptions are applied to each one of the AWS requests made
+	// by the call, which are more than one with the pages and the
+	// retries, so the Interceptors can add handlers to them
+	RequestOptions []request.Option
+}
+
+// Invoker makes the call, setting its Output, Err and
+// Duration, and returns the error of the call
+type Invoker func(ctx context.Context, call *Call) error
+
+// Interceptor wraps each call made by the AWSReader, which is made by
+// calling next, so it can act before and after it, change its Input or
+// the error returned. An Interceptor which doesn't call next must set
+// the Output of the call, unless it returns an error.
+type Interceptor func(ctx context.Context, call *Call, next Invoker) error
+
+// MethodCall is a call to a method of the AWSReader, which
+// makes the Calls to AWS on each one of the Regions
+type MethodCall struct {
+	// Method is the AWSReader method called, like "GetInstances"
+	Method string
+
+	// AccountID is the account of the reader
+	AccountID string
+
+	// Regions are the regions of the reader the Calls are made to
+	Regions []string
+
+	// Input is the input of the method, like an *ec2.DescribeInstancesInput
+	Input interface{}
+
+	// Output is the output of the method, like a
+	// map[string]ec2.DescribeInstancesOutput, or the int64
+	// of DownloadObject, set once it returns
+	Output interface{}
+
+	// Err is the error returned by the method, which is an Errors
+	// with the errors of the regions, other than for DownloadObject
+	Err error
+
+	// Duration is the time the method took, without the MethodInterceptors
+	Duration time.Duration
+}
+
+// MethodInvoker calls the method, setting its Output, Err
+// and Duration, and returns the error of the method
+type MethodInvoker func(ctx context.Context, call *MethodCall) error
+
+// MethodInterceptor wraps each call to a method of the AWSReader, which
+// is made by calling next, with the ctx given to the Calls it makes, so
+// it can act before and after all of them. A MethodInterceptor which
+// doesn't call next must set the Output of the call, and the error it
+// returns must be of the same type than the one of the method.
+type MethodInterceptor func(ctx context.Context, call *MethodCall, next MethodInvoker) error
+
+// ReaderOptions are the options of the AWSReader
+// returned by NewAWSReaderWithOptions
+type ReaderOptions struct {
+	// Interceptors wrap all the calls made by the AWSReader, the first
+	// one being the outermost, so it's the first one called
+	Interceptors []Interceptor
+
+	// MethodInterceptors wrap all the calls to the methods of the
+	// AWSReader, with the same order than the Interceptors
+	MethodInterceptors []MethodInterceptor
+
+	// Logger logs the discovery of the regions and the account,
+	// the calls made to AWS, with their retries, and the regions
+	// DownloadObject falls back to, nothing is logged if nil
+	Logger Logger
+
+	// Progress receives the progress of the methods, as they start
+	// and finish, with the calls they make and the results they
+	// fetch, so it's called often and has to return quickly
+	Progress ProgressFunc
+
+	// Timeout is the time each method can take, after which the
+	// regions not called yet, or being called, fail with ErrTimeout,
+	// while the results of the other ones are returned
+	Timeout time.Duration
+
+	// RegionTimeout is the time the calls made by each method
+	// on each region can take, from the first one, after which
+	// they fail with ErrTimeout, so a slow region doesn't hold
+	// up the others
+	RegionTimeout time.Duration
+}
+
+// invoke makes the call through the Interceptors of the connector,
+// where fn calls the Operation with the input and the options of
+// the requests, and returns the output and the error of the call
+func (c *connector) invoke(
+	ctx context.Context, call *Call, fn func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error),
+) (interface{}, error) {
+	if c.accountID != nil {
+		call.AccountID = *c.accountID
+	}
+
+	next := func(ctx context.Context, call *Call) error {
+		opts := append(append([]request.Option{}, call.RequestOptions...), c.logRetries(call))
+
+		ctx, cancel := c.regionContext(ctx, call.Region)
+		defer cancel()
+
+		return c.progressCall(ctx, call, func() error {
+			start := time.Now()
+			call.Output, call.Err = fn(ctx, call.Input, opts...)
+			call.Err = timeoutErr(ctx, call.Err)
+			call.Duration = time.Since(start)
+			c.logCall(call)
+
+			return call.Err
+		})
+	}
+
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		ic, inner := c.interceptors[i], next
+		next = func(ctx context.Context, call *Call) error {
+			return ic(ctx, call, inner)
+		}
+	}
+
+	err := next(ctx, call)
+
+	return call.Output, err
+}
+
+// invokeMethod calls the method through the MethodInterceptors of the
+// connector, where fn is the method, and returns its output and error
+func (c *connector) invokeMethod(
+	ctx context.Context, call *MethodCall, fn func(ctx context.Context) (interface{}, error),
+) (interface{}, error) {
+	call.Regions = ContextRegions(ctx, c.regions)
+	if c.accountID != nil {
+		call.AccountID = *c.accountID
+	}
+
+	next := func(ctx context.Context, call *MethodCall) error {
+		ctx, cancel := c.methodContext(ctx)
+		defer cancel()
+
+		return c.progressMethod(ctx, call, func(ctx context.Context) error {
+			start := time.Now()
+			call.Output, call.Err = fn(ctx)
+			call.Duration = time.Since(start)
+
+			return call.Err
+		})
+	}
+
+	for i := len(c.methodInterceptors) - 1; i >= 0; i-- {
+		ic, inner := c.methodInterceptors[i], next
+		next = func(ctx context.Context, call *MethodCall) error {
+			return ic(ctx, call, inner)
+		}
+	}
+
+	err := next(ctx, call)
+
+	return call.Output, err
+}
@@ -0,0 +1,170 @@
+package raws
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+)
+
+func TestConnector_invoke(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		dio    = &ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{ReservationId: aws.String("r-1")}}}
+		cached = &ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{ReservationId: aws.String("r-2")}}}
+		input  = &ec2.DescribeInstancesInput{InstanceIds: []*string{aws.String("i-1")}}
+	)
+
+	t.Run("order", func(t *testing.T) {
+		var log []string
+		record := func(name string) Interceptor {
+			return func(ctx context.Context, call *Call, next Invoker) error {
+				log = append(log, name+" "+call.Method+" "+call.Operation+" "+call.Service+" "+call.Region)
+				err := next(ctx, call)
+				log = append(log, name+" done")
+				return err
+			}
+		}
+		c := &connector{
+			svcs:         []*serviceConnector{{region: "eu-west-1", ec2: mockEC2{dio: dio}}},
+			interceptors: []Interceptor{record("a"), record("b")},
+		}
+
+		opts, err := c.GetInstances(ctx, input)
+		if err != nil {
+			t.Fatalf("order - unexpected error: %s", err)
+		}
+		expected := []string{
+			"a GetInstances DescribeInstances ec2 eu-west-1",
+			"b GetInstances DescribeInstances ec2 eu-west-1",
+			"b done",
+			"a done",
+		}
+		if !reflect.DeepEqual(log, expected) {
+			t.Errorf("order - log: received=%+v | expected=%+v", log, expected)
+		}
+		if !reflect.DeepEqual(opts["eu-west-1"], *dio) {
+			t.Errorf("order - output: received=%+v | expected=%+v", opts["eu-west-1"], *dio)
+		}
+	})
+
+	t.Run("call", func(t *testing.T) {
+		var seen Call
+		c := &connector{
+			svcs: []*serviceConnector{{region: "eu-west-1", ec2: mockEC2{dierr: errors.New("fail")}}},
+			interceptors: []Interceptor{
+				func(ctx context.Context, call *Call, next Invoker) error {
+					call.Input = &ec2.DescribeInstancesInput{InstanceIds: []*string{aws.String("i-2")}}
+					err := next(ctx, call)
+					seen = *call
+					return err
+				},
+			},
+		}
+
+		_, err := c.GetInstances(ctx, input)
+		checkErrors(t, "call", -1, err, Errors{NewError("eu-west-1", ec2.ServiceName, errors.New("fail"))})
+		if id := aws.StringValue(seen.Input.(*ec2.DescribeInstancesInput).InstanceIds[0]); id != "i-2" {
+			t.Errorf("call - input: received=%+v | expected=%+v", id, "i-2")
+		}
+		if seen.Err == nil || seen.Err.Error() != "fail" {
+			t.Errorf("call - err: received=%+v | expected=%+v", seen.Err, "fail")
+		}
+		if seen.Duration <= 0 {
+			t.Errorf("call - duration: received=%+v | expected a positive duration", seen.Duration)
+		}
+	})
+
+	t.Run("without next", func(t *testing.T) {
+		c := &connector{
+			svcs: []*serviceConnector{{region: "eu-west-1", ec2: mockEC2{dio: dio}}},
+			interceptors: []Interceptor{
+				func(ctx context.Context, call *Call, next Invoker) error {
+					call.Output = cached
+					return nil
+				},
+			},
+		}
+
+		opts, err := c.GetInstances(ctx, input)
+		if err != nil {
+			t.Fatalf("without next - unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(opts["eu-west-1"], *cached) {
+			t.Errorf("without next - output: received=%+v | expected=%+v", opts["eu-west-1"], *cached)
+		}
+	})
+	t.Run("method", func(t *testing.T) {
+		type key struct{}
+		var (
+			seen    MethodCall
+			regions []string
+		)
+		c := &connector{
+			regions:   []string{"eu-west-1", "eu-west-3"},
+			accountID: aws.String("123456789012"),
+			svcs: []*serviceConnector{
+				{region: "eu-west-1", ec2: mockEC2{dio: dio}},
+				{region: "eu-west-3", ec2: mockEC2{dierr: errors.New("fail")}},
+			},
+			methodInterceptors: []MethodInterceptor{
+				func(ctx context.Context, call *MethodCall, next MethodInvoker) error {
+					err := next(context.WithValue(ctx, key{}, call.Method), call)
+					seen = *call
+					return err
+				},
+			},
+			interceptors: []Interceptor{
+				func(ctx context.Context, call *Call, next Invoker) error {
+					regions = append(regions, ctx.Value(key{}).(string)+" "+call.Region+" "+call.AccountID)
+					return next(ctx, call)
+				},
+			},
+		}
+
+		opts, err := c.GetInstances(ctx, input)
+		checkErrors(t, "method", -1, err, Errors{NewError("eu-west-3", ec2.ServiceName, errors.New("fail"))})
+		if seen.Method != "GetInstances" || seen.AccountID != "123456789012" || !reflect.DeepEqual(seen.Regions, c.regions) {
+			t.Errorf("method - call: received=%+v | expected=%+v", seen, "GetInstances on eu-west-1 and eu-west-3")
+		}
+		if !reflect.DeepEqual(seen.Output, opts) || !reflect.DeepEqual(seen.Err, err) {
+			t.Errorf("method - output: received=%+v, %+v | expected=%+v, %+v", seen.Output, seen.Err, opts, err)
+		}
+		expected := []string{"GetInstances eu-west-1 123456789012", "GetInstances eu-west-3 123456789012"}
+		if !reflect.DeepEqual(regions, expected) {
+			t.Errorf("method - calls: received=%+v | expected=%+v", regions, expected)
+		}
+	})
+	t.Run("method without next", func(t *testing.T) {
+		var dti []*elb.DescribeTagsInput
+		c := &connector{
+			regions: []string{"eu-west-1"},
+			svcs: []*serviceConnector{
+				{region: "eu-west-1", elb: mockELB{dlbo: &elb.DescribeLoadBalancersOutput{}, dti: &dti}},
+			},
+			methodInterceptors: []MethodInterceptor{
+				func(ctx context.Context, call *MethodCall, next MethodInvoker) error {
+					if call.Method == "GetLoadBalancers" {
+						return errors.New("denied")
+					}
+					return next(ctx, call)
+				},
+			},
+		}
+
+		// The methods return no output, and the enrich ones the
+		// error of the method they call, as an Error of its service
+		opts, err := c.GetLoadBalancers(ctx, nil)
+		checkErrors(t, "without next", -1, err, errors.New("denied"))
+		if opts != nil {
+			t.Errorf("without next - output: received=%+v | expected=nil", opts)
+		}
+
+		_, err = c.GetLoadBalancersWithTags(ctx, nil)
+		checkErrors(t, "without next enrich", -1, err, Errors{NewError("", elb.ServiceName, errors.New("denied"))})
+	})
+}
@@ -0,0 +1,151 @@
+// Package awstest is a fake AWS for the tests of the packages of raws, which
+// answers the requests of the query protocol, like the ones of EC2, IAM or
+// STS, with the responses declared by each test
+package awstest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// TransportFunc is an http.RoundTripper calling itself
+type TransportFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f with the req
+func (f TransportFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Fake is an http.RoundTripper returning the Responses of the actions of
+// the requests. The body of the response of the action with the Marker of
+// the request, like "GetAccountAuthorizationDetails&Marker=m1", is used
+// before the one of the action, and REGION on the bodies is replaced by the
+// region of the host.
+// It can be used by concurrent requests.
+type Fake struct {
+	// Responses are the bodies of the responses by action
+	Responses map[string]string
+
+	// Header is added to the header of the Responses
+	Header http.Header
+
+	// Failures are the requests answered with an error instead
+	// of their Responses, the first one matching is used
+	Failures []Failure
+
+	mu    sync.Mutex
+	reqs  []string
+	fails map[int]int
+}
+
+// Failure makes the requests of the Action on the Host, like
+// "ec2.eu-west-1.amazonaws.com", fail with the error of AWS with
+// the Status and the Code, only the first Times if it's not 0
+type Failure struct {
+	Action string
+	Host   string
+	Status int
+	Code   string
+	Times  int
+}
+
+// RoundTrip returns the response of the action of the req
+func (f *Fake) RoundTrip(req *http.Request) (*http.Response, error) {
+	b, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := url.ParseQuery(string(b))
+	if err != nil {
+		return nil, err
+	}
+
+	action := params.Get("Action")
+	if fl, ok := f.record(string(b), action, req.URL.Host); ok {
+		res := Error(fl.Status, fl.Code, fl.Code+" on "+req.URL.Host)
+		res.Header.Set("Retry-After", "0")
+		return res, nil
+	}
+
+	body, ok := f.Responses[action+"&Marker="+params.Get("Marker")]
+	if !ok {
+		body = f.Responses[action]
+	}
+	body = strings.Replace(body, "REGION", strings.Split(req.URL.Host, ".")[1], -1)
+
+	res := Response(http.StatusOK, body)
+	for k, v := range f.Header {
+		res.Header[k] = v
+	}
+
+	return res, nil
+}
+
+// record keeps the body of the request of the action on the host, and
+// returns the Failure to answer it with, if it has to fail
+func (f *Fake) record(body, action, host string) (Failure, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.reqs = append(f.reqs, body)
+	for i, fl := range f.Failures {
+		if fl.Action != action || fl.Host != host {
+			continue
+		}
+		if fl.Times != 0 && f.fails[i] >= fl.Times {
+			continue
+		}
+		if f.fails == nil {
+			f.fails = make(map[int]int)
+		}
+		f.fails[i]++
+
+		return fl, true
+	}
+
+	return Failure{}, false
+}
+
+// Requests returns the bodies of the requests received so far
+func (f *Fake) Requests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string(nil), f.reqs...)
+}
+
+// Config returns an aws.Config sending the requests to f
+func (f *Fake) Config() *aws.Config {
+	return &aws.Config{HTTPClient: &http.Client{Transport: f}}
+}
+
+// Response returns an XML response with the status and the body
+func Response(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"text/xml"}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// Error returns the XML response of an error of AWS with the status and the code
+func Error(status int, code, message string) *http.Response {
+	return Response(status, `<Response><Errors><Error><Code>`+code+`</Code><Message>`+message+`</Message></Error></Errors><RequestID>1</RequestID></Response>`)
+}
+
+// UnsetCABundle unsets the AWS_CA_BUNDLE for the duration of the t, as
+// the SDK can't load a CA bundle on a transport which is not an *http.Transport
+func UnsetCABundle(t testing.TB) {
+	if ca, ok := os.LookupEnv("AWS_CA_BUNDLE"); ok {
+		os.Unsetenv("AWS_CA_BUNDLE")
+		t.Cleanup(func() { os.Setenv("AWS_CA_BUNDLE", ca) })
+	}
+}
@@ -0,0 +1,102 @@
+// Package value handles the Raw of the raws.Resource as a generic JSON
+// value, so the SDK items and the resources read from a snapshot are
+// handled in the same way.
+package value
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Value is a generic JSON value
+type Value struct {
+	V interface{}
+}
+
+// Of returns raw, which is marshaled to JSON, as a Value
+func Of(raw interface{}) (Value, error) {
+	if raw == nil {
+		return Value{}, nil
+	}
+
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return Value{}, err
+	}
+
+	var v interface{}
+	err = json.Unmarshal(b, &v)
+
+	return Value{V: v}, err
+}
+
+// Item returns the "Item" of v if it has it, as the types with
+// follow-up calls have the SDK item on it, or v
+func (v Value) Item() Value {
+	if i := v.Get("Item"); i.V != nil {
+		return i
+	}
+
+	return v
+}
+
+// Get returns the value on the path, separated by dots
+func (v Value) Get(path string) Value {
+	cur := v.V
+	for _, p := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return Value{}
+		}
+		cur = m[p]
+	}
+
+	return Value{V: cur}
+}
+
+// Str returns the string on the path or ""
+func (v Value) Str(path string) string {
+	s, _ := v.Get(path).V.(string)
+	return s
+}
+
+// List returns the values of the list on the path
+func (v Value) List(path string) []Value {
+	l, _ := v.Get(path).V.([]interface{})
+
+	values := make([]Value, 0, len(l))
+	for _, e := range l {
+		values = append(values, Value{V: e})
+	}
+
+	return values
+}
+
+// Strs returns the strings on the field of each one of
+// the elements of the list on the path, or the strings
+// of the list if field is empty
+func (v Value) Strs(path, field string) []string {
+	var s []string
+	for _, e := range v.List(path) {
+		if field != "" {
+			e = e.Get(field)
+		}
+		if es, ok := e.V.(string); ok && es != "" {
+			s = append(s, es)
+		}
+	}
+
+	return s
+}
+
+// Bool returns the bool on the path, ok is false if there is none
+func (v Value) Bool(path string) (b bool, ok bool) {
+	b, ok = v.Get(path).V.(bool)
+	return b, ok
+}
+
+// Num returns the number on the path, ok is false if there is none
+func (v Value) Num(path string) (n float64, ok bool) {
+	n, ok = v.Get(path).V.(float64)
+	return n, ok
+}
@@ -0,0 +1,259 @@
+package raws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// SnapshotVersion is the version of the format of the Snapshot, it's
+// increased when the format changes in a way that the snapshots written
+// before can't be read anymore
+const SnapshotVersion = 1
+
+// Snapshot is the result of an Inventory, it has all the resources
+// found and the information of the calls made to find them
+type Snapshot struct {
+	Version    int            `json:"version"`
+	AccountID  string         `json:"account_id"`
+	Regions    []string       `json:"regions"`
+	StartedAt  time.Time      `json:"started_at"`
+	FinishedAt time.Time      `json:"finished_at"`
+	Calls      []SnapshotCall `json:"calls"`
+	Resources  []Resource     `json:"resources"`
+}
+
+// SnapshotCall is the information of one of the
+// AWSReader methods called to build a Snapshot
+type SnapshotCall struct {
+	Method    string          `json:"method"`
+	Service   string          `json:"service"`
+	Duration  time.Duration   `json:"duration"`
+	Resources int             `json:"resources"`
+	Errors    []SnapshotError `json:"errors,omitempty"`
+}
+
+// SnapshotError is an error returned by
+// one of the calls made to build a Snapshot
+type SnapshotError struct {
+	Region  string `json:"region,omitempty"`
+	Service string `json:"service,omitempty"`
+	Message string `json:"message"`
+}
+
+// InventoryOptions are the options of Inventory
+type InventoryOptions struct {
+	// Services are the services, like "ec2" or "iam", of the methods
+	// to call, if it's empty the methods of all the services are called
+	Services []string
+
+	// Concurrency is the number of methods called at the same time,
+	// if it's lower than 1 they are called one after the other
+	Concurrency int
+
+	// Progress receives the progress of the Inventory, as each one
+	// of its methods starts and finishes, with the number of methods
+	// called and of resources found so far, while the progress of
+	// the calls made by the methods is the one of the AWSReader
+	Progress ProgressFunc
+}
+
+// inventoryCall is one of the calls made by Inventory, which returns
+// items that can be converted to Resources by NewResources
+type inventoryCall struct {
+	method  string
+	service string
+	call    func(ctx context.Context, r AWSReader) (interface{}, error)
+}
+
+// Inventory calls all the methods of r which list resources without any
+// input, on all the regions of r, except the ones of the global services,
+// like IAM, which are only called on the first one, and returns all the
+// resources found in a Snapshot, with the duration and the errors of each
+// one of the calls.
+// The methods which have variants with follow-up calls, like
+// GetLoadBalancersWithTags, are called through them so the resources
+// have their tags, and the record sets are listed for each one of the
+// hosted zones, with ListAllHostedZoneRecordSets.
+// The Snapshot is always returned, with the resources of the calls without
+// errors, and the errors of all the calls are also returned as Errors.
+func Inventory(ctx context.Context, r AWSReader, opts InventoryOptions) (*Snapshot, error) {
+	var calls []inventoryCall
+	for _, c := range inventoryCalls {
+		if len(opts.Services) == 0 || containsString(opts.Services, c.service) {
+			calls = append(calls, c)
+		}
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	s := &Snapshot{
+		Version:   SnapshotVersion,
+		AccountID: r.GetAccountID(),
+		Regions:   append([]string(nil), r.GetRegions()...),
+		StartedAt: time.Now().UTC(),
+		Calls:     make([]SnapshotCall, len(calls)),
+	}
+
+	var (
+		resources = make([][]Resource, len(calls))
+		errs      = make([]Errors, len(calls))
+		sem       = make(chan struct{}, concurrency)
+		wg        sync.WaitGroup
+		progress  = inventoryProgress{fn: opts.Progress, total: len(calls)}
+	)
+
+	// The global services return the same on all the
+	// regions, so their calls are only made on the first one
+	gctx := ctx
+	if regions := ContextRegions(ctx, s.Regions); len(regions) > 0 {
+		gctx = WithRegions(ctx, regions[0])
+	}
+
+	for i, c := range calls {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, c inventoryCall) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			progress.report(Progress{Event: MethodStarted, Method: c.method, Service: c.service}, 0)
+			defer func() {
+				var err error
+				if errs[i] != nil {
+					err = errs[i]
+				}
+				progress.report(Progress{Event: MethodFinished, Method: c.method, Service: c.service, Err: err}, len(resources[i]))
+			}()
+
+			cctx := ctx
+			if isGlobal(c.service) {
+				cctx = gctx
+			}
+
+			start := time.Now()
+			items, err := c.call(cctx, r)
+			s.Calls[i] = SnapshotCall{
+				Method:   c.method,
+				Service:  c.service,
+				Duration: time.Since(start),
+			}
+
+			if err != nil {
+				if e, ok := err.(Errors); ok {
+					errs[i] = append(errs[i], e...)
+				} else {
+					errs[i] = append(errs[i], NewError("", c.service, err))
+				}
+			}
+
+			res, err := NewResources(s.AccountID, items)
+			if err != nil {
+				errs[i] = append(errs[i], NewError("", c.service, err))
+			}
+			resources[i] = res
+		}(i, c)
+	}
+	wg.Wait()
+
+	var allErrs Errors
+	for i := range calls {
+		s.Resources = append(s.Resources, resources[i]...)
+		s.Calls[i].Resources = len(resources[i])
+		for _, e := range errs[i] {
+			s.Calls[i].Errors = append(s.Calls[i].Errors, SnapshotError{
+				Region:  e.region,
+				Service: e.service,
+				Message: e.err.Error(),
+			})
+		}
+		allErrs = append(allErrs, errs[i]...)
+	}
+
+	s.FinishedAt = time.Now().UTC()
+
+	if allErrs != nil {
+		return s, allErrs
+	}
+
+	return s, nil
+}
+
+// Write writes s on w as JSON
+func (s *Snapshot) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(s)
+}
+
+// ReadSnapshot reads from r a Snapshot written by Snapshot.Write.
+// The Raw of the resources are read as generic JSON values, like
+// map[string]interface{}, as their types aren't kept on the JSON.
+// An error is returned if the Snapshot has a different version
+// than SnapshotVersion
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	var s Snapshot
+
+	err := json.NewDecoder(r).Decode(&s)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d, the supported one is %d", s.Version, SnapshotVersion)
+	}
+
+	return &s, nil
+}
+
+// inventoryProgress is the progress of an Inventory
+type inventoryProgress struct {
+	mu    sync.Mutex
+	fn    ProgressFunc
+	items int
+	done  int
+	total int
+}
+
+// report reports p, of one of the methods of the Inventory, with
+// the progress of the Inventory, where the MethodFinished events
+// add the resources found by their method
+func (ip *inventoryProgress) report(p Progress, resources int) {
+	if ip.fn == nil {
+		return
+	}
+
+	// The reports are serialized so they
+	// are received in the order of their counts
+	ip.mu.Lock()
+	defer ip.mu.Unlock()
+
+	if p.Event == MethodFinished {
+		ip.items += resources
+		ip.done++
+	}
+	p.Items = ip.items
+	p.Done = ip.done
+	p.Total = ip.total
+
+	ip.fn(p)
+}
+
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+
+	return false
+}
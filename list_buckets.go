@@ -4,45 +4,60 @@ import (
 	"context"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/s3"
 )
 
 func (c *connector) ListBuckets(ctx context.Context, input *s3.ListBucketsInput) (map[string]s3.ListBucketsOutput, error) {
-	var errs Errors
-	var regionsOpts = map[string]s3.ListBucketsOutput{}
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "ListBuckets", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]s3.ListBucketsOutput{}
 
-	for _, svc := range c.svcs {
-		if svc.s3 == nil {
-			svc.s3 = s3.New(svc.session)
-		}
-
-		opt, err := svc.s3.ListBucketsWithContext(ctx, input)
-		if err != nil {
-			errs = append(errs, NewError(svc.region, s3.ServiceName, err))
-		} else {
-			newOpt := s3.ListBucketsOutput{
-				Owner:   opt.Owner,
-				Buckets: make([]*s3.Bucket, 0),
-			}
-			for _, bucket := range opt.Buckets {
-				inputLocation := &s3.GetBucketLocationInput{
-					Bucket: bucket.Name,
+		for _, svc := range c.services(ctx) {
+			call := &Call{Method: "ListBuckets", Operation: "ListBuckets", Service: s3.ServiceName, Region: svc.region, Input: input}
+			out, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				return svc.s3.ListBucketsWithContext(ctx, input.(*s3.ListBucketsInput), opts...)
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "ListBuckets", NewError(svc.region, s3.ServiceName, err)))
+			} else {
+				opt := out.(*s3.ListBucketsOutput)
+				newOpt := s3.ListBucketsOutput{
+					Owner:   opt.Owner,
+					Buckets: make([]*s3.Bucket, 0),
 				}
-				result, err := svc.s3.GetBucketLocation(inputLocation)
-				if err != nil {
-					errs = append(errs, NewError(svc.region, s3.ServiceName, err))
+				for _, bucket := range opt.Buckets {
+					inputLocation := &s3.GetBucketLocationInput{
+						Bucket: bucket.Name,
+					}
+					call := &Call{Method: "ListBuckets", Operation: "GetBucketLocation", Service: s3.ServiceName, Region: svc.region, Input: inputLocation}
+					out, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+						return svc.s3.GetBucketLocationWithContext(ctx, input.(*s3.GetBucketLocationInput), opts...)
+					})
+					if err != nil {
+						errs = append(errs, sendError(ctx, "ListBuckets", NewError(svc.region, s3.ServiceName, err)))
+						continue
+					}
+					result := out.(*s3.GetBucketLocationOutput)
+					if s3.NormalizeBucketLocation(aws.StringValue(result.LocationConstraint)) == svc.region {
+						newOpt.Buckets = append(newOpt.Buckets, bucket)
+					}
 				}
-				if s3.NormalizeBucketLocation(aws.StringValue(result.LocationConstraint)) == svc.region {
-					newOpt.Buckets = append(newOpt.Buckets, bucket)
+				if !sendOutput(ctx, "ListBuckets", svc.region, 1, newOpt) {
+					regionsOpts[svc.region] = newOpt
 				}
 			}
-			regionsOpts[svc.region] = newOpt
 		}
-	}
 
-	if errs != nil {
-		return regionsOpts, errs
-	}
+		if errs != nil {
+			return regionsOpts, errs
+		}
+
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]s3.ListBucketsOutput)
 
-	return regionsOpts, nil
+	return opts, err
 }
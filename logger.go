@@ -0,0 +1,65 @@
+package raws
+
+import (
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// Logger is a structured logger, which logs the msg with the args,
+// a list of alternated keys and values. A *slog.Logger is a Logger.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// nopLogger is the Logger of the
+// connectors without any, logging nothing
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+// log returns the Logger of the connector
+func (c *connector) log() Logger {
+	if c.logger == nil {
+		return nopLogger{}
+	}
+
+	return c.logger
+}
+
+// callArgs returns the args of the logs of the call
+func callArgs(call *Call, args ...interface{}) []interface{} {
+	return append([]interface{}{"method", call.Method, "service", call.Service, "operation", call.Operation, "region", call.Region}, args...)
+}
+
+// logCall logs the call once it's made, at Debug
+// level, or at Warn level if it failed
+func (c *connector) logCall(call *Call) {
+	if call.Err != nil {
+		c.log().Warn("AWS call failed", callArgs(call, "duration", call.Duration, "code", ErrorCode(call.Err), "error", call.Err)...)
+		return
+	}
+
+	c.log().Debug("AWS call made", callArgs(call, "duration", call.Duration)...)
+}
+
+// logRetries returns the request.Option logging
+// the retries of the requests at Warn level
+func (c *connector) logRetries(call *Call) request.Option {
+	return func(r *request.Request) {
+		var err error
+		r.Handlers.CompleteAttempt.PushBack(func(r *request.Request) {
+			err = r.Error
+		})
+		// The error of the attempt is cleared once the retry is decided
+		r.Handlers.AfterRetry.PushBack(func(r *request.Request) {
+			if err != nil && r.Error == nil {
+				c.log().Warn("retrying AWS request", callArgs(call, "retry", r.RetryCount, "delay", r.RetryDelay, "code", ErrorCode(err), "error", err)...)
+			}
+		})
+	}
+}
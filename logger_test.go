@@ -0,0 +1,132 @@
+package raws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// recordLogger is a Logger keeping the logs, as
+// "level msg key=value key=value...", without the
+// durations and the delays
+type recordLogger struct {
+	logs []string
+}
+
+func (l *recordLogger) record(level, msg string, args []interface{}) {
+	log := level + " " + msg
+	for i := 0; i+1 < len(args); i += 2 {
+		if args[i] == "duration" || args[i] == "delay" {
+			continue
+		}
+		log += fmt.Sprintf(" %s=%v", args[i], args[i+1])
+	}
+	l.logs = append(l.logs, log)
+}
+
+func (l *recordLogger) Debug(msg string, args ...interface{}) { l.record("DEBUG", msg, args) }
+func (l *recordLogger) Info(msg string, args ...interface{})  { l.record("INFO", msg, args) }
+func (l *recordLogger) Warn(msg string, args ...interface{})  { l.record("WARN", msg, args) }
+func (l *recordLogger) Error(msg string, args ...interface{}) { l.record("ERROR", msg, args) }
+
+func TestConnector_logger(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		deny = awserr.New("UnauthorizedOperation", "denied", nil)
+	)
+
+	tests := []struct {
+		name     string
+		fn       func(c *connector)
+		regions  []string
+		svcs     []*serviceConnector
+		expected []string
+	}{
+		{
+			name: "regions",
+			fn: func(c *connector) {
+				c.setRegions(ctx, mockEC2{dro: &ec2.DescribeRegionsOutput{Regions: []*ec2.Region{{RegionName: aws.String("eu-west-1")}, {RegionName: aws.String("us-east-1")}}}}, []string{"eu-*"})
+			},
+			expected: []string{
+				"DEBUG AWS call made method=NewAWSReader service=ec2 operation=DescribeRegions region=eu-west-1",
+				"INFO regions enabled regions=[eu-west-1] enabled_regions=[eu-*]",
+			},
+		},
+		{
+			name: "no regions",
+			fn: func(c *connector) {
+				c.setRegions(ctx, mockEC2{dro: &ec2.DescribeRegionsOutput{}}, []string{"eu-*"})
+			},
+			expected: []string{
+				"DEBUG AWS call made method=NewAWSReader service=ec2 operation=DescribeRegions region=eu-west-1",
+				"ERROR no regions matched enabled_regions=[eu-*] available_regions=0",
+			},
+		},
+		{
+			name: "calls",
+			fn: func(c *connector) {
+				c.GetInstances(ctx, nil)
+			},
+			regions: []string{"eu-west-1", "eu-west-3"},
+			svcs: []*serviceConnector{
+				{region: "eu-west-1", ec2: mockEC2{dio: &ec2.DescribeInstancesOutput{}}},
+				{region: "eu-west-3", ec2: mockEC2{dierr: deny}},
+			},
+			expected: []string{
+				"DEBUG AWS call made method=GetInstances service=ec2 operation=DescribeInstances region=eu-west-1",
+				"WARN AWS call failed method=GetInstances service=ec2 operation=DescribeInstances region=eu-west-3 code=UnauthorizedOperation error=UnauthorizedOperation: denied",
+			},
+		},
+		{
+			name: "download fallback",
+			fn: func(c *connector) {
+				c.DownloadObject(ctx, nil, &s3.GetObjectInput{Bucket: aws.String("bucket"), Key: aws.String("key")})
+			},
+			regions: []string{"eu-west-1", "eu-west-3"},
+			svcs: []*serviceConnector{
+				{region: "eu-west-1", s3downloader: mockS3Downloader{doerr: errors.New("not found")}},
+				{region: "eu-west-3", s3downloader: mockS3Downloader{dob: 42}},
+			},
+			expected: []string{
+				"WARN AWS call failed method=DownloadObject service=s3 operation=GetObject region=eu-west-1 code= error=not found",
+				"INFO object not downloaded from the region bucket=bucket key=key region=eu-west-1 error=not found",
+				"DEBUG AWS call made method=DownloadObject service=s3 operation=GetObject region=eu-west-3",
+			},
+		},
+		{
+			name: "retries",
+			fn: func(c *connector) {
+				r := request.New(aws.Config{}, metadata.ClientInfo{}, request.Handlers{}, client.DefaultRetryer{NumMaxRetries: 1}, &request.Operation{}, nil, nil)
+				r.ApplyOptions(c.logRetries(&Call{Method: "GetInstances", Service: "ec2", Operation: "DescribeInstances", Region: "eu-west-1"}))
+
+				r.Error = awserr.New("RequestLimitExceeded", "slow down", nil)
+				r.Handlers.CompleteAttempt.Run(r)
+				r.RetryCount, r.Error = 1, nil
+				r.Handlers.AfterRetry.Run(r)
+			},
+			expected: []string{
+				"WARN retrying AWS request method=GetInstances service=ec2 operation=DescribeInstances region=eu-west-1 retry=1 code=RequestLimitExceeded error=RequestLimitExceeded: slow down",
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		l := &recordLogger{}
+		c := &connector{regions: tt.regions, svcs: tt.svcs, logger: l}
+		tt.fn(c)
+
+		if !reflect.DeepEqual(l.logs, tt.expected) {
+			t.Errorf("%s [%d] - logs: received=%+v | expected=%+v", tt.name, i, l.logs, tt.expected)
+		}
+	}
+}
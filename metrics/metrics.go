@@ -0,0 +1,150 @@
+// Package metrics has a Prometheus collector with the metrics of the
+// calls made by an AWSReader, which are measured by its Interceptor:
+//
+//	m := metrics.New(metrics.Options{})
+//	prometheus.MustRegister(m)
+//	r, err := raws.NewAWSReaderWithOptions(ctx, "key", "secret", []string{"eu-*"}, nil, false, raws.ReaderOptions{
+//		Interceptors: []raws.Interceptor{m.Interceptor()},
+//	})
+//
+// All the metrics have the method, service and region labels, and the
+// errors also the code of the AWS error, or "Unknown" if it isn't one.
+package metrics
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/cycloidio/raws"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// unknownCode is the code of the errors which are not AWS errors
+const unknownCode = "Unknown"
+
+var labels = []string{"method", "service", "region"}
+
+// Options are the options of a Collector
+type Options struct {
+	// Namespace is the namespace of the metrics, "raws" if empty
+	Namespace string
+
+	// ConstLabels are added to all the metrics
+	ConstLabels prometheus.Labels
+
+	// Buckets are the buckets, in seconds, of the latency
+	// histograms, prometheus.DefBuckets if empty
+	Buckets []float64
+}
+
+// Collector is a prometheus.Collector with the metrics of the calls
+// made by the AWSReaders which use its Interceptor
+type Collector struct {
+	calls     *prometheus.CounterVec
+	errors    *prometheus.CounterVec
+	throttles *prometheus.CounterVec
+	retries   *prometheus.CounterVec
+	pages     *prometheus.CounterVec
+	latency   *prometheus.HistogramVec
+}
+
+// New returns a Collector with the opts, which has
+// to be registered to expose its metrics
+func New(opts Options) *Collector {
+	if opts.Namespace == "" {
+		opts.Namespace = "raws"
+	}
+	if len(opts.Buckets) == 0 {
+		opts.Buckets = prometheus.DefBuckets
+	}
+
+	counter := func(name, help string, labels ...string) *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        name,
+			Help:        help,
+			ConstLabels: opts.ConstLabels,
+		}, labels)
+	}
+
+	return &Collector{
+		calls:     counter("calls_total", "Number of calls made to AWS by the AWSReader methods.", labels...),
+		errors:    counter("errors_total", "Number of calls which returned an error, by its code.", append(labels, "code")...),
+		throttles: counter("throttles_total", "Number of AWS requests throttled.", labels...),
+		retries:   counter("retries_total", "Number of AWS requests retried.", labels...),
+		pages:     counter("pages_total", "Number of AWS requests, and so of pages, which succeeded.", labels...),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   opts.Namespace,
+			Name:        "call_duration_seconds",
+			Help:        "Duration of the calls, with all their pages and retries.",
+			ConstLabels: opts.ConstLabels,
+			Buckets:     opts.Buckets,
+		}, labels),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, col := range c.collectors() {
+		col.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, col := range c.collectors() {
+		col.Collect(ch)
+	}
+}
+
+// Interceptor returns the raws.Interceptor measuring the calls,
+// which has to be the innermost one to measure only the calls
+// which are really made to AWS
+func (c *Collector) Interceptor() raws.Interceptor {
+	return func(ctx context.Context, call *raws.Call, next raws.Invoker) error {
+		lvs := []string{call.Method, call.Service, call.Region}
+		call.RequestOptions = append(call.RequestOptions, c.requestOption(lvs))
+
+		err := next(ctx, call)
+
+		c.calls.WithLabelValues(lvs...).Inc()
+		c.latency.WithLabelValues(lvs...).Observe(call.Duration.Seconds())
+		if err != nil {
+			c.errors.WithLabelValues(append(lvs, code(err))...).Inc()
+		}
+
+		return err
+	}
+}
+
+// requestOption returns the request.Option counting the
+// throttles, retries and pages of each one of the requests
+func (c *Collector) requestOption(lvs []string) request.Option {
+	return func(r *request.Request) {
+		r.Handlers.CompleteAttempt.PushBack(func(r *request.Request) {
+			if r.Error != nil && request.IsErrorThrottle(r.Error) {
+				c.throttles.WithLabelValues(lvs...).Inc()
+			}
+		})
+		r.Handlers.Complete.PushBack(func(r *request.Request) {
+			c.retries.WithLabelValues(lvs...).Add(float64(r.RetryCount))
+			if r.Error == nil {
+				c.pages.WithLabelValues(lvs...).Inc()
+			}
+		})
+	}
+}
+
+func (c *Collector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{c.calls, c.errors, c.throttles, c.retries, c.pages, c.latency}
+}
+
+// code returns the code of the AWS error err,
+// or "Unknown" if it isn't an AWS error
+func code(err error) string {
+	if c := raws.ErrorCode(err); c != "" {
+		return c
+	}
+
+	return unknownCode
+}
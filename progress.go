@@ -0,0 +1,208 @@
+package raws
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+// ProgressEvent is what a Progress reports
+type ProgressEvent int
+
+const (
+	// MethodStarted is reported when a method is called
+	MethodStarted ProgressEvent = iota
+
+	// CallStarted is reported before each call made to AWS
+	CallStarted
+
+	// CallFinished is reported after each call made to AWS
+	CallFinished
+
+	// ResultFetched is reported for each result of a method, which
+	// is the output of a region, or a page of it, or its error
+	ResultFetched
+
+	// MethodFinished is reported when a method returns
+	MethodFinished
+)
+
+// String returns the name of the event
+func (e ProgressEvent) String() string {
+	switch e {
+	case MethodStarted:
+		return "MethodStarted"
+	case CallStarted:
+		return "CallStarted"
+	case CallFinished:
+		return "CallFinished"
+	case ResultFetched:
+		return "ResultFetched"
+	case MethodFinished:
+		return "MethodFinished"
+	}
+
+	return "Unknown"
+}
+
+// Progress is the progress of a method of an AWSReader, or of an Inventory
+type Progress struct {
+	// Event is what is reported
+	Event ProgressEvent
+
+	// Method is the name of the method, like "GetInstances"
+	Method string
+
+	// Service and Operation are the ones of the call, for the
+	// CallStarted and CallFinished events, and Service the one
+	// of the method for the events of an Inventory
+	Service   string
+	Operation string
+
+	// Region is the region of the call or of the result
+	Region string
+
+	// Items is the number of items fetched so far by the method, like
+	// the instances of GetInstances, or the number of resources found
+	// so far by the Inventory
+	Items int
+
+	// Done is the number of regions from which the method has fetched
+	// results, or the number of methods called by the Inventory, out
+	// of the Total, so the remaining work is Total - Done
+	Done  int
+	Total int
+
+	// Err is the error of the call, the region or the method
+	Err error
+}
+
+// ProgressFunc receives the progress of the methods of
+// an AWSReader, or of an Inventory, as it happens
+type ProgressFunc func(p Progress)
+
+// progressKey is the key of the methodProgress of the contexts
+type progressKey struct{}
+
+// methodProgress is the progress of a method, which
+// is on the context given to the calls of the method
+type methodProgress struct {
+	mu      sync.Mutex
+	fn      ProgressFunc
+	method  string
+	items   int
+	regions map[string]bool
+	total   int
+
+	// finished is set once the method returns, so
+	// the regions without results are done too
+	finished bool
+}
+
+// report reports the event p of the method, with its progress
+func (mp *methodProgress) report(p Progress) {
+	mp.mu.Lock()
+	p.Method = mp.method
+	p.Items = mp.items
+	p.Done = len(mp.regions)
+	p.Total = mp.total
+	if mp.finished {
+		p.Done = mp.total
+	}
+	mp.mu.Unlock()
+
+	mp.fn(p)
+}
+
+// fetched reports the result of the method fetched on the
+// region, which is the out with items or the err
+func (mp *methodProgress) fetched(region string, items int, err error) {
+	mp.mu.Lock()
+	mp.items += items
+	mp.regions[region] = true
+	mp.mu.Unlock()
+
+	mp.report(Progress{Event: ResultFetched, Region: region, Err: err})
+}
+
+// progressMethod reports the progress of the call of the method
+// to the ProgressFunc of the connector, if any, while fn runs,
+// which is called with the ctx having the progress of the method
+func (c *connector) progressMethod(ctx context.Context, call *MethodCall, fn func(ctx context.Context) error) error {
+	if c.progress == nil {
+		return fn(ctx)
+	}
+
+	mp := &methodProgress{
+		fn:      c.progress,
+		method:  call.Method,
+		regions: make(map[string]bool),
+		total:   len(call.Regions),
+	}
+
+	mp.report(Progress{Event: MethodStarted})
+	err := fn(context.WithValue(ctx, progressKey{}, mp))
+
+	mp.mu.Lock()
+	mp.finished = true
+	mp.mu.Unlock()
+	mp.report(Progress{Event: MethodFinished, Err: err})
+
+	return err
+}
+
+// progressCall reports the progress of the call to the
+// ProgressFunc of the connector, if any, while fn runs
+func (c *connector) progressCall(ctx context.Context, call *Call, fn func() error) error {
+	if c.progress == nil {
+		return fn()
+	}
+
+	// The calls made out of the methods, by NewAWSReader,
+	// are reported without the progress of any method
+	report := func(p Progress) {
+		p.Method = call.Method
+		c.progress(p)
+	}
+	if mp, ok := ctx.Value(progressKey{}).(*methodProgress); ok {
+		report = mp.report
+	}
+
+	report(Progress{Event: CallStarted, Service: call.Service, Operation: call.Operation, Region: call.Region})
+	err := fn()
+	report(Progress{Event: CallFinished, Service: call.Service, Operation: call.Operation, Region: call.Region, Err: err})
+
+	return err
+}
+
+// progressResult reports the result of the method on the
+// region, which is the out or the err, to the progress of
+// the method of ctx, if any
+func progressResult(ctx context.Context, method, region string, out interface{}, err error) {
+	mp, ok := ctx.Value(progressKey{}).(*methodProgress)
+	if !ok {
+		return
+	}
+
+	mp.fetched(region, countItems(method, out), err)
+}
+
+// countItems returns the number of items of the out of the method,
+// which is the length of the out when it's a list, like the ones
+// of the methods with follow-up calls
+func countItems(method string, out interface{}) int {
+	if out == nil {
+		return 0
+	}
+
+	if v := reflect.ValueOf(out); v.Kind() == reflect.Slice {
+		return v.Len()
+	}
+
+	path, ok := itemsPaths[method]
+	if !ok {
+		return 0
+	}
+
+	return len(flatten(out, path))
+}
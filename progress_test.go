@@ -0,0 +1,138 @@
+package raws
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// progressDesc describes p as "Event Method Service.Operation
+// Region items=Items done=Done/Total err", without the parts
+// which are empty
+func progressDesc(p Progress) string {
+	desc := p.Event.String() + " " + p.Method
+	if p.Operation != "" {
+		desc += " " + p.Service + "." + p.Operation
+	}
+	if p.Region != "" {
+		desc += " " + p.Region
+	}
+	desc += fmt.Sprintf(" items=%d done=%d/%d", p.Items, p.Done, p.Total)
+	if p.Err != nil {
+		desc += " err"
+	}
+
+	return desc
+}
+
+func TestConnector_progress(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		deny = awserr.New("UnauthorizedOperation", "denied", nil)
+		dio  = &ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{
+			{Instances: []*ec2.Instance{{InstanceId: aws.String("i-1")}, {InstanceId: aws.String("i-2")}}},
+		}}
+	)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		svcs     []*serviceConnector
+		expected []string
+	}{
+		{
+			name: "regions",
+			ctx:  ctx,
+			svcs: []*serviceConnector{
+				{region: "eu-west-1", ec2: mockEC2{dio: dio}},
+				{region: "eu-west-3", ec2: mockEC2{dierr: deny}},
+			},
+			expected: []string{
+				"MethodStarted GetInstances items=0 done=0/2",
+				"CallStarted GetInstances ec2.DescribeInstances eu-west-1 items=0 done=0/2",
+				"ResultFetched GetInstances eu-west-1 items=2 done=1/2",
+				"CallFinished GetInstances ec2.DescribeInstances eu-west-1 items=2 done=1/2",
+				"CallStarted GetInstances ec2.DescribeInstances eu-west-3 items=2 done=1/2",
+				"CallFinished GetInstances ec2.DescribeInstances eu-west-3 items=2 done=1/2 err",
+				"ResultFetched GetInstances eu-west-3 items=2 done=2/2 err",
+				"MethodFinished GetInstances items=2 done=2/2 err",
+			},
+		},
+		{
+			name: "streamed",
+			ctx:  WithResultFunc(ctx, func(Result) {}),
+			svcs: []*serviceConnector{
+				{region: "eu-west-1", ec2: mockEC2{dio: dio}},
+			},
+			expected: []string{
+				"MethodStarted GetInstances items=0 done=0/1",
+				"CallStarted GetInstances ec2.DescribeInstances eu-west-1 items=0 done=0/1",
+				"ResultFetched GetInstances eu-west-1 items=2 done=1/1",
+				"CallFinished GetInstances ec2.DescribeInstances eu-west-1 items=2 done=1/1",
+				"MethodFinished GetInstances items=2 done=1/1",
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		var progress []string
+		c := &connector{svcs: tt.svcs, progress: func(p Progress) {
+			progress = append(progress, progressDesc(p))
+		}}
+		for _, svc := range tt.svcs {
+			c.regions = append(c.regions, svc.region)
+		}
+
+		c.GetInstances(tt.ctx, nil)
+
+		if !reflect.DeepEqual(progress, tt.expected) {
+			t.Errorf("%s [%d] - progress: received=%+v | expected=%+v", tt.name, i, progress, tt.expected)
+		}
+	}
+}
+
+func TestInventory_progress(t *testing.T) {
+	var (
+		i1 = &ec2.Instance{InstanceId: aws.String("i-1")}
+		r  = &connector{regions: []string{"eu-west-1"}, accountID: aws.String("123")}
+	)
+
+	calls := inventoryCalls
+	defer func() { inventoryCalls = calls }()
+	inventoryCalls = []inventoryCall{
+		{
+			method:  "GetInstances",
+			service: "ec2",
+			call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+				return []RegionInstance{{Region: "eu-west-1", Item: i1}}, nil
+			},
+		},
+		{
+			method:  "GetVpcs",
+			service: "ec2",
+			call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+				return nil, Errors{NewError("eu-west-1", "ec2", awserr.New("UnauthorizedOperation", "denied", nil))}
+			},
+		},
+	}
+
+	var progress []string
+	Inventory(context.Background(), r, InventoryOptions{Progress: func(p Progress) {
+		progress = append(progress, progressDesc(p))
+	}})
+
+	expected := []string{
+		"MethodStarted GetInstances items=0 done=0/2",
+		"MethodFinished GetInstances items=1 done=1/2",
+		"MethodStarted GetVpcs items=1 done=1/2",
+		"MethodFinished GetVpcs items=1 done=2/2 err",
+	}
+	if !reflect.DeepEqual(progress, expected) {
+		t.Errorf("progress: received=%+v | expected=%+v", progress, expected)
+	}
+}
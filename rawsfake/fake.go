@@ -0,0 +1,691 @@
+// Package rawsfake provides an in-memory implementation of the
+// raws.AWSReader, so the code using raws can be tested without
+// calling AWS nor writing a mock per test.
+//
+// The Reader is seeded with the items the methods return, from Go code
+// or from JSON fixtures, and handles the inputs like AWS does:
+//
+//	r := rawsfake.New("123456789012", "eu-west-1", "eu-west-3")
+//	r.Add("eu-west-1", &ec2.Instance{InstanceId: aws.String("i-1"), State: &ec2.InstanceState{Name: aws.String("running")}})
+//	r.SetError("GetVpcs", "eu-west-3", errors.New("UnauthorizedOperation"))
+//
+//	opts, err := r.GetInstances(ctx, &ec2.DescribeInstancesInput{
+//		Filters: []*ec2.Filter{{Name: aws.String("instance-state-name"), Values: []*string{aws.String("running")}}},
+//	})
+//
+// The items of each method are the ones of its type, like *ec2.Instance
+// for GetInstances, added to the region, or to any region for the global
+// services. They are filtered by:
+//
+//   - the Filters of the input, where the names are the ones of AWS, like
+//     "vpc-id", "instance-state-name" or "tag:Name", and the values can
+//     have the * and ? wildcards
+//   - the lists of IDs or names of the input, like InstanceIds, which
+//     are compared with the field of the items with the same name in
+//     singular, like InstanceId
+//   - the other strings of the input, like UserName, compared with the
+//     field of the items with the same name
+//   - the owners of the input, like Owners, compared with the OwnerId of
+//     the items, where "self" and an empty OwnerId are the account ID
+//
+// The fields of the input which the items don't have are ignored, so the
+// outputs depending on them have to be set with SetOutput.
+// The outputs are paginated with the MaxResults, MaxRecords, MaxItems or
+// PageSize of the input, and the token of the next page is set on the
+// NextToken, NextMarker or Marker of the output, except for the operations
+// paginated by AWS, whose outputs have all the items from the token of the
+// input on, as raws reads all their pages.
+package rawsfake
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/cycloidio/raws"
+)
+
+var _ raws.AWSReader = (*Reader)(nil)
+
+// Reader is a raws.AWSReader returning the
+// items, outputs and errors it is seeded with
+type Reader struct {
+	mu          sync.RWMutex
+	accountID   string
+	regions     []string
+	items       map[string][]interface{}
+	enrichments map[interface{}]interface{}
+	outputs     []output
+	errors      map[string]error
+	objects     map[string][]byte
+}
+
+// output is an output set for the
+// calls of a method with an input
+type output struct {
+	method string
+	region string
+
+	// any is true if the output is returned
+	// for all the inputs of the method
+	any   bool
+	input interface{}
+	data  json.RawMessage
+}
+
+// method is the definition of a method of the AWSReader
+type method struct {
+	name    string
+	service string
+
+	// flatten is the path to the list of items on the output
+	flatten string
+
+	// owner is the field of the input where the account ID
+	// is added, if the method returns only the own items
+	owner string
+
+	// global is true if the service is not regional
+	global bool
+
+	// paginated is true if the operation is paginated by
+	// AWS, so the method returns the items of all the pages
+	paginated bool
+}
+
+// New returns an empty Reader of the accountID with the regions
+func New(accountID string, regions ...string) *Reader {
+	return &Reader{
+		accountID:   accountID,
+		regions:     regions,
+		items:       make(map[string][]interface{}),
+		enrichments: make(map[interface{}]interface{}),
+		errors:      make(map[string]error),
+		objects:     make(map[string][]byte),
+	}
+}
+
+// GetAccountID returns the account ID of the Reader
+func (r *Reader) GetAccountID() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.accountID
+}
+
+// GetRegions returns the regions of the Reader
+func (r *Reader) GetRegions() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]string(nil), r.regions...)
+}
+
+// Add adds the items to the region, where they are returned, in the
+// order they have been added, by the methods returning their type, like
+// an *ec2.Instance by GetInstances. The items of the global services can
+// be added to any region, like the empty one of the raws.Resource.
+// The items can also be the types returned by the methods with follow-up
+// calls, like a raws.LoadBalancerWithTags, in which case the results of
+// the calls are the ones it has and only the missing ones are made.
+func (r *Reader) Add(region string, items ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, v := range items {
+		if it, ok := enrichedItem(reflect.ValueOf(v)); ok {
+			r.enrichments[it] = v
+			v = it
+		}
+		r.items[region] = append(r.items[region], v)
+	}
+}
+
+// SetOutput sets the output returned by the method, like "GetAccountAliases",
+// for the input on the region, or all of them if it's empty, instead of
+// the one built from the items. If the input is nil the output is returned
+// for any input, which is needed for the methods which don't return items.
+// The last output set for the same call is the one returned.
+func (r *Reader) SetOutput(method, region string, input, output interface{}) error {
+	data, err := json.Marshal(output)
+	if err != nil {
+		return err
+	}
+
+	var raw json.RawMessage
+	if input != nil {
+		raw, err = json.Marshal(input)
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.setOutput(method, region, raw, data)
+}
+
+// setOutput sets the output data for the calls with the input
+// JSON, or for all the calls if it's empty
+func (r *Reader) setOutput(method, region string, input, data json.RawMessage) error {
+	o := output{method: method, region: region, any: len(input) == 0, data: data}
+	if !o.any {
+		in, err := normalize(input)
+		if err != nil {
+			return err
+		}
+		o.input = in
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.outputs = append(r.outputs, o)
+
+	return nil
+}
+
+// SetError sets the error returned by the method on the region, where an
+// empty method or region means all of them, so the other regions still
+// return their results. A nil err removes the error.
+// The method can also be "DownloadObject", with an empty region.
+func (r *Reader) SetError(method, region string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k := method + "/" + region
+	if err == nil {
+		delete(r.errors, k)
+		return
+	}
+	r.errors[k] = err
+}
+
+// AddObject adds the object of the bucket with the
+// key and body, which is written by DownloadObject
+func (r *Reader) AddObject(bucket, key string, body []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.objects[bucket+"/"+key] = body
+}
+
+// DownloadObject writes on w the body of the object added
+// with AddObject, or returns a NoSuchKey error if there is none
+func (r *Reader) DownloadObject(
+	ctx context.Context, w io.WriterAt, input *s3.GetObjectInput, options ...func(*s3manager.Downloader),
+) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err := r.err("DownloadObject", ""); err != nil {
+		return 0, err
+	}
+
+	body, ok := r.objects[aws.StringValue(input.Bucket)+"/"+aws.StringValue(input.Key)]
+	if !ok {
+		return 0, awserr.New(s3.ErrCodeNoSuchKey, "The specified key does not exist.", nil)
+	}
+
+	n, err := w.WriteAt(body, 0)
+
+	return int64(n), err
+}
+
+// call sets on opts, a pointer to the map returned by
+// the method m, the output of the input on each region
+func (r *Reader) call(ctx context.Context, m method, input, opts interface{}) error {
+	var (
+		errs  raws.Errors
+		optsv = reflect.ValueOf(opts).Elem()
+		res   = reflect.MakeMap(optsv.Type())
+	)
+
+	for _, region := range raws.ContextRegions(ctx, r.GetRegions()) {
+		opt := reflect.New(optsv.Type().Elem())
+		err := r.output(ctx, m, region, input, opt.Interface())
+		if err != nil {
+			errs = append(errs, sendError(ctx, m.name, raws.NewError(region, m.service, err)))
+			continue
+		}
+		if !sendOutput(ctx, m.name, region, opt.Elem().Interface()) {
+			res.SetMapIndex(reflect.ValueOf(region), opt.Elem())
+		}
+	}
+
+	optsv.Set(res)
+	if errs != nil {
+		return errs
+	}
+
+	return nil
+}
+
+// sendOutput sends the output of the method on the region to the
+// raws.ResultFunc of ctx, as a single page, and returns false if
+// there is none, in which case the output has to be returned
+func sendOutput(ctx context.Context, method, region string, out interface{}) bool {
+	return raws.SendResult(ctx, raws.Result{Method: method, Region: region, Page: 1, Output: out})
+}
+
+// sendError sends the err of the method to the raws.ResultFunc
+// of ctx, if any, and returns it to be returned on the Errors
+func sendError(ctx context.Context, method string, err raws.Error) raws.Error {
+	raws.SendResult(ctx, raws.Result{Method: method, Region: err.Region(), Err: err})
+
+	return err
+}
+
+// asErrors returns err if it's raws.Errors, or otherwise
+// err as the only raws.Error of the service, without region
+func asErrors(service string, err error) raws.Errors {
+	if errs, ok := err.(raws.Errors); ok {
+		return errs
+	}
+
+	return raws.Errors{raws.NewError("", service, err)}
+}
+
+// output sets on opt, a pointer to the output of the method m, the
+// output of the input on the region, which is the error or the output
+// set for it or otherwise the page of the items matching the input
+func (r *Reader) output(ctx context.Context, m method, region string, input, opt interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err := r.err(m.name, region); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	in, err := normalize(b)
+	if err != nil {
+		return err
+	}
+
+	for i := len(r.outputs) - 1; i >= 0; i-- {
+		o := r.outputs[i]
+		if o.method == m.name && (o.region == "" || o.region == region) && (o.any || reflect.DeepEqual(o.input, in)) {
+			return json.Unmarshal(o.data, opt)
+		}
+	}
+
+	if m.flatten == "" {
+		return nil
+	}
+
+	if m.owner != "" {
+		input = withOwner(input, m.owner, r.accountID)
+	}
+
+	var (
+		optv  = reflect.ValueOf(opt).Elem()
+		path  = strings.Split(m.flatten, ".")
+		typ   = itemType(optv.Type(), path)
+		items []interface{}
+	)
+	for _, v := range r.regionItems(region, m.global) {
+		if reflect.TypeOf(v) != typ {
+			continue
+		}
+
+		ok, err := match(input, v, r.accountID)
+		if err != nil {
+			return err
+		} else if ok {
+			items = append(items, v)
+		}
+	}
+
+	items, next, err := paginate(input, items, m.paginated)
+	if err != nil {
+		return err
+	}
+
+	place(optv, path, items)
+
+	page := optv
+	if len(path) > 1 && optv.FieldByName(path[0]).Kind() == reflect.Ptr {
+		page = optv.FieldByName(path[0]).Elem()
+	}
+	setPage(page, next, len(items))
+
+	return nil
+}
+
+// err returns the error set for the method on the region
+func (r *Reader) err(method, region string) error {
+	for _, k := range []string{method + "/" + region, method + "/", "/" + region, "/"} {
+		if err, ok := r.errors[k]; ok {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// regionItems returns the items of the region, or of
+// all the regions if global, without duplicates
+func (r *Reader) regionItems(region string, global bool) []interface{} {
+	if !global {
+		return r.items[region]
+	}
+
+	var (
+		items []interface{}
+		seen  = make(map[interface{}]bool)
+	)
+	for _, reg := range append([]string{""}, r.regions...) {
+		for _, v := range r.items[reg] {
+			if !seen[v] {
+				seen[v] = true
+				items = append(items, v)
+			}
+		}
+	}
+	for reg, l := range r.items {
+		if reg == "" || containsString(r.regions, reg) {
+			continue
+		}
+		for _, v := range l {
+			if !seen[v] {
+				seen[v] = true
+				items = append(items, v)
+			}
+		}
+	}
+
+	return items
+}
+
+// enrichment returns the value added with the item v,
+// if it has been added as part of an enriched one
+func (r *Reader) enrichment(v interface{}) interface{} {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.enrichments[v]
+}
+
+// enrichedItem returns the Item of v if v is one of the types
+// returned by the methods with follow-up calls
+func enrichedItem(v reflect.Value) (interface{}, bool) {
+	if v.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	it := v.FieldByName("Item")
+	if !it.IsValid() || it.Kind() != reflect.Ptr || it.IsNil() {
+		return nil, false
+	}
+
+	return it.Interface(), true
+}
+
+// withOwner returns a copy of the input with the
+// accountID added to its owner field, like Owners
+func withOwner(input interface{}, owner, accountID string) interface{} {
+	v := reflect.ValueOf(input)
+	in := reflect.New(v.Type().Elem())
+	if !v.IsNil() {
+		in.Elem().Set(v.Elem())
+	}
+
+	f := in.Elem().FieldByName(owner)
+	owners := reflect.AppendSlice(reflect.MakeSlice(f.Type(), 0, f.Len()+1), f)
+	f.Set(reflect.Append(owners, reflect.ValueOf(aws.String(accountID))))
+
+	return in.Interface()
+}
+
+// itemType returns the type of the items of the list
+// on the path of the t struct, like *ec2.Instance
+func itemType(t reflect.Type, path []string) reflect.Type {
+	for _, p := range path {
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+			t = t.Elem()
+		}
+		f, _ := t.FieldByName(p)
+		t = f.Type
+	}
+
+	return t.Elem()
+}
+
+// place sets the items on the path of the v struct, where the lists
+// which are not the last one of the path have one element per item,
+// like the Reservations of ec2.DescribeInstancesOutput
+func place(v reflect.Value, path []string, items []interface{}) {
+	f := v.FieldByName(path[0])
+	if len(path) == 1 {
+		l := reflect.MakeSlice(f.Type(), 0, len(items))
+		for _, it := range items {
+			l = reflect.Append(l, reflect.ValueOf(it))
+		}
+		f.Set(l)
+		return
+	}
+
+	switch f.Kind() {
+	case reflect.Ptr:
+		f.Set(reflect.New(f.Type().Elem()))
+		place(f.Elem(), path[1:], items)
+	case reflect.Slice:
+		l := reflect.MakeSlice(f.Type(), 0, len(items))
+		for _, it := range items {
+			e := reflect.New(f.Type().Elem().Elem())
+			place(e.Elem(), path[1:], []interface{}{it})
+			l = reflect.Append(l, e)
+		}
+		f.Set(l)
+	}
+}
+
+// flatten returns the items on the path, separated by dots, of v
+func flatten(v interface{}, path string) []interface{} {
+	var items []interface{}
+
+	var walk func(v reflect.Value, path []string)
+	walk = func(v reflect.Value, path []string) {
+		v = reflect.Indirect(v)
+		if !v.IsValid() {
+			return
+		}
+
+		if len(path) == 0 {
+			return
+		}
+
+		f := v.FieldByName(path[0])
+		if len(path) == 1 {
+			for i := 0; i < f.Len(); i++ {
+				items = append(items, f.Index(i).Interface())
+			}
+			return
+		}
+
+		if f.Kind() == reflect.Slice {
+			for i := 0; i < f.Len(); i++ {
+				walk(f.Index(i), path[1:])
+			}
+			return
+		}
+		walk(f, path[1:])
+	}
+	walk(reflect.ValueOf(v), strings.Split(path, "."))
+
+	return items
+}
+
+var (
+	// pageSizeFields are the fields of the
+	// inputs with the maximum items to return
+	pageSizeFields = []string{"MaxResults", "MaxRecords", "MaxItems", "PageSize"}
+
+	// pageTokenFields are the fields of the inputs
+	// with the token of the page to return
+	pageTokenFields = []string{"NextToken", "Marker"}
+
+	// nextTokenFields are the fields of the outputs where the token of
+	// the next page is set, the first one found on the output is used
+	nextTokenFields = []string{"NextToken", "NextMarker", "Marker"}
+)
+
+// paginate returns the page of the items requested by the input,
+// and the token of the next one, empty if it's the last one, or all
+// the items from the token of the input on if all is true
+func paginate(input interface{}, items []interface{}, all bool) ([]interface{}, string, error) {
+	in := reflect.Indirect(reflect.ValueOf(input))
+	if !in.IsValid() {
+		return items, "", nil
+	}
+
+	var start int
+	for _, n := range pageTokenFields {
+		f := in.FieldByName(n)
+		if !f.IsValid() || f.IsNil() || f.Elem().String() == "" {
+			continue
+		}
+
+		t, err := base64.RawURLEncoding.DecodeString(f.Elem().String())
+		if err == nil {
+			start, err = strconv.Atoi(strings.TrimPrefix(string(t), "rawsfake/"))
+		}
+		if err != nil || !strings.HasPrefix(string(t), "rawsfake/") || start > len(items) {
+			return nil, "", awserr.New("InvalidParameterValue", fmt.Sprintf("The token '%s' is invalid", f.Elem().String()), nil)
+		}
+	}
+
+	if all {
+		return items[start:], "", nil
+	}
+
+	size := len(items)
+	for _, n := range pageSizeFields {
+		f := in.FieldByName(n)
+		if !f.IsValid() || f.IsNil() {
+			continue
+		}
+
+		switch f.Elem().Kind() {
+		case reflect.Int64:
+			size = int(f.Elem().Int())
+		case reflect.String:
+			s, err := strconv.Atoi(f.Elem().String())
+			if err != nil {
+				return nil, "", awserr.New("InvalidParameterValue", fmt.Sprintf("The %s '%s' is invalid", n, f.Elem().String()), nil)
+			}
+			size = s
+		}
+	}
+
+	items = items[start:]
+	if size <= 0 || size >= len(items) {
+		return items, "", nil
+	}
+
+	return items[:size], base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("rawsfake/%d", start+size))), nil
+}
+
+// setPage sets on the v struct the next token, if
+// any, the IsTruncated and the Quantity of items
+func setPage(v reflect.Value, next string, n int) {
+	if next != "" {
+		for _, name := range nextTokenFields {
+			f := v.FieldByName(name)
+			if !f.IsValid() {
+				continue
+			}
+
+			if f.Kind() == reflect.Ptr {
+				f.Set(reflect.ValueOf(aws.String(next)))
+			} else {
+				f.SetString(next)
+			}
+			break
+		}
+	}
+
+	if f := v.FieldByName("IsTruncated"); f.IsValid() {
+		f.Set(reflect.ValueOf(aws.Bool(next != "")))
+	}
+
+	if f := v.FieldByName("Quantity"); f.IsValid() && f.Type() == reflect.TypeOf(aws.Int64(0)) {
+		f.Set(reflect.ValueOf(aws.Int64(int64(n))))
+	}
+}
+
+// normalize returns the generic value of the JSON b without the
+// null values nor the empty lists or objects, so the inputs can be
+// compared whatever the way they have been built
+func normalize(b []byte) (interface{}, error) {
+	var v interface{}
+	err := json.Unmarshal(b, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	v = withoutEmpty(v)
+	if v == nil {
+		v = map[string]interface{}{}
+	}
+
+	return v, nil
+}
+
+// withoutEmpty returns v without the null
+// values nor the empty lists or objects
+func withoutEmpty(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		for k, e := range vv {
+			if e = withoutEmpty(e); e == nil {
+				delete(vv, k)
+			} else {
+				vv[k] = e
+			}
+		}
+		if len(vv) == 0 {
+			return nil
+		}
+	case []interface{}:
+		for i, e := range vv {
+			vv[i] = withoutEmpty(e)
+		}
+		if len(vv) == 0 {
+			return nil
+		}
+	}
+
+	return v
+}
+
+// containsString returns true if s is in l
+func containsString(l []string, s string) bool {
+	for _, v := range l {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
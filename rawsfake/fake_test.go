@@ -0,0 +1,441 @@
+package rawsfake
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/ses"
+	"github.com/cycloidio/raws"
+)
+
+// instanceIDs returns the IDs of the instances of the
+// outputs by region, in the order of the regions
+func instanceIDs(r *Reader, opts map[string]ec2.DescribeInstancesOutput) map[string][]string {
+	ids := make(map[string][]string)
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		ids[region] = []string{}
+		for _, v := range flatten(opt, "Reservations.Instances") {
+			ids[region] = append(ids[region], aws.StringValue(v.(*ec2.Instance).InstanceId))
+		}
+	}
+
+	return ids
+}
+
+func newInstance(id, state, name, vpc string) *ec2.Instance {
+	return &ec2.Instance{
+		InstanceId: aws.String(id),
+		State:      &ec2.InstanceState{Name: aws.String(state)},
+		VpcId:      aws.String(vpc),
+		Placement:  &ec2.Placement{AvailabilityZone: aws.String("eu-west-1a")},
+		Tags:       []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String(name)}},
+	}
+}
+
+func TestReader_GetInstances(t *testing.T) {
+	r := New("123", "eu-west-1", "eu-west-3")
+	r.Add("eu-west-1",
+		newInstance("i-1", "running", "web-1", "vpc-1"),
+		newInstance("i-2", "stopped", "web-2", "vpc-1"),
+		newInstance("i-3", "running", "db", "vpc-2"),
+		&ec2.Vpc{VpcId: aws.String("vpc-1")},
+	)
+	r.Add("eu-west-3", newInstance("i-4", "running", "web-4", "vpc-3"))
+
+	filter := func(name string, values ...string) *ec2.Filter {
+		return &ec2.Filter{Name: aws.String(name), Values: aws.StringSlice(values)}
+	}
+
+	tests := []struct {
+		name     string
+		input    *ec2.DescribeInstancesInput
+		expected map[string][]string
+		err      error
+	}{
+		{
+			name:     "all",
+			input:    nil,
+			expected: map[string][]string{"eu-west-1": {"i-1", "i-2", "i-3"}, "eu-west-3": {"i-4"}},
+		},
+		{
+			name:     "ids",
+			input:    &ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice([]string{"i-1", "i-4"})},
+			expected: map[string][]string{"eu-west-1": {"i-1"}, "eu-west-3": {"i-4"}},
+		},
+		{
+			name:     "state",
+			input:    &ec2.DescribeInstancesInput{Filters: []*ec2.Filter{filter("instance-state-name", "running")}},
+			expected: map[string][]string{"eu-west-1": {"i-1", "i-3"}, "eu-west-3": {"i-4"}},
+		},
+		{
+			name: "tag wildcard and vpc",
+			input: &ec2.DescribeInstancesInput{Filters: []*ec2.Filter{
+				filter("tag:Name", "web-?", "other"),
+				filter("vpc-id", "vpc-1", "vpc-3"),
+			}},
+			expected: map[string][]string{"eu-west-1": {"i-1", "i-2"}, "eu-west-3": {"i-4"}},
+		},
+		{
+			name:     "alias",
+			input:    &ec2.DescribeInstancesInput{Filters: []*ec2.Filter{filter("availability-zone", "eu-west-1*")}},
+			expected: map[string][]string{"eu-west-1": {"i-1", "i-2", "i-3"}, "eu-west-3": {"i-4"}},
+		},
+		{
+			name:     "tag key",
+			input:    &ec2.DescribeInstancesInput{Filters: []*ec2.Filter{filter("tag-key", "Env")}},
+			expected: map[string][]string{"eu-west-1": {}, "eu-west-3": {}},
+		},
+		{
+			name:     "unknown filter",
+			input:    &ec2.DescribeInstancesInput{Filters: []*ec2.Filter{filter("unknown-field", "x")}},
+			expected: map[string][]string{},
+			err: raws.Errors{
+				raws.NewError("eu-west-1", ec2.ServiceName, awserr.New("InvalidParameterValue", "The filter 'unknown-field' is invalid", nil)),
+				raws.NewError("eu-west-3", ec2.ServiceName, awserr.New("InvalidParameterValue", "The filter 'unknown-field' is invalid", nil)),
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		opts, err := r.GetInstances(context.Background(), tt.input)
+		if !reflect.DeepEqual(err, tt.err) {
+			t.Errorf("%s [%d] - errors: received=%+v | expected=%+v", tt.name, i, err, tt.err)
+		}
+
+		if ids := instanceIDs(r, opts); !reflect.DeepEqual(ids, tt.expected) {
+			t.Errorf("%s [%d] - instances: received=%+v | expected=%+v", tt.name, i, ids, tt.expected)
+		}
+	}
+}
+
+func TestReader_GetOwnImages(t *testing.T) {
+	r := New("123", "eu-west-1")
+	r.Add("eu-west-1",
+		&ec2.Image{ImageId: aws.String("ami-own"), OwnerId: aws.String("123")},
+		&ec2.Image{ImageId: aws.String("ami-default")},
+		&ec2.Image{ImageId: aws.String("ami-other"), OwnerId: aws.String("456")},
+	)
+
+	tests := []struct {
+		name     string
+		call     func() (map[string]ec2.DescribeImagesOutput, error)
+		expected []string
+	}{
+		{
+			name:     "all",
+			call:     func() (map[string]ec2.DescribeImagesOutput, error) { return r.GetImages(context.Background(), nil) },
+			expected: []string{"ami-own", "ami-default", "ami-other"},
+		},
+		{
+			name:     "own",
+			call:     func() (map[string]ec2.DescribeImagesOutput, error) { return r.GetOwnImages(context.Background(), nil) },
+			expected: []string{"ami-own", "ami-default"},
+		},
+		{
+			name: "self",
+			call: func() (map[string]ec2.DescribeImagesOutput, error) {
+				return r.GetImages(context.Background(), &ec2.DescribeImagesInput{Owners: aws.StringSlice([]string{"self"})})
+			},
+			expected: []string{"ami-own", "ami-default"},
+		},
+		{
+			name: "other",
+			call: func() (map[string]ec2.DescribeImagesOutput, error) {
+				return r.GetImages(context.Background(), &ec2.DescribeImagesInput{Owners: aws.StringSlice([]string{"456"})})
+			},
+			expected: []string{"ami-other"},
+		},
+	}
+
+	for i, tt := range tests {
+		opts, err := tt.call()
+		if err != nil {
+			t.Fatalf("%s [%d] - errors: received=%+v | expected=nil", tt.name, i, err)
+		}
+
+		var ids []string
+		for _, img := range opts["eu-west-1"].Images {
+			ids = append(ids, aws.StringValue(img.ImageId))
+		}
+		if !reflect.DeepEqual(ids, tt.expected) {
+			t.Errorf("%s [%d] - images: received=%+v | expected=%+v", tt.name, i, ids, tt.expected)
+		}
+	}
+}
+
+func TestReader_Pagination(t *testing.T) {
+	var (
+		ctx = context.Background()
+		r   = New("123", "eu-west-1")
+	)
+	for _, n := range []string{"a", "b", "c", "d", "e"} {
+		r.Add("", &iam.User{UserName: aws.String(n)})
+		r.Add("eu-west-1", &ses.ConfigurationSet{Name: aws.String("cs-" + n)})
+	}
+
+	var (
+		sets  []string
+		input = &ses.ListConfigurationSetsInput{MaxItems: aws.Int64(2)}
+		pages int
+	)
+	for {
+		opts, err := r.GetConfigurationSets(ctx, input)
+		if err != nil {
+			t.Fatalf("configuration sets - errors: received=%+v | expected=nil", err)
+		}
+		pages++
+
+		opt := opts["eu-west-1"]
+		for _, cs := range opt.ConfigurationSets {
+			sets = append(sets, aws.StringValue(cs.Name))
+		}
+		if opt.NextToken == nil {
+			break
+		}
+		input.NextToken = opt.NextToken
+	}
+	if pages != 3 || !reflect.DeepEqual(sets, []string{"cs-a", "cs-b", "cs-c", "cs-d", "cs-e"}) {
+		t.Errorf("configuration sets - pages: received=%d %+v | expected=3 [cs-a cs-b cs-c cs-d cs-e]", pages, sets)
+	}
+
+	// ListUsers is paginated by AWS, so all the
+	// items from the token on are returned
+	opts, err := r.GetUsers(ctx, &iam.ListUsersInput{MaxItems: aws.Int64(2)})
+	if err != nil || len(opts["eu-west-1"].Users) != 5 || aws.BoolValue(opts["eu-west-1"].IsTruncated) || opts["eu-west-1"].Marker != nil {
+		t.Fatalf("users - all the pages: received=%+v, %+v", opts, err)
+	}
+
+	_, err = r.GetConfigurationSets(ctx, &ses.ListConfigurationSetsInput{NextToken: aws.String("invalid")})
+	if err == nil || !strings.Contains(err.Error(), "The token 'invalid' is invalid") {
+		t.Errorf("configuration sets - invalid token: received=%+v", err)
+	}
+}
+
+func TestReader_Errors(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		r      = New("123", "eu-west-1", "eu-west-3")
+		err3   = awserr.New("UnauthorizedOperation", "denied", nil)
+		errAll = errors.New("throttled")
+	)
+	r.Add("eu-west-1", &ec2.Vpc{VpcId: aws.String("vpc-1")})
+	r.SetError("GetVpcs", "eu-west-3", err3)
+
+	opts, err := r.GetVpcs(ctx, nil)
+	if !reflect.DeepEqual(err, raws.Errors{raws.NewError("eu-west-3", ec2.ServiceName, err3)}) {
+		t.Errorf("region - errors: received=%+v", err)
+	}
+	if _, ok := opts["eu-west-3"]; ok || len(opts["eu-west-1"].Vpcs) != 1 {
+		t.Errorf("region - vpcs: received=%+v", opts)
+	}
+
+	_, err = r.GetSubnets(ctx, nil)
+	if err != nil {
+		t.Errorf("other method - errors: received=%+v | expected=nil", err)
+	}
+
+	r.SetError("", "", errAll)
+	_, err = r.GetSubnets(ctx, nil)
+	if errs, ok := err.(raws.Errors); !ok || len(errs) != 2 {
+		t.Errorf("all - errors: received=%+v", err)
+	}
+
+	r.SetError("", "", nil)
+	r.SetError("GetVpcs", "eu-west-3", nil)
+	_, err = r.GetVpcs(ctx, nil)
+	if err != nil {
+		t.Errorf("removed - errors: received=%+v | expected=nil", err)
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = r.GetVpcs(cctx, nil)
+	if errs, ok := err.(raws.Errors); !ok || len(errs) != 2 || errs[0].Unwrap() != context.Canceled {
+		t.Errorf("canceled - errors: received=%+v", err)
+	}
+}
+
+func TestReader_Enrich(t *testing.T) {
+	var (
+		ctx = context.Background()
+		r   = New("123", "eu-west-1")
+		lb2 = &elb.LoadBalancerDescription{LoadBalancerName: aws.String("lb-2")}
+	)
+	r.Add("eu-west-1",
+		&elb.LoadBalancerDescription{LoadBalancerName: aws.String("lb-1")},
+		&elb.TagDescription{LoadBalancerName: aws.String("lb-1"), Tags: []*elb.Tag{{Key: aws.String("env"), Value: aws.String("prod")}}},
+		raws.LoadBalancerWithTags{Item: lb2, Tags: &elb.TagDescription{LoadBalancerName: aws.String("lb-2")}},
+	)
+
+	lbs, err := r.GetLoadBalancersWithTags(ctx, nil)
+	if err != nil {
+		t.Fatalf("load balancers - errors: received=%+v | expected=nil", err)
+	}
+	if l := lbs["eu-west-1"]; len(l) != 2 || aws.StringValue(l[0].Tags.Tags[0].Value) != "prod" || l[1].Item != lb2 || l[1].Tags.Tags != nil {
+		t.Errorf("load balancers: received=%+v", l)
+	}
+
+	r.Add("", &iam.Role{RoleName: aws.String("admin")})
+	err = r.SetOutput("GetAttachedRolePolicies", "", &iam.ListAttachedRolePoliciesInput{RoleName: aws.String("admin")}, iam.ListAttachedRolePoliciesOutput{
+		AttachedPolicies: []*iam.AttachedPolicy{{PolicyName: aws.String("AdministratorAccess")}},
+	})
+	if err != nil {
+		t.Fatalf("set output - errors: received=%+v | expected=nil", err)
+	}
+
+	roles, err := r.GetRolesWithPolicies(ctx, nil)
+	if err != nil {
+		t.Fatalf("roles - errors: received=%+v | expected=nil", err)
+	}
+	if l := roles["eu-west-1"]; len(l) != 1 || l[0].Policies == nil || len(l[0].AttachedPolicies.AttachedPolicies) != 1 {
+		t.Errorf("roles: received=%+v", l)
+	}
+
+	// The output set for an input is not returned for the other ones
+	opts, err := r.GetAttachedRolePolicies(ctx, &iam.ListAttachedRolePoliciesInput{RoleName: aws.String("other")})
+	if err != nil || len(opts["eu-west-1"].AttachedPolicies) != 0 {
+		t.Errorf("other role: received=%+v, %+v", opts, err)
+	}
+}
+
+func TestReader_Stream(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		r    = New("123", "eu-west-1", "eu-west-3")
+		deny = awserr.New("UnauthorizedOperation", "denied", nil)
+	)
+	r.Add("eu-west-1", &elb.LoadBalancerDescription{LoadBalancerName: aws.String("lb-1")})
+	r.SetError("GetLoadBalancers", "eu-west-3", deny)
+
+	var results []string
+	for res := range raws.Stream(ctx, func(ctx context.Context) error {
+		lbs, err := r.GetLoadBalancersWithTags(ctx, nil)
+		if len(lbs) != 0 {
+			t.Errorf("stream - outputs: received=%+v | expected=none", lbs)
+		}
+		return err
+	}) {
+		if res.Err != nil {
+			results = append(results, res.Method+" "+res.Region+" "+res.Err.Error())
+			continue
+		}
+		for _, lb := range res.Output.([]raws.LoadBalancerWithTags) {
+			results = append(results, res.Method+" "+res.Region+" "+aws.StringValue(lb.Item.LoadBalancerName))
+		}
+	}
+
+	expected := []string{
+		"GetLoadBalancersWithTags eu-west-3 " + raws.NewError("eu-west-3", elb.ServiceName, deny).Error(),
+		"GetLoadBalancersWithTags eu-west-1 lb-1",
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("stream - results: received=%+v | expected=%+v", results, expected)
+	}
+}
+
+func TestReader_DownloadObject(t *testing.T) {
+	r := New("123", "eu-west-1")
+	r.AddObject("bucket", "key", []byte("body"))
+
+	var w aws.WriteAtBuffer
+	n, err := r.DownloadObject(context.Background(), &w, &s3.GetObjectInput{Bucket: aws.String("bucket"), Key: aws.String("key")})
+	if err != nil || n != 4 || string(w.Bytes()) != "body" {
+		t.Errorf("download: received=%d %q, %+v", n, w.Bytes(), err)
+	}
+
+	_, err = r.DownloadObject(context.Background(), &w, &s3.GetObjectInput{Bucket: aws.String("bucket"), Key: aws.String("other")})
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != s3.ErrCodeNoSuchKey {
+		t.Errorf("missing - errors: received=%+v | expected=%s", err, s3.ErrCodeNoSuchKey)
+	}
+}
+
+func TestReader_Load(t *testing.T) {
+	ctx := context.Background()
+
+	f, err := os.Open("testdata/fixture.json")
+	if err != nil {
+		t.Fatalf("open - errors: received=%+v | expected=nil", err)
+	}
+	defer f.Close()
+
+	r := New("")
+	err = r.Load(f)
+	if err != nil {
+		t.Fatalf("load - errors: received=%+v | expected=nil", err)
+	}
+
+	if r.GetAccountID() != "123456789012" || !reflect.DeepEqual(r.GetRegions(), []string{"eu-west-1", "eu-west-3"}) {
+		t.Errorf("account: received=%s %+v", r.GetAccountID(), r.GetRegions())
+	}
+
+	vpcs, err := r.GetVpcs(ctx, nil)
+	if !reflect.DeepEqual(err, raws.Errors{raws.NewError("eu-west-3", ec2.ServiceName, awserr.New("UnauthorizedOperation", "denied", nil))}) {
+		t.Errorf("vpcs - errors: received=%+v", err)
+	}
+	if v := vpcs["eu-west-1"].Vpcs; len(v) != 1 || aws.StringValue(v[0].VpcId) != "vpc-1" {
+		t.Errorf("vpcs: received=%+v", vpcs)
+	}
+
+	lbs, err := r.GetLoadBalancersWithTags(ctx, nil)
+	if l := lbs["eu-west-1"]; err != nil || len(l) != 1 || aws.StringValue(l[0].Tags.Tags[0].Key) != "env" {
+		t.Errorf("load balancers: received=%+v, %+v", lbs, err)
+	}
+
+	users, err := r.GetUsers(ctx, nil)
+	if err != nil || len(users["eu-west-1"].Users) != 1 || len(users["eu-west-3"].Users) != 1 {
+		t.Errorf("users: received=%+v, %+v", users, err)
+	}
+
+	aliases, err := r.GetAccountAliases(ctx, &iam.ListAccountAliasesInput{MaxItems: aws.Int64(10)})
+	if err != nil || aws.StringValue(aliases["eu-west-3"].AccountAliases[0]) != "alias" {
+		t.Errorf("aliases: received=%+v, %+v", aliases, err)
+	}
+
+	// The snapshot of the Inventory is a fixture with the same resources
+	snap, err := raws.Inventory(ctx, r, raws.InventoryOptions{Services: []string{"ec2", "elb", "iam"}})
+	if snap == nil {
+		t.Fatalf("inventory - errors: received=%+v", err)
+	}
+
+	var buff bytes.Buffer
+	err = snap.Write(&buff)
+	if err != nil {
+		t.Fatalf("write - errors: received=%+v | expected=nil", err)
+	}
+
+	l := New("")
+	err = l.Load(&buff)
+	if err != nil {
+		t.Fatalf("load snapshot - errors: received=%+v | expected=nil", err)
+	}
+
+	lsnap, err := raws.Inventory(ctx, l, raws.InventoryOptions{Services: []string{"ec2", "elb", "iam"}})
+	if lsnap == nil {
+		t.Fatalf("load inventory - errors: received=%+v", err)
+	}
+	d, err := raws.Diff(snap, lsnap, raws.DiffOptions{Ignore: []string{}})
+	if err != nil || !d.Empty() || len(lsnap.Resources) != 4 {
+		t.Errorf("load inventory: received=%+v | expected=%+v", lsnap.Resources, snap.Resources)
+	}
+
+	err = New("").Load(strings.NewReader(`{"resources": [{"service": "ec2", "type": "unknown", "raw": {}}]}`))
+	if err == nil || err.Error() != "the resources of type ec2/unknown are not supported" {
+		t.Errorf("unknown - errors: received=%+v", err)
+	}
+}
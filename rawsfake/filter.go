@@ -0,0 +1,358 @@
+package rawsfake
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+)
+
+var (
+	// ignoredFields are the fields of the inputs
+	// which are not used to filter the items
+	ignoredFields = append(append([]string{"DryRun"}, pageSizeFields...), pageTokenFields...)
+
+	// ownerFields are the fields of the inputs
+	// with the owners of the items to return
+	ownerFields = []string{"Owners", "OwnerIds"}
+
+	// filterAliases are the names of the filters which are not the
+	// path to the field of the items, used only if the name isn't
+	filterAliases = map[string]string{
+		"availability-zone": "placement.availability-zone",
+		"tenancy":           "placement.tenancy",
+		"ip-address":        "public-ip-address",
+		"db-instance-id":    "db-instance-identifier",
+		"db-cluster-id":     "db-cluster-identifier",
+	}
+)
+
+// match returns true if the item v matches the input, where an
+// empty owner is the accountID, or an error if the input has
+// a filter which can't be applied to the item
+func match(input, v interface{}, accountID string) (bool, error) {
+	in := reflect.Indirect(reflect.ValueOf(input))
+	if !in.IsValid() {
+		return true, nil
+	}
+
+	item := reflect.Indirect(reflect.ValueOf(v))
+	for i := 0; i < in.NumField(); i++ {
+		var (
+			name = in.Type().Field(i).Name
+			f    = in.Field(i)
+		)
+		if containsString(ignoredFields, name) || f.Kind() == reflect.Struct || isEmpty(f) {
+			continue
+		}
+
+		var (
+			ok  = true
+			err error
+		)
+		switch {
+		case name == "Filters" && f.Kind() == reflect.Slice:
+			ok, err = matchFilters(f, item)
+		case name == "TagFilters" && f.Kind() == reflect.Slice:
+			ok = matchTagFilters(f, item)
+		case containsString(ownerFields, name):
+			ok = matchOwners(f, item, accountID)
+		case f.Kind() == reflect.Slice:
+			ok = matchList(name, f, item)
+		case f.Kind() == reflect.Ptr && f.Elem().Kind() == reflect.String:
+			if fv := item.FieldByName(name); fv.IsValid() {
+				ok = containsString(values(fv, nil), f.Elem().String())
+			}
+		}
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// matchFilters returns true if the item matches all the filters,
+// which are a list of structs with Name and Values, or an error
+// if the name of one of them is not known for the item
+func matchFilters(filters, item reflect.Value) (bool, error) {
+	for i := 0; i < filters.Len(); i++ {
+		var (
+			f      = reflect.Indirect(filters.Index(i))
+			name   = stringValue(f.FieldByName("Name"))
+			values = stringValues(f.FieldByName("Values"))
+		)
+
+		vals, ok := filterValues(name, item)
+		if !ok {
+			return false, awserr.New("InvalidParameterValue", fmt.Sprintf("The filter '%s' is invalid", name), nil)
+		}
+
+		if !matchAny(vals, values) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// matchTagFilters returns true if the item has all the tags of the
+// filters, which are a list of structs with Key and Values, with
+// one of the Values if there are any
+func matchTagFilters(filters, item reflect.Value) bool {
+	for i := 0; i < filters.Len(); i++ {
+		var (
+			f      = reflect.Indirect(filters.Index(i))
+			key    = stringValue(f.FieldByName("Key"))
+			values = stringValues(f.FieldByName("Values"))
+		)
+
+		vals, ok := tagValues(item, func(k string) bool { return k == key }, "Value")
+		if !ok || len(vals) == 0 {
+			return false
+		}
+
+		if len(values) > 0 && !matchAny(vals, values) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchOwners returns true if the OwnerId of the item, or the
+// accountID if it has none, is one of the owners, where "self"
+// is the accountID
+func matchOwners(owners, item reflect.Value, accountID string) bool {
+	owner := accountID
+	if f := item.FieldByName("OwnerId"); f.IsValid() && stringValue(f) != "" {
+		owner = stringValue(f)
+	}
+
+	for _, o := range stringValues(owners) {
+		if o == owner || (o == "self" && owner == accountID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchList returns true if the field of the item with the name
+// of the list in singular, like InstanceId for InstanceIds, or
+// with the type of the item as prefix, like LoadBalancerName for
+// Names, has one of the values of the list. The lists without any
+// of those fields on the item are ignored
+func matchList(name string, list, item reflect.Value) bool {
+	singular := strings.TrimSuffix(name, "s")
+	for _, n := range []string{singular, item.Type().Name() + singular} {
+		f := item.FieldByName(n)
+		if !f.IsValid() {
+			continue
+		}
+
+		vals := stringValues(list)
+		for _, v := range values(f, nil) {
+			if containsString(vals, v) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// filterValues returns the values of the item for the filter
+// with the name, or false if the filter is not known for it
+func filterValues(name string, item reflect.Value) ([]string, bool) {
+	switch {
+	case strings.HasPrefix(name, "tag:"):
+		key := strings.TrimPrefix(name, "tag:")
+		return tagValues(item, func(k string) bool { return k == key }, "Value")
+	case name == "tag-key":
+		return tagValues(item, func(string) bool { return true }, "Key")
+	case name == "tag-value":
+		return tagValues(item, func(string) bool { return true }, "Value")
+	}
+
+	path, ok := fieldPath(item.Type(), name)
+	if !ok {
+		alias, aok := filterAliases[name]
+		if !aok {
+			return nil, false
+		}
+
+		path, ok = fieldPath(item.Type(), alias)
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return values(item, path), true
+}
+
+// fieldPath returns the path of fields of the t struct of the filter
+// with the name, like State.Name for "instance-state-name" on an
+// ec2.Instance, where the first word can be the type of the item
+func fieldPath(t reflect.Type, name string) ([]string, bool) {
+	words := strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '.' })
+	if path, ok := wordsPath(t, words); ok {
+		return path, true
+	}
+
+	if len(words) > 1 && strings.Contains(strings.ToLower(t.Name()), words[0]) {
+		return wordsPath(t, words[1:])
+	}
+
+	return nil, false
+}
+
+// wordsPath returns the path of fields of the t struct which names
+// are the words joined, in singular or plural, ending on a field
+// which is not a struct, other than a time
+func wordsPath(t reflect.Type, words []string) ([]string, bool) {
+	t = elem(t)
+	if len(words) == 0 {
+		return nil, t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{})
+	} else if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	for j := len(words); j > 0; j-- {
+		name := strings.Join(words[:j], "")
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+
+			fn := strings.ToLower(f.Name)
+			if fn != name && fn != name+"s" {
+				continue
+			}
+
+			if path, ok := wordsPath(f.Type, words[j:]); ok {
+				return append([]string{f.Name}, path...), true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// tagValues returns the field, Key or Value, of the Tags of the item
+// which key matches, or false if the item doesn't have Tags
+func tagValues(item reflect.Value, match func(string) bool, field string) ([]string, bool) {
+	tags := item.FieldByName("Tags")
+	if !tags.IsValid() || tags.Kind() != reflect.Slice {
+		return nil, false
+	}
+
+	var vals []string
+	for i := 0; i < tags.Len(); i++ {
+		t := reflect.Indirect(tags.Index(i))
+		if !t.IsValid() || !match(stringValue(t.FieldByName("Key"))) {
+			continue
+		}
+		vals = append(vals, stringValue(t.FieldByName(field)))
+	}
+
+	return vals, true
+}
+
+// values returns the values, as strings, of the path of fields
+// of v, going through all the elements of the lists
+func values(v reflect.Value, path []string) []string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Slice {
+		var vals []string
+		for i := 0; i < v.Len(); i++ {
+			vals = append(vals, values(v.Index(i), path)...)
+		}
+		return vals
+	}
+
+	if len(path) > 0 {
+		return values(v.FieldByName(path[0]), path[1:])
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return []string{v.String()}
+	case reflect.Bool:
+		return []string{strconv.FormatBool(v.Bool())}
+	case reflect.Int, reflect.Int64:
+		return []string{strconv.FormatInt(v.Int(), 10)}
+	case reflect.Float64:
+		return []string{strconv.FormatFloat(v.Float(), 'f', -1, 64)}
+	case reflect.Struct:
+		if t, ok := v.Interface().(time.Time); ok {
+			return []string{t.Format(time.RFC3339)}
+		}
+	}
+
+	return nil
+}
+
+// matchAny returns true if any of the vals matches any of the
+// patterns, which can have the * and ? wildcards
+func matchAny(vals, patterns []string) bool {
+	for _, p := range patterns {
+		re := regexp.MustCompile("^" + strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(regexp.QuoteMeta(p)) + "$")
+		for _, v := range vals {
+			if re.MatchString(v) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// elem returns the type of the elements of t if
+// it's a pointer or a list, recursively
+func elem(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+// isEmpty returns true if v is nil or an empty list
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+
+	return false
+}
+
+// stringValue returns the string of v, which can be a *string
+func stringValue(v reflect.Value) string {
+	vals := values(v, nil)
+	if len(vals) == 0 {
+		return ""
+	}
+
+	return vals[0]
+}
+
+// stringValues returns the strings of v, which can be a []*string
+func stringValues(v reflect.Value) []string {
+	return values(v, nil)
+}
@@ -0,0 +1,177 @@
+package rawsfake
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/cycloidio/raws"
+)
+
+// fixture is the JSON read by Load
+type fixture struct {
+	AccountID string          `json:"account_id"`
+	Regions   []string        `json:"regions"`
+	Resources []raws.Resource `json:"resources"`
+	Outputs   []fixtureOutput `json:"outputs"`
+	Errors    []fixtureError  `json:"errors"`
+}
+
+// fixtureOutput is an output of a fixture, set with SetOutput
+type fixtureOutput struct {
+	Method string          `json:"method"`
+	Region string          `json:"region"`
+	Input  json.RawMessage `json:"input"`
+	Output json.RawMessage `json:"output"`
+}
+
+// fixtureError is an error of a fixture, set with SetError
+type fixtureError struct {
+	Method  string `json:"method"`
+	Region  string `json:"region"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Load seeds r with the JSON fixture read from rd, which is like:
+//
+//	{
+//	  "account_id": "123456789012",
+//	  "regions": ["eu-west-1"],
+//	  "resources": [{"service": "ec2", "type": "vpc", "region": "eu-west-1", "raw": {"VpcId": "vpc-1"}}],
+//	  "outputs": [{"method": "GetAccountAliases", "output": {"AccountAliases": ["alias"]}}],
+//	  "errors": [{"method": "GetVpcs", "region": "eu-west-3", "code": "UnauthorizedOperation", "message": "denied"}]
+//	}
+//
+// where the account ID and the regions replace the ones of r if they are
+// defined, the resources are added with AddResources, the outputs are set
+// with SetOutput, for any input if it has none, and the errors with
+// SetError, as AWS errors if they have a code.
+// A raws.Snapshot, written by raws.Inventory, is a valid fixture.
+func (r *Reader) Load(rd io.Reader) error {
+	var f fixture
+	err := json.NewDecoder(rd).Decode(&f)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	if f.AccountID != "" {
+		r.accountID = f.AccountID
+	}
+	if len(f.Regions) > 0 {
+		r.regions = f.Regions
+	}
+	r.mu.Unlock()
+
+	err = r.AddResources(f.Resources...)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range f.Outputs {
+		if o.Method == "" {
+			return errors.New("the method of the outputs is required")
+		}
+
+		err = r.setOutput(o.Method, o.Region, o.Input, o.Output)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, e := range f.Errors {
+		err := errors.New(e.Message)
+		if e.Code != "" {
+			err = awserr.New(e.Code, e.Message, nil)
+		}
+		r.SetError(e.Method, e.Region, err)
+	}
+
+	return nil
+}
+
+// AddResources adds the Raw of each one of the resources to its region,
+// like Add. The Raw can be the item itself, as returned by
+// raws.NewResource, or its JSON, as read from a raws.Snapshot.
+// An error is returned if the service and type of a resource with
+// a JSON Raw are not the ones of any of the items.
+func (r *Reader) AddResources(resources ...raws.Resource) error {
+	for _, res := range resources {
+		if res.Raw == nil {
+			return fmt.Errorf("the resource %s has no raw item", res.Key())
+		}
+
+		if _, ok := res.Raw.(map[string]interface{}); !ok {
+			r.Add(res.Region, res.Raw)
+			continue
+		}
+
+		t, ok := resourceTypes()[res.Service+"/"+res.Type]
+		if !ok {
+			return fmt.Errorf("the resources of type %s/%s are not supported", res.Service, res.Type)
+		}
+
+		// The raw of an enriched item may be only the item
+		if _, ok := res.Raw.(map[string]interface{})["Item"]; !ok && t.Kind() == reflect.Struct {
+			f, _ := t.FieldByName("Item")
+			t = f.Type
+		}
+
+		b, err := json.Marshal(res.Raw)
+		if err != nil {
+			return err
+		}
+
+		v := reflect.New(t)
+		err = json.Unmarshal(b, v.Interface())
+		if err != nil {
+			return fmt.Errorf("invalid raw of the resource %s: %s", res.Key(), err)
+		}
+
+		r.Add(res.Region, v.Elem().Interface())
+	}
+
+	return nil
+}
+
+var (
+	resourceTypesOnce sync.Once
+	resourceTypesMap  map[string]reflect.Type
+)
+
+// resourceTypes returns the itemTypes by the service and type, like
+// "ec2/instance", of the raws.Resource built from them, where the
+// enriched types are preferred as they are the ones of the Inventory
+func resourceTypes() map[string]reflect.Type {
+	resourceTypesOnce.Do(func() {
+		resourceTypesMap = make(map[string]reflect.Type)
+		for _, t := range itemTypes {
+			var v reflect.Value
+			if t.Kind() == reflect.Ptr {
+				v = reflect.New(t.Elem())
+			} else {
+				v = reflect.New(t).Elem()
+				it := v.FieldByName("Item")
+				it.Set(reflect.New(it.Type().Elem()))
+			}
+
+			res, err := raws.NewResource("", "", v.Interface())
+			if err != nil {
+				continue
+			}
+
+			k := res.Service + "/" + res.Type
+			if et, ok := resourceTypesMap[k]; ok && et.Kind() == reflect.Struct {
+				continue
+			}
+			resourceTypesMap[k] = t
+		}
+	})
+
+	return resourceTypesMap
+}
@@ -0,0 +1,917 @@
+package rawsfake
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53resolver"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/ses"
+	"github.com/cycloidio/raws"
+)
+
+// Code generated by github.com/cycloidio/raws/cmd; DO NOT EDIT
+
+// itemTypes are the types of the items returned by the methods,
+// used to read the resources of the fixtures
+var itemTypes = []reflect.Type{
+	reflect.TypeOf((*ec2.Instance)(nil)),
+	reflect.TypeOf((*ec2.Vpc)(nil)),
+	reflect.TypeOf((*ec2.Image)(nil)),
+	reflect.TypeOf((*ec2.Image)(nil)),
+	reflect.TypeOf((*ec2.SecurityGroup)(nil)),
+	reflect.TypeOf((*ec2.Subnet)(nil)),
+	reflect.TypeOf((*ec2.Volume)(nil)),
+	reflect.TypeOf((*ec2.Snapshot)(nil)),
+	reflect.TypeOf((*ec2.Snapshot)(nil)),
+	reflect.TypeOf((*ec2.LaunchTemplate)(nil)),
+	reflect.TypeOf((*autoscaling.Group)(nil)),
+	reflect.TypeOf((*autoscaling.LaunchConfiguration)(nil)),
+	reflect.TypeOf((*elasticache.CacheCluster)(nil)),
+	reflect.TypeOf((*elb.LoadBalancerDescription)(nil)),
+	reflect.TypeOf(raws.LoadBalancerWithTags{}),
+	reflect.TypeOf((*elbv2.LoadBalancer)(nil)),
+	reflect.TypeOf(raws.LoadBalancerV2WithTags{}),
+	reflect.TypeOf((*rds.DBInstance)(nil)),
+	reflect.TypeOf(raws.DBInstanceWithTags{}),
+	reflect.TypeOf((*s3.Bucket)(nil)),
+	reflect.TypeOf((*s3.Object)(nil)),
+	reflect.TypeOf((*cloudfront.DistributionSummary)(nil)),
+	reflect.TypeOf((*cloudfront.PublicKeySummary)(nil)),
+	reflect.TypeOf((*cloudfront.OriginAccessIdentitySummary)(nil)),
+	reflect.TypeOf((*iam.AccessKeyMetadata)(nil)),
+	reflect.TypeOf((*iam.Group)(nil)),
+	reflect.TypeOf(raws.GroupWithPolicies{}),
+	reflect.TypeOf((*iam.AttachedPolicy)(nil)),
+	reflect.TypeOf((*iam.InstanceProfile)(nil)),
+	reflect.TypeOf((*iam.OpenIDConnectProviderListEntry)(nil)),
+	reflect.TypeOf((*iam.Policy)(nil)),
+	reflect.TypeOf((*iam.Role)(nil)),
+	reflect.TypeOf(raws.RoleWithPolicies{}),
+	reflect.TypeOf((*iam.AttachedPolicy)(nil)),
+	reflect.TypeOf((*iam.SAMLProviderListEntry)(nil)),
+	reflect.TypeOf((*iam.ServerCertificateMetadata)(nil)),
+	reflect.TypeOf((*iam.User)(nil)),
+	reflect.TypeOf(raws.UserWithPolicies{}),
+	reflect.TypeOf((*iam.AttachedPolicy)(nil)),
+	reflect.TypeOf((*iam.MFADevice)(nil)),
+	reflect.TypeOf((*ses.ReceiptFilter)(nil)),
+	reflect.TypeOf((*ses.ConfigurationSet)(nil)),
+	reflect.TypeOf((*ses.TemplateMetadata)(nil)),
+	reflect.TypeOf((*route53.DelegationSet)(nil)),
+	reflect.TypeOf((*route53.HealthCheck)(nil)),
+	reflect.TypeOf((*route53.QueryLoggingConfig)(nil)),
+	reflect.TypeOf((*route53.ResourceRecordSet)(nil)),
+	reflect.TypeOf((*route53.HostedZone)(nil)),
+	reflect.TypeOf((*route53resolver.ResolverEndpoint)(nil)),
+	reflect.TypeOf((*route53resolver.ResolverRule)(nil)),
+	reflect.TypeOf((*route53resolver.ResolverRuleAssociation)(nil)),
+	reflect.TypeOf((*resourcegroupstaggingapi.ResourceTagMapping)(nil)),
+}
+
+func (r *Reader) GetInstances(ctx context.Context, input *ec2.DescribeInstancesInput) (map[string]ec2.DescribeInstancesOutput, error) {
+	var opts map[string]ec2.DescribeInstancesOutput
+	err := r.call(ctx, method{name: "GetInstances", service: ec2.ServiceName, flatten: "Reservations.Instances", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetVpcs(ctx context.Context, input *ec2.DescribeVpcsInput) (map[string]ec2.DescribeVpcsOutput, error) {
+	var opts map[string]ec2.DescribeVpcsOutput
+	err := r.call(ctx, method{name: "GetVpcs", service: ec2.ServiceName, flatten: "Vpcs", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetImages(ctx context.Context, input *ec2.DescribeImagesInput) (map[string]ec2.DescribeImagesOutput, error) {
+	var opts map[string]ec2.DescribeImagesOutput
+	err := r.call(ctx, method{name: "GetImages", service: ec2.ServiceName, flatten: "Images", owner: "", global: false, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetOwnImages(ctx context.Context, input *ec2.DescribeImagesInput) (map[string]ec2.DescribeImagesOutput, error) {
+	var opts map[string]ec2.DescribeImagesOutput
+	err := r.call(ctx, method{name: "GetOwnImages", service: ec2.ServiceName, flatten: "Images", owner: "Owners", global: false, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetSecurityGroups(ctx context.Context, input *ec2.DescribeSecurityGroupsInput) (map[string]ec2.DescribeSecurityGroupsOutput, error) {
+	var opts map[string]ec2.DescribeSecurityGroupsOutput
+	err := r.call(ctx, method{name: "GetSecurityGroups", service: ec2.ServiceName, flatten: "SecurityGroups", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput) (map[string]ec2.DescribeSubnetsOutput, error) {
+	var opts map[string]ec2.DescribeSubnetsOutput
+	err := r.call(ctx, method{name: "GetSubnets", service: ec2.ServiceName, flatten: "Subnets", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetVolumes(ctx context.Context, input *ec2.DescribeVolumesInput) (map[string]ec2.DescribeVolumesOutput, error) {
+	var opts map[string]ec2.DescribeVolumesOutput
+	err := r.call(ctx, method{name: "GetVolumes", service: ec2.ServiceName, flatten: "Volumes", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetSnapshots(ctx context.Context, input *ec2.DescribeSnapshotsInput) (map[string]ec2.DescribeSnapshotsOutput, error) {
+	var opts map[string]ec2.DescribeSnapshotsOutput
+	err := r.call(ctx, method{name: "GetSnapshots", service: ec2.ServiceName, flatten: "Snapshots", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetOwnSnapshots(ctx context.Context, input *ec2.DescribeSnapshotsInput) (map[string]ec2.DescribeSnapshotsOutput, error) {
+	var opts map[string]ec2.DescribeSnapshotsOutput
+	err := r.call(ctx, method{name: "GetOwnSnapshots", service: ec2.ServiceName, flatten: "Snapshots", owner: "OwnerIds", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetLaunchTemplates(ctx context.Context, input *ec2.DescribeLaunchTemplatesInput) (map[string]ec2.DescribeLaunchTemplatesOutput, error) {
+	var opts map[string]ec2.DescribeLaunchTemplatesOutput
+	err := r.call(ctx, method{name: "GetLaunchTemplates", service: ec2.ServiceName, flatten: "LaunchTemplates", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetAutoScalingGroups(ctx context.Context, input *autoscaling.DescribeAutoScalingGroupsInput) (map[string]autoscaling.DescribeAutoScalingGroupsOutput, error) {
+	var opts map[string]autoscaling.DescribeAutoScalingGroupsOutput
+	err := r.call(ctx, method{name: "GetAutoScalingGroups", service: autoscaling.ServiceName, flatten: "AutoScalingGroups", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetLaunchConfigurations(ctx context.Context, input *autoscaling.DescribeLaunchConfigurationsInput) (map[string]autoscaling.DescribeLaunchConfigurationsOutput, error) {
+	var opts map[string]autoscaling.DescribeLaunchConfigurationsOutput
+	err := r.call(ctx, method{name: "GetLaunchConfigurations", service: autoscaling.ServiceName, flatten: "LaunchConfigurations", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetElastiCacheClusters(ctx context.Context, input *elasticache.DescribeCacheClustersInput) (map[string]elasticache.DescribeCacheClustersOutput, error) {
+	var opts map[string]elasticache.DescribeCacheClustersOutput
+	err := r.call(ctx, method{name: "GetElastiCacheClusters", service: elasticache.ServiceName, flatten: "CacheClusters", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetElastiCacheTags(ctx context.Context, input *elasticache.ListTagsForResourceInput) (map[string]elasticache.TagListMessage, error) {
+	var opts map[string]elasticache.TagListMessage
+	err := r.call(ctx, method{name: "GetElastiCacheTags", service: elasticache.ServiceName, flatten: "", owner: "", global: false, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetLoadBalancers(ctx context.Context, input *elb.DescribeLoadBalancersInput) (map[string]elb.DescribeLoadBalancersOutput, error) {
+	var opts map[string]elb.DescribeLoadBalancersOutput
+	err := r.call(ctx, method{name: "GetLoadBalancers", service: elb.ServiceName, flatten: "LoadBalancerDescriptions", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetLoadBalancersWithTags(ctx context.Context, input *elb.DescribeLoadBalancersInput) (map[string][]raws.LoadBalancerWithTags, error) {
+	var errs raws.Errors
+	var regionsOpts = map[string][]raws.LoadBalancerWithTags{}
+
+	// The items are sent once they have the results of the calls done for them
+	opts, err := r.GetLoadBalancers(raws.WithResultFunc(ctx, nil), input)
+	if err != nil {
+		for _, e := range asErrors(elb.ServiceName, err) {
+			errs = append(errs, sendError(ctx, "GetLoadBalancersWithTags", e))
+		}
+	}
+
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		var items []raws.LoadBalancerWithTags
+		for _, v := range flatten(opt, "LoadBalancerDescriptions") {
+			it, _ := r.enrichment(v).(raws.LoadBalancerWithTags)
+			it.Item = v.(*elb.LoadBalancerDescription)
+			items = append(items, it)
+		}
+
+		for i := 0; i < len(items); i += 20 {
+			batch := items[i:]
+			if len(batch) > 20 {
+				batch = batch[:20]
+			}
+
+			fin := &elb.DescribeTagsInput{}
+			for _, it := range batch {
+				if it.Tags == nil {
+					fin.LoadBalancerNames = append(fin.LoadBalancerNames, it.Item.LoadBalancerName)
+				}
+			}
+			if len(fin.LoadBalancerNames) == 0 {
+				continue
+			}
+
+			var fopt elb.DescribeTagsOutput
+			err := r.output(ctx, method{name: "GetLoadBalancersTags", service: elb.ServiceName, flatten: "TagDescriptions", owner: "", global: false, paginated: false}, region, fin, &fopt)
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetLoadBalancersWithTags", raws.NewError(region, elb.ServiceName, err)))
+				continue
+			}
+
+			for _, v := range flatten(fopt, "TagDescriptions") {
+				res := v.(*elb.TagDescription)
+				for j, it := range batch {
+					if it.Tags == nil && aws.StringValue(it.Item.LoadBalancerName) == aws.StringValue(res.LoadBalancerName) {
+						batch[j].Tags = res
+					}
+				}
+			}
+		}
+
+		if !sendOutput(ctx, "GetLoadBalancersWithTags", region, items) {
+			regionsOpts[region] = items
+		}
+	}
+
+	if errs != nil {
+		return regionsOpts, errs
+	}
+
+	return regionsOpts, nil
+}
+
+func (r *Reader) GetLoadBalancersTags(ctx context.Context, input *elb.DescribeTagsInput) (map[string]elb.DescribeTagsOutput, error) {
+	var opts map[string]elb.DescribeTagsOutput
+	err := r.call(ctx, method{name: "GetLoadBalancersTags", service: elb.ServiceName, flatten: "", owner: "", global: false, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetLoadBalancersV2(ctx context.Context, input *elbv2.DescribeLoadBalancersInput) (map[string]elbv2.DescribeLoadBalancersOutput, error) {
+	var opts map[string]elbv2.DescribeLoadBalancersOutput
+	err := r.call(ctx, method{name: "GetLoadBalancersV2", service: elbv2.ServiceName, flatten: "LoadBalancers", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetLoadBalancersV2WithTags(ctx context.Context, input *elbv2.DescribeLoadBalancersInput) (map[string][]raws.LoadBalancerV2WithTags, error) {
+	var errs raws.Errors
+	var regionsOpts = map[string][]raws.LoadBalancerV2WithTags{}
+
+	// The items are sent once they have the results of the calls done for them
+	opts, err := r.GetLoadBalancersV2(raws.WithResultFunc(ctx, nil), input)
+	if err != nil {
+		for _, e := range asErrors(elbv2.ServiceName, err) {
+			errs = append(errs, sendError(ctx, "GetLoadBalancersV2WithTags", e))
+		}
+	}
+
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		var items []raws.LoadBalancerV2WithTags
+		for _, v := range flatten(opt, "LoadBalancers") {
+			it, _ := r.enrichment(v).(raws.LoadBalancerV2WithTags)
+			it.Item = v.(*elbv2.LoadBalancer)
+			items = append(items, it)
+		}
+
+		for i := 0; i < len(items); i += 20 {
+			batch := items[i:]
+			if len(batch) > 20 {
+				batch = batch[:20]
+			}
+
+			fin := &elbv2.DescribeTagsInput{}
+			for _, it := range batch {
+				if it.Tags == nil {
+					fin.ResourceArns = append(fin.ResourceArns, it.Item.LoadBalancerArn)
+				}
+			}
+			if len(fin.ResourceArns) == 0 {
+				continue
+			}
+
+			var fopt elbv2.DescribeTagsOutput
+			err := r.output(ctx, method{name: "GetLoadBalancersV2Tags", service: elbv2.ServiceName, flatten: "TagDescriptions", owner: "", global: false, paginated: false}, region, fin, &fopt)
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetLoadBalancersV2WithTags", raws.NewError(region, elbv2.ServiceName, err)))
+				continue
+			}
+
+			for _, v := range flatten(fopt, "TagDescriptions") {
+				res := v.(*elbv2.TagDescription)
+				for j, it := range batch {
+					if it.Tags == nil && aws.StringValue(it.Item.LoadBalancerArn) == aws.StringValue(res.ResourceArn) {
+						batch[j].Tags = res
+					}
+				}
+			}
+		}
+
+		if !sendOutput(ctx, "GetLoadBalancersV2WithTags", region, items) {
+			regionsOpts[region] = items
+		}
+	}
+
+	if errs != nil {
+		return regionsOpts, errs
+	}
+
+	return regionsOpts, nil
+}
+
+func (r *Reader) GetLoadBalancersV2Tags(ctx context.Context, input *elbv2.DescribeTagsInput) (map[string]elbv2.DescribeTagsOutput, error) {
+	var opts map[string]elbv2.DescribeTagsOutput
+	err := r.call(ctx, method{name: "GetLoadBalancersV2Tags", service: elbv2.ServiceName, flatten: "", owner: "", global: false, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetDBInstances(ctx context.Context, input *rds.DescribeDBInstancesInput) (map[string]rds.DescribeDBInstancesOutput, error) {
+	var opts map[string]rds.DescribeDBInstancesOutput
+	err := r.call(ctx, method{name: "GetDBInstances", service: rds.ServiceName, flatten: "DBInstances", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetDBInstancesWithTags(ctx context.Context, input *rds.DescribeDBInstancesInput) (map[string][]raws.DBInstanceWithTags, error) {
+	var errs raws.Errors
+	var regionsOpts = map[string][]raws.DBInstanceWithTags{}
+
+	// The items are sent once they have the results of the calls done for them
+	opts, err := r.GetDBInstances(raws.WithResultFunc(ctx, nil), input)
+	if err != nil {
+		for _, e := range asErrors(rds.ServiceName, err) {
+			errs = append(errs, sendError(ctx, "GetDBInstancesWithTags", e))
+		}
+	}
+
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		var items []raws.DBInstanceWithTags
+		for _, v := range flatten(opt, "DBInstances") {
+			it, _ := r.enrichment(v).(raws.DBInstanceWithTags)
+			it.Item = v.(*rds.DBInstance)
+			items = append(items, it)
+		}
+
+		for i, it := range items {
+			if it.Tags != nil {
+				continue
+			}
+
+			fin := &rds.ListTagsForResourceInput{
+				ResourceName: it.Item.DBInstanceArn,
+			}
+
+			var fopt rds.ListTagsForResourceOutput
+			err := r.output(ctx, method{name: "GetDBInstancesTags", service: rds.ServiceName, flatten: "", owner: "", global: false, paginated: false}, region, fin, &fopt)
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetDBInstancesWithTags", raws.NewError(region, rds.ServiceName, err)))
+				continue
+			}
+
+			items[i].Tags = &fopt
+		}
+
+		if !sendOutput(ctx, "GetDBInstancesWithTags", region, items) {
+			regionsOpts[region] = items
+		}
+	}
+
+	if errs != nil {
+		return regionsOpts, errs
+	}
+
+	return regionsOpts, nil
+}
+
+func (r *Reader) GetDBInstancesTags(ctx context.Context, input *rds.ListTagsForResourceInput) (map[string]rds.ListTagsForResourceOutput, error) {
+	var opts map[string]rds.ListTagsForResourceOutput
+	err := r.call(ctx, method{name: "GetDBInstancesTags", service: rds.ServiceName, flatten: "", owner: "", global: false, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetBucketPolicyStatus(ctx context.Context, input *s3.GetBucketPolicyStatusInput) (map[string]s3.GetBucketPolicyStatusOutput, error) {
+	var opts map[string]s3.GetBucketPolicyStatusOutput
+	err := r.call(ctx, method{name: "GetBucketPolicyStatus", service: s3.ServiceName, flatten: "", owner: "", global: false, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetBucketAcl(ctx context.Context, input *s3.GetBucketAclInput) (map[string]s3.GetBucketAclOutput, error) {
+	var opts map[string]s3.GetBucketAclOutput
+	err := r.call(ctx, method{name: "GetBucketAcl", service: s3.ServiceName, flatten: "", owner: "", global: false, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetPublicAccessBlock(ctx context.Context, input *s3.GetPublicAccessBlockInput) (map[string]s3.GetPublicAccessBlockOutput, error) {
+	var opts map[string]s3.GetPublicAccessBlockOutput
+	err := r.call(ctx, method{name: "GetPublicAccessBlock", service: s3.ServiceName, flatten: "", owner: "", global: false, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) ListBuckets(ctx context.Context, input *s3.ListBucketsInput) (map[string]s3.ListBucketsOutput, error) {
+	var opts map[string]s3.ListBucketsOutput
+	err := r.call(ctx, method{name: "ListBuckets", service: s3.ServiceName, flatten: "Buckets", owner: "", global: false, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetBucketTags(ctx context.Context, input *s3.GetBucketTaggingInput) (map[string]s3.GetBucketTaggingOutput, error) {
+	var opts map[string]s3.GetBucketTaggingOutput
+	err := r.call(ctx, method{name: "GetBucketTags", service: s3.ServiceName, flatten: "", owner: "", global: false, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) ListObjects(ctx context.Context, input *s3.ListObjectsInput) (map[string]s3.ListObjectsOutput, error) {
+	var opts map[string]s3.ListObjectsOutput
+	err := r.call(ctx, method{name: "ListObjects", service: s3.ServiceName, flatten: "Contents", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetObjectsTags(ctx context.Context, input *s3.GetObjectTaggingInput) (map[string]s3.GetObjectTaggingOutput, error) {
+	var opts map[string]s3.GetObjectTaggingOutput
+	err := r.call(ctx, method{name: "GetObjectsTags", service: s3.ServiceName, flatten: "", owner: "", global: false, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetRecordedResourceCounts(ctx context.Context, input *configservice.GetDiscoveredResourceCountsInput) (map[string]configservice.GetDiscoveredResourceCountsOutput, error) {
+	var opts map[string]configservice.GetDiscoveredResourceCountsOutput
+	err := r.call(ctx, method{name: "GetRecordedResourceCounts", service: configservice.ServiceName, flatten: "", owner: "", global: false, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetCloudFrontDistributions(ctx context.Context, input *cloudfront.ListDistributionsInput) (map[string]cloudfront.ListDistributionsOutput, error) {
+	var opts map[string]cloudfront.ListDistributionsOutput
+	err := r.call(ctx, method{name: "GetCloudFrontDistributions", service: cloudfront.ServiceName, flatten: "DistributionList.Items", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetCloudFrontPublicKeys(ctx context.Context, input *cloudfront.ListPublicKeysInput) (map[string]cloudfront.ListPublicKeysOutput, error) {
+	var opts map[string]cloudfront.ListPublicKeysOutput
+	err := r.call(ctx, method{name: "GetCloudFrontPublicKeys", service: cloudfront.ServiceName, flatten: "PublicKeyList.Items", owner: "", global: true, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetCloudFrontOriginAccessIdentities(ctx context.Context, input *cloudfront.ListCloudFrontOriginAccessIdentitiesInput) (map[string]cloudfront.ListCloudFrontOriginAccessIdentitiesOutput, error) {
+	var opts map[string]cloudfront.ListCloudFrontOriginAccessIdentitiesOutput
+	err := r.call(ctx, method{name: "GetCloudFrontOriginAccessIdentities", service: cloudfront.ServiceName, flatten: "CloudFrontOriginAccessIdentityList.Items", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetAccessKeys(ctx context.Context, input *iam.ListAccessKeysInput) (map[string]iam.ListAccessKeysOutput, error) {
+	var opts map[string]iam.ListAccessKeysOutput
+	err := r.call(ctx, method{name: "GetAccessKeys", service: iam.ServiceName, flatten: "AccessKeyMetadata", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetAccountAliases(ctx context.Context, input *iam.ListAccountAliasesInput) (map[string]iam.ListAccountAliasesOutput, error) {
+	var opts map[string]iam.ListAccountAliasesOutput
+	err := r.call(ctx, method{name: "GetAccountAliases", service: iam.ServiceName, flatten: "", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetAccountPasswordPolicy(ctx context.Context, input *iam.GetAccountPasswordPolicyInput) (map[string]iam.GetAccountPasswordPolicyOutput, error) {
+	var opts map[string]iam.GetAccountPasswordPolicyOutput
+	err := r.call(ctx, method{name: "GetAccountPasswordPolicy", service: iam.ServiceName, flatten: "", owner: "", global: true, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetGroups(ctx context.Context, input *iam.ListGroupsInput) (map[string]iam.ListGroupsOutput, error) {
+	var opts map[string]iam.ListGroupsOutput
+	err := r.call(ctx, method{name: "GetGroups", service: iam.ServiceName, flatten: "Groups", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetGroupsWithPolicies(ctx context.Context, input *iam.ListGroupsInput) (map[string][]raws.GroupWithPolicies, error) {
+	var errs raws.Errors
+	var regionsOpts = map[string][]raws.GroupWithPolicies{}
+
+	// The items are sent once they have the results of the calls done for them
+	opts, err := r.GetGroups(raws.WithResultFunc(ctx, nil), input)
+	if err != nil {
+		for _, e := range asErrors(iam.ServiceName, err) {
+			errs = append(errs, sendError(ctx, "GetGroupsWithPolicies", e))
+		}
+	}
+
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		var items []raws.GroupWithPolicies
+		for _, v := range flatten(opt, "Groups") {
+			it, _ := r.enrichment(v).(raws.GroupWithPolicies)
+			it.Item = v.(*iam.Group)
+			items = append(items, it)
+		}
+
+		for i, it := range items {
+			if it.Policies != nil {
+				continue
+			}
+
+			fin := &iam.ListGroupPoliciesInput{
+				GroupName: it.Item.GroupName,
+			}
+
+			var fopt iam.ListGroupPoliciesOutput
+			err := r.output(ctx, method{name: "GetGroupPolicies", service: iam.ServiceName, flatten: "", owner: "", global: true, paginated: true}, region, fin, &fopt)
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetGroupsWithPolicies", raws.NewError(region, iam.ServiceName, err)))
+				continue
+			}
+
+			items[i].Policies = &fopt
+		}
+
+		for i, it := range items {
+			if it.AttachedPolicies != nil {
+				continue
+			}
+
+			fin := &iam.ListAttachedGroupPoliciesInput{
+				GroupName: it.Item.GroupName,
+			}
+
+			var fopt iam.ListAttachedGroupPoliciesOutput
+			err := r.output(ctx, method{name: "GetAttachedGroupPolicies", service: iam.ServiceName, flatten: "AttachedPolicies", owner: "", global: true, paginated: true}, region, fin, &fopt)
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetGroupsWithPolicies", raws.NewError(region, iam.ServiceName, err)))
+				continue
+			}
+
+			items[i].AttachedPolicies = &fopt
+		}
+
+		if !sendOutput(ctx, "GetGroupsWithPolicies", region, items) {
+			regionsOpts[region] = items
+		}
+	}
+
+	if errs != nil {
+		return regionsOpts, errs
+	}
+
+	return regionsOpts, nil
+}
+
+func (r *Reader) GetGroupPolicies(ctx context.Context, input *iam.ListGroupPoliciesInput) (map[string]iam.ListGroupPoliciesOutput, error) {
+	var opts map[string]iam.ListGroupPoliciesOutput
+	err := r.call(ctx, method{name: "GetGroupPolicies", service: iam.ServiceName, flatten: "", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetAttachedGroupPolicies(ctx context.Context, input *iam.ListAttachedGroupPoliciesInput) (map[string]iam.ListAttachedGroupPoliciesOutput, error) {
+	var opts map[string]iam.ListAttachedGroupPoliciesOutput
+	err := r.call(ctx, method{name: "GetAttachedGroupPolicies", service: iam.ServiceName, flatten: "AttachedPolicies", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetInstanceProfiles(ctx context.Context, input *iam.ListInstanceProfilesInput) (map[string]iam.ListInstanceProfilesOutput, error) {
+	var opts map[string]iam.ListInstanceProfilesOutput
+	err := r.call(ctx, method{name: "GetInstanceProfiles", service: iam.ServiceName, flatten: "InstanceProfiles", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetOpenIDConnectProviders(ctx context.Context, input *iam.ListOpenIDConnectProvidersInput) (map[string]iam.ListOpenIDConnectProvidersOutput, error) {
+	var opts map[string]iam.ListOpenIDConnectProvidersOutput
+	err := r.call(ctx, method{name: "GetOpenIDConnectProviders", service: iam.ServiceName, flatten: "OpenIDConnectProviderList", owner: "", global: true, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetPolicies(ctx context.Context, input *iam.ListPoliciesInput) (map[string]iam.ListPoliciesOutput, error) {
+	var opts map[string]iam.ListPoliciesOutput
+	err := r.call(ctx, method{name: "GetPolicies", service: iam.ServiceName, flatten: "Policies", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetRoles(ctx context.Context, input *iam.ListRolesInput) (map[string]iam.ListRolesOutput, error) {
+	var opts map[string]iam.ListRolesOutput
+	err := r.call(ctx, method{name: "GetRoles", service: iam.ServiceName, flatten: "Roles", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetRolesWithPolicies(ctx context.Context, input *iam.ListRolesInput) (map[string][]raws.RoleWithPolicies, error) {
+	var errs raws.Errors
+	var regionsOpts = map[string][]raws.RoleWithPolicies{}
+
+	// The items are sent once they have the results of the calls done for them
+	opts, err := r.GetRoles(raws.WithResultFunc(ctx, nil), input)
+	if err != nil {
+		for _, e := range asErrors(iam.ServiceName, err) {
+			errs = append(errs, sendError(ctx, "GetRolesWithPolicies", e))
+		}
+	}
+
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		var items []raws.RoleWithPolicies
+		for _, v := range flatten(opt, "Roles") {
+			it, _ := r.enrichment(v).(raws.RoleWithPolicies)
+			it.Item = v.(*iam.Role)
+			items = append(items, it)
+		}
+
+		for i, it := range items {
+			if it.Policies != nil {
+				continue
+			}
+
+			fin := &iam.ListRolePoliciesInput{
+				RoleName: it.Item.RoleName,
+			}
+
+			var fopt iam.ListRolePoliciesOutput
+			err := r.output(ctx, method{name: "GetRolePolicies", service: iam.ServiceName, flatten: "", owner: "", global: true, paginated: true}, region, fin, &fopt)
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetRolesWithPolicies", raws.NewError(region, iam.ServiceName, err)))
+				continue
+			}
+
+			items[i].Policies = &fopt
+		}
+
+		for i, it := range items {
+			if it.AttachedPolicies != nil {
+				continue
+			}
+
+			fin := &iam.ListAttachedRolePoliciesInput{
+				RoleName: it.Item.RoleName,
+			}
+
+			var fopt iam.ListAttachedRolePoliciesOutput
+			err := r.output(ctx, method{name: "GetAttachedRolePolicies", service: iam.ServiceName, flatten: "AttachedPolicies", owner: "", global: true, paginated: true}, region, fin, &fopt)
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetRolesWithPolicies", raws.NewError(region, iam.ServiceName, err)))
+				continue
+			}
+
+			items[i].AttachedPolicies = &fopt
+		}
+
+		if !sendOutput(ctx, "GetRolesWithPolicies", region, items) {
+			regionsOpts[region] = items
+		}
+	}
+
+	if errs != nil {
+		return regionsOpts, errs
+	}
+
+	return regionsOpts, nil
+}
+
+func (r *Reader) GetRolePolicies(ctx context.Context, input *iam.ListRolePoliciesInput) (map[string]iam.ListRolePoliciesOutput, error) {
+	var opts map[string]iam.ListRolePoliciesOutput
+	err := r.call(ctx, method{name: "GetRolePolicies", service: iam.ServiceName, flatten: "", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetAttachedRolePolicies(ctx context.Context, input *iam.ListAttachedRolePoliciesInput) (map[string]iam.ListAttachedRolePoliciesOutput, error) {
+	var opts map[string]iam.ListAttachedRolePoliciesOutput
+	err := r.call(ctx, method{name: "GetAttachedRolePolicies", service: iam.ServiceName, flatten: "AttachedPolicies", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetRoleTags(ctx context.Context, input *iam.ListRoleTagsInput) (map[string]iam.ListRoleTagsOutput, error) {
+	var opts map[string]iam.ListRoleTagsOutput
+	err := r.call(ctx, method{name: "GetRoleTags", service: iam.ServiceName, flatten: "", owner: "", global: true, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetSAMLProviders(ctx context.Context, input *iam.ListSAMLProvidersInput) (map[string]iam.ListSAMLProvidersOutput, error) {
+	var opts map[string]iam.ListSAMLProvidersOutput
+	err := r.call(ctx, method{name: "GetSAMLProviders", service: iam.ServiceName, flatten: "SAMLProviderList", owner: "", global: true, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetServerCertificates(ctx context.Context, input *iam.ListServerCertificatesInput) (map[string]iam.ListServerCertificatesOutput, error) {
+	var opts map[string]iam.ListServerCertificatesOutput
+	err := r.call(ctx, method{name: "GetServerCertificates", service: iam.ServiceName, flatten: "ServerCertificateMetadataList", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetUsers(ctx context.Context, input *iam.ListUsersInput) (map[string]iam.ListUsersOutput, error) {
+	var opts map[string]iam.ListUsersOutput
+	err := r.call(ctx, method{name: "GetUsers", service: iam.ServiceName, flatten: "Users", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetUsersWithPolicies(ctx context.Context, input *iam.ListUsersInput) (map[string][]raws.UserWithPolicies, error) {
+	var errs raws.Errors
+	var regionsOpts = map[string][]raws.UserWithPolicies{}
+
+	// The items are sent once they have the results of the calls done for them
+	opts, err := r.GetUsers(raws.WithResultFunc(ctx, nil), input)
+	if err != nil {
+		for _, e := range asErrors(iam.ServiceName, err) {
+			errs = append(errs, sendError(ctx, "GetUsersWithPolicies", e))
+		}
+	}
+
+	for _, region := range r.GetRegions() {
+		opt, ok := opts[region]
+		if !ok {
+			continue
+		}
+
+		var items []raws.UserWithPolicies
+		for _, v := range flatten(opt, "Users") {
+			it, _ := r.enrichment(v).(raws.UserWithPolicies)
+			it.Item = v.(*iam.User)
+			items = append(items, it)
+		}
+
+		for i, it := range items {
+			if it.Policies != nil {
+				continue
+			}
+
+			fin := &iam.ListUserPoliciesInput{
+				UserName: it.Item.UserName,
+			}
+
+			var fopt iam.ListUserPoliciesOutput
+			err := r.output(ctx, method{name: "GetUserPolicies", service: iam.ServiceName, flatten: "", owner: "", global: true, paginated: true}, region, fin, &fopt)
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetUsersWithPolicies", raws.NewError(region, iam.ServiceName, err)))
+				continue
+			}
+
+			items[i].Policies = &fopt
+		}
+
+		for i, it := range items {
+			if it.AttachedPolicies != nil {
+				continue
+			}
+
+			fin := &iam.ListAttachedUserPoliciesInput{
+				UserName: it.Item.UserName,
+			}
+
+			var fopt iam.ListAttachedUserPoliciesOutput
+			err := r.output(ctx, method{name: "GetAttachedUserPolicies", service: iam.ServiceName, flatten: "AttachedPolicies", owner: "", global: true, paginated: true}, region, fin, &fopt)
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetUsersWithPolicies", raws.NewError(region, iam.ServiceName, err)))
+				continue
+			}
+
+			items[i].AttachedPolicies = &fopt
+		}
+
+		if !sendOutput(ctx, "GetUsersWithPolicies", region, items) {
+			regionsOpts[region] = items
+		}
+	}
+
+	if errs != nil {
+		return regionsOpts, errs
+	}
+
+	return regionsOpts, nil
+}
+
+func (r *Reader) GetUserPolicies(ctx context.Context, input *iam.ListUserPoliciesInput) (map[string]iam.ListUserPoliciesOutput, error) {
+	var opts map[string]iam.ListUserPoliciesOutput
+	err := r.call(ctx, method{name: "GetUserPolicies", service: iam.ServiceName, flatten: "", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetAttachedUserPolicies(ctx context.Context, input *iam.ListAttachedUserPoliciesInput) (map[string]iam.ListAttachedUserPoliciesOutput, error) {
+	var opts map[string]iam.ListAttachedUserPoliciesOutput
+	err := r.call(ctx, method{name: "GetAttachedUserPolicies", service: iam.ServiceName, flatten: "AttachedPolicies", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetUserTags(ctx context.Context, input *iam.ListUserTagsInput) (map[string]iam.ListUserTagsOutput, error) {
+	var opts map[string]iam.ListUserTagsOutput
+	err := r.call(ctx, method{name: "GetUserTags", service: iam.ServiceName, flatten: "", owner: "", global: true, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetMFADevices(ctx context.Context, input *iam.ListMFADevicesInput) (map[string]iam.ListMFADevicesOutput, error) {
+	var opts map[string]iam.ListMFADevicesOutput
+	err := r.call(ctx, method{name: "GetMFADevices", service: iam.ServiceName, flatten: "MFADevices", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetAccountAuthorizationDetails(ctx context.Context, input *iam.GetAccountAuthorizationDetailsInput) (map[string]iam.GetAccountAuthorizationDetailsOutput, error) {
+	var opts map[string]iam.GetAccountAuthorizationDetailsOutput
+	err := r.call(ctx, method{name: "GetAccountAuthorizationDetails", service: iam.ServiceName, flatten: "", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetSSHPublicKey(ctx context.Context, input *iam.GetSSHPublicKeyInput) (map[string]iam.GetSSHPublicKeyOutput, error) {
+	var opts map[string]iam.GetSSHPublicKeyOutput
+	err := r.call(ctx, method{name: "GetSSHPublicKey", service: iam.ServiceName, flatten: "", owner: "", global: true, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetActiveReceiptRuleSet(ctx context.Context, input *ses.DescribeActiveReceiptRuleSetInput) (map[string]ses.DescribeActiveReceiptRuleSetOutput, error) {
+	var opts map[string]ses.DescribeActiveReceiptRuleSetOutput
+	err := r.call(ctx, method{name: "GetActiveReceiptRuleSet", service: ses.ServiceName, flatten: "", owner: "", global: false, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetIdentities(ctx context.Context, input *ses.ListIdentitiesInput) (map[string]ses.ListIdentitiesOutput, error) {
+	var opts map[string]ses.ListIdentitiesOutput
+	err := r.call(ctx, method{name: "GetIdentities", service: ses.ServiceName, flatten: "", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetReceiptFilters(ctx context.Context, input *ses.ListReceiptFiltersInput) (map[string]ses.ListReceiptFiltersOutput, error) {
+	var opts map[string]ses.ListReceiptFiltersOutput
+	err := r.call(ctx, method{name: "GetReceiptFilters", service: ses.ServiceName, flatten: "Filters", owner: "", global: false, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetConfigurationSets(ctx context.Context, input *ses.ListConfigurationSetsInput) (map[string]ses.ListConfigurationSetsOutput, error) {
+	var opts map[string]ses.ListConfigurationSetsOutput
+	err := r.call(ctx, method{name: "GetConfigurationSets", service: ses.ServiceName, flatten: "ConfigurationSets", owner: "", global: false, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetIdentityNotificationAttributes(ctx context.Context, input *ses.GetIdentityNotificationAttributesInput) (map[string]ses.GetIdentityNotificationAttributesOutput, error) {
+	var opts map[string]ses.GetIdentityNotificationAttributesOutput
+	err := r.call(ctx, method{name: "GetIdentityNotificationAttributes", service: ses.ServiceName, flatten: "", owner: "", global: false, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetTemplates(ctx context.Context, input *ses.ListTemplatesInput) (map[string]ses.ListTemplatesOutput, error) {
+	var opts map[string]ses.ListTemplatesOutput
+	err := r.call(ctx, method{name: "GetTemplates", service: ses.ServiceName, flatten: "TemplatesMetadata", owner: "", global: false, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetReusableDelegationSets(ctx context.Context, input *route53.ListReusableDelegationSetsInput) (map[string]route53.ListReusableDelegationSetsOutput, error) {
+	var opts map[string]route53.ListReusableDelegationSetsOutput
+	err := r.call(ctx, method{name: "GetReusableDelegationSets", service: route53.ServiceName, flatten: "DelegationSets", owner: "", global: true, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetHealthChecks(ctx context.Context, input *route53.ListHealthChecksInput) (map[string]route53.ListHealthChecksOutput, error) {
+	var opts map[string]route53.ListHealthChecksOutput
+	err := r.call(ctx, method{name: "GetHealthChecks", service: route53.ServiceName, flatten: "HealthChecks", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetQueryLoggingConfigs(ctx context.Context, input *route53.ListQueryLoggingConfigsInput) (map[string]route53.ListQueryLoggingConfigsOutput, error) {
+	var opts map[string]route53.ListQueryLoggingConfigsOutput
+	err := r.call(ctx, method{name: "GetQueryLoggingConfigs", service: route53.ServiceName, flatten: "QueryLoggingConfigs", owner: "", global: true, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetResourceRecordSets(ctx context.Context, input *route53.ListResourceRecordSetsInput) (map[string]route53.ListResourceRecordSetsOutput, error) {
+	var opts map[string]route53.ListResourceRecordSetsOutput
+	err := r.call(ctx, method{name: "GetResourceRecordSets", service: route53.ServiceName, flatten: "ResourceRecordSets", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetHostedZones(ctx context.Context, input *route53.ListHostedZonesInput) (map[string]route53.ListHostedZonesOutput, error) {
+	var opts map[string]route53.ListHostedZonesOutput
+	err := r.call(ctx, method{name: "GetHostedZones", service: route53.ServiceName, flatten: "HostedZones", owner: "", global: true, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetVPCAssociationAuthorizations(ctx context.Context, input *route53.ListVPCAssociationAuthorizationsInput) (map[string]route53.ListVPCAssociationAuthorizationsOutput, error) {
+	var opts map[string]route53.ListVPCAssociationAuthorizationsOutput
+	err := r.call(ctx, method{name: "GetVPCAssociationAuthorizations", service: route53.ServiceName, flatten: "", owner: "", global: true, paginated: false}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetResolverEndpoints(ctx context.Context, input *route53resolver.ListResolverEndpointsInput) (map[string]route53resolver.ListResolverEndpointsOutput, error) {
+	var opts map[string]route53resolver.ListResolverEndpointsOutput
+	err := r.call(ctx, method{name: "GetResolverEndpoints", service: route53resolver.ServiceName, flatten: "ResolverEndpoints", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetResolverRules(ctx context.Context, input *route53resolver.ListResolverRulesInput) (map[string]route53resolver.ListResolverRulesOutput, error) {
+	var opts map[string]route53resolver.ListResolverRulesOutput
+	err := r.call(ctx, method{name: "GetResolverRules", service: route53resolver.ServiceName, flatten: "ResolverRules", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetResolverRuleAssociations(ctx context.Context, input *route53resolver.ListResolverRuleAssociationsInput) (map[string]route53resolver.ListResolverRuleAssociationsOutput, error) {
+	var opts map[string]route53resolver.ListResolverRuleAssociationsOutput
+	err := r.call(ctx, method{name: "GetResolverRuleAssociations", service: route53resolver.ServiceName, flatten: "ResolverRuleAssociations", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
+
+func (r *Reader) GetTaggedResources(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput) (map[string]resourcegroupstaggingapi.GetResourcesOutput, error) {
+	var opts map[string]resourcegroupstaggingapi.GetResourcesOutput
+	err := r.call(ctx, method{name: "GetTaggedResources", service: resourcegroupstaggingapi.ServiceName, flatten: "ResourceTagMappingList", owner: "", global: false, paginated: true}, input, &opts)
+	return opts, err
+}
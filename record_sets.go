@@ -0,0 +1,61 @@
+package raws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+)
+
+// HostedZoneRecordSet is a route53.ResourceRecordSet with the ID of its
+// hosted zone, which the record sets don't have, so the record sets with
+// the same name and type on different zones, like on the public and the
+// private zones of a split-horizon DNS, are different Resources
+type HostedZoneRecordSet struct {
+	// HostedZoneID is the ID of the hosted zone, like "Z1" or "/hostedzone/Z1"
+	HostedZoneID string
+	Item         *route53.ResourceRecordSet
+}
+
+// ListAllHostedZoneRecordSets returns the record sets of all the hosted
+// zones returned by GetHostedZones paired with the
+// ID of their zone. As Route 53 is a global service the calls are only
+// made on the first region of r, which is the key of the returned map.
+// The errors are the ones of all the calls, so the record sets of the
+// zones without errors are always returned.
+func ListAllHostedZoneRecordSets(ctx context.Context, r AWSReader) (map[string][]HostedZoneRecordSet, error) {
+	var (
+		errs    Errors
+		regions = r.GetRegions()
+	)
+	if len(regions) == 0 {
+		return nil, nil
+	}
+
+	region := regions[0]
+	ctx = WithRegions(ctx, region)
+
+	zones, err := ListAllHostedZones(ctx, r, nil)
+	if err != nil {
+		errs = append(errs, asErrors(route53.ServiceName, err)...)
+	}
+
+	var items []HostedZoneRecordSet
+	for _, z := range zones {
+		opts, err := r.GetResourceRecordSets(ctx, &route53.ListResourceRecordSetsInput{HostedZoneId: z.Item.Id})
+		if err != nil {
+			errs = append(errs, asErrors(route53.ServiceName, err)...)
+		}
+
+		for _, rs := range opts[region].ResourceRecordSets {
+			items = append(items, HostedZoneRecordSet{HostedZoneID: aws.StringValue(z.Item.Id), Item: rs})
+		}
+	}
+
+	res := map[string][]HostedZoneRecordSet{region: items}
+	if errs != nil {
+		return res, errs
+	}
+
+	return res, nil
+}
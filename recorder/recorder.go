@@ -0,0 +1,300 @@
+// Package recorder records the HTTP requests made to AWS, and their
+// responses, on fixture files to replay them later without any network,
+// so the code using raws can be tested with realistic responses.
+//
+// The Recorder is the Transport of the HTTP client of the *aws.Config
+// given to raws.NewAWSReader:
+//
+//	rec, err := recorder.New("testdata/fixtures", recorder.Replay, recorder.Options{})
+//	r, err := raws.NewAWSReader(ctx, "key", "secret", []string{"eu-*"}, rec.Config(), false)
+//
+// The credentials and the account IDs are scrubbed from the fixtures.
+// As the Recorder is not an *http.Transport, the SDK can't use the
+// AWS_CA_BUNDLE environment variable with it.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// Mode is the mode of a Recorder
+type Mode int
+
+const (
+	// Record makes the requests and writes them, with their
+	// responses, on the fixtures
+	Record Mode = iota
+
+	// Replay returns the responses of the fixtures without
+	// making any request
+	Replay
+)
+
+// FakeAccountID is the account ID which replaces the one of the
+// credentials on the fixtures, the other ones found are replaced
+// by FakeAccountID+1, +2...
+const FakeAccountID = "123456789012"
+
+// Options are the options of a Recorder
+type Options struct {
+	// Transport makes the requests on Record mode,
+	// http.DefaultTransport if nil
+	Transport http.RoundTripper
+
+	// AccountIDs are other account IDs to scrub, like the ones
+	// of the cross account roles which may not be in any ARN
+	AccountIDs []string
+}
+
+// Recorder is an http.RoundTripper which records the requests to AWS
+// and their responses, or replays them
+type Recorder struct {
+	dir  string
+	mode Mode
+	opts Options
+
+	mu           sync.Mutex
+	n            int
+	scrub        *scrubber
+	interactions map[string][]*Interaction
+	served       map[string]int
+}
+
+// Interaction is a request and its response, as
+// they are written on each one of the fixtures
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is the recorded HTTP request
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is the recorded HTTP response
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// requestHeaders are the headers of the requests which are recorded and
+// compared, the other ones are either signatures, dates or credentials
+var requestHeaders = []string{"Content-Type", "X-Amz-Target"}
+
+// New returns a Recorder of the fixtures of the dir with the mode.
+// On Replay mode the fixtures are read, and an error is returned
+// if any of them is invalid, and on Record mode the dir is created.
+func New(dir string, mode Mode, opts Options) (*Recorder, error) {
+	if opts.Transport == nil {
+		opts.Transport = http.DefaultTransport
+	}
+
+	r := &Recorder{
+		dir:          dir,
+		mode:         mode,
+		opts:         opts,
+		scrub:        newScrubber(opts.AccountIDs),
+		interactions: make(map[string][]*Interaction),
+		served:       make(map[string]int),
+	}
+
+	if mode == Record {
+		return r, os.MkdirAll(dir, 0755)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	for _, f := range files {
+		b, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+
+		var i Interaction
+		err = json.Unmarshal(b, &i)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fixture %s: %s", f, err)
+		}
+
+		k := i.Request.key()
+		r.interactions[k] = append(r.interactions[k], &i)
+	}
+	r.n = len(files)
+
+	return r, nil
+}
+
+// Config returns an *aws.Config with an HTTP client using r
+func (r *Recorder) Config() *aws.Config {
+	return &aws.Config{HTTPClient: &http.Client{Transport: r}}
+}
+
+// RoundTrip records or replays the req, according to the Mode
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	rreq := Request{Method: req.Method, URL: req.URL.String(), Header: make(http.Header), Body: string(body)}
+	for _, h := range requestHeaders {
+		if v := req.Header.Get(h); v != "" {
+			rreq.Header.Set(h, v)
+		}
+	}
+
+	if r.mode == Replay {
+		return r.replay(req, rreq)
+	}
+
+	return r.record(req, rreq)
+}
+
+// replay returns the response of the interaction with the same request
+// as rreq, the ones with the same request are returned in order and
+// the last one is returned again once all of them have been
+func (r *Recorder) replay(req *http.Request, rreq Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k := rreq.key()
+	is := r.interactions[k]
+	if len(is) == 0 {
+		return nil, fmt.Errorf("no recorded response for %s %s %s", rreq.Method, rreq.URL, rreq.Header.Get("X-Amz-Target")+rreq.Body)
+	}
+
+	i := is[len(is)-1]
+	if n := r.served[k]; n < len(is) {
+		i = is[n]
+		r.served[k] = n + 1
+	}
+
+	return i.Response.http(req), nil
+}
+
+// record makes the req and writes its scrubbed interaction on a fixture
+func (r *Recorder) record(req *http.Request, rreq Request) (*http.Response, error) {
+	resp, err := r.opts.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := Interaction{
+		Request: rreq,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     make(http.Header),
+			Body:       string(body),
+		},
+	}
+	for h, v := range resp.Header {
+		if h != "Date" && h != "Content-Length" {
+			i.Response.Header[h] = v
+		}
+	}
+
+	r.scrub.learn(i.Response.Body)
+	i.Request.URL = r.scrub.scrub(i.Request.URL)
+	i.Request.Body = r.scrub.scrub(i.Request.Body)
+	i.Response.Body = r.scrub.scrub(i.Response.Body)
+
+	b, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	r.n++
+	name := fmt.Sprintf("%04d-%s-%s.json", r.n, strings.SplitN(req.URL.Host, ".", 2)[0], operation(rreq))
+	err = ioutil.WriteFile(filepath.Join(r.dir, name), b, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// key returns the key of the request to find its interaction
+func (r Request) key() string {
+	var hs []string
+	for _, h := range requestHeaders {
+		hs = append(hs, r.Header.Get(h))
+	}
+
+	return strings.Join(append([]string{r.Method, r.URL, r.Body}, hs...), "\n")
+}
+
+// http returns the response to the req
+func (r Response) http(req *http.Request) *http.Response {
+	h := r.Header.Clone()
+	if h == nil {
+		h = make(http.Header)
+	}
+	h.Set("Content-Length", strconv.Itoa(len(r.Body)))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        h,
+		Body:          ioutil.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// actionParam is the action of the requests of the query protocol
+var actionParam = regexp.MustCompile(`(?:^|&)Action=([A-Za-z0-9]+)`)
+
+// operation returns the name of the AWS operation of the request, from
+// its action or target, or its HTTP method for the REST services
+func operation(r Request) string {
+	if m := actionParam.FindStringSubmatch(r.Body); m != nil {
+		return m[1]
+	}
+
+	if t := r.Header.Get("X-Amz-Target"); t != "" {
+		return t[strings.LastIndex(t, ".")+1:]
+	}
+
+	return strings.ToLower(r.Method)
+}
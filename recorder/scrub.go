@@ -0,0 +1,102 @@
+package recorder
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// callerAccount is the account ID of the credentials,
+	// on the response of sts:GetCallerIdentity
+	callerAccount = regexp.MustCompile(`<Account>(\d{12})</Account>`)
+
+	// accountIDs are the account IDs on the ARNs
+	// and on the owner fields of the responses
+	accountIDs = []*regexp.Regexp{
+		regexp.MustCompile(`arn:aws[a-z-]*:[a-z0-9-]*:[a-z0-9-]*:(\d{12}):`),
+		regexp.MustCompile(`<(?:ownerId|OwnerId|imageOwnerId|requesterId|userId|AccountId|accountId|OwnerAccount)>(\d{12})</`),
+		regexp.MustCompile(`"(?:OwnerId|AccountId|OwnerAccount)"\s*:\s*"(\d{12})"`),
+	}
+
+	// secrets are the credentials returned on the responses
+	secrets = []struct {
+		re   *regexp.Regexp
+		repl string
+	}{
+		{re: regexp.MustCompile(`<(SecretAccessKey|SessionToken)>[^<]*</`), repl: "<$1>REDACTED</"},
+		{re: regexp.MustCompile(`"(SecretAccessKey|SessionToken)"(\s*:\s*)"[^"]*"`), repl: `"$1"$2"REDACTED"`},
+	}
+
+	// accessKeyIDs are the IDs of the access keys
+	accessKeyIDs = regexp.MustCompile(`\b(?:AKIA|ASIA)[A-Z0-9]{16}\b`)
+)
+
+// scrubber replaces the credentials and account IDs
+// by fake ones, always the same for the same value
+type scrubber struct {
+	accounts   map[string]string
+	others     int
+	accessKeys map[string]string
+	extra      []string
+}
+
+// newScrubber returns a scrubber of the account IDs found
+// on the responses and of the extra ones
+func newScrubber(extra []string) *scrubber {
+	return &scrubber{
+		accounts:   make(map[string]string),
+		accessKeys: make(map[string]string),
+		extra:      extra,
+	}
+}
+
+// learn adds the account IDs found on body to the ones scrubbed,
+// where the one of the credentials is replaced by FakeAccountID
+func (s *scrubber) learn(body string) {
+	if m := callerAccount.FindStringSubmatch(body); m != nil {
+		s.accounts[m[1]] = FakeAccountID
+	}
+
+	ids := s.extra
+	for _, re := range accountIDs {
+		for _, m := range re.FindAllStringSubmatch(body, -1) {
+			ids = append(ids, m[1])
+		}
+	}
+
+	for _, id := range ids {
+		if _, ok := s.accounts[id]; ok {
+			continue
+		}
+
+		s.others++
+		n, _ := strconv.ParseInt(FakeAccountID, 10, 64)
+		s.accounts[id] = fmt.Sprintf("%012d", n+int64(s.others))
+	}
+}
+
+// scrub returns v with the credentials and
+// the account IDs replaced by fake ones
+func (s *scrubber) scrub(v string) string {
+	for _, sc := range secrets {
+		v = sc.re.ReplaceAllString(v, sc.repl)
+	}
+
+	v = accessKeyIDs.ReplaceAllStringFunc(v, func(k string) string {
+		f, ok := s.accessKeys[k]
+		if !ok {
+			f = fmt.Sprintf("%sEXAMPLE%09d", k[:4], len(s.accessKeys)+1)
+			s.accessKeys[k] = f
+		}
+		return f
+	})
+
+	var pairs []string
+	for id, f := range s.accounts {
+		pairs = append(pairs, id, f)
+	}
+
+	return strings.NewReplacer(pairs...).Replace(v)
+}
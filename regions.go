@@ -0,0 +1,55 @@
+package raws
+
+import "context"
+
+// regionsKey is the key of the regions of the contexts
+type regionsKey struct{}
+
+// WithRegions returns a copy of ctx with the regions, so the methods of the
+// AWSReader called with it only make their calls on them, instead of on all
+// the regions of the reader, like for the global services, as IAM, which
+// return the same on all the regions, or for the S3 buckets, which can only
+// be read on their own region.
+// The regions which aren't regions of the reader are ignored, and no
+// regions makes the methods call all the regions again.
+func WithRegions(ctx context.Context, regions ...string) context.Context {
+	return context.WithValue(ctx, regionsKey{}, regions)
+}
+
+// ContextRegions returns the regions, between the regions of an AWSReader,
+// on which its methods called with ctx make their calls, which are the ones
+// of WithRegions if ctx has them, or all the regions otherwise.
+// It's used by the implementations of the AWSReader.
+func ContextRegions(ctx context.Context, regions []string) []string {
+	only, _ := ctx.Value(regionsKey{}).([]string)
+	if len(only) == 0 {
+		return regions
+	}
+
+	var rs []string
+	for _, r := range regions {
+		if containsString(only, r) {
+			rs = append(rs, r)
+		}
+	}
+
+	return rs
+}
+
+// services returns the serviceConnectors of the
+// regions on which the calls are made with ctx
+func (c *connector) services(ctx context.Context) []*serviceConnector {
+	only, _ := ctx.Value(regionsKey{}).([]string)
+	if len(only) == 0 {
+		return c.svcs
+	}
+
+	var svcs []*serviceConnector
+	for _, svc := range c.svcs {
+		if containsString(only, svc.region) {
+			svcs = append(svcs, svc)
+		}
+	}
+
+	return svcs
+}
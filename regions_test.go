@@ -0,0 +1,65 @@
+package raws
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestWithRegions(t *testing.T) {
+	var (
+		opt = &ec2.DescribeInstancesOutput{}
+		c   = &connector{
+			regions: []string{"eu-west-1", "eu-west-3", "us-east-1"},
+			svcs: []*serviceConnector{
+				{region: "eu-west-1", ec2: mockEC2{dio: opt}},
+				{region: "eu-west-3", ec2: mockEC2{dio: opt}},
+				{region: "us-east-1", ec2: mockEC2{dio: opt}},
+			},
+			accountID: aws.String("123"),
+		}
+	)
+
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		expectedRegions []string
+	}{
+		{name: "all the regions",
+			ctx:             context.Background(),
+			expectedRegions: []string{"eu-west-1", "eu-west-3", "us-east-1"},
+		},
+		{name: "no regions",
+			ctx:             WithRegions(context.Background()),
+			expectedRegions: []string{"eu-west-1", "eu-west-3", "us-east-1"},
+		},
+		{name: "some regions",
+			ctx:             WithRegions(context.Background(), "us-east-1", "eu-west-3", "eu-central-1"),
+			expectedRegions: []string{"eu-west-3", "us-east-1"},
+		},
+	}
+
+	for i, tt := range tests {
+		if rs := ContextRegions(tt.ctx, c.GetRegions()); !reflect.DeepEqual(rs, tt.expectedRegions) {
+			t.Errorf("%s [%d] - context regions: received=%+v | expected=%+v", tt.name, i, rs, tt.expectedRegions)
+		}
+
+		opts, err := c.GetInstances(tt.ctx, nil)
+		if err != nil {
+			t.Errorf("%s [%d] - errors: received=%+v | expected=nil", tt.name, i, err)
+		}
+
+		var regions []string
+		for r := range opts {
+			regions = append(regions, r)
+		}
+		sort.Strings(regions)
+		if !reflect.DeepEqual(regions, tt.expectedRegions) {
+			t.Errorf("%s [%d] - regions: received=%+v | expected=%+v", tt.name, i, regions, tt.expectedRegions)
+		}
+	}
+}
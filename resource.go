@@ -0,0 +1,597 @@
+package raws
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53resolver"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/ses"
+)
+
+// Resource is the normalized representation of any of the items returned by
+// the AWSReader, so all of them can be handled in the same way, no matter
+// the service they belong to.
+//
+// The fields which aren't known for the item, like the ARN of the resources
+// which don't have one, are left empty. Region is empty for the resources of
+// global services (IAM, Route 53 and CloudFront) as they are the same in all
+// the regions.
+type Resource struct {
+	ARN       string            `json:"arn,omitempty"`
+	Service   string            `json:"service"`
+	Type      string            `json:"type"`
+	ID        string            `json:"id"`
+	Name      string            `json:"name,omitempty"`
+	Region    string            `json:"region,omitempty"`
+	AccountID string            `json:"account_id,omitempty"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	CreatedAt *time.Time        `json:"created_at,omitempty"`
+
+	// Raw is the item the Resource has been built from, like
+	// an *ec2.Instance or a LoadBalancerWithTags
+	Raw interface{} `json:"raw,omitempty"`
+}
+
+// MarshalJSON marshals r with the nil fields of the
+// Raw removed, as the SDK items have most of them nil
+func (r Resource) MarshalJSON() ([]byte, error) {
+	type resource Resource
+
+	if r.Raw != nil {
+		b, err := json.Marshal(r.Raw)
+		if err != nil {
+			return nil, err
+		}
+
+		var raw interface{}
+		err = json.Unmarshal(b, &raw)
+		if err != nil {
+			return nil, err
+		}
+		r.Raw = withoutNulls(raw)
+	}
+
+	return json.Marshal(resource(r))
+}
+
+// withoutNulls removes recursively the nil values
+// of the maps of v, which is a generic JSON value
+func withoutNulls(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		for k, e := range vv {
+			if e == nil {
+				delete(vv, k)
+				continue
+			}
+			vv[k] = withoutNulls(e)
+		}
+	case []interface{}:
+		for i, e := range vv {
+			vv[i] = withoutNulls(e)
+		}
+	}
+
+	return v
+}
+
+// Key returns the value identifying r between all the resources of
+// all the accounts, which is the ARN when it has one or otherwise the
+// service, type, account, region and ID joined by slashes.
+func (r Resource) Key() string {
+	if r.ARN != "" {
+		return r.ARN
+	}
+
+	return strings.Join([]string{r.Service, r.Type, r.AccountID, r.Region, r.ID}, "/")
+}
+
+// NewResource converts v, which must be one of the items returned by the
+// AWSReader methods, to a Resource of the accountID in the region where it
+// has been found.
+// v can be the SDK item, like an *ec2.Instance, or one of the types returned
+// by the methods with follow-up calls, like a LoadBalancerWithTags, in which
+// case the tags are also set.
+// The record sets should be given as HostedZoneRecordSet, as the ID of a
+// *route53.ResourceRecordSet alone doesn't have its hosted zone, so it's
+// the same for the records with the same name and type on different zones.
+// An error is returned if v is not a supported item.
+func NewResource(accountID, region string, v interface{}) (Resource, error) {
+	r := Resource{
+		AccountID: accountID,
+		Region:    region,
+		Raw:       v,
+	}
+
+	switch i := v.(type) {
+	// ec2
+	case *ec2.Instance:
+		r.Service, r.Type, r.ID = "ec2", "instance", aws.StringValue(i.InstanceId)
+		r.ARN = ec2ARN(region, accountID, r.Type, r.ID)
+		r.Tags = tagsOf(i.Tags)
+		r.CreatedAt = i.LaunchTime
+	case *ec2.Vpc:
+		r.Service, r.Type, r.ID = "ec2", "vpc", aws.StringValue(i.VpcId)
+		r.AccountID = stringOr(i.OwnerId, accountID)
+		r.ARN = ec2ARN(region, r.AccountID, r.Type, r.ID)
+		r.Tags = tagsOf(i.Tags)
+	case *ec2.Image:
+		r.Service, r.Type, r.ID = "ec2", "image", aws.StringValue(i.ImageId)
+		r.AccountID = stringOr(i.OwnerId, accountID)
+		r.ARN = ec2ARN(region, "", r.Type, r.ID)
+		r.Name = aws.StringValue(i.Name)
+		r.Tags = tagsOf(i.Tags)
+		if t, err := time.Parse(time.RFC3339, aws.StringValue(i.CreationDate)); err == nil {
+			r.CreatedAt = &t
+		}
+	case *ec2.SecurityGroup:
+		r.Service, r.Type, r.ID = "ec2", "security-group", aws.StringValue(i.GroupId)
+		r.AccountID = stringOr(i.OwnerId, accountID)
+		r.ARN = ec2ARN(region, r.AccountID, r.Type, r.ID)
+		r.Name = aws.StringValue(i.GroupName)
+		r.Tags = tagsOf(i.Tags)
+	case *ec2.Subnet:
+		r.Service, r.Type, r.ID = "ec2", "subnet", aws.StringValue(i.SubnetId)
+		r.AccountID = stringOr(i.OwnerId, accountID)
+		r.ARN = stringOr(i.SubnetArn, ec2ARN(region, r.AccountID, r.Type, r.ID))
+		r.Tags = tagsOf(i.Tags)
+	case *ec2.Volume:
+		r.Service, r.Type, r.ID = "ec2", "volume", aws.StringValue(i.VolumeId)
+		r.ARN = ec2ARN(region, accountID, r.Type, r.ID)
+		r.Tags = tagsOf(i.Tags)
+		r.CreatedAt = i.CreateTime
+	case *ec2.Snapshot:
+		r.Service, r.Type, r.ID = "ec2", "snapshot", aws.StringValue(i.SnapshotId)
+		r.AccountID = stringOr(i.OwnerId, accountID)
+		r.ARN = ec2ARN(region, "", r.Type, r.ID)
+		r.Tags = tagsOf(i.Tags)
+		r.CreatedAt = i.StartTime
+	case *ec2.LaunchTemplate:
+		r.Service, r.Type, r.ID = "ec2", "launch-template", aws.StringValue(i.LaunchTemplateId)
+		r.ARN = ec2ARN(region, accountID, r.Type, r.ID)
+		r.Name = aws.StringValue(i.LaunchTemplateName)
+		r.Tags = tagsOf(i.Tags)
+		r.CreatedAt = i.CreateTime
+
+	// autoscaling
+	case *autoscaling.Group:
+		r.Service, r.Type, r.ID = "autoscaling", "auto-scaling-group", aws.StringValue(i.AutoScalingGroupName)
+		r.ARN = aws.StringValue(i.AutoScalingGroupARN)
+		r.Name = r.ID
+		r.Tags = tagsOf(i.Tags)
+		r.CreatedAt = i.CreatedTime
+	case *autoscaling.LaunchConfiguration:
+		r.Service, r.Type, r.ID = "autoscaling", "launch-configuration", aws.StringValue(i.LaunchConfigurationName)
+		r.ARN = aws.StringValue(i.LaunchConfigurationARN)
+		r.Name = r.ID
+		r.CreatedAt = i.CreatedTime
+
+	// elasticache
+	case *elasticache.CacheCluster:
+		r.Service, r.Type, r.ID = "elasticache", "cache-cluster", aws.StringValue(i.CacheClusterId)
+		r.ARN = fmt.Sprintf("arn:%s:elasticache:%s:%s:cluster:%s", partition(region), region, accountID, r.ID)
+		r.Name = r.ID
+		r.CreatedAt = i.CacheClusterCreateTime
+
+	// elb
+	case *elb.LoadBalancerDescription:
+		r.Service, r.Type, r.ID = "elb", "load-balancer", aws.StringValue(i.LoadBalancerName)
+		r.ARN = fmt.Sprintf("arn:%s:elasticloadbalancing:%s:%s:loadbalancer/%s", partition(region), region, accountID, r.ID)
+		r.Name = r.ID
+		r.CreatedAt = i.CreatedTime
+	case LoadBalancerWithTags:
+		return withTags(accountID, region, v, i.Item, i.Tags)
+
+	// elbv2
+	case *elbv2.LoadBalancer:
+		r.Service, r.Type, r.ID = "elbv2", "load-balancer", aws.StringValue(i.LoadBalancerArn)
+		r.ARN = r.ID
+		r.Name = aws.StringValue(i.LoadBalancerName)
+		r.CreatedAt = i.CreatedTime
+	case LoadBalancerV2WithTags:
+		return withTags(accountID, region, v, i.Item, i.Tags)
+
+	// rds
+	case *rds.DBInstance:
+		r.Service, r.Type, r.ID = "rds", "db-instance", aws.StringValue(i.DBInstanceIdentifier)
+		r.ARN = aws.StringValue(i.DBInstanceArn)
+		r.Name = r.ID
+		r.CreatedAt = i.InstanceCreateTime
+	case DBInstanceWithTags:
+		var tags interface{}
+		if i.Tags != nil {
+			tags = i.Tags.TagList
+		}
+		return withTags(accountID, region, v, i.Item, tags)
+
+	// s3
+	case *s3.Bucket:
+		r.Service, r.Type, r.ID = "s3", "bucket", aws.StringValue(i.Name)
+		r.ARN = fmt.Sprintf("arn:%s:s3:::%s", partition(region), r.ID)
+		r.Name = r.ID
+		r.CreatedAt = i.CreationDate
+
+	// cloudfront
+	case *cloudfront.DistributionSummary:
+		r.Service, r.Type, r.ID = "cloudfront", "distribution", aws.StringValue(i.Id)
+		r.ARN = aws.StringValue(i.ARN)
+		r.Name = aws.StringValue(i.DomainName)
+	case *cloudfront.PublicKeySummary:
+		r.Service, r.Type, r.ID = "cloudfront", "public-key", aws.StringValue(i.Id)
+		r.Name = aws.StringValue(i.Name)
+		r.CreatedAt = i.CreatedTime
+	case *cloudfront.OriginAccessIdentitySummary:
+		r.Service, r.Type, r.ID = "cloudfront", "origin-access-identity", aws.StringValue(i.Id)
+		r.Name = aws.StringValue(i.Comment)
+
+	// iam
+	case *iam.AccessKeyMetadata:
+		r.Service, r.Type, r.ID = "iam", "access-key", aws.StringValue(i.AccessKeyId)
+		r.Name = aws.StringValue(i.UserName)
+		r.CreatedAt = i.CreateDate
+	case *iam.Group:
+		r.Service, r.Type, r.ID = "iam", "group", aws.StringValue(i.GroupId)
+		r.ARN = aws.StringValue(i.Arn)
+		r.Name = aws.StringValue(i.GroupName)
+		r.CreatedAt = i.CreateDate
+	case GroupWithPolicies:
+		return withTags(accountID, region, v, i.Item, nil)
+	case *iam.InstanceProfile:
+		r.Service, r.Type, r.ID = "iam", "instance-profile", aws.StringValue(i.InstanceProfileId)
+		r.ARN = aws.StringValue(i.Arn)
+		r.Name = aws.StringValue(i.InstanceProfileName)
+		r.CreatedAt = i.CreateDate
+	case *iam.OpenIDConnectProviderListEntry:
+		r.Service, r.Type, r.ID = "iam", "open-id-connect-provider", aws.StringValue(i.Arn)
+		r.ARN = r.ID
+	case *iam.Policy:
+		r.Service, r.Type, r.ID = "iam", "policy", aws.StringValue(i.PolicyId)
+		r.ARN = aws.StringValue(i.Arn)
+		r.Name = aws.StringValue(i.PolicyName)
+		r.CreatedAt = i.CreateDate
+	case *iam.Role:
+		r.Service, r.Type, r.ID = "iam", "role", aws.StringValue(i.RoleId)
+		r.ARN = aws.StringValue(i.Arn)
+		r.Name = aws.StringValue(i.RoleName)
+		r.Tags = tagsOf(i.Tags)
+		r.CreatedAt = i.CreateDate
+	case RoleWithPolicies:
+		return withTags(accountID, region, v, i.Item, nil)
+	case *iam.SAMLProviderListEntry:
+		r.Service, r.Type, r.ID = "iam", "saml-provider", aws.StringValue(i.Arn)
+		r.ARN = r.ID
+		r.CreatedAt = i.CreateDate
+	case *iam.ServerCertificateMetadata:
+		r.Service, r.Type, r.ID = "iam", "server-certificate", aws.StringValue(i.ServerCertificateId)
+		r.ARN = aws.StringValue(i.Arn)
+		r.Name = aws.StringValue(i.ServerCertificateName)
+		r.CreatedAt = i.UploadDate
+	case *iam.User:
+		r.Service, r.Type, r.ID = "iam", "user", aws.StringValue(i.UserId)
+		r.ARN = aws.StringValue(i.Arn)
+		r.Name = aws.StringValue(i.UserName)
+		r.Tags = tagsOf(i.Tags)
+		r.CreatedAt = i.CreateDate
+	case UserWithPolicies:
+		return withTags(accountID, region, v, i.Item, nil)
+
+	// ses
+	case *ses.ReceiptFilter:
+		r.Service, r.Type, r.ID = "ses", "receipt-filter", aws.StringValue(i.Name)
+		r.Name = r.ID
+	case *ses.ConfigurationSet:
+		r.Service, r.Type, r.ID = "ses", "configuration-set", aws.StringValue(i.Name)
+		r.ARN = fmt.Sprintf("arn:%s:ses:%s:%s:configuration-set/%s", partition(region), region, accountID, r.ID)
+		r.Name = r.ID
+	case *ses.TemplateMetadata:
+		r.Service, r.Type, r.ID = "ses", "template", aws.StringValue(i.Name)
+		r.ARN = fmt.Sprintf("arn:%s:ses:%s:%s:template/%s", partition(region), region, accountID, r.ID)
+		r.Name = r.ID
+		r.CreatedAt = i.CreatedTimestamp
+
+	// route53
+	case *route53.DelegationSet:
+		r.Service, r.Type, r.ID = "route53", "delegation-set", strings.TrimPrefix(aws.StringValue(i.Id), "/delegationset/")
+	case *route53.HealthCheck:
+		r.Service, r.Type, r.ID = "route53", "health-check", aws.StringValue(i.Id)
+		r.ARN = fmt.Sprintf("arn:%s:route53:::healthcheck/%s", partition(region), r.ID)
+	case *route53.QueryLoggingConfig:
+		r.Service, r.Type, r.ID = "route53", "query-logging-config", aws.StringValue(i.Id)
+	case *route53.ResourceRecordSet:
+		r.Service, r.Type = "route53", "record-set"
+		r.ID = strings.Join([]string{aws.StringValue(i.Name), aws.StringValue(i.Type), aws.StringValue(i.SetIdentifier)}, "_")
+		r.ID = strings.TrimSuffix(r.ID, "_")
+		r.Name = aws.StringValue(i.Name)
+	case HostedZoneRecordSet:
+		r, err := NewResource(accountID, region, i.Item)
+		if err != nil {
+			return Resource{}, err
+		}
+		r.ID = strings.TrimPrefix(i.HostedZoneID, "/hostedzone/") + "_" + r.ID
+		r.Raw = v
+
+		return r, nil
+	case *route53.HostedZone:
+		r.Service, r.Type, r.ID = "route53", "hosted-zone", strings.TrimPrefix(aws.StringValue(i.Id), "/hostedzone/")
+		r.ARN = fmt.Sprintf("arn:%s:route53:::hostedzone/%s", partition(region), r.ID)
+		r.Name = aws.StringValue(i.Name)
+
+	// route53resolver
+	case *route53resolver.ResolverEndpoint:
+		r.Service, r.Type, r.ID = "route53resolver", "resolver-endpoint", aws.StringValue(i.Id)
+		r.ARN = aws.StringValue(i.Arn)
+		r.Name = aws.StringValue(i.Name)
+		if t, err := time.Parse(time.RFC3339, aws.StringValue(i.CreationTime)); err == nil {
+			r.CreatedAt = &t
+		}
+	case *route53resolver.ResolverRule:
+		r.Service, r.Type, r.ID = "route53resolver", "resolver-rule", aws.StringValue(i.Id)
+		r.AccountID = stringOr(i.OwnerId, accountID)
+		r.ARN = aws.StringValue(i.Arn)
+		r.Name = aws.StringValue(i.Name)
+	case *route53resolver.ResolverRuleAssociation:
+		r.Service, r.Type, r.ID = "route53resolver", "resolver-rule-association", aws.StringValue(i.Id)
+		r.Name = aws.StringValue(i.Name)
+
+	// resourcegroupstaggingapi
+	case *resourcegroupstaggingapi.ResourceTagMapping:
+		a, err := arn.Parse(aws.StringValue(i.ResourceARN))
+		if err != nil {
+			return Resource{}, err
+		}
+		r.ARN = a.String()
+		r.Service, r.Type, r.ID = arnResource(a)
+		r.Tags = tagsOf(i.Tags)
+		if a.Region != "" {
+			r.Region = a.Region
+		}
+		if a.AccountID != "" {
+			r.AccountID = a.AccountID
+		}
+
+	default:
+		return Resource{}, fmt.Errorf("the type %T can not be converted to a Resource", v)
+	}
+
+	if r.Name == "" {
+		r.Name = r.Tags["Name"]
+	}
+
+	if isGlobal(r.Service) {
+		r.Region = ""
+	}
+
+	return r, nil
+}
+
+// NewResources converts all the items to Resources of the accountID.
+// items can be the slice returned by any of the ListAll functions, like
+// []RegionInstance, or the map returned by the methods with follow-up
+// calls, like map[string][]LoadBalancerWithTags.
+// The resources of the global services are only returned once, even if
+// they have been returned for all the regions.
+// An error is returned if items or any of its items is not supported.
+func NewResources(accountID string, items interface{}) ([]Resource, error) {
+	var (
+		res  []Resource
+		seen = make(map[string]bool)
+	)
+
+	add := func(region string, v interface{}) error {
+		r, err := NewResource(accountID, region, v)
+		if err != nil {
+			return err
+		}
+
+		if seen[r.Key()] {
+			return nil
+		}
+		seen[r.Key()] = true
+		res = append(res, r)
+
+		return nil
+	}
+
+	iv := reflect.ValueOf(items)
+	switch iv.Kind() {
+	case reflect.Slice:
+		for i := 0; i < iv.Len(); i++ {
+			e := iv.Index(i)
+			if e.Kind() != reflect.Struct || !e.FieldByName("Region").IsValid() || !e.FieldByName("Item").IsValid() {
+				return nil, fmt.Errorf("the type %T can not be converted to Resources", items)
+			}
+
+			err := add(e.FieldByName("Region").String(), e.FieldByName("Item").Interface())
+			if err != nil {
+				return nil, err
+			}
+		}
+	case reflect.Map:
+		if iv.Type().Key().Kind() != reflect.String || iv.Type().Elem().Kind() != reflect.Slice {
+			return nil, fmt.Errorf("the type %T can not be converted to Resources", items)
+		}
+
+		regions := make([]string, 0, iv.Len())
+		for _, k := range iv.MapKeys() {
+			regions = append(regions, k.String())
+		}
+		sort.Strings(regions)
+
+		for _, region := range regions {
+			l := iv.MapIndex(reflect.ValueOf(region))
+			for i := 0; i < l.Len(); i++ {
+				err := add(region, l.Index(i).Interface())
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("the type %T can not be converted to Resources", items)
+	}
+
+	return res, nil
+}
+
+// withTags converts item to a Resource setting v as the Raw and
+// the tags, if it's not nil, as the Tags of the Resource
+func withTags(accountID, region string, v, item, tags interface{}) (Resource, error) {
+	r, err := NewResource(accountID, region, item)
+	if err != nil {
+		return Resource{}, err
+	}
+
+	r.Raw = v
+	if t := tagsOf(tags); t != nil {
+		r.Tags = t
+		if r.Name == "" {
+			r.Name = t["Name"]
+		}
+	}
+
+	return r, nil
+}
+
+// tagsOf returns the tags of l, which can be a list of any of the
+// SDK tag types, which all have the Key and Value fields, or a
+// struct with a Tags field with that list, like elb.TagDescription.
+// It returns nil if there are no tags
+func tagsOf(l interface{}) map[string]string {
+	var tags map[string]string
+
+	v := reflect.Indirect(reflect.ValueOf(l))
+	if v.Kind() == reflect.Struct {
+		v = v.FieldByName("Tags")
+	}
+
+	if v.Kind() != reflect.Slice {
+		return nil
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		t := reflect.Indirect(v.Index(i))
+		if t.Kind() != reflect.Struct {
+			continue
+		}
+
+		kf, vf := t.FieldByName("Key"), t.FieldByName("Value")
+		if !kf.IsValid() || !vf.IsValid() {
+			continue
+		}
+
+		k, ok := kf.Interface().(*string)
+		if !ok || k == nil {
+			continue
+		}
+		val, _ := vf.Interface().(*string)
+
+		if tags == nil {
+			tags = make(map[string]string)
+		}
+		tags[*k] = aws.StringValue(val)
+	}
+
+	return tags
+}
+
+// arnTypes are the types of the Resources by the service and
+// the type of the resource on their ARN, when they are different
+var arnTypes = map[string]string{
+	"elasticache:cluster": "cache-cluster",
+	"rds:db":              "db-instance",
+	"route53:healthcheck": "health-check",
+	"route53:hostedzone":  "hosted-zone",
+}
+
+// arnResource returns the service, type and ID of the Resource
+// of the ARN a, which are the same ones than the Resources built
+// from the items of the services
+func arnResource(a arn.ARN) (string, string, string) {
+	i := strings.IndexAny(a.Resource, "/:")
+	if i < 0 {
+		// The buckets are the only S3
+		// resources without a type
+		if a.Service == "s3" {
+			return "s3", "bucket", a.Resource
+		}
+		return a.Service, "", a.Resource
+	}
+
+	typ, id := a.Resource[:i], a.Resource[i+1:]
+	switch {
+	case a.Service == "elasticloadbalancing" && typ == "loadbalancer":
+		if strings.HasPrefix(id, "app/") || strings.HasPrefix(id, "net/") {
+			return "elbv2", "load-balancer", a.String()
+		}
+		return "elb", "load-balancer", id
+	case a.Service == "elasticloadbalancing" && typ == "targetgroup":
+		return "elbv2", "target-group", a.String()
+	case a.Service == "autoscaling" && typ == "autoScalingGroup":
+		const name = "autoScalingGroupName/"
+		if j := strings.Index(id, name); j >= 0 {
+			id = id[j+len(name):]
+		}
+		return "autoscaling", "auto-scaling-group", id
+	}
+
+	if t, ok := arnTypes[a.Service+":"+typ]; ok {
+		typ = t
+	}
+
+	return a.Service, typ, id
+}
+
+// partition returns the partition of the region, like "aws-cn" for
+// "cn-north-1", which is "aws" for the empty or unknown regions
+func partition(region string) string {
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		return p.ID()
+	}
+
+	return endpoints.AwsPartitionID
+}
+
+// ec2ARN returns the ARN of the EC2 resource of the type and ID, which
+// the EC2 items don't have, so it's the same one than the ARN of the
+// resource on the Resource Groups Tagging API. The images and the
+// snapshots don't have the accountID on their ARN
+func ec2ARN(region, accountID, typ, id string) string {
+	return fmt.Sprintf("arn:%s:ec2:%s:%s:%s/%s", partition(region), region, accountID, typ, id)
+}
+
+// stringOr returns the value of s if it's not empty or d otherwise
+func stringOr(s *string, d string) string {
+	if v := aws.StringValue(s); v != "" {
+		return v
+	}
+
+	return d
+}
+
+// isGlobal returns if the service is not regional
+func isGlobal(service string) bool {
+	switch service {
+	case "iam", "route53", "cloudfront":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,318 @@
+package raws
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+func TestNewResource(t *testing.T) {
+	var (
+		now = time.Date(2019, 6, 7, 10, 0, 0, 0, time.UTC)
+		i1  = &ec2.Instance{
+			InstanceId: aws.String("i-1"),
+			LaunchTime: &now,
+			Tags: []*ec2.Tag{
+				{Key: aws.String("Name"), Value: aws.String("web")},
+				{Key: aws.String("env"), Value: aws.String("prod")},
+			},
+		}
+		img = &ec2.Image{
+			ImageId:      aws.String("ami-1"),
+			OwnerId:      aws.String("099720109477"),
+			Name:         aws.String("ubuntu"),
+			CreationDate: aws.String("2019-06-07T10:00:00.000Z"),
+		}
+		lb = &elb.LoadBalancerDescription{LoadBalancerName: aws.String("lb-1")}
+		db = &rds.DBInstance{
+			DBInstanceIdentifier: aws.String("db-1"),
+			DBInstanceArn:        aws.String("arn:aws:rds:eu-west-1:123:db:db-1"),
+		}
+		role = &iam.Role{
+			RoleId:   aws.String("AROA1"),
+			RoleName: aws.String("admin"),
+			Arn:      aws.String("arn:aws:iam::123:role/admin"),
+		}
+		hz = &route53.HostedZone{Id: aws.String("/hostedzone/Z1"), Name: aws.String("example.com.")}
+		rs = &route53.ResourceRecordSet{Name: aws.String("www.example.com."), Type: aws.String("A")}
+		tm = &resourcegroupstaggingapi.ResourceTagMapping{
+			ResourceARN: aws.String("arn:aws:elasticloadbalancing:eu-west-3:456:loadbalancer/app/lb-1/50dc6c495c0c9188"),
+			Tags:        []*resourcegroupstaggingapi.Tag{{Key: aws.String("env"), Value: aws.String("prod")}},
+		}
+		bm = &resourcegroupstaggingapi.ResourceTagMapping{ResourceARN: aws.String("arn:aws:s3:::bucket-1")}
+	)
+
+	tests := []struct {
+		name             string
+		input            interface{}
+		expectedResource Resource
+		expectedError    bool
+	}{
+		{name: "tags and name from the tags",
+			input: i1,
+			expectedResource: Resource{
+				ARN:       "arn:aws:ec2:eu-west-1:123:instance/i-1",
+				Service:   "ec2",
+				Type:      "instance",
+				ID:        "i-1",
+				Name:      "web",
+				Region:    "eu-west-1",
+				AccountID: "123",
+				Tags:      map[string]string{"Name": "web", "env": "prod"},
+				CreatedAt: &now,
+				Raw:       i1,
+			},
+		},
+		{name: "owner of the item",
+			input: img,
+			expectedResource: Resource{
+				ARN:       "arn:aws:ec2:eu-west-1::image/ami-1",
+				Service:   "ec2",
+				Type:      "image",
+				ID:        "ami-1",
+				Name:      "ubuntu",
+				Region:    "eu-west-1",
+				AccountID: "099720109477",
+				CreatedAt: &now,
+				Raw:       img,
+			},
+		},
+		{name: "with tags",
+			input: LoadBalancerWithTags{
+				Item: lb,
+				Tags: &elb.TagDescription{Tags: []*elb.Tag{{Key: aws.String("env"), Value: aws.String("prod")}}},
+			},
+			expectedResource: Resource{
+				ARN:       "arn:aws:elasticloadbalancing:eu-west-1:123:loadbalancer/lb-1",
+				Service:   "elb",
+				Type:      "load-balancer",
+				ID:        "lb-1",
+				Name:      "lb-1",
+				Region:    "eu-west-1",
+				AccountID: "123",
+				Tags:      map[string]string{"env": "prod"},
+				Raw: LoadBalancerWithTags{
+					Item: lb,
+					Tags: &elb.TagDescription{Tags: []*elb.Tag{{Key: aws.String("env"), Value: aws.String("prod")}}},
+				},
+			},
+		},
+		{name: "with tags without tags",
+			input: DBInstanceWithTags{Item: db},
+			expectedResource: Resource{
+				ARN:       "arn:aws:rds:eu-west-1:123:db:db-1",
+				Service:   "rds",
+				Type:      "db-instance",
+				ID:        "db-1",
+				Name:      "db-1",
+				Region:    "eu-west-1",
+				AccountID: "123",
+				Raw:       DBInstanceWithTags{Item: db},
+			},
+		},
+		{name: "global service",
+			input: role,
+			expectedResource: Resource{
+				ARN:       "arn:aws:iam::123:role/admin",
+				Service:   "iam",
+				Type:      "role",
+				ID:        "AROA1",
+				Name:      "admin",
+				AccountID: "123",
+				Raw:       role,
+			},
+		},
+		{name: "prefixed ID",
+			input: hz,
+			expectedResource: Resource{
+				ARN:       "arn:aws:route53:::hostedzone/Z1",
+				Service:   "route53",
+				Type:      "hosted-zone",
+				ID:        "Z1",
+				Name:      "example.com.",
+				AccountID: "123",
+				Raw:       hz,
+			},
+		},
+		{name: "record set",
+			input: rs,
+			expectedResource: Resource{
+				Service:   "route53",
+				Type:      "record-set",
+				ID:        "www.example.com._A",
+				Name:      "www.example.com.",
+				AccountID: "123",
+				Raw:       rs,
+			},
+		},
+		{name: "record set of a hosted zone",
+			input: HostedZoneRecordSet{HostedZoneID: "/hostedzone/Z1", Item: rs},
+			expectedResource: Resource{
+				Service:   "route53",
+				Type:      "record-set",
+				ID:        "Z1_www.example.com._A",
+				Name:      "www.example.com.",
+				AccountID: "123",
+				Raw:       HostedZoneRecordSet{HostedZoneID: "/hostedzone/Z1", Item: rs},
+			},
+		},
+		{name: "from the ARN",
+			input: tm,
+			expectedResource: Resource{
+				ARN:       "arn:aws:elasticloadbalancing:eu-west-3:456:loadbalancer/app/lb-1/50dc6c495c0c9188",
+				Service:   "elbv2",
+				Type:      "load-balancer",
+				ID:        "arn:aws:elasticloadbalancing:eu-west-3:456:loadbalancer/app/lb-1/50dc6c495c0c9188",
+				Region:    "eu-west-3",
+				AccountID: "456",
+				Tags:      map[string]string{"env": "prod"},
+				Raw:       tm,
+			},
+		},
+		{name: "from the ARN without region",
+			input: bm,
+			expectedResource: Resource{
+				ARN:       "arn:aws:s3:::bucket-1",
+				Service:   "s3",
+				Type:      "bucket",
+				ID:        "bucket-1",
+				Region:    "eu-west-1",
+				AccountID: "123",
+				Raw:       bm,
+			},
+		},
+		{name: "unsupported",
+			input:         &s3.Object{Key: aws.String("key")},
+			expectedError: true,
+		}}
+
+	for i, tt := range tests {
+		r, err := NewResource("123", "eu-west-1", tt.input)
+		if (err != nil) != tt.expectedError {
+			t.Errorf("%s [%d] - errors: received=%+v | expected=%+v", tt.name, i, err, tt.expectedError)
+		}
+
+		if !reflect.DeepEqual(r, tt.expectedResource) {
+			t.Errorf("%s [%d] - resource: received=%+v | expected=%+v",
+				tt.name, i, r, tt.expectedResource)
+		}
+	}
+}
+
+func TestNewResource_key(t *testing.T) {
+	tests := []struct {
+		name   string
+		region string
+		item   interface{}
+		arn    string
+	}{
+		{name: "ec2 instance",
+			region: "eu-west-1",
+			item:   &ec2.Instance{InstanceId: aws.String("i-1")},
+			arn:    "arn:aws:ec2:eu-west-1:123:instance/i-1",
+		},
+		{name: "ec2 image",
+			region: "eu-west-1",
+			item:   &ec2.Image{ImageId: aws.String("ami-1")},
+			arn:    "arn:aws:ec2:eu-west-1::image/ami-1",
+		},
+		{name: "elb on aws-us-gov",
+			region: "us-gov-west-1",
+			item:   &elb.LoadBalancerDescription{LoadBalancerName: aws.String("lb-1")},
+			arn:    "arn:aws-us-gov:elasticloadbalancing:us-gov-west-1:123:loadbalancer/lb-1",
+		},
+		{name: "s3 bucket on aws-cn",
+			region: "cn-north-1",
+			item:   &s3.Bucket{Name: aws.String("bucket-1")},
+			arn:    "arn:aws-cn:s3:::bucket-1",
+		},
+		{name: "hosted zone",
+			region: "eu-west-1",
+			item:   &route53.HostedZone{Id: aws.String("/hostedzone/Z1")},
+			arn:    "arn:aws:route53:::hostedzone/Z1",
+		},
+	}
+
+	for i, tt := range tests {
+		r, err := NewResource("123", tt.region, tt.item)
+		checkErrors(t, tt.name, i, err, nil)
+
+		tr, err := NewResource("123", tt.region, &resourcegroupstaggingapi.ResourceTagMapping{ResourceARN: aws.String(tt.arn)})
+		checkErrors(t, tt.name, i, err, nil)
+
+		if r.ARN != tt.arn {
+			t.Errorf("%s [%d] - arn: received=%+v | expected=%+v", tt.name, i, r.ARN, tt.arn)
+		}
+		if r.Key() != tr.Key() {
+			t.Errorf("%s [%d] - key: received=%+v | expected=%+v", tt.name, i, r.Key(), tr.Key())
+		}
+	}
+}
+
+func TestNewResources(t *testing.T) {
+	var (
+		i1   = &ec2.Instance{InstanceId: aws.String("i-1")}
+		i2   = &ec2.Instance{InstanceId: aws.String("i-2")}
+		role = &iam.Role{RoleId: aws.String("AROA1"), Arn: aws.String("arn:aws:iam::123:role/admin")}
+		rs   = &route53.ResourceRecordSet{Name: aws.String("www.example.com."), Type: aws.String("A")}
+	)
+
+	tests := []struct {
+		name          string
+		input         interface{}
+		expectedIDs   []string
+		expectedError bool
+	}{
+		{name: "ListAll items",
+			input: []RegionInstance{
+				{Region: "eu-west-1", Item: i1},
+				{Region: "eu-west-3", Item: i2},
+			},
+			expectedIDs: []string{"i-1", "i-2"},
+		},
+		{name: "global items only once",
+			input: map[string][]RoleWithPolicies{
+				"eu-west-1": {{Item: role}},
+				"eu-west-3": {{Item: role}},
+			},
+			expectedIDs: []string{"AROA1"},
+		},
+		{name: "record sets of split-horizon zones",
+			input: map[string][]HostedZoneRecordSet{
+				"eu-west-1": {
+					{HostedZoneID: "/hostedzone/Z1", Item: rs},
+					{HostedZoneID: "/hostedzone/Z2", Item: rs},
+				},
+			},
+			expectedIDs: []string{"Z1_www.example.com._A", "Z2_www.example.com._A"},
+		},
+		{name: "unsupported",
+			input:         ec2.DescribeInstancesOutput{},
+			expectedError: true,
+		}}
+
+	for i, tt := range tests {
+		res, err := NewResources("123", tt.input)
+		if (err != nil) != tt.expectedError {
+			t.Errorf("%s [%d] - errors: received=%+v | expected=%+v", tt.name, i, err, tt.expectedError)
+		}
+
+		var ids []string
+		for _, r := range res {
+			ids = append(ids, r.ID)
+		}
+		if !reflect.DeepEqual(ids, tt.expectedIDs) {
+			t.Errorf("%s [%d] - IDs: received=%+v | expected=%+v",
+				tt.name, i, ids, tt.expectedIDs)
+		}
+	}
+}
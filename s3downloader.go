@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 )
@@ -12,20 +14,35 @@ import (
 func (c *connector) DownloadObject(
 	ctx context.Context, w io.WriterAt, input *s3.GetObjectInput, options ...func(*s3manager.Downloader),
 ) (int64, error) {
-	var err error
-	var n int64
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "DownloadObject", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var err error
+		var n int64
 
-	if input.Bucket == nil || input.Key == nil {
-		return n, fmt.Errorf("couldn't download undefined object (keys or bucket not set)")
-	}
-	for _, svc := range c.svcs {
-		if svc.s3downloader == nil {
-			svc.s3downloader = s3manager.NewDownloader(svc.session)
+		if input.Bucket == nil || input.Key == nil {
+			return n, fmt.Errorf("couldn't download undefined object (keys or bucket not set)")
 		}
-		n, err = svc.s3downloader.DownloadWithContext(ctx, w, input, options...)
-		if err == nil {
-			return n, nil
+		var regions []string
+		for _, svc := range c.services(ctx) {
+			regions = append(regions, svc.region)
+			// The Output of the call is the number of bytes downloaded
+			call := &Call{Method: "DownloadObject", Operation: "GetObject", Service: s3.ServiceName, Region: svc.region, Input: input}
+			var out interface{}
+			out, err = c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				dopts := append([]func(*s3manager.Downloader){s3manager.WithDownloaderRequestOptions(opts...)}, options...)
+				return svc.s3downloader.DownloadWithContext(ctx, w, input.(*s3.GetObjectInput), dopts...)
+			})
+			n, _ = out.(int64)
+			if err == nil {
+				return n, nil
+			}
+			c.log().Info("object not downloaded from the region", "bucket", aws.StringValue(input.Bucket), "key", aws.StringValue(input.Key), "region", svc.region, "error", err)
 		}
-	}
-	return n, fmt.Errorf("couldn't download '%s/%s' in any of '%+v' regions", *input.Bucket, *input.Key, c.GetRegions())
+		c.log().Warn("object not downloaded from any region", "bucket", aws.StringValue(input.Bucket), "key", aws.StringValue(input.Key), "regions", regions)
+		return n, fmt.Errorf("couldn't download '%s/%s' in any of '%+v' regions", *input.Bucket, *input.Key, regions)
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	n, _ := out.(int64)
+
+	return n, err
 }
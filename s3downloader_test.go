@@ -33,6 +33,7 @@ func TestDownloadObjet(t *testing.T) {
 		name          string
 		mocked        []*serviceConnector
 		regions       []string
+		ctxRegions    []string
 		input         *s3.GetObjectInput
 		expectedBytes int64
 		expectedError error
@@ -41,6 +42,7 @@ func TestDownloadObjet(t *testing.T) {
 			name: "one region with error",
 			mocked: []*serviceConnector{
 				{
+					region: "test",
 					s3downloader: mockS3Downloader{
 						dob:   0,
 						doerr: errors.New("error with test"),
@@ -55,6 +57,31 @@ func TestDownloadObjet(t *testing.T) {
 			expectedError: fmt.Errorf("couldn't download 'bucket/key' in any of '[test]' regions"),
 			expectedBytes: 0,
 		},
+		{
+			name: "regions of the context with error",
+			mocked: []*serviceConnector{
+				{
+					region: "test-1",
+					s3downloader: mockS3Downloader{
+						doerr: errors.New("error with test"),
+					},
+				},
+				{
+					region: "test-2",
+					s3downloader: mockS3Downloader{
+						doerr: errors.New("error with test"),
+					},
+				},
+			},
+			regions:    []string{"test-1", "test-2"},
+			ctxRegions: []string{"test-2"},
+			input: &s3.GetObjectInput{
+				Bucket: aws.String("bucket"),
+				Key:    aws.String("key"),
+			},
+			expectedError: fmt.Errorf("couldn't download 'bucket/key' in any of '[test-2]' regions"),
+			expectedBytes: 0,
+		},
 		{
 			name:    "invalid file requested",
 			mocked:  nil,
@@ -93,7 +120,7 @@ func TestDownloadObjet(t *testing.T) {
 			regions: tt.regions,
 			svcs:    tt.mocked,
 		}
-		bytes, err := c.DownloadObject(ctx, nil, tt.input, nil)
+		bytes, err := c.DownloadObject(WithRegions(ctx, tt.ctxRegions...), nil, tt.input, nil)
 		checkErrors(t, tt.name, i, err, tt.expectedError)
 		if tt.expectedBytes != bytes {
 			t.Errorf("%s [%d] - S3 download object: received=%+v | expected=%+v",
@@ -0,0 +1,324 @@
+package security
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/cycloidio/raws"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// CustomRule is the declarative definition of a Rule, which is applied to
+// each one of the resources selected by Resources and Where, and has a
+// finding on the ones for which Assert is false.
+//
+// The conditions are expressions over the JSON of the raws.Resource, like
+// `raw.State.Name == "running" and tags.env != "prod"`, where:
+//
+//   - the paths, separated by dots, are the values of the JSON of the
+//     resource, like id, tags.env or raw.VpcId, where the lists are walked
+//     element by element, so raw.SecurityGroups.GroupId are the IDs of all
+//     the security groups. A path without value is null
+//   - the comparisons, ==, !=, <, <=, >, >=, =~ (a regular expression) and
+//     in (a list, like ["a", "b"]), are true if any of the values on the left
+//     compares to any of the values on the right, but != which is true if
+//     none is equal
+//   - and, or, not (or &&, ||, !) and parentheses combine the conditions
+//   - the functions are exists(path), len(path), lower(path),
+//     days_since(date), which is the number of days since the date, and
+//     any(list, condition) and all(list, condition), which are true if the
+//     condition is true for any or all the elements of the list, to which
+//     the paths of the condition are relative: @ is the element and $ the
+//     resource, like any(raw.IpPermissions, FromPort <= 22 and ToPort >= 22)
+//
+// The Message can have expressions between braces which are replaced by
+// their values, like "the instance {id} has the public IP {raw.PublicIpAddress}".
+type CustomRule struct {
+	ID       string `json:"id" yaml:"id"`
+	Title    string `json:"title" yaml:"title"`
+	Severity string `json:"severity" yaml:"severity"`
+
+	// Resources are the patterns of the resources to which the rule is
+	// applied, as on TagRule, like "ec2/instance", and all the resources
+	// if it's empty
+	Resources []string `json:"resources,omitempty" yaml:"resources,omitempty"`
+
+	// Where is the condition selecting the resources to which the
+	// rule is applied, all the ones of Resources if it's empty
+	Where string `json:"where,omitempty" yaml:"where,omitempty"`
+
+	// Assert is the condition the resources must comply with
+	Assert string `json:"assert" yaml:"assert"`
+
+	// Message is the message of the findings, the Title if empty
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// messageExprs are the expressions between braces of the messages
+var messageExprs = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// Compile returns the Rule defined by c.
+// An error is returned if any of its fields is not valid.
+func (c CustomRule) Compile() (Rule, error) {
+	if c.ID == "" {
+		return Rule{}, fmt.Errorf("the rule %q has no id", c.Title)
+	}
+
+	sev, err := ParseSeverity(c.Severity)
+	if err != nil {
+		return Rule{}, fmt.Errorf("rule %s: %s", c.ID, err)
+	}
+
+	for _, m := range c.Resources {
+		if _, err := path.Match(m, ""); err != nil {
+			return Rule{}, fmt.Errorf("rule %s: invalid resources pattern %q: %s", c.ID, m, err)
+		}
+	}
+
+	where := expr(literal{v: true})
+	if c.Where != "" {
+		where, err = parseExpr(c.Where)
+		if err != nil {
+			return Rule{}, fmt.Errorf("rule %s: invalid where: %s", c.ID, err)
+		}
+	}
+
+	if c.Assert == "" {
+		return Rule{}, fmt.Errorf("rule %s: the assert is required", c.ID)
+	}
+	assert, err := parseExpr(c.Assert)
+	if err != nil {
+		return Rule{}, fmt.Errorf("rule %s: invalid assert: %s", c.ID, err)
+	}
+
+	message := c.Message
+	if message == "" {
+		message = c.Title
+	}
+
+	var msgExprs []expr
+	for _, m := range messageExprs.FindAllStringSubmatch(message, -1) {
+		e, err := parseExpr(m[1])
+		if err != nil {
+			return Rule{}, fmt.Errorf("rule %s: invalid message expression {%s}: %s", c.ID, m[1], err)
+		}
+		msgExprs = append(msgExprs, e)
+	}
+
+	check := func(d *Data) []Finding {
+		var findings []Finding
+		for _, r := range d.Resources {
+			if !matchResource(c.Resources, r) {
+				continue
+			}
+
+			v, err := resourceJSON(r)
+			if err != nil {
+				continue
+			}
+
+			ec := evalContext{current: v, resource: v, now: d.CollectedAt}
+			if !truthy(where.eval(ec)) || truthy(assert.eval(ec)) {
+				continue
+			}
+
+			i := 0
+			msg := messageExprs.ReplaceAllStringFunc(message, func(string) string {
+				s := format(msgExprs[i].eval(ec))
+				i++
+				return s
+			})
+			findings = append(findings, newFinding(r, "%s", msg))
+		}
+
+		return findings
+	}
+
+	return Rule{ID: c.ID, Title: c.Title, Severity: sev, Check: check}, nil
+}
+
+// matchResource returns if r is matched by any of the patterns, or
+// if there are no patterns
+func matchResource(patterns []string, r raws.Resource) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	for _, m := range patterns {
+		if ok, _ := path.Match(m, r.Service+"/"+r.Type); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resourceJSON returns the JSON of r as a generic value
+func resourceJSON(r raws.Resource) (interface{}, error) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	err = json.Unmarshal(b, &v)
+
+	return v, err
+}
+
+// ReadRules reads from r the CustomRules, written in YAML or JSON as
+// a list under "rules", and returns the Rules they define.
+// An error is returned if any of them is not valid or if 2 of them, or
+// one of them and a built-in rule, have the same ID.
+func ReadRules(r io.Reader) ([]Rule, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		Rules []CustomRule `json:"rules" yaml:"rules"`
+	}
+	err = yaml.UnmarshalStrict(b, &file)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool)
+	for _, rule := range Rules {
+		ids[rule.ID] = true
+	}
+
+	var rules []Rule
+	for _, c := range file.Rules {
+		rule, err := c.Compile()
+		if err != nil {
+			return nil, err
+		}
+
+		if ids[rule.ID] {
+			return nil, fmt.Errorf("the rule %s is defined twice", rule.ID)
+		}
+		ids[rule.ID] = true
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// FromSnapshot returns the Data of the resources of the Snapshot s, which
+// only has the resources, so the rules needing the details gathered by
+// Collect, like the MFA devices of the users, don't have findings on it
+func FromSnapshot(s *raws.Snapshot) *Data {
+	return &Data{
+		AccountID:   s.AccountID,
+		CollectedAt: s.FinishedAt,
+		Resources:   s.Resources,
+	}
+}
+
+// Expected is a finding expected on a fixture by CheckFixtures
+type Expected struct {
+	Rule string `json:"rule" yaml:"rule"`
+	Key  string `json:"key" yaml:"key"`
+}
+
+// FixtureResult is the result of CheckFixtures on one fixture
+type FixtureResult struct {
+	// Name is the name of the file of the fixture
+	Name string `json:"name" yaml:"name"`
+
+	// Missing are the findings expected which the rules don't have
+	Missing []Expected `json:"missing,omitempty" yaml:"missing,omitempty"`
+
+	// Unexpected are the findings the rules have which are not expected
+	Unexpected []Expected `json:"unexpected,omitempty" yaml:"unexpected,omitempty"`
+}
+
+// Passed returns if the findings of the fixture are the expected ones
+func (f FixtureResult) Passed() bool {
+	return len(f.Missing) == 0 && len(f.Unexpected) == 0
+}
+
+// CheckFixtures runs the rules on each one of the fixtures of the dir,
+// which are the raws.Snapshots on the *.json files, and compares their
+// findings to the ones expected, listed on the file with the same name
+// and the .expected.yaml extension, like:
+//
+//   - rule: ec2-instance-public
+//     key: ec2/instance/123456789012/eu-west-1/i-0123456789
+//
+// A fixture without the file of the expected findings must have none.
+func CheckFixtures(rules []Rule, dir string) ([]FixtureResult, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]FixtureResult, 0, len(files))
+	for _, f := range files {
+		s, err := readFixture(f)
+		if err != nil {
+			return nil, err
+		}
+
+		var expected []Expected
+		b, err := ioutil.ReadFile(strings.TrimSuffix(f, ".json") + ".expected.yaml")
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		err = yaml.UnmarshalStrict(b, &expected)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expected findings of %s: %s", f, err)
+		}
+
+		want := make(map[Expected]bool, len(expected))
+		for _, e := range expected {
+			want[e] = true
+		}
+
+		res := FixtureResult{Name: filepath.Base(f)}
+		for _, fd := range Run(FromSnapshot(s), rules, Low) {
+			e := Expected{Rule: fd.RuleID, Key: fd.Key}
+			if want[e] {
+				delete(want, e)
+			} else {
+				res.Unexpected = append(res.Unexpected, e)
+			}
+		}
+
+		for _, e := range expected {
+			if want[e] {
+				res.Missing = append(res.Missing, e)
+			}
+		}
+
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+// readFixture reads the raws.Snapshot of the fixture on the path
+func readFixture(path string) (*raws.Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s, err := raws.ReadSnapshot(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %s", path, err)
+	}
+
+	return s, nil
+}
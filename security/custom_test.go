@@ -0,0 +1,110 @@
+package security
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func readTestRules(t *testing.T) []Rule {
+	f, err := os.Open("testdata/rules.yaml")
+	if err != nil {
+		t.Fatalf("rules - errors: received=%+v | expected=nil", err)
+	}
+	defer f.Close()
+
+	rules, err := ReadRules(f)
+	if err != nil {
+		t.Fatalf("rules - errors: received=%+v | expected=nil", err)
+	}
+
+	return rules
+}
+
+func TestReadRules(t *testing.T) {
+	rules := readTestRules(t)
+	if len(rules) != 3 || rules[0].ID != "ec2-instance-public-ip" || rules[0].Severity != High || rules[2].Title != "Resources without owner" {
+		t.Fatalf("rules - read: received=%+v", rules)
+	}
+
+	tests := []struct {
+		rules    string
+		expected string
+	}{
+		{"rules:\n  - title: t\n    severity: low\n    assert: 'true'", `the rule "t" has no id`},
+		{"rules:\n  - id: r\n    severity: urgent\n    assert: 'true'", `rule r: invalid severity "urgent", it has to be low, medium, high or critical`},
+		{"rules:\n  - id: r\n    severity: low", "rule r: the assert is required"},
+		{"rules:\n  - id: r\n    severity: low\n    resources: ['[']\n    assert: 'true'", `rule r: invalid resources pattern "[": syntax error in pattern`},
+		{"rules:\n  - id: r\n    severity: low\n    where: id ==\n    assert: 'true'", "rule r: invalid where: unexpected end of the expression"},
+		{"rules:\n  - id: r\n    severity: low\n    assert: id = 1", `rule r: invalid assert: unexpected "=" at position 3`},
+		{"rules:\n  - id: r\n    severity: low\n    assert: 'true'\n    message: '{id ==}'", "rule r: invalid message expression {id ==}: unexpected end of the expression"},
+		{"rules:\n  - id: r\n    severity: low\n    assert: 'true'\n  - id: r\n    severity: low\n    assert: 'true'", "the rule r is defined twice"},
+		{"rules:\n  - id: s3-bucket-public\n    severity: low\n    assert: 'true'", "the rule s3-bucket-public is defined twice"},
+		{"rules:\n  - id: r\n    severity: low\n    assert: 'true'\n    when: 'true'", "yaml: unmarshal errors:\n  line 5: field when not found in type security.CustomRule"},
+	}
+
+	for i, tt := range tests {
+		_, err := ReadRules(strings.NewReader(tt.rules))
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("[%d] - errors: received=%+v | expected=%+v", i, err, tt.expected)
+		}
+	}
+}
+
+func TestCustomRule(t *testing.T) {
+	s, err := readFixture("testdata/fixtures/instances.json")
+	if err != nil {
+		t.Fatalf("fixture - errors: received=%+v | expected=nil", err)
+	}
+
+	var (
+		findings = Run(FromSnapshot(s), readTestRules(t), "")
+		expected = []struct{ rule, id, message string }{
+			{"ec2-instance-public-ip", "i-1", "the instance i-1 has the public IP 203.0.113.1"},
+			{"ec2-instance-ssh-group", "i-1", "EC2 instances in the ssh security group"},
+			{"tag-owner", "i-2", "ec2/instance i-2 has no owner"},
+		}
+	)
+
+	if len(findings) != len(expected) {
+		t.Fatalf("findings - count: received=%d | expected=%d (%+v)", len(findings), len(expected), findings)
+	}
+
+	for i, f := range findings {
+		e := expected[i]
+		if f.RuleID != e.rule || f.ID != e.id || f.Message != e.message {
+			t.Errorf("findings [%d] - finding: received=%s %s %q | expected=%s %s %q", i, f.RuleID, f.ID, f.Message, e.rule, e.id, e.message)
+		}
+	}
+}
+
+func TestCheckFixtures(t *testing.T) {
+	rules := readTestRules(t)
+
+	results, err := CheckFixtures(rules, "testdata/fixtures")
+	if err != nil {
+		t.Fatalf("fixtures - errors: received=%+v | expected=nil", err)
+	}
+
+	expected := []FixtureResult{{Name: "empty.json"}, {Name: "instances.json"}}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("fixtures - results: received=%+v | expected=%+v", results, expected)
+	}
+
+	// Without the tag-owner rule its finding is missing, and the
+	// built-in rules have no findings on the fixtures
+	results, err = CheckFixtures(append(rules[:2:2], Rules...), "testdata/fixtures")
+	if err != nil {
+		t.Fatalf("fixtures - errors: received=%+v | expected=nil", err)
+	}
+
+	if len(results) != 2 || !results[0].Passed() || results[1].Passed() {
+		t.Fatalf("fixtures - passed: received=%+v", results)
+	}
+
+	missing := []Expected{{Rule: "tag-owner", Key: "ec2/instance/123456789012/eu-west-1/i-2"}}
+	if !reflect.DeepEqual(results[1].Missing, missing) || results[1].Unexpected != nil {
+		t.Errorf("fixtures - instances: received=%+v | expected=%+v", results[1], FixtureResult{Name: "instances.json", Missing: missing})
+	}
+}
@@ -0,0 +1,657 @@
+package security
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// expr is an expression of the language of the custom rules, see
+// CustomRule for its syntax, which is evaluated to a list of values
+type expr interface {
+	eval(c evalContext) []interface{}
+}
+
+// evalContext are the values the paths of the expressions are resolved
+// against: the current one, which is the resource or the element of the
+// list walked by any or all, and the resource, which is $
+type evalContext struct {
+	current  interface{}
+	resource interface{}
+	now      time.Time
+}
+
+// parseExpr parses the expression s
+func parseExpr(s string) (expr, error) {
+	toks, err := lexExpr(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(toks) == 0 {
+		return nil, errors.New("the expression is empty")
+	}
+
+	p := &exprParser{toks: toks}
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	if t, ok := p.peek(); ok {
+		return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.at)
+	}
+
+	return e, nil
+}
+
+// truthy returns if any of the values is true
+func truthy(vals []interface{}) bool {
+	for _, v := range vals {
+		if b, ok := v.(bool); ok && b {
+			return true
+		}
+	}
+
+	return false
+}
+
+// flatten returns the values with the elements of their lists
+func flatten(vals []interface{}) []interface{} {
+	var res []interface{}
+	for _, v := range vals {
+		if l, ok := v.([]interface{}); ok {
+			res = append(res, flatten(l)...)
+			continue
+		}
+		res = append(res, v)
+	}
+
+	return res
+}
+
+// format returns the values as text for the messages
+func format(vals []interface{}) string {
+	var s []string
+	for _, v := range flatten(vals) {
+		switch vv := v.(type) {
+		case nil:
+		case string:
+			s = append(s, vv)
+		default:
+			b, _ := json.Marshal(vv)
+			s = append(s, string(b))
+		}
+	}
+
+	return strings.Join(s, ", ")
+}
+
+type literal struct {
+	v interface{}
+}
+
+func (e literal) eval(_ evalContext) []interface{} {
+	return []interface{}{e.v}
+}
+
+// valuePath is a path, separated by dots, to the values of the current value
+// or of the resource if it starts by "$", and the lists on the way are
+// walked element by element. It's null if there is no value on the path
+type valuePath struct {
+	root     string
+	segments []string
+}
+
+func (e valuePath) eval(c evalContext) []interface{} {
+	vals := []interface{}{c.current}
+	if e.root == "$" {
+		vals = []interface{}{c.resource}
+	}
+
+	for _, s := range e.segments {
+		var next []interface{}
+		for _, v := range vals {
+			next = append(next, get(v, s)...)
+		}
+		vals = next
+	}
+
+	if len(vals) == 0 {
+		return []interface{}{nil}
+	}
+
+	return vals
+}
+
+// get returns the values of the key of v, or of its elements if it's a list
+func get(v interface{}, key string) []interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		if e, ok := vv[key]; ok {
+			return []interface{}{e}
+		}
+	case []interface{}:
+		var res []interface{}
+		for _, e := range vv {
+			res = append(res, get(e, key)...)
+		}
+		return res
+	}
+
+	return nil
+}
+
+type list struct {
+	items []expr
+}
+
+func (e list) eval(c evalContext) []interface{} {
+	var l []interface{}
+	for _, i := range e.items {
+		l = append(l, i.eval(c)...)
+	}
+
+	return []interface{}{l}
+}
+
+type logical struct {
+	op    string
+	exprs []expr
+}
+
+func (e logical) eval(c evalContext) []interface{} {
+	switch e.op {
+	case "not":
+		return []interface{}{!truthy(e.exprs[0].eval(c))}
+	case "and":
+		for _, ee := range e.exprs {
+			if !truthy(ee.eval(c)) {
+				return []interface{}{false}
+			}
+		}
+		return []interface{}{true}
+	default:
+		for _, ee := range e.exprs {
+			if truthy(ee.eval(c)) {
+				return []interface{}{true}
+			}
+		}
+		return []interface{}{false}
+	}
+}
+
+// comparison is true if any of the values on the left
+// compares to any of the values on the right with op,
+// but "!=" which is true if none of them is equal
+type comparison struct {
+	op          string
+	left, right expr
+	re          *regexp.Regexp
+}
+
+func (e comparison) eval(c evalContext) []interface{} {
+	if e.op == "!=" {
+		eq := comparison{op: "==", left: e.left, right: e.right}
+		return []interface{}{!truthy(eq.eval(c))}
+	}
+
+	left, right := flatten(e.left.eval(c)), flatten(e.right.eval(c))
+	for _, l := range left {
+		for _, r := range right {
+			if compare(e.op, l, r, e.re) {
+				return []interface{}{true}
+			}
+		}
+	}
+
+	return []interface{}{false}
+}
+
+func compare(op string, l, r interface{}, re *regexp.Regexp) bool {
+	switch op {
+	case "==", "in":
+		return reflect.DeepEqual(l, r)
+	case "=~":
+		s, ok := l.(string)
+		return ok && re.MatchString(s)
+	}
+
+	var cmp int
+	switch lv := l.(type) {
+	case float64:
+		rv, ok := r.(float64)
+		if !ok {
+			return false
+		}
+		if lv < rv {
+			cmp = -1
+		} else if lv > rv {
+			cmp = 1
+		}
+	case string:
+		rv, ok := r.(string)
+		if !ok {
+			return false
+		}
+		cmp = strings.Compare(lv, rv)
+	default:
+		return false
+	}
+
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+// functions are the functions of the expressions by their name,
+// with their number of arguments
+var functions = map[string]int{
+	"exists":     1,
+	"len":        1,
+	"lower":      1,
+	"days_since": 1,
+	"any":        2,
+	"all":        2,
+}
+
+type call struct {
+	name string
+	args []expr
+}
+
+func (e call) eval(c evalContext) []interface{} {
+	switch e.name {
+	case "exists":
+		for _, v := range e.args[0].eval(c) {
+			if v != nil {
+				return []interface{}{true}
+			}
+		}
+		return []interface{}{false}
+	case "len":
+		var n int
+		for _, v := range e.args[0].eval(c) {
+			switch vv := v.(type) {
+			case []interface{}:
+				n += len(vv)
+			case map[string]interface{}:
+				n += len(vv)
+			case string:
+				n += len(vv)
+			}
+		}
+		return []interface{}{float64(n)}
+	case "lower":
+		var res []interface{}
+		for _, v := range flatten(e.args[0].eval(c)) {
+			if s, ok := v.(string); ok {
+				v = strings.ToLower(s)
+			}
+			res = append(res, v)
+		}
+		return res
+	case "days_since":
+		var res []interface{}
+		for _, v := range flatten(e.args[0].eval(c)) {
+			s, _ := v.(string)
+			t, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				res = append(res, nil)
+				continue
+			}
+			res = append(res, math.Floor(c.now.Sub(t).Hours()/24))
+		}
+		return res
+	default:
+		all := e.name == "all"
+		for _, v := range flatten(e.args[0].eval(c)) {
+			if v == nil {
+				continue
+			}
+			ok := truthy(e.args[1].eval(evalContext{current: v, resource: c.resource, now: c.now}))
+			if ok != all {
+				return []interface{}{ok}
+			}
+		}
+		return []interface{}{all}
+	}
+}
+
+type exprToken struct {
+	// kind is "ident" for the paths, keywords and names of
+	// the functions, "string", "number" or the operator
+	kind string
+	text string
+	at   int
+}
+
+// exprOperators are the operators, the longest ones first
+var exprOperators = []string{"==", "!=", "<=", ">=", "=~", "&&", "||", "<", ">", "!", "(", ")", "[", "]", ","}
+
+// lexExpr splits s into tokens
+func lexExpr(s string) ([]exprToken, error) {
+	var toks []exprToken
+
+	isIdent := func(r rune, first bool) bool {
+		return unicode.IsLetter(r) || r == '_' || r == '$' || r == '@' ||
+			(!first && (unicode.IsDigit(r) || strings.ContainsRune(".-:/", r)))
+	}
+
+lex:
+	for i := 0; i < len(s); {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '"' || c == '\'':
+			j := i + 1
+			for ; j < len(s) && rune(s[j]) != c; j++ {
+				if s[j] == '\\' && c == '"' {
+					j++
+				}
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+
+			str := s[i+1 : j]
+			if c == '"' {
+				var err error
+				str, err = strconv.Unquote(s[i : j+1])
+				if err != nil {
+					return nil, fmt.Errorf("invalid string at position %d: %s", i, err)
+				}
+			}
+			toks = append(toks, exprToken{kind: "string", text: str, at: i})
+			i = j + 1
+		case unicode.IsDigit(c) || (c == '-' && i+1 < len(s) && unicode.IsDigit(rune(s[i+1]))):
+			j := i + 1
+			for ; j < len(s) && (unicode.IsDigit(rune(s[j])) || s[j] == '.'); j++ {
+			}
+			toks = append(toks, exprToken{kind: "number", text: s[i:j], at: i})
+			i = j
+		case isIdent(c, true):
+			j := i + 1
+			for ; j < len(s) && isIdent(rune(s[j]), false); j++ {
+			}
+			toks = append(toks, exprToken{kind: "ident", text: s[i:j], at: i})
+			i = j
+		default:
+			for _, op := range exprOperators {
+				if strings.HasPrefix(s[i:], op) {
+					toks = append(toks, exprToken{kind: op, text: op, at: i})
+					i += len(op)
+					continue lex
+				}
+			}
+			return nil, fmt.Errorf("unexpected %q at position %d", string(c), i)
+		}
+	}
+
+	return toks, nil
+}
+
+// exprParser is a recursive descent parser of the grammar:
+//
+//	or         = and { ( "or" | "||" ) and }
+//	and        = not { ( "and" | "&&" ) not }
+//	not        = ( "not" | "!" ) not | comparison
+//	comparison = operand [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" | "=~" | "in" ) operand ]
+//	operand    = string | number | "true" | "false" | "null" | path
+//	           | function "(" or { "," or } ")" | "(" or ")" | "[" [ or { "," or } ] "]"
+type exprParser struct {
+	toks []exprToken
+	pos  int
+}
+
+func (p *exprParser) peek() (exprToken, bool) {
+	if p.pos >= len(p.toks) {
+		return exprToken{}, false
+	}
+
+	return p.toks[p.pos], true
+}
+
+// accept returns if the next token is one of the kinds, or
+// of the keywords, and consumes it if it is
+func (p *exprParser) accept(kinds ...string) (exprToken, bool) {
+	t, ok := p.peek()
+	if !ok {
+		return t, false
+	}
+
+	for _, k := range kinds {
+		if t.kind == k || (t.kind == "ident" && t.text == k) {
+			p.pos++
+			return t, true
+		}
+	}
+
+	return t, false
+}
+
+// expect consumes the next token, which must be of the kind
+func (p *exprParser) expect(kind string) error {
+	t, ok := p.peek()
+	if !ok {
+		return fmt.Errorf("missing %s at the end of the expression", kind)
+	}
+	if t.kind != kind {
+		return fmt.Errorf("unexpected %q at position %d, expected %s", t.text, t.at, kind)
+	}
+	p.pos++
+
+	return nil
+}
+
+func (p *exprParser) or() (expr, error) {
+	e, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []expr{e}
+	for _, ok := p.accept("or", "||"); ok; _, ok = p.accept("or", "||") {
+		e, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+	}
+
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+
+	return logical{op: "or", exprs: exprs}, nil
+}
+
+func (p *exprParser) and() (expr, error) {
+	e, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []expr{e}
+	for _, ok := p.accept("and", "&&"); ok; _, ok = p.accept("and", "&&") {
+		e, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+	}
+
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+
+	return logical{op: "and", exprs: exprs}, nil
+}
+
+func (p *exprParser) not() (expr, error) {
+	if _, ok := p.accept("not", "!"); ok {
+		e, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return logical{op: "not", exprs: []expr{e}}, nil
+	}
+
+	return p.comparison()
+}
+
+func (p *exprParser) comparison() (expr, error) {
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	t, ok := p.accept("==", "!=", "<", "<=", ">", ">=", "=~", "in")
+	if !ok {
+		return left, nil
+	}
+
+	right, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	c := comparison{op: t.text, left: left, right: right}
+	if c.op == "=~" {
+		l, ok := right.(literal)
+		s, isString := l.v.(string)
+		if !ok || !isString {
+			return nil, fmt.Errorf("the right of =~ at position %d must be a string", t.at)
+		}
+
+		c.re, err = regexp.Compile(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression at position %d: %s", t.at, err)
+		}
+	}
+
+	return c, nil
+}
+
+func (p *exprParser) operand() (expr, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, errors.New("unexpected end of the expression")
+	}
+	p.pos++
+
+	switch t.kind {
+	case "string":
+		return literal{v: t.text}, nil
+	case "number":
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", t.text, t.at)
+		}
+		return literal{v: n}, nil
+	case "(":
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return e, p.expect(")")
+	case "[":
+		var l list
+		if _, ok := p.accept("]"); ok {
+			return l, nil
+		}
+		for {
+			e, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			l.items = append(l.items, e)
+
+			if _, ok := p.accept(","); !ok {
+				return l, p.expect("]")
+			}
+		}
+	case "ident":
+		switch t.text {
+		case "true", "false":
+			return literal{v: t.text == "true"}, nil
+		case "null":
+			return literal{v: nil}, nil
+		}
+
+		if _, ok := p.accept("("); ok {
+			return p.call(t)
+		}
+
+		return parsePath(t)
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.at)
+	}
+}
+
+// call parses the arguments of the function t
+func (p *exprParser) call(t exprToken) (expr, error) {
+	n, ok := functions[t.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q at position %d", t.text, t.at)
+	}
+
+	c := call{name: t.text}
+	for {
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		c.args = append(c.args, e)
+
+		if _, ok := p.accept(","); !ok {
+			break
+		}
+	}
+
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	if len(c.args) != n {
+		return nil, fmt.Errorf("the function %s at position %d takes %d arguments, not %d", t.text, t.at, n, len(c.args))
+	}
+
+	return c, nil
+}
+
+func parsePath(t exprToken) (expr, error) {
+	var (
+		pt       valuePath
+		segments = strings.Split(t.text, ".")
+	)
+
+	if segments[0] == "$" || segments[0] == "@" {
+		pt.root = segments[0]
+		segments = segments[1:]
+	}
+
+	for _, s := range segments {
+		if s == "" {
+			return nil, fmt.Errorf("invalid path %q at position %d", t.text, t.at)
+		}
+	}
+	pt.segments = segments
+
+	return pt, nil
+}
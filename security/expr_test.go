@@ -0,0 +1,98 @@
+package security
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestParseExpr(t *testing.T) {
+	r := newResource(t, "eu-west-1", &ec2.Instance{
+		InstanceId: aws.String("i-1"),
+		LaunchTime: aws.Time(time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)),
+		State:      &ec2.InstanceState{Name: aws.String("running")},
+		SecurityGroups: []*ec2.GroupIdentifier{
+			{GroupId: aws.String("sg-1"), GroupName: aws.String("web")},
+			{GroupId: aws.String("sg-2"), GroupName: aws.String("ssh")},
+		},
+		BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+			{DeviceName: aws.String("/dev/sda1"), Ebs: &ec2.EbsInstanceBlockDevice{DeleteOnTermination: aws.Bool(true)}},
+		},
+		Tags: []*ec2.Tag{{Key: aws.String("env"), Value: aws.String("Prod")}},
+	})
+
+	v, err := resourceJSON(r)
+	if err != nil {
+		t.Fatalf("json - errors: received=%+v | expected=nil", err)
+	}
+
+	var (
+		ec    = evalContext{current: v, resource: v, now: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)}
+		tests = []struct {
+			expr     string
+			expected bool
+		}{
+			{`id == "i-1"`, true},
+			{`id != "i-1"`, false},
+			{`raw.State.Name == "running" and region == "eu-west-1"`, true},
+			{`raw.State.Name == "stopped" || tags.env == "Prod"`, true},
+			{`not (raw.State.Name == "running")`, false},
+			{`!exists(raw.PublicIpAddress)`, true},
+			{`raw.PublicIpAddress == null`, true},
+			{`raw.SecurityGroups.GroupId == "sg-2"`, true},
+			{`raw.SecurityGroups.GroupId != "sg-3"`, true},
+			{`raw.SecurityGroups.GroupName in ["db", "ssh"]`, true},
+			{`len(raw.SecurityGroups) > 1`, true},
+			{`len(raw.SecurityGroups) >= 3`, false},
+			{`lower(tags.env) == 'prod'`, true},
+			{`tags.env =~ "^(Prod|Staging)$"`, true},
+			{`days_since(raw.LaunchTime) == 30`, true},
+			{`days_since(created_at) > 30`, false},
+			{`any(raw.SecurityGroups, GroupName == "ssh" and $.id == "i-1")`, true},
+			{`all(raw.SecurityGroups, @.GroupId =~ "^sg-")`, true},
+			{`all(raw.BlockDeviceMappings, Ebs.DeleteOnTermination == true)`, true},
+			{`all(raw.NetworkInterfaces, Association.PublicIp == null)`, true},
+			{`any(raw.NetworkInterfaces, true)`, false},
+			{`tags.team < "a" or -1 < 0`, true},
+		}
+	)
+
+	for i, tt := range tests {
+		e, err := parseExpr(tt.expr)
+		if err != nil {
+			t.Errorf("%s [%d] - errors: received=%+v | expected=nil", tt.expr, i, err)
+			continue
+		}
+
+		if b := truthy(e.eval(ec)); b != tt.expected {
+			t.Errorf("%s [%d] - value: received=%+v | expected=%+v", tt.expr, i, b, tt.expected)
+		}
+	}
+}
+
+func TestParseExpr_Errors(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{``, "the expression is empty"},
+		{`id ==`, "unexpected end of the expression"},
+		{`id == "i-1" )`, `unexpected ")" at position 12`},
+		{`id = "i-1"`, `unexpected "=" at position 3`},
+		{`id == "i-1`, "unterminated string at position 6"},
+		{`id =~ tags.re`, "the right of =~ at position 3 must be a string"},
+		{`id =~ "("`, "invalid regular expression at position 3: error parsing regexp: missing closing ): `(`"},
+		{`upper(id)`, `unknown function "upper" at position 0`},
+		{`any(raw.SecurityGroups)`, "the function any at position 0 takes 2 arguments, not 1"},
+		{`raw..State`, `invalid path "raw..State" at position 0`},
+	}
+
+	for i, tt := range tests {
+		_, err := parseExpr(tt.expr)
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("%s [%d] - errors: received=%+v | expected=%+v", tt.expr, i, err, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,437 @@
+package security
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/cycloidio/raws"
+	"github.com/cycloidio/raws/internal/value"
+)
+
+// SensitivePorts are the ports of the remote access, database and
+// administration services which must not be open to the Internet
+var SensitivePorts = []int64{20, 21, 22, 23, 25, 445, 1433, 1521, 2375, 2376, 3306, 3389, 5432, 5601, 5900, 6379, 9200, 9300, 11211, 27017}
+
+// AccessKeyMaxAge is the age from which the active access keys are stale
+var AccessKeyMaxAge = 90 * 24 * time.Hour
+
+// Rules are the built-in rules
+var Rules = []Rule{
+	{
+		ID:       "ec2-security-group-open",
+		Title:    "Security groups must not allow the Internet on sensitive ports",
+		Severity: High,
+		Check:    openSecurityGroups,
+	},
+	{
+		ID:       "ec2-snapshot-public",
+		Title:    "EBS snapshots must not be public",
+		Severity: Critical,
+		Check:    publicSnapshots,
+	},
+	{
+		ID:       "ec2-snapshot-unencrypted",
+		Title:    "EBS snapshots must be encrypted",
+		Severity: Medium,
+		Check:    unencrypted("ec2", "snapshot", "Encrypted", "the snapshot is not encrypted"),
+	},
+	{
+		ID:       "ec2-image-public",
+		Title:    "AMIs must not be public",
+		Severity: High,
+		Check:    publicImages,
+	},
+	{
+		ID:       "ec2-image-unencrypted",
+		Title:    "The EBS volumes of the AMIs must be encrypted",
+		Severity: Medium,
+		Check:    unencryptedImages,
+	},
+	{
+		ID:       "ec2-volume-unencrypted",
+		Title:    "EBS volumes must be encrypted",
+		Severity: Medium,
+		Check:    unencrypted("ec2", "volume", "Encrypted", "the volume is not encrypted"),
+	},
+	{
+		ID:       "rds-instance-unencrypted",
+		Title:    "RDS instances must have their storage encrypted",
+		Severity: Medium,
+		Check:    unencrypted("rds", "db-instance", "StorageEncrypted", "the storage of the instance is not encrypted"),
+	},
+	{
+		ID:       "iam-access-key-stale",
+		Title:    "Active access keys must be rotated",
+		Severity: Medium,
+		Check:    staleAccessKeys,
+	},
+	{
+		ID:       "iam-password-policy-weak",
+		Title:    "The password policy must enforce strong passwords",
+		Severity: Medium,
+		Check:    weakPasswordPolicy,
+	},
+	{
+		ID:       "iam-user-no-mfa",
+		Title:    "IAM users must have an MFA device",
+		Severity: High,
+		Check:    usersWithoutMFA,
+	},
+	{
+		ID:       "iam-policy-wildcard",
+		Title:    "IAM policies must not allow all the actions on all the resources",
+		Severity: High,
+		Check:    wildcardPolicies,
+	},
+	{
+		ID:       "s3-bucket-public",
+		Title:    "S3 buckets must not be public",
+		Severity: Critical,
+		Check:    publicBuckets,
+	},
+}
+
+// resources calls fn with the Raw of each one of the resources
+// of d of the service and type, as a generic value of the item
+func resources(d *Data, service, typ string, fn func(r raws.Resource, v value.Value)) {
+	for _, r := range d.Resources {
+		if r.Service != service || r.Type != typ {
+			continue
+		}
+
+		v, err := value.Of(r.Raw)
+		if err != nil {
+			continue
+		}
+		fn(r, v.Item())
+	}
+}
+
+func openSecurityGroups(d *Data) []Finding {
+	var findings []Finding
+	resources(d, "ec2", "security-group", func(r raws.Resource, v value.Value) {
+		var ports []string
+		for _, p := range v.List("IpPermissions") {
+			if !containsString(p.Strs("IpRanges", "CidrIp"), "0.0.0.0/0") && !containsString(p.Strs("Ipv6Ranges", "CidrIpv6"), "::/0") {
+				continue
+			}
+
+			if p.Str("IpProtocol") == "-1" {
+				ports = []string{"all"}
+				break
+			}
+
+			if p.Str("IpProtocol") != "tcp" && p.Str("IpProtocol") != "udp" {
+				continue
+			}
+
+			from, _ := p.Num("FromPort")
+			to, _ := p.Num("ToPort")
+			for _, sp := range SensitivePorts {
+				port := fmt.Sprintf("%d/%s", sp, p.Str("IpProtocol"))
+				if float64(sp) >= from && float64(sp) <= to && !containsString(ports, port) {
+					ports = append(ports, port)
+				}
+			}
+		}
+
+		if containsString(ports, "all") {
+			findings = append(findings, newFinding(r, "all the ports are open to the Internet"))
+		} else if len(ports) > 0 {
+			findings = append(findings, newFinding(r, "the ports %s are open to the Internet", strings.Join(ports, ", ")))
+		}
+	})
+
+	return findings
+}
+
+func publicSnapshots(d *Data) []Finding {
+	var findings []Finding
+	resources(d, "ec2", "snapshot", func(r raws.Resource, _ value.Value) {
+		if containsString(d.PublicSnapshots, r.ID) {
+			findings = append(findings, newFinding(r, "the snapshot can be restored by anyone"))
+		}
+	})
+
+	return findings
+}
+
+func publicImages(d *Data) []Finding {
+	var findings []Finding
+	resources(d, "ec2", "image", func(r raws.Resource, v value.Value) {
+		if public, _ := v.Bool("Public"); public {
+			findings = append(findings, newFinding(r, "the image can be launched by anyone"))
+		}
+	})
+
+	return findings
+}
+
+func unencryptedImages(d *Data) []Finding {
+	var findings []Finding
+	resources(d, "ec2", "image", func(r raws.Resource, v value.Value) {
+		var devices []string
+		for _, m := range v.List("BlockDeviceMappings") {
+			if enc, ok := m.Bool("Ebs.Encrypted"); ok && !enc {
+				devices = append(devices, m.Str("DeviceName"))
+			}
+		}
+
+		if len(devices) > 0 {
+			findings = append(findings, newFinding(r, "the snapshots of the devices %s are not encrypted", strings.Join(devices, ", ")))
+		}
+	})
+
+	return findings
+}
+
+// unencrypted returns the Check of the resources of the service
+// and type which have the field, a boolean, set to false
+func unencrypted(service, typ, field, message string) func(d *Data) []Finding {
+	return func(d *Data) []Finding {
+		var findings []Finding
+		resources(d, service, typ, func(r raws.Resource, v value.Value) {
+			if enc, ok := v.Bool(field); ok && !enc {
+				findings = append(findings, newFinding(r, "%s", message))
+			}
+		})
+
+		return findings
+	}
+}
+
+func staleAccessKeys(d *Data) []Finding {
+	var findings []Finding
+	resources(d, "iam", "access-key", func(r raws.Resource, v value.Value) {
+		created, err := time.Parse(time.RFC3339, v.Str("CreateDate"))
+		if err != nil || v.Str("Status") != "Active" {
+			return
+		}
+
+		if age := d.CollectedAt.Sub(created); age > AccessKeyMaxAge {
+			findings = append(findings, newFinding(r, "the access key of %s has been created %d days ago", v.Str("UserName"), int(age.Hours()/24)))
+		}
+	})
+
+	return findings
+}
+
+func weakPasswordPolicy(d *Data) []Finding {
+	p := d.PasswordPolicy
+	if p == nil {
+		return nil
+	}
+
+	account := raws.Resource{Service: "iam", Type: "account-password-policy", ID: d.AccountID, AccountID: d.AccountID}
+	if *p == (iam.PasswordPolicy{}) {
+		return []Finding{newFinding(account, "the account has no password policy")}
+	}
+
+	var weak []string
+	if aws.Int64Value(p.MinimumPasswordLength) < 14 {
+		weak = append(weak, "the minimum length is lower than 14")
+	}
+	if !aws.BoolValue(p.RequireUppercaseCharacters) || !aws.BoolValue(p.RequireLowercaseCharacters) ||
+		!aws.BoolValue(p.RequireNumbers) || !aws.BoolValue(p.RequireSymbols) {
+		weak = append(weak, "uppercase and lowercase letters, numbers and symbols are not required")
+	}
+	if age := aws.Int64Value(p.MaxPasswordAge); age == 0 || age > 90 {
+		weak = append(weak, "the passwords don't expire in 90 days")
+	}
+	if aws.Int64Value(p.PasswordReusePrevention) < 24 {
+		weak = append(weak, "the 24 last passwords can be reused")
+	}
+
+	if len(weak) == 0 {
+		return nil
+	}
+
+	return []Finding{newFinding(account, "%s", strings.Join(weak, ", "))}
+}
+
+func usersWithoutMFA(d *Data) []Finding {
+	var findings []Finding
+	resources(d, "iam", "user", func(r raws.Resource, v value.Value) {
+		if devices, ok := d.MFADevices[v.Str("UserName")]; ok && len(devices) == 0 {
+			findings = append(findings, newFinding(r, "the user has no MFA device"))
+		}
+	})
+
+	return findings
+}
+
+func wildcardPolicies(d *Data) []Finding {
+	a := d.Authorization
+	if a == nil {
+		return nil
+	}
+
+	var findings []Finding
+	check := func(r raws.Resource, policies []*iam.PolicyDetail) {
+		for _, p := range policies {
+			if allowsAll(aws.StringValue(p.PolicyDocument)) {
+				findings = append(findings, newFinding(r, "the inline policy %s allows all the actions on all the resources", aws.StringValue(p.PolicyName)))
+			}
+		}
+	}
+
+	for _, u := range a.UserDetailList {
+		check(iamResource(d, "user", u.Arn, u.UserId, u.UserName), u.UserPolicyList)
+	}
+	for _, g := range a.GroupDetailList {
+		check(iamResource(d, "group", g.Arn, g.GroupId, g.GroupName), g.GroupPolicyList)
+	}
+	for _, r := range a.RoleDetailList {
+		check(iamResource(d, "role", r.Arn, r.RoleId, r.RoleName), r.RolePolicyList)
+	}
+	for _, p := range a.Policies {
+		for _, pv := range p.PolicyVersionList {
+			if aws.BoolValue(pv.IsDefaultVersion) && allowsAll(aws.StringValue(pv.Document)) {
+				findings = append(findings, newFinding(iamResource(d, "policy", p.Arn, p.PolicyId, p.PolicyName), "the policy allows all the actions on all the resources"))
+			}
+		}
+	}
+
+	return findings
+}
+
+// iamResource returns the IAM resource of d with the arn, or a
+// resource of the type built from the arn, id and name if d has none
+func iamResource(d *Data, typ string, arn, id, name *string) raws.Resource {
+	for _, r := range d.Resources {
+		if r.ARN == aws.StringValue(arn) {
+			return r
+		}
+	}
+
+	return raws.Resource{
+		ARN:       aws.StringValue(arn),
+		Service:   "iam",
+		Type:      typ,
+		ID:        aws.StringValue(id),
+		Name:      aws.StringValue(name),
+		AccountID: d.AccountID,
+	}
+}
+
+// allowsAll returns if the policy document, which is
+// URL encoded as returned by IAM, has a statement
+// allowing all the actions on all the resources
+func allowsAll(doc string) bool {
+	if s, err := url.QueryUnescape(doc); err == nil {
+		doc = s
+	}
+
+	var policy struct {
+		Statement json.RawMessage
+	}
+	if err := json.Unmarshal([]byte(doc), &policy); err != nil {
+		return false
+	}
+
+	type statement struct {
+		Effect   string
+		Action   json.RawMessage
+		Resource json.RawMessage
+	}
+
+	// Statement is a list or a single statement
+	var statements []statement
+	if err := json.Unmarshal(policy.Statement, &statements); err != nil {
+		var st statement
+		if err := json.Unmarshal(policy.Statement, &st); err != nil {
+			return false
+		}
+		statements = []statement{st}
+	}
+
+	for _, st := range statements {
+		if st.Effect != "Allow" {
+			continue
+		}
+
+		actions, resources := stringOrList(st.Action), stringOrList(st.Resource)
+		if (containsString(actions, "*") || containsString(actions, "*:*")) && containsString(resources, "*") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stringOrList returns the strings of the JSON
+// value, which is a string or a list of strings
+func stringOrList(b json.RawMessage) []string {
+	var l []string
+	if err := json.Unmarshal(b, &l); err == nil {
+		return l
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		return []string{s}
+	}
+
+	return nil
+}
+
+// publicGrantees are the URIs of the groups of the
+// S3 ACLs which make the buckets public
+var publicGrantees = []string{
+	"http://acs.amazonaws.com/groups/global/AllUsers",
+	"http://acs.amazonaws.com/groups/global/AuthenticatedUsers",
+}
+
+func publicBuckets(d *Data) []Finding {
+	var findings []Finding
+	resources(d, "s3", "bucket", func(r raws.Resource, _ value.Value) {
+		a, ok := d.Buckets[r.ID]
+		if !ok {
+			return
+		}
+
+		var (
+			reasons []string
+			block   = a.PublicAccessBlock
+		)
+
+		if a.PolicyStatus != nil && aws.BoolValue(a.PolicyStatus.IsPublic) && (block == nil || !aws.BoolValue(block.RestrictPublicBuckets)) {
+			reasons = append(reasons, "its policy")
+		}
+
+		if block == nil || !aws.BoolValue(block.IgnorePublicAcls) {
+			var perms []string
+			for _, g := range a.Grants {
+				if g.Grantee != nil && containsString(publicGrantees, aws.StringValue(g.Grantee.URI)) && !containsString(perms, aws.StringValue(g.Permission)) {
+					perms = append(perms, aws.StringValue(g.Permission))
+				}
+			}
+			if len(perms) > 0 {
+				sort.Strings(perms)
+				reasons = append(reasons, fmt.Sprintf("its ACL (%s)", strings.Join(perms, ", ")))
+			}
+		}
+
+		if len(reasons) > 0 {
+			findings = append(findings, newFinding(r, "the bucket is public by %s", strings.Join(reasons, " and ")))
+		}
+	})
+
+	return findings
+}
+
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+
+	return false
+}
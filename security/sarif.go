@@ -0,0 +1,130 @@
+package security
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// sarifLevels are the SARIF levels of the severities
+var sarifLevels = map[Severity]string{
+	Low:      "note",
+	Medium:   "warning",
+	High:     "error",
+	Critical: "error",
+}
+
+// securitySeverities are the "security-severity" properties of the
+// severities, a score from 0 to 10 used by the code scanning tools
+var securitySeverities = map[Severity]string{
+	Low:      "3.0",
+	Medium:   "5.0",
+	High:     "8.0",
+	Critical: "9.5",
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string            `json:"id"`
+	ShortDescription     sarifMessage      `json:"shortDescription"`
+	DefaultConfiguration sarifConfig       `json:"defaultConfiguration"`
+	Properties           map[string]string `json:"properties"`
+}
+
+type sarifConfig struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	RuleIndex  int               `json:"ruleIndex"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// WriteSARIF writes on w the findings, of the rules, as a SARIF 2.1.0 log.
+// The resources of the findings are logical locations, named by their ID
+// and fully qualified by their Key, with their region as a property.
+func WriteSARIF(w io.Writer, rules []Rule, findings []Finding) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "raws",
+			InformationURI: "https://github.com/cycloidio/raws",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	index := make(map[string]int)
+	for i, r := range rules {
+		index[r.ID] = i
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:                   r.ID,
+			ShortDescription:     sarifMessage{Text: r.Title},
+			DefaultConfiguration: sarifConfig{Level: sarifLevels[r.Severity]},
+			Properties:           map[string]string{"security-severity": securitySeverities[r.Severity]},
+		})
+	}
+
+	for _, f := range findings {
+		res := sarifResult{
+			RuleID:    f.RuleID,
+			RuleIndex: index[f.RuleID],
+			Level:     sarifLevels[f.Severity],
+			Message:   sarifMessage{Text: f.Message},
+			Locations: []sarifLocation{{LogicalLocations: []sarifLogicalLocation{{
+				Name:               f.ID,
+				FullyQualifiedName: f.Key,
+				Kind:               "resource",
+			}}}},
+		}
+		if f.Region != "" {
+			res.Properties = map[string]string{"region": f.Region}
+		}
+		run.Results = append(run.Results, res)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
@@ -0,0 +1,356 @@
+// Package security checks the security posture of an AWS account with
+// rules over the data read by raws, like the security groups open to the
+// Internet or the IAM users without MFA.
+//
+// The Data is gathered with Collect, which builds an Inventory of the
+// services checked and makes the calls for the details which aren't on the
+// resources, like the MFA devices of each user. Run applies the Rules to it
+// and returns the Findings, which can be written as JSON or, with
+// WriteSARIF, in the Static Analysis Results Interchange Format read by the
+// code scanning tools.
+package security
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/cycloidio/raws"
+	"github.com/cycloidio/raws/internal/value"
+)
+
+// Severity is the severity of a Rule
+type Severity string
+
+// The severities, from the lowest to the highest
+const (
+	Low      Severity = "low"
+	Medium   Severity = "medium"
+	High     Severity = "high"
+	Critical Severity = "critical"
+)
+
+var severities = []Severity{Low, Medium, High, Critical}
+
+// ParseSeverity returns the Severity named s
+func ParseSeverity(s string) (Severity, error) {
+	for _, sv := range severities {
+		if string(sv) == s {
+			return sv, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid severity %q, it has to be low, medium, high or critical", s)
+}
+
+// rank returns the position of s between the severities,
+// which is -1 if it's not one of them
+func (s Severity) rank() int {
+	for i, sv := range severities {
+		if sv == s {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Services are the services of the resources checked by the
+// rules, which are the ones on which Collect calls Inventory
+var Services = []string{"ec2", "rds", "s3", "iam"}
+
+// inventory is the function used to build the Inventory of
+// the account, it's a variable so it can be replaced on tests
+var inventory = raws.Inventory
+
+// Data is the data of an account on which the Rules are applied
+type Data struct {
+	AccountID   string    `json:"account_id"`
+	CollectedAt time.Time `json:"collected_at"`
+
+	// Resources are the resources of the account, with the
+	// access keys of all the users and not only the caller's
+	Resources []raws.Resource `json:"resources"`
+
+	// PasswordPolicy is the password policy of the account,
+	// which is empty if the account has none and nil if unknown
+	PasswordPolicy *iam.PasswordPolicy `json:"password_policy,omitempty"`
+
+	// MFADevices are the MFA devices of the IAM users by their name
+	MFADevices map[string][]*iam.MFADevice `json:"mfa_devices,omitempty"`
+
+	// Authorization are the IAM users, groups, roles and customer
+	// managed policies with their policy documents
+	Authorization *iam.GetAccountAuthorizationDetailsOutput `json:"authorization,omitempty"`
+
+	// PublicSnapshots are the IDs of the EBS snapshots of the
+	// account which can be restored by anyone, it's nil if unknown
+	PublicSnapshots []string `json:"public_snapshots,omitempty"`
+
+	// Buckets is the access configuration of the S3 buckets by their name
+	Buckets map[string]*BucketAccess `json:"buckets,omitempty"`
+}
+
+// BucketAccess is the configuration of the public access of a S3 bucket,
+// the fields are nil when the bucket doesn't have them
+type BucketAccess struct {
+	PolicyStatus      *s3.PolicyStatus                   `json:"policy_status,omitempty"`
+	Grants            []*s3.Grant                        `json:"grants,omitempty"`
+	PublicAccessBlock *s3.PublicAccessBlockConfiguration `json:"public_access_block,omitempty"`
+}
+
+// Rule is a check of the security posture
+type Rule struct {
+	// ID identifies the rule, like "ec2-volume-unencrypted"
+	ID string
+
+	// Title is a short description of what is checked
+	Title string
+
+	Severity Severity
+
+	// Check returns the findings of the rule on the data, only the
+	// resource and the message have to be set on them
+	Check func(d *Data) []Finding
+}
+
+// Finding is a resource which doesn't comply with a Rule
+type Finding struct {
+	RuleID   string   `json:"rule_id"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+
+	// Key is the raws.Resource Key of the resource
+	Key     string `json:"key"`
+	ARN     string `json:"arn,omitempty"`
+	Service string `json:"service"`
+	Type    string `json:"type"`
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Region  string `json:"region,omitempty"`
+}
+
+// newFinding returns a Finding on the resource r with the message
+func newFinding(r raws.Resource, format string, args ...interface{}) Finding {
+	return Finding{
+		Message: fmt.Sprintf(format, args...),
+		Key:     r.Key(),
+		ARN:     r.ARN,
+		Service: r.Service,
+		Type:    r.Type,
+		ID:      r.ID,
+		Name:    r.Name,
+		Region:  r.Region,
+	}
+}
+
+// Run applies the rules to d and returns their findings with at
+// least the min severity, or all of them if min is empty. The findings
+// are sorted by severity, from the highest, rule and resource.
+func Run(d *Data, rules []Rule, min Severity) []Finding {
+	findings := []Finding{}
+	for _, rule := range rules {
+		if rule.Severity.rank() < min.rank() {
+			continue
+		}
+
+		for _, f := range rule.Check(d) {
+			f.RuleID = rule.ID
+			f.Severity = rule.Severity
+			findings = append(findings, f)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		fi, fj := findings[i], findings[j]
+		if fi.Severity != fj.Severity {
+			return fi.Severity.rank() > fj.Severity.rank()
+		}
+		if fi.RuleID != fj.RuleID {
+			return fi.RuleID < fj.RuleID
+		}
+		return fi.Key < fj.Key
+	})
+
+	return findings
+}
+
+// Collect returns the Data of the account of r, from all its regions.
+// As raws.Inventory, the Data is always returned, with what has been
+// read by the calls without errors, and the errors of the calls are
+// returned as raws.Errors.
+func Collect(ctx context.Context, r raws.AWSReader) (*Data, error) {
+	var errs raws.Errors
+
+	s, err := inventory(ctx, r, raws.InventoryOptions{Services: Services})
+	errs = appendErrors(errs, err)
+
+	d := &Data{
+		AccountID:   r.GetAccountID(),
+		CollectedAt: s.FinishedAt,
+		Resources:   s.Resources,
+		MFADevices:  make(map[string][]*iam.MFADevice),
+		Buckets:     make(map[string]*BucketAccess),
+	}
+
+	keys := make(map[string]bool)
+	for _, res := range d.Resources {
+		keys[res.Key()] = true
+	}
+
+	// The IAM calls return the same on all the
+	// regions, so they are only made on the first one
+	ictx := ctx
+	if regions := r.GetRegions(); len(regions) > 0 {
+		ictx = raws.WithRegions(ctx, regions[0])
+	}
+
+	for _, res := range s.Resources {
+		if res.Service != "iam" || res.Type != "user" {
+			continue
+		}
+
+		v, err := value.Of(res.Raw)
+		if err != nil {
+			errs = append(errs, raws.NewError("", "iam", err))
+			continue
+		}
+		name := v.Item().Str("UserName")
+
+		ak, err := r.GetAccessKeys(ictx, &iam.ListAccessKeysInput{UserName: aws.String(name)})
+		errs = appendErrors(errs, err)
+		for _, o := range ak {
+			for _, k := range o.AccessKeyMetadata {
+				kr, err := raws.NewResource(d.AccountID, "", k)
+				if err == nil && !keys[kr.Key()] {
+					keys[kr.Key()] = true
+					d.Resources = append(d.Resources, kr)
+				}
+			}
+		}
+
+		mfa, err := r.GetMFADevices(ictx, &iam.ListMFADevicesInput{UserName: aws.String(name)})
+		errs = appendErrors(errs, err)
+		for _, o := range mfa {
+			d.MFADevices[name] = append([]*iam.MFADevice{}, o.MFADevices...)
+		}
+	}
+
+	pp, err := r.GetAccountPasswordPolicy(ictx, &iam.GetAccountPasswordPolicyInput{})
+	for _, o := range pp {
+		d.PasswordPolicy = o.PasswordPolicy
+		break
+	}
+	if len(pp) == 0 {
+		if hasCode(err, iam.ErrCodeNoSuchEntityException) {
+			d.PasswordPolicy = &iam.PasswordPolicy{}
+		} else {
+			errs = appendErrors(errs, err)
+		}
+	}
+
+	ad, err := r.GetAccountAuthorizationDetails(ictx, &iam.GetAccountAuthorizationDetailsInput{
+		Filter: aws.StringSlice([]string{"User", "Group", "Role", "LocalManagedPolicy"}),
+	})
+	for _, o := range ad {
+		o := o
+		d.Authorization = &o
+		break
+	}
+	if len(ad) == 0 {
+		errs = appendErrors(errs, err)
+	}
+
+	snaps, err := r.GetSnapshots(ctx, &ec2.DescribeSnapshotsInput{
+		OwnerIds:            aws.StringSlice([]string{"self"}),
+		RestorableByUserIds: aws.StringSlice([]string{"all"}),
+	})
+	errs = appendErrors(errs, err)
+	if len(snaps) > 0 {
+		d.PublicSnapshots = []string{}
+	}
+	for _, o := range snaps {
+		for _, sn := range o.Snapshots {
+			d.PublicSnapshots = append(d.PublicSnapshots, aws.StringValue(sn.SnapshotId))
+		}
+	}
+	sort.Strings(d.PublicSnapshots)
+
+	// The S3 calls only succeed on the region of
+	// the bucket, so they are only made on it
+	for _, res := range s.Resources {
+		if res.Service != "s3" || res.Type != "bucket" {
+			continue
+		}
+
+		var (
+			access BucketAccess
+			bucket = aws.String(res.ID)
+			bctx   = raws.WithRegions(ctx, res.Region)
+		)
+
+		ps, err := r.GetBucketPolicyStatus(bctx, &s3.GetBucketPolicyStatusInput{Bucket: bucket})
+		if o, ok := ps[res.Region]; ok {
+			access.PolicyStatus = o.PolicyStatus
+		} else if !hasCode(err, "NoSuchBucketPolicy") {
+			errs = appendErrors(errs, err)
+		}
+
+		acl, err := r.GetBucketAcl(bctx, &s3.GetBucketAclInput{Bucket: bucket})
+		errs = appendErrors(errs, err)
+		if o, ok := acl[res.Region]; ok {
+			access.Grants = o.Grants
+		}
+
+		pab, err := r.GetPublicAccessBlock(bctx, &s3.GetPublicAccessBlockInput{Bucket: bucket})
+		if o, ok := pab[res.Region]; ok {
+			access.PublicAccessBlock = o.PublicAccessBlockConfiguration
+		} else if !hasCode(err, "NoSuchPublicAccessBlockConfiguration") {
+			errs = appendErrors(errs, err)
+		}
+
+		d.Buckets[res.ID] = &access
+	}
+
+	if errs != nil {
+		return d, errs
+	}
+
+	return d, nil
+}
+
+// appendErrors appends to errs the errors of err, which
+// is a raws.Error or raws.Errors when it's not nil
+func appendErrors(errs raws.Errors, err error) raws.Errors {
+	switch e := err.(type) {
+	case nil:
+	case raws.Errors:
+		errs = append(errs, e...)
+	case raws.Error:
+		errs = append(errs, e)
+	default:
+		errs = append(errs, raws.NewError("", "", err))
+	}
+
+	return errs
+}
+
+// hasCode returns if err, which is a raws.Error or raws.Errors,
+// has any error from the AWS SDK with the code
+func hasCode(err error, code string) bool {
+	for _, e := range appendErrors(nil, err) {
+		var aerr awserr.Error
+		if errors.As(e, &aerr) && aerr.Code() == code {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,394 @@
+package security
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/cycloidio/raws"
+)
+
+func newResource(t *testing.T, region string, v interface{}) raws.Resource {
+	r, err := raws.NewResource("123", region, v)
+	if err != nil {
+		t.Fatalf("resource - errors: received=%+v | expected=nil", err)
+	}
+
+	return r
+}
+
+func newData(t *testing.T) *Data {
+	var (
+		now   = time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+		admin = url.QueryEscape(`{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"*","Resource":"*"}}`)
+		read  = url.QueryEscape(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:Get*"],"Resource":"*"}]}`)
+	)
+
+	return &Data{
+		AccountID:   "123",
+		CollectedAt: now,
+		Resources: []raws.Resource{
+			newResource(t, "eu-west-1", &ec2.SecurityGroup{
+				GroupId: aws.String("sg-1"),
+				IpPermissions: []*ec2.IpPermission{
+					{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(443), ToPort: aws.Int64(443), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}}},
+					{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(20), ToPort: aws.Int64(22), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}}},
+					{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(3306), ToPort: aws.Int64(3306), Ipv6Ranges: []*ec2.Ipv6Range{{CidrIpv6: aws.String("::/0")}}},
+					{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(5432), ToPort: aws.Int64(5432), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/8")}}},
+				},
+			}),
+			newResource(t, "eu-west-1", &ec2.SecurityGroup{
+				GroupId:       aws.String("sg-2"),
+				IpPermissions: []*ec2.IpPermission{{IpProtocol: aws.String("-1"), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}}}},
+			}),
+			newResource(t, "eu-west-1", &ec2.Snapshot{SnapshotId: aws.String("snap-1"), Encrypted: aws.Bool(false)}),
+			newResource(t, "eu-west-1", &ec2.Snapshot{SnapshotId: aws.String("snap-2"), Encrypted: aws.Bool(true)}),
+			newResource(t, "eu-west-1", &ec2.Image{
+				ImageId: aws.String("ami-1"),
+				Public:  aws.Bool(true),
+				BlockDeviceMappings: []*ec2.BlockDeviceMapping{
+					{DeviceName: aws.String("/dev/sda1"), Ebs: &ec2.EbsBlockDevice{Encrypted: aws.Bool(false)}},
+					{DeviceName: aws.String("/dev/sdb"), Ebs: &ec2.EbsBlockDevice{Encrypted: aws.Bool(true)}},
+				},
+			}),
+			newResource(t, "eu-west-1", &ec2.Volume{VolumeId: aws.String("vol-1"), Encrypted: aws.Bool(false)}),
+			newResource(t, "eu-west-1", &ec2.Volume{VolumeId: aws.String("vol-2"), Encrypted: aws.Bool(true)}),
+			newResource(t, "eu-west-1", raws.DBInstanceWithTags{Item: &rds.DBInstance{
+				DBInstanceIdentifier: aws.String("db-1"),
+				DBInstanceArn:        aws.String("arn:aws:rds:eu-west-1:123:db:db-1"),
+				StorageEncrypted:     aws.Bool(false),
+			}}),
+			newResource(t, "", &iam.AccessKeyMetadata{AccessKeyId: aws.String("AKIA1"), UserName: aws.String("alice"), Status: aws.String("Active"), CreateDate: aws.Time(now.AddDate(0, 0, -100))}),
+			newResource(t, "", &iam.AccessKeyMetadata{AccessKeyId: aws.String("AKIA2"), UserName: aws.String("alice"), Status: aws.String("Inactive"), CreateDate: aws.Time(now.AddDate(0, 0, -100))}),
+			newResource(t, "", &iam.AccessKeyMetadata{AccessKeyId: aws.String("AKIA3"), UserName: aws.String("bob"), Status: aws.String("Active"), CreateDate: aws.Time(now.AddDate(0, 0, -10))}),
+			newResource(t, "", raws.UserWithPolicies{Item: &iam.User{UserId: aws.String("AIDA1"), UserName: aws.String("alice"), Arn: aws.String("arn:aws:iam::123:user/alice")}}),
+			newResource(t, "", raws.UserWithPolicies{Item: &iam.User{UserId: aws.String("AIDA2"), UserName: aws.String("bob"), Arn: aws.String("arn:aws:iam::123:user/bob")}}),
+			newResource(t, "eu-west-1", &s3.Bucket{Name: aws.String("public")}),
+			newResource(t, "eu-west-1", &s3.Bucket{Name: aws.String("blocked")}),
+		},
+		PasswordPolicy: &iam.PasswordPolicy{
+			MinimumPasswordLength:      aws.Int64(8),
+			RequireUppercaseCharacters: aws.Bool(true),
+			RequireLowercaseCharacters: aws.Bool(true),
+			RequireNumbers:             aws.Bool(true),
+			RequireSymbols:             aws.Bool(true),
+			MaxPasswordAge:             aws.Int64(90),
+			PasswordReusePrevention:    aws.Int64(24),
+		},
+		MFADevices: map[string][]*iam.MFADevice{
+			"alice": {},
+			"bob":   {{SerialNumber: aws.String("arn:aws:iam::123:mfa/bob")}},
+		},
+		Authorization: &iam.GetAccountAuthorizationDetailsOutput{
+			UserDetailList: []*iam.UserDetail{{
+				Arn:            aws.String("arn:aws:iam::123:user/alice"),
+				UserPolicyList: []*iam.PolicyDetail{{PolicyName: aws.String("admin"), PolicyDocument: aws.String(admin)}},
+			}},
+			RoleDetailList: []*iam.RoleDetail{{
+				Arn:            aws.String("arn:aws:iam::123:role/reader"),
+				RoleId:         aws.String("AROA1"),
+				RoleName:       aws.String("reader"),
+				RolePolicyList: []*iam.PolicyDetail{{PolicyName: aws.String("read"), PolicyDocument: aws.String(read)}},
+			}},
+			Policies: []*iam.ManagedPolicyDetail{{
+				Arn:        aws.String("arn:aws:iam::123:policy/everything"),
+				PolicyId:   aws.String("ANPA1"),
+				PolicyName: aws.String("everything"),
+				PolicyVersionList: []*iam.PolicyVersion{
+					{IsDefaultVersion: aws.Bool(false), Document: aws.String(read)},
+					{IsDefaultVersion: aws.Bool(true), Document: aws.String(admin)},
+				},
+			}},
+		},
+		PublicSnapshots: []string{"snap-1"},
+		Buckets: map[string]*BucketAccess{
+			"public": {
+				PolicyStatus: &s3.PolicyStatus{IsPublic: aws.Bool(true)},
+				Grants:       []*s3.Grant{{Grantee: &s3.Grantee{URI: aws.String("http://acs.amazonaws.com/groups/global/AllUsers")}, Permission: aws.String("READ")}},
+			},
+			"blocked": {
+				PolicyStatus:      &s3.PolicyStatus{IsPublic: aws.Bool(true)},
+				PublicAccessBlock: &s3.PublicAccessBlockConfiguration{RestrictPublicBuckets: aws.Bool(true)},
+			},
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	var (
+		d        = newData(t)
+		expected = []struct{ rule, id, message string }{
+			{"ec2-snapshot-public", "snap-1", "the snapshot can be restored by anyone"},
+			{"s3-bucket-public", "public", "the bucket is public by its policy and its ACL (READ)"},
+			{"ec2-image-public", "ami-1", "the image can be launched by anyone"},
+			{"ec2-security-group-open", "sg-1", "the ports 20/tcp, 21/tcp, 22/tcp, 3306/tcp are open to the Internet"},
+			{"ec2-security-group-open", "sg-2", "all the ports are open to the Internet"},
+			{"iam-policy-wildcard", "ANPA1", "the policy allows all the actions on all the resources"},
+			{"iam-policy-wildcard", "AIDA1", "the inline policy admin allows all the actions on all the resources"},
+			{"iam-user-no-mfa", "AIDA1", "the user has no MFA device"},
+			{"ec2-image-unencrypted", "ami-1", "the snapshots of the devices /dev/sda1 are not encrypted"},
+			{"ec2-snapshot-unencrypted", "snap-1", "the snapshot is not encrypted"},
+			{"ec2-volume-unencrypted", "vol-1", "the volume is not encrypted"},
+			{"iam-access-key-stale", "AKIA1", "the access key of alice has been created 100 days ago"},
+			{"iam-password-policy-weak", "123", "the minimum length is lower than 14"},
+			{"rds-instance-unencrypted", "db-1", "the storage of the instance is not encrypted"},
+		}
+	)
+
+	findings := Run(d, Rules, "")
+	if len(findings) != len(expected) {
+		t.Fatalf("findings - count: received=%d | expected=%d (%+v)", len(findings), len(expected), findings)
+	}
+
+	for i, f := range findings {
+		e := expected[i]
+		if f.RuleID != e.rule || f.ID != e.id || f.Message != e.message {
+			t.Errorf("findings [%d] - finding: received=%s %s %q | expected=%s %s %q", i, f.RuleID, f.ID, f.Message, e.rule, e.id, e.message)
+		}
+	}
+
+	if findings[0].Severity != Critical || findings[0].Region != "eu-west-1" || findings[0].Key != "arn:aws:ec2:eu-west-1::snapshot/snap-1" {
+		t.Errorf("findings - first: received=%+v", findings[0])
+	}
+
+	high := Run(d, Rules, High)
+	if len(high) != 8 {
+		t.Errorf("high - count: received=%d | expected=8", len(high))
+	}
+
+	// The Data read from JSON has the same findings
+	b, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("json - errors: received=%+v | expected=nil", err)
+	}
+
+	var jd Data
+	err = json.Unmarshal(b, &jd)
+	if err != nil {
+		t.Fatalf("json - errors: received=%+v | expected=nil", err)
+	}
+
+	jfindings := Run(&jd, Rules, "")
+	if !reflect.DeepEqual(jfindings, findings) {
+		t.Errorf("json - findings: received=%+v | expected=%+v", jfindings, findings)
+	}
+
+	d.PasswordPolicy = &iam.PasswordPolicy{}
+	if f := weakPasswordPolicy(d); len(f) != 1 || f[0].Message != "the account has no password policy" {
+		t.Errorf("no password policy - findings: received=%+v", f)
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var (
+		buf      bytes.Buffer
+		findings = Run(newData(t), Rules, Critical)
+	)
+
+	err := WriteSARIF(&buf, Rules, findings)
+	if err != nil {
+		t.Fatalf("sarif - errors: received=%+v | expected=nil", err)
+	}
+
+	var log struct {
+		Version string
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct{ ID string }
+				}
+			}
+			Results []struct {
+				RuleID    string
+				RuleIndex int
+				Level     string
+				Locations []struct {
+					LogicalLocations []struct{ FullyQualifiedName string }
+				}
+			}
+		}
+	}
+	err = json.Unmarshal(buf.Bytes(), &log)
+	if err != nil {
+		t.Fatalf("sarif - errors: received=%+v | expected=nil", err)
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || len(log.Runs[0].Tool.Driver.Rules) != len(Rules) || len(log.Runs[0].Results) != 2 {
+		t.Fatalf("sarif - log: received=%s", buf.String())
+	}
+
+	res := log.Runs[0].Results[1]
+	if res.RuleID != "s3-bucket-public" || log.Runs[0].Tool.Driver.Rules[res.RuleIndex].ID != res.RuleID || res.Level != "error" ||
+		res.Locations[0].LogicalLocations[0].FullyQualifiedName != "arn:aws:s3:::public" {
+		t.Errorf("sarif - result: received=%+v", res)
+	}
+}
+
+// mockReader returns the outputs and the errors of its methods
+// only for the regions of their contexts, and keeps those regions
+// by method on calls
+type mockReader struct {
+	raws.AWSReader
+
+	calls map[string][]string
+}
+
+func (m mockReader) GetAccountID() string {
+	return "123"
+}
+
+func (m mockReader) GetRegions() []string {
+	return []string{"eu-west-1", "eu-west-3"}
+}
+
+// regions records the regions of the call of the method with ctx, and
+// returns the outputs, a map by region, and the errors of those regions
+func (m mockReader) regions(ctx context.Context, method string, outputs interface{}, errs raws.Errors) error {
+	regions := raws.ContextRegions(ctx, m.GetRegions())
+	m.calls[method] = append(m.calls[method], regions...)
+
+	v := reflect.ValueOf(outputs)
+	for _, k := range v.MapKeys() {
+		if !containsString(regions, k.String()) {
+			v.SetMapIndex(k, reflect.Value{})
+		}
+	}
+
+	var rerrs raws.Errors
+	for _, e := range errs {
+		if containsString(regions, e.Region()) {
+			rerrs = append(rerrs, e)
+		}
+	}
+	if rerrs != nil {
+		return rerrs
+	}
+
+	return nil
+}
+
+func (m mockReader) GetAccessKeys(ctx context.Context, input *iam.ListAccessKeysInput) (map[string]iam.ListAccessKeysOutput, error) {
+	opts := map[string]iam.ListAccessKeysOutput{"eu-west-1": {AccessKeyMetadata: []*iam.AccessKeyMetadata{
+		{AccessKeyId: aws.String("AKIA1"), UserName: input.UserName},
+	}}}
+	err := m.regions(ctx, "GetAccessKeys", opts, raws.Errors{raws.NewError("eu-west-3", "iam", errors.New("throttled"))})
+	return opts, err
+}
+
+func (m mockReader) GetMFADevices(ctx context.Context, _ *iam.ListMFADevicesInput) (map[string]iam.ListMFADevicesOutput, error) {
+	opts := map[string]iam.ListMFADevicesOutput{"eu-west-3": {}}
+	err := m.regions(ctx, "GetMFADevices", opts, raws.Errors{raws.NewError("eu-west-1", "iam", errors.New("denied"))})
+	return opts, err
+}
+
+func (m mockReader) GetAccountPasswordPolicy(ctx context.Context, _ *iam.GetAccountPasswordPolicyInput) (map[string]iam.GetAccountPasswordPolicyOutput, error) {
+	opts := map[string]iam.GetAccountPasswordPolicyOutput{}
+	err := m.regions(ctx, "GetAccountPasswordPolicy", opts, raws.Errors{raws.NewError("eu-west-1", "iam", awserr.New(iam.ErrCodeNoSuchEntityException, "no policy", nil))})
+	return opts, err
+}
+
+func (m mockReader) GetAccountAuthorizationDetails(ctx context.Context, _ *iam.GetAccountAuthorizationDetailsInput) (map[string]iam.GetAccountAuthorizationDetailsOutput, error) {
+	opts := map[string]iam.GetAccountAuthorizationDetailsOutput{"eu-west-1": {}}
+	err := m.regions(ctx, "GetAccountAuthorizationDetails", opts, nil)
+	return opts, err
+}
+
+func (m mockReader) GetSnapshots(ctx context.Context, _ *ec2.DescribeSnapshotsInput) (map[string]ec2.DescribeSnapshotsOutput, error) {
+	opts := map[string]ec2.DescribeSnapshotsOutput{"eu-west-3": {Snapshots: []*ec2.Snapshot{{SnapshotId: aws.String("snap-1")}}}}
+	err := m.regions(ctx, "GetSnapshots", opts, nil)
+	return opts, err
+}
+
+func (m mockReader) GetBucketPolicyStatus(ctx context.Context, _ *s3.GetBucketPolicyStatusInput) (map[string]s3.GetBucketPolicyStatusOutput, error) {
+	opts := map[string]s3.GetBucketPolicyStatusOutput{}
+	err := m.regions(ctx, "GetBucketPolicyStatus", opts, raws.Errors{
+		raws.NewError("eu-west-1", "s3", errors.New("redirect")),
+		raws.NewError("eu-west-3", "s3", awserr.New("NoSuchBucketPolicy", "no policy", nil)),
+	})
+	return opts, err
+}
+
+func (m mockReader) GetBucketAcl(ctx context.Context, _ *s3.GetBucketAclInput) (map[string]s3.GetBucketAclOutput, error) {
+	opts := map[string]s3.GetBucketAclOutput{"eu-west-3": {Grants: []*s3.Grant{{Permission: aws.String("FULL_CONTROL")}}}}
+	err := m.regions(ctx, "GetBucketAcl", opts, raws.Errors{raws.NewError("eu-west-1", "s3", errors.New("redirect"))})
+	return opts, err
+}
+
+func (m mockReader) GetPublicAccessBlock(ctx context.Context, _ *s3.GetPublicAccessBlockInput) (map[string]s3.GetPublicAccessBlockOutput, error) {
+	opts := map[string]s3.GetPublicAccessBlockOutput{}
+	err := m.regions(ctx, "GetPublicAccessBlock", opts, raws.Errors{raws.NewError("eu-west-3", "s3", errors.New("denied"))})
+	return opts, err
+}
+
+func TestCollect(t *testing.T) {
+	var (
+		r    = mockReader{calls: make(map[string][]string)}
+		user = newResource(t, "", raws.UserWithPolicies{Item: &iam.User{UserId: aws.String("AIDA1"), UserName: aws.String("alice")}})
+		bckt = newResource(t, "eu-west-3", &s3.Bucket{Name: aws.String("bucket-1")})
+	)
+
+	defer func() { inventory = raws.Inventory }()
+	inventory = func(_ context.Context, _ raws.AWSReader, opts raws.InventoryOptions) (*raws.Snapshot, error) {
+		if !reflect.DeepEqual(opts.Services, Services) {
+			t.Errorf("inventory - services: received=%+v | expected=%+v", opts.Services, Services)
+		}
+		return &raws.Snapshot{Resources: []raws.Resource{user, bckt}}, nil
+	}
+
+	d, err := Collect(context.Background(), r)
+
+	expectedErrors := raws.Errors{
+		raws.NewError("eu-west-1", "iam", errors.New("denied")),
+		raws.NewError("eu-west-3", "s3", errors.New("denied")),
+	}
+	if !reflect.DeepEqual(err, expectedErrors) {
+		t.Errorf("collect - errors: received=%+v | expected=%+v", err, expectedErrors)
+	}
+
+	if len(d.Resources) != 3 || d.Resources[2].ID != "AKIA1" {
+		t.Errorf("collect - resources: received=%+v | expected=user, bucket and access key", d.Resources)
+	}
+
+	if _, ok := d.MFADevices["alice"]; ok {
+		t.Errorf("collect - MFA devices: received=%+v | expected=unknown", d.MFADevices)
+	}
+
+	if d.PasswordPolicy == nil || *d.PasswordPolicy != (iam.PasswordPolicy{}) {
+		t.Errorf("collect - password policy: received=%+v | expected=empty", d.PasswordPolicy)
+	}
+
+	if !reflect.DeepEqual(d.PublicSnapshots, []string{"snap-1"}) || d.Authorization == nil {
+		t.Errorf("collect - data: received=%+v", d)
+	}
+
+	// IAM is called once on the first region and S3 on the region of the bucket
+	expectedCalls := map[string][]string{
+		"GetAccessKeys":                  {"eu-west-1"},
+		"GetMFADevices":                  {"eu-west-1"},
+		"GetAccountPasswordPolicy":       {"eu-west-1"},
+		"GetAccountAuthorizationDetails": {"eu-west-1"},
+		"GetSnapshots":                   {"eu-west-1", "eu-west-3"},
+		"GetBucketPolicyStatus":          {"eu-west-3"},
+		"GetBucketAcl":                   {"eu-west-3"},
+		"GetPublicAccessBlock":           {"eu-west-3"},
+	}
+	if !reflect.DeepEqual(r.calls, expectedCalls) {
+		t.Errorf("collect - calls: received=%+v | expected=%+v", r.calls, expectedCalls)
+	}
+
+	expectedBucket := &BucketAccess{Grants: []*s3.Grant{{Permission: aws.String("FULL_CONTROL")}}}
+	if !reflect.DeepEqual(d.Buckets["bucket-1"], expectedBucket) {
+		t.Errorf("collect - bucket: received=%+v | expected=%+v", d.Buckets["bucket-1"], expectedBucket)
+	}
+}
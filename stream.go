@@ -0,0 +1,117 @@
+package raws
+
+import "context"
+
+// Result is one of the results of a method of an AWSReader,
+// sent to the ResultFunc of the context given to the method
+type Result struct {
+	// Method is the name of the method, like "GetInstances"
+	Method string
+
+	// Region is the region of the result
+	Region string
+
+	// Page is the number of the page of the Output on the Region,
+	// starting at 1, as the methods returning all the pages of
+	// their calls on a single output send each page on its own
+	Page int
+
+	// Output is the value the method returns for the Region,
+	// like an ec2.DescribeInstancesOutput, with only the items
+	// of the Page, or nil if there is an Err
+	Output interface{}
+
+	// Err is the error of the Region, which is an Error
+	// for the errors returned by the method as Errors
+	Err error
+}
+
+// ResultFunc receives the results of the methods of an AWSReader
+type ResultFunc func(res Result)
+
+// resultFuncKey is the key of the ResultFunc of the contexts
+type resultFuncKey struct{}
+
+// WithResultFunc returns a copy of ctx with fn, so the methods of the
+// AWSReader called with it send their results to fn, region by region
+// and page by page, as they arrive, instead of returning them.
+// The maps returned by those methods have no outputs, so all the results
+// don't have to be kept in memory, but their errors are still returned,
+// after being sent to fn.
+// DownloadObject, which writes the object, doesn't send anything, and
+// the functions using the outputs of the methods, like Inventory, find
+// nothing with ctx, so they have to be called with another context.
+// A nil fn makes the methods return their results again.
+func WithResultFunc(ctx context.Context, fn ResultFunc) context.Context {
+	return context.WithValue(ctx, resultFuncKey{}, fn)
+}
+
+// SendResult sends res to the ResultFunc of ctx, and returns false if
+// ctx doesn't have any, in which case the result has to be returned.
+// It's used by the implementations of the AWSReader, which send
+// their results with it instead of returning them.
+func SendResult(ctx context.Context, res Result) bool {
+	fn := resultFunc(ctx)
+	if fn == nil {
+		return false
+	}
+
+	fn(res)
+
+	return true
+}
+
+// resultFunc returns the ResultFunc of ctx, or nil if there is none
+func resultFunc(ctx context.Context) ResultFunc {
+	fn, _ := ctx.Value(resultFuncKey{}).(ResultFunc)
+
+	return fn
+}
+
+// Stream calls fn, in a goroutine, with a copy of ctx sending the results
+// of the methods of the AWSReader called with it on the returned channel,
+// which is closed once fn returns, and on which the error returned by fn
+// is the last Result if it's not Errors, which are sent on their region.
+// The methods wait for their results to be received, so the channel has
+// to be drained, or ctx canceled, for fn to end.
+func Stream(ctx context.Context, fn func(ctx context.Context) error) <-chan Result {
+	results := make(chan Result)
+
+	send := func(res Result) {
+		select {
+		case results <- res:
+		case <-ctx.Done():
+		}
+	}
+
+	go func() {
+		defer close(results)
+
+		err := fn(WithResultFunc(ctx, send))
+		if _, ok := err.(Errors); err != nil && !ok {
+			send(Result{Err: err})
+		}
+	}()
+
+	return results
+}
+
+// sendOutput sends the output of the page of the method on the
+// region to the ResultFunc of ctx, and reports it to the progress
+// of the method, and returns false if there is no ResultFunc, in
+// which case the output has to be returned
+func sendOutput(ctx context.Context, method, region string, page int, out interface{}) bool {
+	progressResult(ctx, method, region, out, nil)
+
+	return SendResult(ctx, Result{Method: method, Region: region, Page: page, Output: out})
+}
+
+// sendError sends the err of the method to the ResultFunc of ctx,
+// if any, and reports it to the progress of the method, and
+// returns it to be returned on the Errors
+func sendError(ctx context.Context, method string, err Error) Error {
+	progressResult(ctx, method, err.Region(), nil, err)
+	SendResult(ctx, Result{Method: method, Region: err.Region(), Err: err})
+
+	return err
+}
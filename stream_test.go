@@ -0,0 +1,97 @@
+package raws
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestStream(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		deny = awserr.New("UnauthorizedOperation", "denied", nil)
+		dio  = &ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{ReservationId: aws.String("r-1")}}}
+		c    = &connector{
+			regions: []string{"eu-west-1", "eu-west-3"},
+			svcs: []*serviceConnector{
+				{region: "eu-west-1", ec2: mockEC2{dio: dio}},
+				{region: "eu-west-3", ec2: mockEC2{dierr: deny}},
+			},
+		}
+	)
+
+	tests := []struct {
+		name     string
+		fn       func(ctx context.Context) error
+		expected []Result
+	}{
+		{
+			name: "results",
+			fn: func(ctx context.Context) error {
+				opts, err := c.GetInstances(ctx, nil)
+				if len(opts) != 0 {
+					t.Errorf("results - outputs: received=%+v | expected=%+v", opts, map[string]ec2.DescribeInstancesOutput{})
+				}
+				return err
+			},
+			expected: []Result{
+				{Method: "GetInstances", Region: "eu-west-1", Page: 1, Output: *dio},
+				{Method: "GetInstances", Region: "eu-west-3", Err: NewError("eu-west-3", ec2.ServiceName, deny)},
+			},
+		},
+		{
+			name: "error",
+			fn: func(ctx context.Context) error {
+				return errors.New("failed")
+			},
+			expected: []Result{
+				{Err: errors.New("failed")},
+			},
+		},
+		{
+			name: "without results",
+			fn: func(ctx context.Context) error {
+				opts, _ := c.GetInstances(WithResultFunc(ctx, nil), nil)
+				if !reflect.DeepEqual(opts, map[string]ec2.DescribeInstancesOutput{"eu-west-1": *dio}) {
+					t.Errorf("without results - outputs: received=%+v | expected=%+v", opts, map[string]ec2.DescribeInstancesOutput{"eu-west-1": *dio})
+				}
+				return nil
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		var results []Result
+		for res := range Stream(ctx, tt.fn) {
+			results = append(results, res)
+		}
+
+		if !reflect.DeepEqual(results, tt.expected) {
+			t.Errorf("%s [%d] - results: received=%+v | expected=%+v", tt.name, i, results, tt.expected)
+		}
+	}
+}
+
+func TestStream_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	results := Stream(ctx, func(ctx context.Context) error {
+		defer close(done)
+		SendResult(ctx, Result{Method: "GetInstances", Region: "eu-west-1", Page: 1})
+		return nil
+	})
+
+	// The result is never received, so it's dropped once ctx is canceled
+	cancel()
+	<-done
+
+	for res := range results {
+		t.Errorf("canceled - result: received=%+v | expected=none", res)
+	}
+}
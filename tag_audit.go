@@ -0,0 +1,298 @@
+package raws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// TagPolicy are the rules the tags of the resources have to comply with,
+// like the tags required for the cost allocation, which are checked with
+// TagPolicy.Check or AuditTags.
+//
+// It can be read with ReadTagPolicy from YAML or JSON like:
+//
+//	rules:
+//	- resources: ["*"]
+//	  exclude: ["iam/access-key"]
+//	  required: [env, cost-center]
+//	  allowed:
+//	    env: [prod, staging, dev]
+//	  patterns:
+//	    cost-center: "[0-9]{4}"
+//	- resources: ["ec2/instance", "rds/*"]
+//	  required: [owner]
+type TagPolicy struct {
+	Rules []TagRule `json:"rules" yaml:"rules"`
+}
+
+// TagRule are the tags required, and the values allowed, on the resources
+// of some types. When a resource is selected by multiple rules it has to
+// comply with all of them.
+type TagRule struct {
+	// Resources are the patterns of the resources to which the rule is
+	// applied, matched against "<service>/<type>" of the Resource with the
+	// syntax of path.Match, like "ec2/instance", "ec2/*" or "*". The rule
+	// is applied to all the resources if it's empty
+	Resources []string `json:"resources,omitempty" yaml:"resources,omitempty"`
+
+	// Exclude are the patterns, with the same syntax than Resources,
+	// of the resources to which the rule is not applied
+	Exclude []string `json:"exclude,omitempty" yaml:"exclude,omitempty"`
+
+	// Required are the keys of the tags the resources must have
+	Required []string `json:"required,omitempty" yaml:"required,omitempty"`
+
+	// Allowed are the values allowed for the tags of each key,
+	// which are only checked when the resources have the tag
+	Allowed map[string][]string `json:"allowed,omitempty" yaml:"allowed,omitempty"`
+
+	// Patterns are the regular expressions which the whole value of the
+	// tags of each key must match, which are only checked when the
+	// resources have the tag
+	Patterns map[string]string `json:"patterns,omitempty" yaml:"patterns,omitempty"`
+}
+
+// TagReport is the result of the check of a TagPolicy
+type TagReport struct {
+	// Checked is the number of resources to
+	// which at least one rule has been applied
+	Checked int `json:"checked"`
+
+	// NonCompliant are the resources with missing or invalid
+	// tags, sorted by their Key
+	NonCompliant []TagViolation `json:"non_compliant"`
+}
+
+// TagViolation are the missing and invalid tags of a resource
+type TagViolation struct {
+	Key     string `json:"key"`
+	ARN     string `json:"arn,omitempty"`
+	Service string `json:"service"`
+	Type    string `json:"type"`
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Region  string `json:"region,omitempty"`
+
+	Missing []string     `json:"missing,omitempty"`
+	Invalid []InvalidTag `json:"invalid,omitempty"`
+}
+
+// InvalidTag is a tag with a value which is not allowed, either because it's
+// not one of the Allowed values or because it doesn't match the Pattern
+type InvalidTag struct {
+	Key     string   `json:"key"`
+	Value   string   `json:"value"`
+	Allowed []string `json:"allowed,omitempty"`
+	Pattern string   `json:"pattern,omitempty"`
+}
+
+// ReadTagPolicy reads from r a TagPolicy written in YAML or JSON.
+// An error is returned if any of its patterns is not valid.
+func ReadTagPolicy(r io.Reader) (*TagPolicy, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var p TagPolicy
+	err = yaml.UnmarshalStrict(b, &p)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// Check applies the rules of p to the resources and returns the
+// ones which don't comply with them.
+// An error is returned if any of the patterns of p is not valid.
+func (p *TagPolicy) Check(resources []Resource) (*TagReport, error) {
+	patterns, err := p.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &TagReport{NonCompliant: []TagViolation{}}
+	for _, r := range resources {
+		var (
+			applied bool
+			v       = TagViolation{
+				Key:     r.Key(),
+				ARN:     r.ARN,
+				Service: r.Service,
+				Type:    r.Type,
+				ID:      r.ID,
+				Name:    r.Name,
+				Region:  r.Region,
+			}
+		)
+
+		for i, rule := range p.Rules {
+			if !rule.applies(r) {
+				continue
+			}
+			applied = true
+
+			for _, k := range rule.Required {
+				if _, ok := r.Tags[k]; !ok && !containsString(v.Missing, k) {
+					v.Missing = append(v.Missing, k)
+				}
+			}
+
+			for k, allowed := range rule.Allowed {
+				if val, ok := r.Tags[k]; ok && !containsString(allowed, val) {
+					v.addInvalid(InvalidTag{Key: k, Value: val, Allowed: allowed})
+				}
+			}
+
+			for k, re := range patterns[i] {
+				if val, ok := r.Tags[k]; ok && !re.MatchString(val) {
+					v.addInvalid(InvalidTag{Key: k, Value: val, Pattern: rule.Patterns[k]})
+				}
+			}
+		}
+
+		if !applied {
+			continue
+		}
+
+		// The maps of the rules are walked in random order
+		sort.SliceStable(v.Invalid, func(i, j int) bool {
+			if v.Invalid[i].Key != v.Invalid[j].Key {
+				return v.Invalid[i].Key < v.Invalid[j].Key
+			}
+			return v.Invalid[i].Pattern < v.Invalid[j].Pattern
+		})
+
+		report.Checked++
+		if len(v.Missing) > 0 || len(v.Invalid) > 0 {
+			report.NonCompliant = append(report.NonCompliant, v)
+		}
+	}
+
+	sort.SliceStable(report.NonCompliant, func(i, j int) bool {
+		return report.NonCompliant[i].Key < report.NonCompliant[j].Key
+	})
+
+	return report, nil
+}
+
+// services returns the services of the resources to which the rules of p
+// are applied, it's nil if the rules can be applied to any service
+func (p *TagPolicy) services() []string {
+	var services []string
+	for _, rule := range p.Rules {
+		if len(rule.Resources) == 0 {
+			return nil
+		}
+
+		for _, res := range rule.Resources {
+			s := strings.SplitN(res, "/", 2)[0]
+			if strings.ContainsAny(s, `*?[\`) {
+				return nil
+			}
+			if !containsString(services, s) {
+				services = append(services, s)
+			}
+		}
+	}
+
+	return services
+}
+
+// compile validates the patterns of the rules of p and returns, for
+// each rule, the regular expressions of its Patterns
+func (p *TagPolicy) compile() ([]map[string]*regexp.Regexp, error) {
+	res := make([]map[string]*regexp.Regexp, len(p.Rules))
+	for i, rule := range p.Rules {
+		for _, m := range append(append([]string{}, rule.Resources...), rule.Exclude...) {
+			if _, err := path.Match(m, ""); err != nil {
+				return nil, fmt.Errorf("invalid resources pattern %q of the rule %d: %s", m, i, err)
+			}
+		}
+
+		res[i] = make(map[string]*regexp.Regexp)
+		for k, pt := range rule.Patterns {
+			re, err := regexp.Compile(`^(?:` + pt + `)$`)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern of the tag %q of the rule %d: %s", k, i, err)
+			}
+			res[i][k] = re
+		}
+	}
+
+	return res, nil
+}
+
+// applies returns if the rule is applied to the resource r
+func (rule TagRule) applies(r Resource) bool {
+	match := func(patterns []string) bool {
+		for _, m := range patterns {
+			if ok, _ := path.Match(m, r.Service+"/"+r.Type); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(rule.Resources) > 0 && !match(rule.Resources) {
+		return false
+	}
+
+	return !match(rule.Exclude)
+}
+
+// addInvalid adds t to the invalid tags of v, unless a
+// previous rule already reported the same constraint
+func (v *TagViolation) addInvalid(t InvalidTag) {
+	for _, i := range v.Invalid {
+		if i.Key == t.Key && i.Pattern == t.Pattern && equalStrings(i.Allowed, t.Allowed) {
+			return
+		}
+	}
+
+	v.Invalid = append(v.Invalid, t)
+}
+
+// AuditTags checks the TagPolicy p against all the resources of r, found
+// with Inventory on the services to which the rules are applied.
+// As Inventory, the report is always returned, for the resources found by
+// the calls without errors, and the errors of the calls are returned as
+// Errors.
+func AuditTags(ctx context.Context, r AWSReader, p *TagPolicy) (*TagReport, error) {
+	s, ierr := Inventory(ctx, r, InventoryOptions{Services: p.services()})
+
+	report, err := p.Check(s.Resources)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, ierr
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
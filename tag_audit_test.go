@@ -0,0 +1,193 @@
+package raws
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestTagPolicy_Check(t *testing.T) {
+	var (
+		instance = Resource{ARN: "arn:aws:ec2:eu-west-1:123:instance/i-1", Service: "ec2", Type: "instance", ID: "i-1", Region: "eu-west-1",
+			Tags: map[string]string{"env": "qa", "cost-center": "12a"}}
+		volume = Resource{ARN: "arn:aws:ec2:eu-west-1:123:volume/vol-1", Service: "ec2", Type: "volume", ID: "vol-1", Region: "eu-west-1",
+			Tags: map[string]string{"env": "prod", "cost-center": "1234"}}
+		key = Resource{Service: "iam", Type: "access-key", ID: "AKIA1", AccountID: "123"}
+
+		resources = []Resource{volume, instance, key}
+		policy    = TagPolicy{Rules: []TagRule{
+			{
+				Exclude:  []string{"iam/*"},
+				Required: []string{"env", "cost-center"},
+				Allowed:  map[string][]string{"env": {"prod", "dev"}},
+				Patterns: map[string]string{"cost-center": "[0-9]{4}", "env": "[a-z]+"},
+			},
+			{
+				Resources: []string{"ec2/instance", "rds/*"},
+				Required:  []string{"owner", "env"},
+				Allowed:   map[string][]string{"env": {"prod", "dev"}},
+			},
+		}}
+	)
+
+	tests := []struct {
+		name           string
+		policy         TagPolicy
+		expectedReport *TagReport
+		expectedError  string
+	}{
+		{name: "missing and invalid",
+			policy: policy,
+			expectedReport: &TagReport{
+				Checked: 2,
+				NonCompliant: []TagViolation{
+					{
+						Key: instance.ARN, ARN: instance.ARN, Service: "ec2", Type: "instance", ID: "i-1", Region: "eu-west-1",
+						Missing: []string{"owner"},
+						Invalid: []InvalidTag{
+							{Key: "cost-center", Value: "12a", Pattern: "[0-9]{4}"},
+							{Key: "env", Value: "qa", Allowed: []string{"prod", "dev"}},
+						},
+					},
+				},
+			},
+		},
+		{name: "all resources",
+			policy: TagPolicy{Rules: []TagRule{{Required: []string{"env"}}}},
+			expectedReport: &TagReport{
+				Checked: 3,
+				NonCompliant: []TagViolation{
+					{Key: key.Key(), Service: "iam", Type: "access-key", ID: "AKIA1", Missing: []string{"env"}},
+				},
+			},
+		},
+		{name: "compliant",
+			policy:         TagPolicy{Rules: []TagRule{{Resources: []string{"ec2/volume"}, Required: []string{"env"}}}},
+			expectedReport: &TagReport{Checked: 1, NonCompliant: []TagViolation{}},
+		},
+		{name: "invalid regexp",
+			policy:        TagPolicy{Rules: []TagRule{{Patterns: map[string]string{"env": "[a-z"}}}},
+			expectedError: `invalid pattern of the tag "env" of the rule 0: error parsing regexp: missing closing ]: ` + "`[a-z)$`",
+		},
+		{name: "invalid resources",
+			policy:        TagPolicy{Rules: []TagRule{{Resources: []string{"ec2/["}}}},
+			expectedError: `invalid resources pattern "ec2/[" of the rule 0: syntax error in pattern`,
+		}}
+
+	for i, tt := range tests {
+		report, err := tt.policy.Check(resources)
+		if tt.expectedError != "" {
+			if err == nil || err.Error() != tt.expectedError {
+				t.Errorf("%s [%d] - errors: received=%+v | expected=%+v", tt.name, i, err, tt.expectedError)
+			}
+			continue
+		}
+		checkErrors(t, tt.name, i, err, nil)
+
+		if !reflect.DeepEqual(report, tt.expectedReport) {
+			t.Errorf("%s [%d] - report: received=%+v | expected=%+v", tt.name, i, report, tt.expectedReport)
+		}
+	}
+}
+
+func TestReadTagPolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         string
+		expectedPolicy *TagPolicy
+		expectedError  string
+	}{
+		{name: "yaml",
+			policy: `
+rules:
+- resources: ["ec2/*"]
+  required: [env]
+  allowed:
+    env: [prod, dev]
+  patterns:
+    cost-center: "[0-9]+"
+`,
+			expectedPolicy: &TagPolicy{Rules: []TagRule{{
+				Resources: []string{"ec2/*"},
+				Required:  []string{"env"},
+				Allowed:   map[string][]string{"env": {"prod", "dev"}},
+				Patterns:  map[string]string{"cost-center": "[0-9]+"},
+			}}},
+		},
+		{name: "json",
+			policy:         `{"rules": [{"required": ["env"]}]}`,
+			expectedPolicy: &TagPolicy{Rules: []TagRule{{Required: []string{"env"}}}},
+		},
+		{name: "unknown field",
+			policy:        `{"rules": [{"requried": ["env"]}]}`,
+			expectedError: "yaml: unmarshal errors:\n  line 1: field requried not found in type raws.TagRule",
+		},
+		{name: "invalid pattern",
+			policy:        `{"rules": [{"patterns": {"env": "("}}]}`,
+			expectedError: `invalid pattern of the tag "env" of the rule 0: error parsing regexp: missing closing ): ` + "`^(?:()$`",
+		}}
+
+	for i, tt := range tests {
+		p, err := ReadTagPolicy(strings.NewReader(tt.policy))
+		if tt.expectedError != "" {
+			if err == nil || err.Error() != tt.expectedError {
+				t.Errorf("%s [%d] - errors: received=%+v | expected=%+v", tt.name, i, err, tt.expectedError)
+			}
+			continue
+		}
+		checkErrors(t, tt.name, i, err, nil)
+
+		if !reflect.DeepEqual(p, tt.expectedPolicy) {
+			t.Errorf("%s [%d] - policy: received=%+v | expected=%+v", tt.name, i, p, tt.expectedPolicy)
+		}
+	}
+}
+
+func TestAuditTags(t *testing.T) {
+	var (
+		services []string
+		err1     = NewError("eu-west-1", "ec2", errors.New("error"))
+		r        = &connector{regions: []string{"eu-west-1"}, accountID: aws.String("123")}
+	)
+
+	calls := inventoryCalls
+	defer func() { inventoryCalls = calls }()
+	inventoryCalls = []inventoryCall{
+		{
+			method:  "GetInstances",
+			service: "ec2",
+			call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+				services = append(services, "ec2")
+				return []RegionInstance{
+					{Region: "eu-west-1", Item: &ec2.Instance{InstanceId: aws.String("i-1"), Tags: []*ec2.Tag{{Key: aws.String("env"), Value: aws.String("prod")}}}},
+					{Region: "eu-west-1", Item: &ec2.Instance{InstanceId: aws.String("i-2")}},
+				}, Errors{err1}
+			},
+		},
+		{
+			method:  "GetUsers",
+			service: "iam",
+			call: func(ctx context.Context, r AWSReader) (interface{}, error) {
+				services = append(services, "iam")
+				return nil, nil
+			},
+		},
+	}
+
+	p := &TagPolicy{Rules: []TagRule{{Resources: []string{"ec2/instance"}, Required: []string{"env"}}}}
+	report, err := AuditTags(context.Background(), r, p)
+	checkErrors(t, "audit", 0, err, Errors{err1})
+
+	if !reflect.DeepEqual(services, []string{"ec2"}) {
+		t.Errorf("audit - services: received=%+v | expected=%+v", services, []string{"ec2"})
+	}
+
+	if report.Checked != 2 || len(report.NonCompliant) != 1 || report.NonCompliant[0].ID != "i-2" {
+		t.Errorf("audit - report: received=%+v | expected=1 non compliant of 2", report)
+	}
+}
@@ -0,0 +1,390 @@
+package raws
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+)
+
+// TagQuery is an expression over the tags of the resources, parsed with
+// ParseTagQuery.
+//
+// The expressions are made of the conditions:
+//
+//	env          the tag env exists
+//	env=prod     the tag env has the value prod
+//	env!=prod    the tag env doesn't exist or doesn't have the value prod
+//
+// where the keys and the values ending by "*" match by prefix, like "env=pr*"
+// or "cost-*", and which are joined with AND, OR, NOT (or !) and parentheses,
+// like "env=prod AND (team=web OR NOT team)". AND has precedence over OR and
+// the keywords are case insensitive.
+// The keys and values can be quoted, like "\"cost center\"=\"R&D\"", in which
+// case they can have any character and "*" has no special meaning, which is
+// needed for the keys named like the keywords.
+type TagQuery struct {
+	query string
+	expr  tagExpr
+}
+
+// ParseTagQuery parses the expression q, see TagQuery for its syntax.
+// An error is returned if it's not valid.
+func ParseTagQuery(q string) (*TagQuery, error) {
+	toks, err := lexTagQuery(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(toks) == 0 {
+		return nil, errors.New("the tag query is empty")
+	}
+
+	p := &tagQueryParser{toks: toks}
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.toks) {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.toks[p.pos].text, p.toks[p.pos].at)
+	}
+
+	return &TagQuery{query: q, expr: e}, nil
+}
+
+// Match returns if the tags match the query
+func (q *TagQuery) Match(tags map[string]string) bool {
+	return q.expr.match(tags)
+}
+
+// String returns the expression the query has been parsed from
+func (q *TagQuery) String() string {
+	return q.query
+}
+
+// tagFilters returns the filters of the Resource Groups Tagging API
+// which select, at least, all the resources matching the query, which
+// are the conditions, without prefixes, which must always be true
+func (q *TagQuery) tagFilters() []*resourcegroupstaggingapi.TagFilter {
+	var (
+		filters []*resourcegroupstaggingapi.TagFilter
+		exprs   = []tagExpr{q.expr}
+	)
+
+	for len(exprs) > 0 {
+		e := exprs[0]
+		exprs = exprs[1:]
+
+		switch ee := e.(type) {
+		case tagAnd:
+			exprs = append(exprs, ee.exprs...)
+		case tagCondition:
+			if ee.key.prefix || ee.op == "!=" {
+				continue
+			}
+
+			f := &resourcegroupstaggingapi.TagFilter{Key: aws.String(ee.key.text)}
+			if ee.op == "=" && !ee.value.prefix {
+				f.Values = []*string{aws.String(ee.value.text)}
+			}
+			filters = append(filters, f)
+		}
+	}
+
+	return filters
+}
+
+type tagExpr interface {
+	match(tags map[string]string) bool
+}
+
+type tagAnd struct {
+	exprs []tagExpr
+}
+
+func (e tagAnd) match(tags map[string]string) bool {
+	for _, ee := range e.exprs {
+		if !ee.match(tags) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type tagOr struct {
+	exprs []tagExpr
+}
+
+func (e tagOr) match(tags map[string]string) bool {
+	for _, ee := range e.exprs {
+		if ee.match(tags) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type tagNot struct {
+	expr tagExpr
+}
+
+func (e tagNot) match(tags map[string]string) bool {
+	return !e.expr.match(tags)
+}
+
+// tagCondition is a condition over a tag, op is "" when it
+// only checks that the key exists, "=" or "!="
+type tagCondition struct {
+	key   tagPattern
+	op    string
+	value tagPattern
+}
+
+func (e tagCondition) match(tags map[string]string) bool {
+	var found bool
+	for k, v := range tags {
+		if !e.key.match(k) {
+			continue
+		}
+
+		if e.op == "" || e.value.match(v) {
+			found = true
+			break
+		}
+	}
+
+	if e.op == "!=" {
+		return !found
+	}
+
+	return found
+}
+
+// tagPattern is a key or a value of a condition
+type tagPattern struct {
+	text   string
+	prefix bool
+}
+
+func (p tagPattern) match(s string) bool {
+	if p.prefix {
+		return strings.HasPrefix(s, p.text)
+	}
+
+	return s == p.text
+}
+
+type tagToken struct {
+	// kind is "word", "string" for the
+	// quoted words or the operator
+	kind string
+	text string
+	at   int
+}
+
+// lexTagQuery splits q into tokens
+func lexTagQuery(q string) ([]tagToken, error) {
+	var toks []tagToken
+
+	for i := 0; i < len(q); {
+		c := q[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '(' || c == ')' || c == '=':
+			toks = append(toks, tagToken{kind: string(c), text: string(c), at: i})
+			i++
+		case c == '!':
+			if strings.HasPrefix(q[i:], "!=") {
+				toks = append(toks, tagToken{kind: "!=", text: "!=", at: i})
+				i += 2
+			} else {
+				toks = append(toks, tagToken{kind: "!", text: "!", at: i})
+				i++
+			}
+		case c == '"':
+			j := i + 1
+			for ; j < len(q) && q[j] != '"'; j++ {
+				if q[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(q) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+
+			s, err := strconv.Unquote(q[i : j+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at position %d: %s", i, err)
+			}
+			toks = append(toks, tagToken{kind: "string", text: s, at: i})
+			i = j + 1
+		default:
+			j := i
+			for ; j < len(q) && !unicode.IsSpace(rune(q[j])) && !strings.ContainsRune(`()=!"`, rune(q[j])); j++ {
+			}
+			toks = append(toks, tagToken{kind: "word", text: q[i:j], at: i})
+			i = j
+		}
+	}
+
+	return toks, nil
+}
+
+// tagQueryParser is a recursive descent parser of the grammar:
+//
+//	or        = and { "OR" and }
+//	and       = not { "AND" not }
+//	not       = ( "NOT" | "!" ) not | primary
+//	primary   = "(" or ")" | condition
+//	condition = pattern [ ( "=" | "!=" ) pattern ]
+type tagQueryParser struct {
+	toks []tagToken
+	pos  int
+}
+
+func (p *tagQueryParser) peek() (tagToken, bool) {
+	if p.pos >= len(p.toks) {
+		return tagToken{}, false
+	}
+
+	return p.toks[p.pos], true
+}
+
+// keyword returns if the next token is the keyword kw,
+// and consumes it if it is
+func (p *tagQueryParser) keyword(kw string) bool {
+	t, ok := p.peek()
+	if ok && t.kind == "word" && strings.EqualFold(t.text, kw) {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *tagQueryParser) or() (tagExpr, error) {
+	e, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []tagExpr{e}
+	for p.keyword("OR") {
+		e, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+	}
+
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+
+	return tagOr{exprs: exprs}, nil
+}
+
+func (p *tagQueryParser) and() (tagExpr, error) {
+	e, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []tagExpr{e}
+	for p.keyword("AND") {
+		e, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+	}
+
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+
+	return tagAnd{exprs: exprs}, nil
+}
+
+func (p *tagQueryParser) not() (tagExpr, error) {
+	if t, ok := p.peek(); ok && t.kind == "!" {
+		p.pos++
+		e, err := p.not()
+		return tagNot{expr: e}, err
+	}
+
+	if p.keyword("NOT") {
+		e, err := p.not()
+		return tagNot{expr: e}, err
+	}
+
+	return p.primary()
+}
+
+func (p *tagQueryParser) primary() (tagExpr, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, errors.New("unexpected end of the tag query")
+	}
+
+	if t.kind == "(" {
+		p.pos++
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		if c, ok := p.peek(); !ok || c.kind != ")" {
+			return nil, fmt.Errorf("missing ) for the ( at position %d", t.at)
+		}
+		p.pos++
+
+		return e, nil
+	}
+
+	key, err := p.pattern()
+	if err != nil {
+		return nil, err
+	}
+
+	c := tagCondition{key: key}
+	if t, ok := p.peek(); ok && (t.kind == "=" || t.kind == "!=") {
+		p.pos++
+		c.op = t.kind
+		c.value, err = p.pattern()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+func (p *tagQueryParser) pattern() (tagPattern, error) {
+	t, ok := p.peek()
+	if !ok {
+		return tagPattern{}, errors.New("unexpected end of the tag query")
+	}
+
+	switch t.kind {
+	case "string":
+		p.pos++
+		return tagPattern{text: t.text}, nil
+	case "word":
+		p.pos++
+		if strings.HasSuffix(t.text, "*") {
+			return tagPattern{text: strings.TrimSuffix(t.text, "*"), prefix: true}, nil
+		}
+		return tagPattern{text: t.text}, nil
+	default:
+		return tagPattern{}, fmt.Errorf("unexpected %q at position %d", t.text, t.at)
+	}
+}
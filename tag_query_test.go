@@ -0,0 +1,141 @@
+package raws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+)
+
+func TestTagQuery_Match(t *testing.T) {
+	var (
+		prod = map[string]string{"env": "prod", "team": "web", "cost-center": "42"}
+		dev  = map[string]string{"env": "dev", "team": "data"}
+		none = map[string]string{}
+	)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []bool
+	}{
+		{name: "existence",
+			query:    "team",
+			expected: []bool{true, true, false},
+		},
+		{name: "equal",
+			query:    "env=prod",
+			expected: []bool{true, false, false},
+		},
+		{name: "not equal is true without the key",
+			query:    "team!=data",
+			expected: []bool{true, false, true},
+		},
+		{name: "and",
+			query:    "env=prod AND team!=data",
+			expected: []bool{true, false, false},
+		},
+		{name: "or and precedence",
+			query:    "env=dev or env=prod and team=data",
+			expected: []bool{false, true, false},
+		},
+		{name: "parentheses",
+			query:    "(env=dev OR env=prod) AND team=data",
+			expected: []bool{false, true, false},
+		},
+		{name: "not",
+			query:    "NOT env AND !team",
+			expected: []bool{false, false, true},
+		},
+		{name: "prefix",
+			query:    "env=pr* OR cost-*",
+			expected: []bool{true, false, false},
+		},
+		{name: "any tag",
+			query:    "*",
+			expected: []bool{true, true, false},
+		},
+		{name: "quoted",
+			query:    `"env" = "d*" OR "team"="data"`,
+			expected: []bool{false, true, false},
+		}}
+
+	for i, tt := range tests {
+		q, err := ParseTagQuery(tt.query)
+		checkErrors(t, tt.name, i, err, nil)
+
+		var matches []bool
+		for _, tags := range []map[string]string{prod, dev, none} {
+			matches = append(matches, q.Match(tags))
+		}
+
+		if !reflect.DeepEqual(matches, tt.expected) {
+			t.Errorf("%s [%d] - matches: received=%+v | expected=%+v", tt.name, i, matches, tt.expected)
+		}
+	}
+}
+
+func TestParseTagQuery(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		expectedError string
+	}{
+		{name: "empty",
+			query:         " ",
+			expectedError: "the tag query is empty",
+		},
+		{name: "missing value",
+			query:         "env=",
+			expectedError: "unexpected end of the tag query",
+		},
+		{name: "missing parenthesis",
+			query:         "(env OR team",
+			expectedError: "missing ) for the ( at position 0",
+		},
+		{name: "extra token",
+			query:         "env team",
+			expectedError: `unexpected "team" at position 4`,
+		},
+		{name: "unterminated string",
+			query:         `env="prod`,
+			expectedError: "unterminated string at position 4",
+		}}
+
+	for i, tt := range tests {
+		_, err := ParseTagQuery(tt.query)
+		if err == nil || err.Error() != tt.expectedError {
+			t.Errorf("%s [%d] - errors: received=%+v | expected=%+v", tt.name, i, err, tt.expectedError)
+		}
+	}
+}
+
+func TestTagQuery_tagFilters(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected []*resourcegroupstaggingapi.TagFilter
+	}{
+		{name: "conditions always true",
+			query: "env=prod AND team AND (owner=a OR owner=b) AND cost=4* AND app!=web",
+			expected: []*resourcegroupstaggingapi.TagFilter{
+				{Key: aws.String("env"), Values: []*string{aws.String("prod")}},
+				{Key: aws.String("team")},
+				{Key: aws.String("cost")},
+			},
+		},
+		{name: "no filters",
+			query: "env=prod OR NOT team",
+		}}
+
+	for i, tt := range tests {
+		q, err := ParseTagQuery(tt.query)
+		checkErrors(t, tt.name, i, err, nil)
+
+		filters := q.tagFilters()
+		if !reflect.DeepEqual(filters, tt.expected) {
+			t.Errorf("%s [%d] - filters: received=%+v | expected=%+v", tt.name, i, filters, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package raws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+)
+
+func (c *connector) GetTaggedResources(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput) (map[string]resourcegroupstaggingapi.GetResourcesOutput, error) {
+	out, err := c.invokeMethod(ctx, &MethodCall{Method: "GetTaggedResources", Input: input}, func(ctx context.Context) (interface{}, error) {
+		var errs Errors
+		var regionsOpts = map[string]resourcegroupstaggingapi.GetResourcesOutput{}
+
+		for _, svc := range c.services(ctx) {
+			// The resources of all the pages are returned on a
+			// single output, without the PaginationToken
+			call := &Call{Method: "GetTaggedResources", Operation: "GetResources", Service: resourcegroupstaggingapi.ServiceName, Region: svc.region, Input: input}
+			opt, err := c.invoke(ctx, call, func(ctx context.Context, input interface{}, opts ...request.Option) (interface{}, error) {
+				var opt resourcegroupstaggingapi.GetResourcesOutput
+				var n int
+				err := svc.tagging.GetResourcesPagesWithContext(ctx, input.(*resourcegroupstaggingapi.GetResourcesInput), func(page *resourcegroupstaggingapi.GetResourcesOutput, _ bool) bool {
+					n++
+					if sendOutput(ctx, "GetTaggedResources", svc.region, n, *page) {
+						return true
+					}
+					opt.ResourceTagMappingList = append(opt.ResourceTagMappingList, page.ResourceTagMappingList...)
+					return true
+				}, opts...)
+				return &opt, err
+			})
+			if err != nil {
+				errs = append(errs, sendError(ctx, "GetTaggedResources", NewError(svc.region, resourcegroupstaggingapi.ServiceName, err)))
+			} else if resultFunc(ctx) == nil {
+				regionsOpts[svc.region] = *opt.(*resourcegroupstaggingapi.GetResourcesOutput)
+			}
+		}
+
+		if errs != nil {
+			return regionsOpts, errs
+		}
+
+		return regionsOpts, nil
+	})
+
+	// out is nil if a MethodInterceptor doesn't call the method
+	opts, _ := out.(map[string]resourcegroupstaggingapi.GetResourcesOutput)
+
+	return opts, err
+}
@@ -0,0 +1,288 @@
+package terraform
+
+import (
+	"strings"
+
+	"github.com/cycloidio/raws"
+	"github.com/cycloidio/raws/internal/value"
+)
+
+// The converters use item, which is the SDK item of the resource, and
+// the tags of the Resource, as they are already normalized.
+
+func instance(r raws.Resource, item value.Value) (string, string, []attr) {
+	var a args
+	a.add("ami", item.Get("ImageId"))
+	a.add("instance_type", item.Get("InstanceType"))
+	a.add("availability_zone", item.Get("Placement.AvailabilityZone"))
+	a.add("subnet_id", item.Get("SubnetId"))
+	a.add("key_name", item.Get("KeyName"))
+	a.add("vpc_security_group_ids", item.Strs("SecurityGroups", "GroupId"))
+	if arn := item.Str("IamInstanceProfile.Arn"); arn != "" {
+		a.add("iam_instance_profile", lastSegment(arn))
+	}
+	a.add("tags", r.Tags)
+
+	return "aws_instance", r.ID, a
+}
+
+func vpc(r raws.Resource, item value.Value) (string, string, []attr) {
+	var a args
+	a.add("cidr_block", item.Get("CidrBlock"))
+	a.add("instance_tenancy", item.Get("InstanceTenancy"))
+	a.add("tags", r.Tags)
+
+	return "aws_vpc", r.ID, a
+}
+
+func subnet(r raws.Resource, item value.Value) (string, string, []attr) {
+	var a args
+	a.add("vpc_id", item.Get("VpcId"))
+	a.add("cidr_block", item.Get("CidrBlock"))
+	a.add("availability_zone", item.Get("AvailabilityZone"))
+	a.add("map_public_ip_on_launch", item.Get("MapPublicIpOnLaunch"))
+	a.add("tags", r.Tags)
+
+	return "aws_subnet", r.ID, a
+}
+
+func securityGroup(r raws.Resource, item value.Value) (string, string, []attr) {
+	var a args
+	a.add("name", item.Get("GroupName"))
+	a.add("description", item.Get("Description"))
+	a.add("vpc_id", item.Get("VpcId"))
+	a.add("ingress", securityGroupRules(item.List("IpPermissions")))
+	a.add("egress", securityGroupRules(item.List("IpPermissionsEgress")))
+	a.add("tags", r.Tags)
+
+	return "aws_security_group", r.ID, a
+}
+
+func securityGroupRules(perms []value.Value) []block {
+	var rules []block
+	for _, p := range perms {
+		var a args
+
+		// The ports are not set when all the protocols
+		// are allowed but they are required by Terraform
+		from, to := p.Get("FromPort").V, p.Get("ToPort").V
+		if from == nil {
+			from = int64(0)
+		}
+		if to == nil {
+			to = int64(0)
+		}
+
+		a.add("from_port", from)
+		a.add("to_port", to)
+		a.add("protocol", p.Get("IpProtocol"))
+		a.add("cidr_blocks", p.Strs("IpRanges", "CidrIp"))
+		a.add("ipv6_cidr_blocks", p.Strs("Ipv6Ranges", "CidrIpv6"))
+		a.add("prefix_list_ids", p.Strs("PrefixListIds", "PrefixListId"))
+		a.add("security_groups", p.Strs("UserIdGroupPairs", "GroupId"))
+		rules = append(rules, block(a))
+	}
+
+	return rules
+}
+
+func volume(r raws.Resource, item value.Value) (string, string, []attr) {
+	var a args
+	a.add("availability_zone", item.Get("AvailabilityZone"))
+	a.add("size", item.Get("Size"))
+	a.add("type", item.Get("VolumeType"))
+	if item.Str("VolumeType") == "io1" {
+		a.add("iops", item.Get("Iops"))
+	}
+	a.add("encrypted", item.Get("Encrypted"))
+	a.add("kms_key_id", item.Get("KmsKeyId"))
+	a.add("snapshot_id", item.Get("SnapshotId"))
+	a.add("tags", r.Tags)
+
+	return "aws_ebs_volume", r.ID, a
+}
+
+func launchTemplate(r raws.Resource, item value.Value) (string, string, []attr) {
+	var a args
+	a.add("name", item.Get("LaunchTemplateName"))
+	a.add("tags", r.Tags)
+
+	return "aws_launch_template", r.ID, a
+}
+
+func autoScalingGroup(r raws.Resource, item value.Value) (string, string, []attr) {
+	var a args
+	a.add("name", item.Get("AutoScalingGroupName"))
+	a.add("min_size", item.Get("MinSize"))
+	a.add("max_size", item.Get("MaxSize"))
+	a.add("desired_capacity", item.Get("DesiredCapacity"))
+	a.add("health_check_type", item.Get("HealthCheckType"))
+	a.add("launch_configuration", item.Get("LaunchConfigurationName"))
+	if id := item.Str("LaunchTemplate.LaunchTemplateId"); id != "" {
+		var lt args
+		lt.add("id", id)
+		lt.add("version", item.Get("LaunchTemplate.Version"))
+		a.add("launch_template", block(lt))
+	}
+	if ids := item.Str("VPCZoneIdentifier"); ids != "" {
+		a.add("vpc_zone_identifier", strings.Split(ids, ","))
+	}
+	a.add("load_balancers", item.Strs("LoadBalancerNames", ""))
+	a.add("target_group_arns", item.Strs("TargetGroupARNs", ""))
+
+	// The tags of the groups have the
+	// propagation to the instances
+	var tags []block
+	for _, t := range item.List("Tags") {
+		var tag args
+		tag.add("key", t.Get("Key"))
+		tag.add("value", t.Get("Value"))
+		tag.add("propagate_at_launch", t.Get("PropagateAtLaunch"))
+		tags = append(tags, block(tag))
+	}
+	a.add("tag", tags)
+
+	return "aws_autoscaling_group", r.ID, a
+}
+
+func launchConfiguration(r raws.Resource, item value.Value) (string, string, []attr) {
+	var a args
+	a.add("name", item.Get("LaunchConfigurationName"))
+	a.add("image_id", item.Get("ImageId"))
+	a.add("instance_type", item.Get("InstanceType"))
+	a.add("key_name", item.Get("KeyName"))
+	a.add("security_groups", item.Strs("SecurityGroups", ""))
+	a.add("iam_instance_profile", item.Get("IamInstanceProfile"))
+	a.add("associate_public_ip_address", item.Get("AssociatePublicIpAddress"))
+
+	return "aws_launch_configuration", r.ID, a
+}
+
+func cacheCluster(r raws.Resource, item value.Value) (string, string, []attr) {
+	var a args
+	a.add("cluster_id", item.Get("CacheClusterId"))
+	a.add("engine", item.Get("Engine"))
+	a.add("engine_version", item.Get("EngineVersion"))
+	a.add("node_type", item.Get("CacheNodeType"))
+	a.add("num_cache_nodes", item.Get("NumCacheNodes"))
+	a.add("subnet_group_name", item.Get("CacheSubnetGroupName"))
+	a.add("security_group_ids", item.Strs("SecurityGroups", "SecurityGroupId"))
+	a.add("tags", r.Tags)
+
+	return "aws_elasticache_cluster", r.ID, a
+}
+
+func elb(r raws.Resource, item value.Value) (string, string, []attr) {
+	var a args
+	a.add("name", item.Get("LoadBalancerName"))
+	a.add("internal", item.Str("Scheme") == "internal")
+	a.add("subnets", item.Strs("Subnets", ""))
+	a.add("security_groups", item.Strs("SecurityGroups", ""))
+	a.add("instances", item.Strs("Instances", "InstanceId"))
+
+	var listeners []block
+	for _, l := range item.List("ListenerDescriptions") {
+		var la args
+		la.add("instance_port", l.Get("Listener.InstancePort"))
+		la.add("instance_protocol", strings.ToLower(l.Str("Listener.InstanceProtocol")))
+		la.add("lb_port", l.Get("Listener.LoadBalancerPort"))
+		la.add("lb_protocol", strings.ToLower(l.Str("Listener.Protocol")))
+		la.add("ssl_certificate_id", l.Get("Listener.SSLCertificateId"))
+		listeners = append(listeners, block(la))
+	}
+	a.add("listener", listeners)
+	a.add("tags", r.Tags)
+
+	return "aws_elb", r.ID, a
+}
+
+func elbv2(r raws.Resource, item value.Value) (string, string, []attr) {
+	var a args
+	a.add("name", item.Get("LoadBalancerName"))
+	a.add("load_balancer_type", item.Get("Type"))
+	a.add("internal", item.Str("Scheme") == "internal")
+	a.add("ip_address_type", item.Get("IpAddressType"))
+	a.add("subnets", item.Strs("AvailabilityZones", "SubnetId"))
+	a.add("security_groups", item.Strs("SecurityGroups", ""))
+	a.add("tags", r.Tags)
+
+	return "aws_lb", r.ID, a
+}
+
+func dbInstance(r raws.Resource, item value.Value) (string, string, []attr) {
+	var a args
+	a.add("identifier", item.Get("DBInstanceIdentifier"))
+	a.add("engine", item.Get("Engine"))
+	a.add("engine_version", item.Get("EngineVersion"))
+	a.add("instance_class", item.Get("DBInstanceClass"))
+	a.add("allocated_storage", item.Get("AllocatedStorage"))
+	a.add("storage_type", item.Get("StorageType"))
+	a.add("storage_encrypted", item.Get("StorageEncrypted"))
+	a.add("username", item.Get("MasterUsername"))
+	a.add("db_subnet_group_name", item.Get("DBSubnetGroup.DBSubnetGroupName"))
+	a.add("vpc_security_group_ids", item.Strs("VpcSecurityGroups", "VpcSecurityGroupId"))
+	a.add("multi_az", item.Get("MultiAZ"))
+	a.add("publicly_accessible", item.Get("PubliclyAccessible"))
+	a.add("tags", r.Tags)
+
+	return "aws_db_instance", r.ID, a
+}
+
+func bucket(r raws.Resource, item value.Value) (string, string, []attr) {
+	var a args
+	a.add("bucket", item.Get("Name"))
+	a.add("tags", r.Tags)
+
+	return "aws_s3_bucket", r.ID, a
+}
+
+func role(r raws.Resource, item value.Value) (string, string, []attr) {
+	var a args
+	a.add("name", item.Get("RoleName"))
+	a.add("path", item.Get("Path"))
+	a.add("description", item.Get("Description"))
+	a.add("max_session_duration", item.Get("MaxSessionDuration"))
+	if doc := item.Str("AssumeRolePolicyDocument"); doc != "" {
+		a.add("assume_role_policy", policyDocument(doc))
+	}
+	a.add("tags", r.Tags)
+
+	return "aws_iam_role", r.Name, a
+}
+
+func user(r raws.Resource, item value.Value) (string, string, []attr) {
+	var a args
+	a.add("name", item.Get("UserName"))
+	a.add("path", item.Get("Path"))
+	a.add("tags", r.Tags)
+
+	return "aws_iam_user", r.Name, a
+}
+
+func group(r raws.Resource, item value.Value) (string, string, []attr) {
+	var a args
+	a.add("name", item.Get("GroupName"))
+	a.add("path", item.Get("Path"))
+
+	return "aws_iam_group", r.Name, a
+}
+
+func instanceProfile(r raws.Resource, item value.Value) (string, string, []attr) {
+	var a args
+	a.add("name", item.Get("InstanceProfileName"))
+	a.add("path", item.Get("Path"))
+	if roles := item.Strs("Roles", "RoleName"); len(roles) > 0 {
+		a.add("role", roles[0])
+	}
+
+	return "aws_iam_instance_profile", r.Name, a
+}
+
+func hostedZone(r raws.Resource, item value.Value) (string, string, []attr) {
+	var a args
+	a.add("name", strings.TrimSuffix(item.Str("Name"), "."))
+	a.add("comment", item.Get("Config.Comment"))
+	a.add("tags", r.Tags)
+
+	return "aws_route53_zone", r.ID, a
+}
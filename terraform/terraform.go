@@ -0,0 +1,499 @@
+// Package terraform generates the Terraform configuration of the resources
+// found by raws, so the infrastructure created without Terraform can be
+// imported on it.
+//
+// For each supported resource it generates the HCL resource block, with the
+// arguments known from the resource, and its import, as a "terraform import"
+// command or as an "import" block (Terraform 1.5 and later). A skeleton of
+// the state of all the resources can also be generated.
+//
+// The resources of each region use a provider with the region as alias,
+// like "aws.eu_west_1", so the resources of several regions can be
+// imported at once, while the ones of the global services, like IAM, use
+// the default provider.
+//
+// The generated configuration is a starting point: the arguments which are
+// not returned by the AWSReader methods aren't set, so "terraform plan"
+// should be run after the import to complete them.
+package terraform
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cycloidio/raws"
+	"github.com/cycloidio/raws/internal/value"
+)
+
+// Config is the Terraform configuration of a list of resources
+type Config struct {
+	// Blocks are the resource blocks of the
+	// supported resources, in the same order
+	Blocks []Block
+
+	// Skipped are the resources which
+	// can't be converted to Terraform
+	Skipped []raws.Resource
+}
+
+// Block is a Terraform resource block
+type Block struct {
+	// Type is the Terraform type of the
+	// resource, like "aws_instance"
+	Type string
+
+	// Name is the name of the resource block,
+	// unique between the ones of the Type
+	Name string
+
+	// ImportID is the ID used to import the resource
+	ImportID string
+
+	// Provider is the alias of the provider of the region of the
+	// resource, like "eu_west_1", empty for the global resources
+	Provider string
+
+	// Resource is the resource of the block
+	Resource raws.Resource
+
+	attrs []attr
+}
+
+// Address returns the address of the block, "{{.Type}}.{{.Name}}"
+func (b Block) Address() string {
+	return fmt.Sprintf("%s.%s", b.Type, b.Name)
+}
+
+// attr is an argument, or a nested block, of a resource block.
+// The value can be a string, a float64 or int64, a bool, a []string,
+// a map[string]string or a block, which is a []attr, or a list of them
+type attr struct {
+	name  string
+	value interface{}
+}
+
+type block []attr
+
+// expr is an HCL expression written as it is, like a reference
+type expr string
+
+// terraformVersion is the version of Terraform of the state,
+// which is the first one supporting the import blocks
+const terraformVersion = "1.5.0"
+
+// converter converts the item of a resource to the
+// Terraform type, import ID and arguments
+type converter func(r raws.Resource, item value.Value) (typ, importID string, attrs []attr)
+
+// converters are the converters of the supported resources,
+// by the Service and Type of the resources joined by a dot
+var converters = map[string]converter{
+	"ec2.instance":                     instance,
+	"ec2.vpc":                          vpc,
+	"ec2.subnet":                       subnet,
+	"ec2.security-group":               securityGroup,
+	"ec2.volume":                       volume,
+	"ec2.launch-template":              launchTemplate,
+	"autoscaling.auto-scaling-group":   autoScalingGroup,
+	"autoscaling.launch-configuration": launchConfiguration,
+	"elasticache.cache-cluster":        cacheCluster,
+	"elb.load-balancer":                elb,
+	"elbv2.load-balancer":              elbv2,
+	"rds.db-instance":                  dbInstance,
+	"s3.bucket":                        bucket,
+	"iam.role":                         role,
+	"iam.user":                         user,
+	"iam.group":                        group,
+	"iam.instance-profile":             instanceProfile,
+	"route53.hosted-zone":              hostedZone,
+}
+
+// Generate returns the Terraform configuration of the resources, which can
+// be the ones of a raws.Snapshot, built by raws.Inventory or read with
+// raws.ReadSnapshot.
+// The resources which aren't supported are returned on Config.Skipped.
+func Generate(resources []raws.Resource) (*Config, error) {
+	var (
+		c     = &Config{}
+		names = make(map[string]bool)
+	)
+
+	for _, r := range resources {
+		conv, ok := converters[r.Service+"."+r.Type]
+		if !ok {
+			c.Skipped = append(c.Skipped, r)
+			continue
+		}
+
+		raw, err := value.Of(r.Raw)
+		if err != nil {
+			return nil, err
+		}
+
+		typ, id, attrs := conv(r, raw.Item())
+
+		// The suffix can give the name of another
+		// resource, like "web_2", so it's checked too
+		base := blockName(r)
+		name := base
+		for n := 2; names[typ+"."+name]; n++ {
+			name = fmt.Sprintf("%s_%d", base, n)
+		}
+		names[typ+"."+name] = true
+
+		c.Blocks = append(c.Blocks, Block{
+			Type:     typ,
+			Name:     name,
+			ImportID: id,
+			Provider: providerAlias(r.Region),
+			Resource: r,
+			attrs:    attrs,
+		})
+	}
+
+	return c, nil
+}
+
+// WriteProviders writes on w the provider blocks of the regions
+// of the resource blocks, with the alias used by their blocks
+func (c *Config) WriteProviders(w io.Writer) error {
+	regions := make(map[string]string)
+	for _, b := range c.Blocks {
+		if b.Provider != "" {
+			regions[b.Provider] = b.Resource.Region
+		}
+	}
+
+	for i, alias := range sortedKeys(regions) {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		fmt.Fprintln(w, `provider "aws" {`)
+		writeAttrs(w, []attr{{name: "alias", value: alias}, {name: "region", value: regions[alias]}}, 1)
+		_, err := fmt.Fprintln(w, "}")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteHCL writes on w the resource blocks
+func (c *Config) WriteHCL(w io.Writer) error {
+	for i, b := range c.Blocks {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		fmt.Fprintf(w, "resource %q %q {\n", b.Type, b.Name)
+		if b.Provider != "" {
+			fmt.Fprintf(w, "  provider = aws.%s\n\n", b.Provider)
+		}
+		writeAttrs(w, b.attrs, 1)
+		_, err := fmt.Fprintln(w, "}")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteImportCommands writes on w the "terraform
+// import" commands of the resource blocks
+func (c *Config) WriteImportCommands(w io.Writer) error {
+	for _, b := range c.Blocks {
+		_, err := fmt.Fprintf(w, "terraform import %s %s\n", shellQuote(b.Address()), shellQuote(b.ImportID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteImportBlocks writes on w the "import" blocks of
+// the resource blocks, supported since Terraform 1.5
+func (c *Config) WriteImportBlocks(w io.Writer) error {
+	for i, b := range c.Blocks {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		attrs := []attr{{name: "to", value: expr(b.Address())}, {name: "id", value: b.ImportID}}
+		if b.Provider != "" {
+			attrs = append(attrs, attr{name: "provider", value: expr("aws." + b.Provider)})
+		}
+
+		fmt.Fprintln(w, "import {")
+		writeAttrs(w, attrs, 1)
+		_, err := fmt.Fprintln(w, "}")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteState writes on w the skeleton of the Terraform state (version 4)
+// with the resource blocks, which only have their ID and the arguments
+// of the blocks which are not nested blocks as attributes, and a new
+// random lineage
+func (c *Config) WriteState(w io.Writer) error {
+	lineage, err := newLineage()
+	if err != nil {
+		return err
+	}
+
+	type instance struct {
+		SchemaVersion int                    `json:"schema_version"`
+		Attributes    map[string]interface{} `json:"attributes"`
+	}
+
+	type resource struct {
+		Mode      string     `json:"mode"`
+		Type      string     `json:"type"`
+		Name      string     `json:"name"`
+		Provider  string     `json:"provider"`
+		Instances []instance `json:"instances"`
+	}
+
+	state := struct {
+		Version          int                    `json:"version"`
+		TerraformVersion string                 `json:"terraform_version"`
+		Serial           int                    `json:"serial"`
+		Lineage          string                 `json:"lineage"`
+		Outputs          map[string]interface{} `json:"outputs"`
+		Resources        []resource             `json:"resources"`
+	}{
+		Version:          4,
+		TerraformVersion: terraformVersion,
+		Serial:           1,
+		Lineage:          lineage,
+		Outputs:          map[string]interface{}{},
+		Resources:        []resource{},
+	}
+
+	for _, b := range c.Blocks {
+		attrs := map[string]interface{}{"id": b.ImportID}
+		for _, a := range b.attrs {
+			switch a.value.(type) {
+			case block, []block:
+			default:
+				attrs[a.name] = a.value
+			}
+		}
+		if b.Resource.ARN != "" {
+			attrs["arn"] = b.Resource.ARN
+		}
+
+		provider := `provider["registry.terraform.io/hashicorp/aws"]`
+		if b.Provider != "" {
+			provider += "." + b.Provider
+		}
+
+		state.Resources = append(state.Resources, resource{
+			Mode:      "managed",
+			Type:      b.Type,
+			Name:      b.Name,
+			Provider:  provider,
+			Instances: []instance{{Attributes: attrs}},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(state)
+}
+
+func writeAttrs(w io.Writer, attrs []attr, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	// The arguments are aligned as terraform fmt does
+	var width int
+	for _, a := range attrs {
+		switch a.value.(type) {
+		case block, []block:
+		default:
+			if len(a.name) > width {
+				width = len(a.name)
+			}
+		}
+	}
+
+	for _, a := range attrs {
+		switch v := a.value.(type) {
+		case block:
+			fmt.Fprintf(w, "\n%s%s {\n", indent, a.name)
+			writeAttrs(w, v, depth+1)
+			fmt.Fprintf(w, "%s}\n", indent)
+		case []block:
+			for _, b := range v {
+				fmt.Fprintf(w, "\n%s%s {\n", indent, a.name)
+				writeAttrs(w, b, depth+1)
+				fmt.Fprintf(w, "%s}\n", indent)
+			}
+		case map[string]string:
+			fmt.Fprintf(w, "%s%-*s = {\n", indent, width, a.name)
+			for _, k := range sortedKeys(v) {
+				fmt.Fprintf(w, "%s  %s = %s\n", indent, quote(k), quote(v[k]))
+			}
+			fmt.Fprintf(w, "%s}\n", indent)
+		default:
+			fmt.Fprintf(w, "%s%-*s = %s\n", indent, width, a.name, hclValue(v))
+		}
+	}
+}
+
+func hclValue(v interface{}) string {
+	switch vv := v.(type) {
+	case expr:
+		return string(vv)
+	case string:
+		return quote(vv)
+	case bool:
+		return strconv.FormatBool(vv)
+	case int64:
+		return strconv.FormatInt(vv, 10)
+	case float64:
+		return strconv.FormatFloat(vv, 'f', -1, 64)
+	case []string:
+		q := make([]string, 0, len(vv))
+		for _, s := range vv {
+			q = append(q, quote(s))
+		}
+		return "[" + strings.Join(q, ", ") + "]"
+	default:
+		return quote(fmt.Sprint(vv))
+	}
+}
+
+// quote returns s as an HCL string, escaping
+// the template sequences
+func quote(s string) string {
+	s = strconv.Quote(s)
+	s = strings.Replace(s, "${", "$${", -1)
+	return strings.Replace(s, "%{", "%%{", -1)
+}
+
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_./:@=+-]+$`)
+
+func shellQuote(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+var invalidName = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// blockName returns the name of the block of r, from its
+// Name or its ID, valid as a Terraform identifier
+func blockName(r raws.Resource) string {
+	name := r.Name
+	if name == "" {
+		name = r.ID
+	}
+
+	name = strings.Trim(invalidName.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '-' {
+		name = "r_" + name
+	}
+
+	return name
+}
+
+// providerAlias returns the alias of the provider of the region,
+// which is the region with underscores, like "eu_west_1"
+func providerAlias(region string) string {
+	return strings.Replace(region, "-", "_", -1)
+}
+
+// newLineage returns a random UUID (version 4),
+// used as the lineage of the states
+func newLineage() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// args builds the arguments skipping the ones without value
+type args []attr
+
+func (a *args) add(name string, v interface{}) {
+	switch vv := v.(type) {
+	case nil:
+		return
+	case string:
+		if vv == "" {
+			return
+		}
+	case []string:
+		if len(vv) == 0 {
+			return
+		}
+	case map[string]string:
+		if len(vv) == 0 {
+			return
+		}
+	case block:
+		if len(vv) == 0 {
+			return
+		}
+	case []block:
+		if len(vv) == 0 {
+			return
+		}
+	case value.Value:
+		a.add(name, vv.V)
+		return
+	}
+
+	*a = append(*a, attr{name: name, value: v})
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// lastSegment returns the last segment of the ARN, like
+// the name of an instance profile or a role
+func lastSegment(arn string) string {
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
+
+// policyDocument decodes the policy documents
+// returned URL encoded by IAM
+func policyDocument(doc string) string {
+	if d, err := url.QueryUnescape(doc); err == nil {
+		return d
+	}
+
+	return doc
+}
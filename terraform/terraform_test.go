@@ -0,0 +1,290 @@
+package terraform
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/cycloidio/raws"
+)
+
+func newResource(t *testing.T, region string, v interface{}) raws.Resource {
+	r, err := raws.NewResource("123", region, v)
+	if err != nil {
+		t.Fatalf("resource - errors: received=%+v | expected=nil", err)
+	}
+
+	return r
+}
+
+func TestGenerate(t *testing.T) {
+	resources := []raws.Resource{
+		newResource(t, "eu-west-1", &ec2.Instance{
+			InstanceId:         aws.String("i-1"),
+			ImageId:            aws.String("ami-1"),
+			InstanceType:       aws.String("t2.micro"),
+			SubnetId:           aws.String("subnet-1"),
+			SecurityGroups:     []*ec2.GroupIdentifier{{GroupId: aws.String("sg-1")}},
+			IamInstanceProfile: &ec2.IamInstanceProfile{Arn: aws.String("arn:aws:iam::123:instance-profile/web")},
+			Tags:               []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String("Web Server")}},
+		}),
+		newResource(t, "eu-west-1", &ec2.Instance{
+			InstanceId: aws.String("i-2"),
+			Tags:       []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String("web-server")}},
+		}),
+		newResource(t, "eu-west-1", &ec2.SecurityGroup{
+			GroupId:   aws.String("sg-1"),
+			GroupName: aws.String("web"),
+			IpPermissions: []*ec2.IpPermission{
+				{FromPort: aws.Int64(443), ToPort: aws.Int64(443), IpProtocol: aws.String("tcp"), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}}},
+			},
+			IpPermissionsEgress: []*ec2.IpPermission{
+				{IpProtocol: aws.String("-1"), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}}},
+			},
+		}),
+		newResource(t, "eu-west-1", &s3.Bucket{Name: aws.String("1-logs")}),
+		newResource(t, "", &iam.Role{
+			RoleId:                   aws.String("AROA1"),
+			RoleName:                 aws.String("admin"),
+			Path:                     aws.String("/"),
+			AssumeRolePolicyDocument: aws.String("%7B%22Version%22%3A%222012-10-17%22%7D"),
+		}),
+		newResource(t, "eu-west-1", &ec2.Image{ImageId: aws.String("ami-1")}),
+	}
+
+	c, err := Generate(resources)
+	if err != nil {
+		t.Fatalf("generate - errors: received=%+v | expected=nil", err)
+	}
+
+	var addresses, ids []string
+	for _, b := range c.Blocks {
+		addresses = append(addresses, b.Address())
+		ids = append(ids, b.ImportID)
+	}
+
+	tests := []struct {
+		name     string
+		received interface{}
+		expected interface{}
+	}{
+		{name: "addresses",
+			received: addresses,
+			expected: []string{"aws_instance.web_server", "aws_instance.web-server", "aws_security_group.web", "aws_s3_bucket.r_1-logs", "aws_iam_role.admin"},
+		},
+		{name: "import IDs",
+			received: ids,
+			expected: []string{"i-1", "i-2", "sg-1", "1-logs", "admin"},
+		},
+		{name: "skipped",
+			received: len(c.Skipped),
+			expected: 1,
+		}}
+
+	for i, tt := range tests {
+		if !reflect.DeepEqual(tt.received, tt.expected) {
+			t.Errorf("%s [%d] - received=%+v | expected=%+v", tt.name, i, tt.received, tt.expected)
+		}
+	}
+
+	// The second instance only differs from the first one by its name
+	c.Blocks = append(c.Blocks[:1:1], c.Blocks[2:]...)
+
+	var buff bytes.Buffer
+	err = c.WriteHCL(&buff)
+	if err != nil {
+		t.Fatalf("hcl - errors: received=%+v | expected=nil", err)
+	}
+
+	expectedHCL := `resource "aws_instance" "web_server" {
+  provider = aws.eu_west_1
+
+  ami                    = "ami-1"
+  instance_type          = "t2.micro"
+  subnet_id              = "subnet-1"
+  vpc_security_group_ids = ["sg-1"]
+  iam_instance_profile   = "web"
+  tags                   = {
+    "Name" = "Web Server"
+  }
+}
+
+resource "aws_security_group" "web" {
+  provider = aws.eu_west_1
+
+  name = "web"
+
+  ingress {
+    from_port   = 443
+    to_port     = 443
+    protocol    = "tcp"
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+
+  egress {
+    from_port   = 0
+    to_port     = 0
+    protocol    = "-1"
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+}
+
+resource "aws_s3_bucket" "r_1-logs" {
+  provider = aws.eu_west_1
+
+  bucket = "1-logs"
+}
+
+resource "aws_iam_role" "admin" {
+  name               = "admin"
+  path               = "/"
+  assume_role_policy = "{\"Version\":\"2012-10-17\"}"
+}
+`
+	if buff.String() != expectedHCL {
+		t.Errorf("hcl: received=%s | expected=%s", buff.String(), expectedHCL)
+	}
+
+	buff.Reset()
+	err = c.WriteImportCommands(&buff)
+	if err != nil {
+		t.Fatalf("commands - errors: received=%+v | expected=nil", err)
+	}
+
+	expectedCommands := `terraform import aws_instance.web_server i-1
+terraform import aws_security_group.web sg-1
+terraform import aws_s3_bucket.r_1-logs 1-logs
+terraform import aws_iam_role.admin admin
+`
+	if buff.String() != expectedCommands {
+		t.Errorf("commands: received=%s | expected=%s", buff.String(), expectedCommands)
+	}
+
+	buff.Reset()
+	c.Blocks = c.Blocks[:1]
+	err = c.WriteImportBlocks(&buff)
+	if err != nil {
+		t.Fatalf("blocks - errors: received=%+v | expected=nil", err)
+	}
+
+	expectedBlocks := `import {
+  to       = aws_instance.web_server
+  id       = "i-1"
+  provider = aws.eu_west_1
+}
+`
+	if buff.String() != expectedBlocks {
+		t.Errorf("blocks: received=%s | expected=%s", buff.String(), expectedBlocks)
+	}
+
+	buff.Reset()
+	err = c.WriteState(&buff)
+	if err != nil {
+		t.Fatalf("state - errors: received=%+v | expected=nil", err)
+	}
+
+	var state struct {
+		Version          int
+		TerraformVersion string `json:"terraform_version"`
+		Lineage          string
+		Resources        []struct {
+			Type      string
+			Name      string
+			Provider  string
+			Instances []struct {
+				Attributes map[string]interface{}
+			}
+		}
+	}
+	err = json.Unmarshal(buff.Bytes(), &state)
+	if err != nil {
+		t.Fatalf("state - errors: received=%+v | expected=nil", err)
+	}
+
+	if state.Version != 4 || len(state.Resources) != 1 || state.Resources[0].Type != "aws_instance" ||
+		state.Resources[0].Instances[0].Attributes["id"] != "i-1" || state.Resources[0].Instances[0].Attributes["ami"] != "ami-1" ||
+		state.Resources[0].Provider != `provider["registry.terraform.io/hashicorp/aws"].eu_west_1` {
+		t.Errorf("state: received=%s", buff.String())
+	}
+
+	if state.TerraformVersion != "1.5.0" || !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(state.Lineage) {
+		t.Errorf("state - version and lineage: received=%s, %s", state.TerraformVersion, state.Lineage)
+	}
+}
+
+func TestGenerate_names(t *testing.T) {
+	instance := func(id, name string) raws.Resource {
+		return newResource(t, "eu-west-1", &ec2.Instance{
+			InstanceId: aws.String(id),
+			Tags:       []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String(name)}},
+		})
+	}
+
+	c, err := Generate([]raws.Resource{
+		instance("i-1", "web"),
+		instance("i-2", "web_2"),
+		instance("i-3", "web"),
+		instance("i-4", "web"),
+	})
+	if err != nil {
+		t.Fatalf("generate - errors: received=%+v | expected=nil", err)
+	}
+
+	var names []string
+	for _, b := range c.Blocks {
+		names = append(names, b.Name)
+	}
+
+	expected := []string{"web", "web_2", "web_3", "web_4"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("names: received=%+v | expected=%+v", names, expected)
+	}
+}
+
+func TestConfig_WriteProviders(t *testing.T) {
+	c, err := Generate([]raws.Resource{
+		newResource(t, "eu-west-3", &ec2.Vpc{VpcId: aws.String("vpc-1")}),
+		newResource(t, "eu-west-1", &ec2.Vpc{VpcId: aws.String("vpc-2")}),
+		newResource(t, "eu-west-3", &ec2.Vpc{VpcId: aws.String("vpc-3")}),
+		newResource(t, "", &iam.Role{RoleName: aws.String("admin")}),
+	})
+	if err != nil {
+		t.Fatalf("generate - errors: received=%+v | expected=nil", err)
+	}
+
+	var providers []string
+	for _, b := range c.Blocks {
+		providers = append(providers, b.Provider)
+	}
+
+	expectedProviders := []string{"eu_west_3", "eu_west_1", "eu_west_3", ""}
+	if !reflect.DeepEqual(providers, expectedProviders) {
+		t.Errorf("providers: received=%+v | expected=%+v", providers, expectedProviders)
+	}
+
+	var buff bytes.Buffer
+	err = c.WriteProviders(&buff)
+	if err != nil {
+		t.Fatalf("write - errors: received=%+v | expected=nil", err)
+	}
+
+	expected := `provider "aws" {
+  alias  = "eu_west_1"
+  region = "eu-west-1"
+}
+
+provider "aws" {
+  alias  = "eu_west_3"
+  region = "eu-west-3"
+}
+`
+	if buff.String() != expected {
+		t.Errorf("write: received=%s | expected=%s", buff.String(), expected)
+	}
+}
@@ -0,0 +1,107 @@
+package raws
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrTimeout is the error, found with errors.Is, of the regions
+// on which the calls made by a method exceed its time budget,
+// which is the RegionTimeout or the Timeout of the AWSReader,
+// or the deadline of the context given to the method
+var ErrTimeout = errors.New("the time budget has been exceeded")
+
+// timeoutError is the err of a call which exceeded its time budget
+type timeoutError struct {
+	err error
+}
+
+func (e timeoutError) Error() string {
+	return ErrTimeout.Error() + ": " + e.err.Error()
+}
+
+// Is makes the timeoutError be an ErrTimeout for errors.Is
+func (e timeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+// Unwrap returns the error returned by the call
+func (e timeoutError) Unwrap() error {
+	return e.err
+}
+
+// budgetKey is the key of the regionBudgets of the contexts
+type budgetKey struct{}
+
+// regionBudgets are the deadlines of the calls made
+// by a method on each region, which start with the
+// first call made on the region
+type regionBudgets struct {
+	mu        sync.Mutex
+	timeout   time.Duration
+	deadlines map[string]time.Time
+}
+
+// deadline returns the deadline of the calls made on the region
+func (b *regionBudgets) deadline(region string) time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	d, ok := b.deadlines[region]
+	if !ok {
+		d = time.Now().Add(b.timeout)
+		b.deadlines[region] = d
+	}
+
+	return d
+}
+
+// methodContext returns a copy of ctx with the time budget of a
+// method, which is the Timeout of the connector, and the budgets
+// of each one of the regions, if the connector has a RegionTimeout.
+// The budgets already on ctx, of the method calling this one like
+// GetRolesWithPolicies calling GetRoles, are kept, so the calls of
+// both methods on a region share the same one
+func (c *connector) methodContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	cancel := func() {}
+	if c.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+	}
+
+	if _, ok := ctx.Value(budgetKey{}).(*regionBudgets); c.regionTimeout > 0 && !ok {
+		ctx = context.WithValue(ctx, budgetKey{}, &regionBudgets{
+			timeout:   c.regionTimeout,
+			deadlines: make(map[string]time.Time),
+		})
+	}
+
+	return ctx, cancel
+}
+
+// regionContext returns a copy of ctx with the deadline of the calls
+// made on the region by the method of ctx, or with the RegionTimeout
+// of the connector for the calls made out of the methods
+func (c *connector) regionContext(ctx context.Context, region string) (context.Context, context.CancelFunc) {
+	if c.regionTimeout <= 0 {
+		return ctx, func() {}
+	}
+
+	b, ok := ctx.Value(budgetKey{}).(*regionBudgets)
+	if !ok {
+		return context.WithTimeout(ctx, c.regionTimeout)
+	}
+
+	return context.WithDeadline(ctx, b.deadline(region))
+}
+
+// timeoutErr returns err as a timeoutError if
+// it's returned once the deadline of ctx passed
+func timeoutErr(ctx context.Context, err error) error {
+	if err == nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+
+	return timeoutError{err: err}
+}
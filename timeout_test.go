@@ -0,0 +1,155 @@
+package raws
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+)
+
+// hangingEC2 is an EC2 which never answers, until the ctx is done
+type hangingEC2 struct {
+	ec2iface.EC2API
+}
+
+func (hangingEC2) DescribeInstancesPagesWithContext(
+	ctx aws.Context, _ *ec2.DescribeInstancesInput, _ func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option,
+) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestConnector_timeout(t *testing.T) {
+	var (
+		ctx = context.Background()
+		dio = &ec2.DescribeInstancesOutput{}
+	)
+
+	tests := []struct {
+		name            string
+		timeout         time.Duration
+		regionTimeout   time.Duration
+		svcs            []*serviceConnector
+		expectedRegions []string
+		expectedTimeout []string
+	}{
+		{
+			name:          "region",
+			regionTimeout: 10 * time.Millisecond,
+			svcs: []*serviceConnector{
+				{region: "eu-west-1", ec2: hangingEC2{}},
+				{region: "eu-west-3", ec2: mockEC2{dio: dio}},
+			},
+			expectedRegions: []string{"eu-west-3"},
+			expectedTimeout: []string{"eu-west-1"},
+		},
+		{
+			name:    "method",
+			timeout: 10 * time.Millisecond,
+			svcs: []*serviceConnector{
+				{region: "eu-west-1", ec2: mockEC2{dio: dio}},
+				{region: "eu-west-3", ec2: hangingEC2{}},
+				{region: "us-east-1", ec2: hangingEC2{}},
+			},
+			expectedRegions: []string{"eu-west-1"},
+			expectedTimeout: []string{"eu-west-3", "us-east-1"},
+		},
+	}
+
+	for i, tt := range tests {
+		c := &connector{svcs: tt.svcs, timeout: tt.timeout, regionTimeout: tt.regionTimeout}
+
+		opts, err := c.GetInstances(ctx, nil)
+
+		for _, region := range tt.expectedRegions {
+			if _, ok := opts[region]; !ok {
+				t.Errorf("%s [%d] - outputs: received=%+v | expected=%+v", tt.name, i, opts, tt.expectedRegions)
+			}
+		}
+
+		errs, _ := err.(Errors)
+		if len(errs) != len(tt.expectedTimeout) {
+			t.Fatalf("%s [%d] - errors: received=%+v | expected=%+v", tt.name, i, err, tt.expectedTimeout)
+		}
+		for j, e := range errs {
+			if e.Region() != tt.expectedTimeout[j] || !errors.Is(e, ErrTimeout) {
+				t.Errorf("%s [%d] - timeout: received=%+v | expected=%+v", tt.name, i, e, tt.expectedTimeout[j])
+			}
+		}
+	}
+}
+
+// deadlineIAM is an IAM returning a role, which
+// keeps the deadlines of the ctx of each call
+type deadlineIAM struct {
+	iamiface.IAMAPI
+
+	mu        *sync.Mutex
+	deadlines map[string]time.Time
+}
+
+func (m deadlineIAM) keep(ctx aws.Context, op string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.deadlines[op], _ = ctx.Deadline()
+}
+
+func (m deadlineIAM) ListRolesPagesWithContext(
+	ctx aws.Context, _ *iam.ListRolesInput, fn func(*iam.ListRolesOutput, bool) bool, _ ...request.Option,
+) error {
+	m.keep(ctx, "ListRoles")
+
+	// The calls made afterwards start once the
+	// budget of the region is already counting
+	time.Sleep(5 * time.Millisecond)
+
+	fn(&iam.ListRolesOutput{Roles: []*iam.Role{{RoleName: aws.String("role")}}}, true)
+	return nil
+}
+
+func (m deadlineIAM) ListRolePoliciesPagesWithContext(
+	ctx aws.Context, _ *iam.ListRolePoliciesInput, fn func(*iam.ListRolePoliciesOutput, bool) bool, _ ...request.Option,
+) error {
+	m.keep(ctx, "ListRolePolicies")
+	fn(&iam.ListRolePoliciesOutput{}, true)
+
+	return nil
+}
+
+func (m deadlineIAM) ListAttachedRolePoliciesPagesWithContext(
+	ctx aws.Context, _ *iam.ListAttachedRolePoliciesInput, fn func(*iam.ListAttachedRolePoliciesOutput, bool) bool, _ ...request.Option,
+) error {
+	m.keep(ctx, "ListAttachedRolePolicies")
+	fn(&iam.ListAttachedRolePoliciesOutput{}, true)
+
+	return nil
+}
+
+func TestConnector_timeoutEnrich(t *testing.T) {
+	m := deadlineIAM{mu: &sync.Mutex{}, deadlines: make(map[string]time.Time)}
+	c := &connector{
+		svcs:          []*serviceConnector{{region: "eu-west-1", iam: m}},
+		regionTimeout: time.Minute,
+	}
+
+	_, err := c.GetRolesWithPolicies(context.Background(), nil)
+	checkErrors(t, "enrich", -1, err, nil)
+
+	// The follow-up calls have the budget of the region
+	// which started with the call of the method they enrich
+	d := m.deadlines["ListRoles"]
+	expected := map[string]time.Time{"ListRoles": d, "ListRolePolicies": d, "ListAttachedRolePolicies": d}
+	if d.IsZero() || !reflect.DeepEqual(m.deadlines, expected) {
+		t.Errorf("enrich - deadlines: received=%+v | expected=%+v", m.deadlines, expected)
+	}
+}
@@ -0,0 +1,156 @@
+// Package tracing traces the calls made by an AWSReader with OpenTelemetry,
+// with a span for each method called, a child span for each one of the
+// calls made to AWS on each region, and a child span of it for each one
+// of the AWS requests of the call, which are more than one with the pages
+// and the retries:
+//
+//	t := tracing.New(tracing.Options{})
+//	r, err := raws.NewAWSReaderWithOptions(ctx, "key", "secret", []string{"eu-*"}, nil, false, raws.ReaderOptions{
+//		Interceptors:       []raws.Interceptor{t.Interceptor()},
+//		MethodInterceptors: []raws.MethodInterceptor{t.MethodInterceptor()},
+//	})
+//
+// The spans of the methods are children of the span of the ctx given to
+// them, and have the attributes of the method and the account, to which
+// the ones of the calls add the service, the operation and the region,
+// and the code of the AWS error if they fail.
+package tracing
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/cycloidio/raws"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the name of the Tracer
+const instrumentationName = "github.com/cycloidio/raws/tracing"
+
+// The attributes of the spans
+const (
+	MethodKey     = attribute.Key("raws.method")
+	AccountIDKey  = attribute.Key("aws.account_id")
+	ServiceKey    = attribute.Key("aws.service")
+	OperationKey  = attribute.Key("aws.operation")
+	RegionKey     = attribute.Key("aws.region")
+	ErrorCodeKey  = attribute.Key("aws.error_code")
+	RequestIDKey  = attribute.Key("aws.request_id")
+	PageKey       = attribute.Key("aws.page")
+	RetryCountKey = attribute.Key("aws.retry_count")
+)
+
+// Options are the options of a Tracer
+type Options struct {
+	// TracerProvider provides the Tracer of the spans,
+	// the global one of otel if nil
+	TracerProvider trace.TracerProvider
+}
+
+// Tracer traces the calls made by the AWSReaders
+// which use its Interceptor and MethodInterceptor
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// New returns a Tracer with the opts
+func New(opts Options) *Tracer {
+	if opts.TracerProvider == nil {
+		opts.TracerProvider = otel.GetTracerProvider()
+	}
+
+	return &Tracer{
+		tracer: opts.TracerProvider.Tracer(instrumentationName),
+	}
+}
+
+// MethodInterceptor returns the raws.MethodInterceptor
+// starting the span of each method called
+func (t *Tracer) MethodInterceptor() raws.MethodInterceptor {
+	return func(ctx context.Context, call *raws.MethodCall, next raws.MethodInvoker) error {
+		ctx, span := t.tracer.Start(ctx, "raws."+call.Method, trace.WithAttributes(
+			MethodKey.String(call.Method),
+			AccountIDKey.String(call.AccountID),
+		))
+		defer span.End()
+
+		err := next(ctx, call)
+		setError(span, err)
+
+		return err
+	}
+}
+
+// Interceptor returns the raws.Interceptor starting the span of each
+// call made to AWS, and the ones of each one of its AWS requests
+func (t *Tracer) Interceptor() raws.Interceptor {
+	return func(ctx context.Context, call *raws.Call, next raws.Invoker) error {
+		attrs := []attribute.KeyValue{
+			MethodKey.String(call.Method),
+			AccountIDKey.String(call.AccountID),
+			ServiceKey.String(call.Service),
+			OperationKey.String(call.Operation),
+			RegionKey.String(call.Region),
+		}
+
+		ctx, span := t.tracer.Start(ctx, call.Service+"."+call.Operation, trace.WithAttributes(attrs...))
+		defer span.End()
+
+		call.RequestOptions = append(call.RequestOptions, t.requestOption(attrs))
+
+		err := next(ctx, call)
+		setError(span, err)
+
+		return err
+	}
+}
+
+// requestOption returns the request.Option starting the span of each
+// attempt of the requests, as a child of the span of their context,
+// with the page of the request, as the option is applied to each new
+// request, and the number of the retry
+func (t *Tracer) requestOption(attrs []attribute.KeyValue) request.Option {
+	var page int
+	return func(r *request.Request) {
+		var span trace.Span
+
+		page++
+		pageAttr := PageKey.Int(page)
+
+		end := func(r *request.Request) {
+			if span == nil {
+				return
+			}
+			if r.RequestID != "" {
+				span.SetAttributes(RequestIDKey.String(r.RequestID))
+			}
+			setError(span, r.Error)
+			span.End()
+			span = nil
+		}
+
+		r.Handlers.Sign.PushFront(func(r *request.Request) {
+			_, span = t.tracer.Start(r.Context(), r.ClientInfo.ServiceName+"."+r.Operation.Name+" request", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+			span.SetAttributes(pageAttr, RetryCountKey.Int(r.RetryCount))
+		})
+		r.Handlers.CompleteAttempt.PushBack(end)
+		// The requests failing to be signed don't complete any attempt
+		r.Handlers.Complete.PushBack(end)
+	}
+}
+
+// setError sets the error, and its code if it's an AWS error, on the span
+func setError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	if c := raws.ErrorCode(err); c != "" {
+		span.SetAttributes(ErrorCodeKey.String(c))
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}